@@ -103,6 +103,43 @@ func ReadGolombSigned(buf []byte, pos *int) (int32, error) {
 	return -vi / 2, nil
 }
 
+// ReadUVLC reads a variable length unsigned integer, as defined by AV1.
+// Specification: https://aomediacodec.github.io/av1-spec/#4104-uvlc-semantics
+func ReadUVLC(buf []byte, pos *int) (uint32, error) {
+	leadingZeros := 0
+
+	for {
+		done, err := ReadFlag(buf, pos)
+		if err != nil {
+			return 0, err
+		}
+
+		if done {
+			break
+		}
+
+		leadingZeros++
+		if leadingZeros > 32 {
+			return 0, fmt.Errorf("invalid value")
+		}
+	}
+
+	if leadingZeros == 32 {
+		return 0xFFFFFFFF, nil
+	}
+
+	if leadingZeros == 0 {
+		return 0, nil
+	}
+
+	v, err := ReadBits(buf, pos, leadingZeros)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint32(v + (1 << leadingZeros) - 1), nil
+}
+
 // ReadFlag reads a boolean flag.
 func ReadFlag(buf []byte, pos *int) (bool, error) {
 	err := HasSpace(buf, *pos, 1)
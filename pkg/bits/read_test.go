@@ -73,6 +73,54 @@ func TestReadGolombSignedErrors(t *testing.T) {
 	require.EqualError(t, err, "not enough bits")
 }
 
+func TestReadUVLC(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		buf  []byte
+		v    uint32
+	}{
+		{
+			"zero",
+			[]byte{0x80},
+			0,
+		},
+		{
+			"small",
+			[]byte{0x38},
+			6,
+		},
+		{
+			"max",
+			[]byte{0x00, 0x00, 0x00, 0x00, 0x80},
+			0xFFFFFFFF,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			pos := 0
+			v, err := ReadUVLC(ca.buf, &pos)
+			require.NoError(t, err)
+			require.Equal(t, ca.v, v)
+		})
+	}
+}
+
+func TestReadUVLCErrors(t *testing.T) {
+	buf := []byte{0x00}
+	pos := 0
+	_, err := ReadUVLC(buf, &pos)
+	require.EqualError(t, err, "not enough bits")
+
+	buf = []byte{0x01}
+	pos = 0
+	_, err = ReadUVLC(buf, &pos)
+	require.EqualError(t, err, "not enough bits")
+
+	buf = []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x80}
+	pos = 0
+	_, err = ReadUVLC(buf, &pos)
+	require.EqualError(t, err, "invalid value")
+}
+
 func TestReadFlag(t *testing.T) {
 	buf := []byte{0xFF}
 	pos := 0
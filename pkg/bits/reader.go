@@ -0,0 +1,169 @@
+package bits
+
+import (
+	"errors"
+)
+
+// ErrLimitExceeded is returned by a Reader when a read would exceed its limit.
+var ErrLimitExceeded = errors.New("bit limit exceeded")
+
+// Reader reads bits from a buffer.
+type Reader struct {
+	buf      []byte
+	pos      int
+	limit    int
+	hasLimit bool
+}
+
+// NewReader allocates a Reader.
+func NewReader(buf []byte) *Reader {
+	return &Reader{
+		buf: buf,
+	}
+}
+
+// NewLimitedReader allocates a Reader that reads at most maxBits bits.
+// Reads that would exceed the limit return ErrLimitExceeded,
+// allowing parsers of untrusted input to bound their work.
+func NewLimitedReader(buf []byte, maxBits int) *Reader {
+	return &Reader{
+		buf:      buf,
+		limit:    maxBits,
+		hasLimit: true,
+	}
+}
+
+// Reset makes the Reader read from buf, from the beginning and without limits,
+// allowing to reuse it across buffers, for instance through a sync.Pool.
+// The Reader retains buf until the next call to Reset, without copying it.
+func (r *Reader) Reset(buf []byte) {
+	r.buf = buf
+	r.pos = 0
+	r.limit = 0
+	r.hasLimit = false
+}
+
+// Pos returns the current position, in bits.
+func (r *Reader) Pos() int {
+	return r.pos
+}
+
+// BitsLeft returns the number of bits that can still be read.
+func (r *Reader) BitsLeft() int {
+	n := len(r.buf)*8 - r.pos
+	if r.hasLimit && (r.limit-r.pos) < n {
+		n = r.limit - r.pos
+	}
+	if n < 0 {
+		return 0
+	}
+	return n
+}
+
+func (r *Reader) checkLimit(n int) error {
+	if r.hasLimit && n > (r.limit-r.pos) {
+		return ErrLimitExceeded
+	}
+	return nil
+}
+
+// checkLimitAfter is used with variable-length values,
+// whose size is known after they have been read.
+func (r *Reader) checkLimitAfter() error {
+	if r.hasLimit && r.pos > r.limit {
+		return ErrLimitExceeded
+	}
+	return nil
+}
+
+// ReadBits reads N bits.
+func (r *Reader) ReadBits(n int) (uint64, error) {
+	err := r.checkLimit(n)
+	if err != nil {
+		return 0, err
+	}
+	return ReadBits(r.buf, &r.pos, n)
+}
+
+// ReadBitsUnsigned reads N bits as an unsigned integer.
+// It has the same semantics as ReadBits.
+func (r *Reader) ReadBitsUnsigned(n int) (uint64, error) {
+	return r.ReadBits(n)
+}
+
+// ReadFlag reads a boolean flag.
+func (r *Reader) ReadFlag() (bool, error) {
+	err := r.checkLimit(1)
+	if err != nil {
+		return false, err
+	}
+	return ReadFlag(r.buf, &r.pos)
+}
+
+// ReadGolombUnsigned reads an unsigned golomb-encoded value.
+func (r *Reader) ReadGolombUnsigned() (uint32, error) {
+	err := r.checkLimit(1)
+	if err != nil {
+		return 0, err
+	}
+
+	v, err := ReadGolombUnsigned(r.buf, &r.pos)
+	if err != nil {
+		return 0, err
+	}
+
+	return v, r.checkLimitAfter()
+}
+
+// ReadGolombSigned reads a signed golomb-encoded value.
+func (r *Reader) ReadGolombSigned() (int32, error) {
+	err := r.checkLimit(1)
+	if err != nil {
+		return 0, err
+	}
+
+	v, err := ReadGolombSigned(r.buf, &r.pos)
+	if err != nil {
+		return 0, err
+	}
+
+	return v, r.checkLimitAfter()
+}
+
+// ReadUVLC reads a variable length unsigned integer, as defined by AV1.
+func (r *Reader) ReadUVLC() (uint32, error) {
+	err := r.checkLimit(1)
+	if err != nil {
+		return 0, err
+	}
+
+	v, err := ReadUVLC(r.buf, &r.pos)
+	if err != nil {
+		return 0, err
+	}
+
+	return v, r.checkLimitAfter()
+}
+
+// SkipBits skips N bits.
+func (r *Reader) SkipBits(n int) error {
+	err := r.checkLimit(n)
+	if err != nil {
+		return err
+	}
+
+	err = HasSpace(r.buf, r.pos, n)
+	if err != nil {
+		return err
+	}
+
+	r.pos += n
+	return nil
+}
+
+// ByteAlign moves the position to the next byte boundary.
+func (r *Reader) ByteAlign() {
+	if (r.pos % 8) != 0 {
+		r.pos += 8 - (r.pos % 8)
+	}
+}
@@ -0,0 +1,147 @@
+package bits
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReader(t *testing.T) {
+	r := NewReader([]byte{0xA8, 0xC7, 0x38, 0x24, 0xFF})
+	require.Equal(t, 40, r.BitsLeft())
+
+	v, err := r.ReadBits(6)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0x2a), v)
+	require.Equal(t, 6, r.Pos())
+	require.Equal(t, 34, r.BitsLeft())
+
+	f, err := r.ReadFlag()
+	require.NoError(t, err)
+	require.Equal(t, false, f)
+
+	r.ByteAlign()
+	require.Equal(t, 8, r.Pos())
+
+	r.ByteAlign()
+	require.Equal(t, 8, r.Pos())
+
+	v, err = r.ReadBits(8)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0xc7), v)
+
+	u, err := r.ReadGolombUnsigned()
+	require.NoError(t, err)
+	require.Equal(t, uint32(6), u)
+
+	r.ByteAlign()
+
+	s, err := r.ReadGolombSigned()
+	require.NoError(t, err)
+	require.Equal(t, int32(2), s)
+
+	r.ByteAlign()
+	require.Equal(t, 8, r.BitsLeft())
+
+	u, err = r.ReadUVLC()
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), u)
+
+	_, err = r.ReadBits(8)
+	require.EqualError(t, err, "not enough bits")
+}
+
+func TestReaderReadBitsUnsigned(t *testing.T) {
+	buf := []byte{0xA8, 0xC7, 0x38, 0x24, 0xFF}
+	r := NewReader(buf)
+	pos := 0
+
+	for _, n := range []int{3, 0, 13, 24} {
+		v1, err := r.ReadBitsUnsigned(n)
+		require.NoError(t, err)
+
+		v2, err := ReadBits(buf, &pos, n)
+		require.NoError(t, err)
+
+		require.Equal(t, v2, v1)
+		require.Equal(t, pos, r.Pos())
+	}
+
+	_, err := r.ReadBitsUnsigned(1)
+	require.EqualError(t, err, "not enough bits")
+
+	r = NewLimitedReader(buf, 4)
+
+	_, err = r.ReadBitsUnsigned(5)
+	require.ErrorIs(t, err, ErrLimitExceeded)
+}
+
+func TestReaderSkipBits(t *testing.T) {
+	r := NewReader([]byte{0xA8, 0xC7})
+
+	err := r.SkipBits(4)
+	require.NoError(t, err)
+	require.Equal(t, 4, r.Pos())
+
+	v, err := r.ReadBits(4)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0x8), v)
+
+	err = r.SkipBits(9)
+	require.EqualError(t, err, "not enough bits")
+
+	r = NewLimitedReader([]byte{0xA8, 0xC7}, 4)
+
+	err = r.SkipBits(5)
+	require.ErrorIs(t, err, ErrLimitExceeded)
+}
+
+func TestLimitedReader(t *testing.T) {
+	r := NewLimitedReader([]byte{0xA8, 0xC7, 0x38, 0x24, 0xFF}, 20)
+	require.Equal(t, 20, r.BitsLeft())
+
+	v, err := r.ReadBits(16)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0xa8c7), v)
+	require.Equal(t, 4, r.BitsLeft())
+
+	_, err = r.ReadBits(5)
+	require.ErrorIs(t, err, ErrLimitExceeded)
+	require.Equal(t, 16, r.Pos())
+
+	// 0011 1000: the golomb-encoded value ends after the limit
+	_, err = r.ReadGolombUnsigned()
+	require.ErrorIs(t, err, ErrLimitExceeded)
+
+	r = NewLimitedReader([]byte{0xA8}, 100)
+	require.Equal(t, 8, r.BitsLeft())
+
+	_, err = r.ReadBits(16)
+	require.EqualError(t, err, "not enough bits")
+
+	r = NewLimitedReader([]byte{0xA8}, 0)
+
+	_, err = r.ReadFlag()
+	require.ErrorIs(t, err, ErrLimitExceeded)
+
+	_, err = r.ReadUVLC()
+	require.ErrorIs(t, err, ErrLimitExceeded)
+
+	_, err = r.ReadGolombSigned()
+	require.ErrorIs(t, err, ErrLimitExceeded)
+}
+
+func TestReaderReset(t *testing.T) {
+	r := NewLimitedReader([]byte{0xA8, 0xC7}, 4)
+
+	_, err := r.ReadBits(4)
+	require.NoError(t, err)
+
+	r.Reset([]byte{0x38, 0x24})
+	require.Equal(t, 0, r.Pos())
+	require.Equal(t, 16, r.BitsLeft())
+
+	v, err := r.ReadBits(16)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0x3824), v)
+}
@@ -1,7 +1,27 @@
 package bits
 
+import (
+	"fmt"
+)
+
 // WriteBits writes N bits.
-func WriteBits(buf []byte, pos *int, bits uint64, n int) {
+// It returns an error if N is greater than 64 or if the buffer is too small.
+func WriteBits(buf []byte, pos *int, bits uint64, n int) error {
+	if n > 64 {
+		return fmt.Errorf("cannot write more than 64 bits at once")
+	}
+
+	err := HasSpace(buf, *pos, n)
+	if err != nil {
+		return err
+	}
+
+	WriteBitsUnsafe(buf, pos, bits, n)
+	return nil
+}
+
+// WriteBitsUnsafe writes N bits.
+func WriteBitsUnsafe(buf []byte, pos *int, bits uint64, n int) {
 	res := 8 - (*pos & 0x07)
 	if n < res {
 		buf[*pos>>0x03] |= byte(bits << (res - n))
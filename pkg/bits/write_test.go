@@ -9,10 +9,40 @@ import (
 func TestWriteBits(t *testing.T) {
 	buf := make([]byte, 6)
 	pos := 0
-	WriteBits(buf, &pos, uint64(0x2a), 6)
-	WriteBits(buf, &pos, uint64(0x0c), 6)
-	WriteBits(buf, &pos, uint64(0x1f), 6)
-	WriteBits(buf, &pos, uint64(0x5a), 8)
-	WriteBits(buf, &pos, uint64(0xaaec4), 20)
+	err := WriteBits(buf, &pos, uint64(0x2a), 6)
+	require.NoError(t, err)
+	err = WriteBits(buf, &pos, uint64(0x0c), 6)
+	require.NoError(t, err)
+	err = WriteBits(buf, &pos, uint64(0x1f), 6)
+	require.NoError(t, err)
+	err = WriteBits(buf, &pos, uint64(0x5a), 8)
+	require.NoError(t, err)
+	err = WriteBits(buf, &pos, uint64(0xaaec4), 20)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0xA8, 0xC7, 0xD6, 0xAA, 0xBB, 0x10}, buf)
+}
+
+func TestWriteBitsErrors(t *testing.T) {
+	buf := make([]byte, 1)
+	pos := 0
+	err := WriteBits(buf, &pos, 0, 6)
+	require.NoError(t, err)
+	err = WriteBits(buf, &pos, 0, 6)
+	require.EqualError(t, err, "not enough bits")
+
+	buf = make([]byte, 10)
+	pos = 0
+	err = WriteBits(buf, &pos, 0, 65)
+	require.EqualError(t, err, "cannot write more than 64 bits at once")
+}
+
+func TestWriteBitsUnsafe(t *testing.T) {
+	buf := make([]byte, 6)
+	pos := 0
+	WriteBitsUnsafe(buf, &pos, uint64(0x2a), 6)
+	WriteBitsUnsafe(buf, &pos, uint64(0x0c), 6)
+	WriteBitsUnsafe(buf, &pos, uint64(0x1f), 6)
+	WriteBitsUnsafe(buf, &pos, uint64(0x5a), 8)
+	WriteBitsUnsafe(buf, &pos, uint64(0xaaec4), 20)
 	require.Equal(t, []byte{0xA8, 0xC7, 0xD6, 0xAA, 0xBB, 0x10}, buf)
 }
@@ -0,0 +1,61 @@
+package bits
+
+// Writer writes bits into a growable buffer.
+type Writer struct {
+	buf []byte
+	pos int
+}
+
+// NewWriter allocates a Writer.
+func NewWriter() *Writer {
+	return &Writer{}
+}
+
+func (w *Writer) grow(n int) {
+	size := (w.pos + n + 7) / 8
+	for len(w.buf) < size {
+		w.buf = append(w.buf, 0)
+	}
+}
+
+// Reset discards written bits, allowing to reuse the Writer,
+// for instance through a sync.Pool.
+// The underlying buffer is retained and reused, therefore slices returned by Bytes
+// are overwritten by subsequent writes and must be copied before calling Reset.
+func (w *Writer) Reset() {
+	w.buf = w.buf[:0]
+	w.pos = 0
+}
+
+// Pos returns the current position, in bits.
+func (w *Writer) Pos() int {
+	return w.pos
+}
+
+// WriteBits writes N bits.
+func (w *Writer) WriteBits(v uint64, n int) {
+	w.grow(n)
+	WriteBitsUnsafe(w.buf, &w.pos, v, n)
+}
+
+// WriteFlag writes a boolean flag.
+func (w *Writer) WriteFlag(v bool) {
+	if v {
+		w.WriteBits(1, 1)
+	} else {
+		w.WriteBits(0, 1)
+	}
+}
+
+// ByteAlign writes zero bits until the position is on a byte boundary.
+func (w *Writer) ByteAlign() {
+	if (w.pos % 8) != 0 {
+		w.WriteBits(0, 8-(w.pos%8))
+	}
+}
+
+// Bytes returns written bytes.
+// The last byte is padded with zeroes.
+func (w *Writer) Bytes() []byte {
+	return w.buf
+}
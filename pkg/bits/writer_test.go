@@ -0,0 +1,46 @@
+package bits
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter(t *testing.T) {
+	w := NewWriter()
+	w.WriteBits(uint64(0x2a), 6)
+	w.WriteBits(uint64(0x0c), 6)
+	w.WriteBits(uint64(0x1f), 6)
+	w.WriteBits(uint64(0x5a), 8)
+	w.WriteBits(uint64(0xaaec4), 20)
+	require.Equal(t, 46, w.Pos())
+	require.Equal(t, []byte{0xA8, 0xC7, 0xD6, 0xAA, 0xBB, 0x10}, w.Bytes())
+
+	w.WriteFlag(true)
+	w.WriteFlag(false)
+	w.WriteFlag(true)
+	w.ByteAlign()
+	require.Equal(t, 56, w.Pos())
+
+	w.ByteAlign()
+	require.Equal(t, 56, w.Pos())
+
+	w.WriteBits(0xFF, 8)
+	require.Equal(t, []byte{0xA8, 0xC7, 0xD6, 0xAA, 0xBB, 0x12, 0x80, 0xFF}, w.Bytes())
+}
+
+func TestWriterReset(t *testing.T) {
+	w := NewWriter()
+	w.WriteBits(0xFF, 8)
+	w.WriteBits(0x7, 3)
+
+	w.Reset()
+	require.Equal(t, 0, w.Pos())
+	require.Equal(t, []byte{}, w.Bytes())
+
+	// reused bytes are zeroed before being written
+	w.WriteBits(0x1, 1)
+	w.WriteBits(0x1, 9)
+	require.Equal(t, 10, w.Pos())
+	require.Equal(t, []byte{0x80, 0x40}, w.Bytes())
+}
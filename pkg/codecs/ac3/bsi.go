@@ -17,12 +17,13 @@ type BSI struct {
 
 // Unmarshal decodes a BSI.
 func (b *BSI) Unmarshal(buf []byte) error {
-	if len(buf) < 2 {
+	if len(buf) < 3 {
 		return fmt.Errorf("not enough bits")
 	}
 
+	// streams with a lower bsid use a subset of the syntax and are decodable.
 	b.Bsid = buf[0] >> 3
-	if b.Bsid != 0x08 {
+	if b.Bsid > 0x08 {
 		return fmt.Errorf("invalid bsid")
 	}
 
@@ -53,8 +54,12 @@ func (b *BSI) Unmarshal(buf []byte) error {
 
 // ChannelCount returns the channel count.
 func (b BSI) ChannelCount() int {
+	return channelCount(b.Acmod, b.LfeOn)
+}
+
+func channelCount(acmod uint8, lfeOn bool) int {
 	var n int
-	switch b.Acmod {
+	switch acmod {
 	case 0b001:
 		n = 1
 	case 0b010, 0b000:
@@ -67,7 +72,7 @@ func (b BSI) ChannelCount() int {
 		n = 5
 	}
 
-	if b.LfeOn {
+	if lfeOn {
 		return n + 1
 	}
 	return n
@@ -0,0 +1,188 @@
+package ac3
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/pkg/bits"
+)
+
+// EAC3StreamType is an E-AC-3 stream type.
+// Specification: ATSC, AC-3, E.1.3.1.1
+type EAC3StreamType uint8
+
+// stream types.
+const (
+	EAC3StreamTypeIndependent EAC3StreamType = 0
+	EAC3StreamTypeDependent   EAC3StreamType = 1
+	EAC3StreamTypeAC3Convert  EAC3StreamType = 2
+)
+
+// IsIndependent checks whether the stream type is an independent substream.
+func (t EAC3StreamType) IsIndependent() bool {
+	return t == EAC3StreamTypeIndependent || t == EAC3StreamTypeAC3Convert
+}
+
+// EAC3BSI is the synchronization information and Bit Stream Information of an E-AC-3 sync frame.
+// Specification: ATSC, AC-3, Table E1.1 and E1.2
+type EAC3BSI struct {
+	Strmtyp     EAC3StreamType
+	Substreamid uint8
+	Frmsiz      uint16
+	Fscod       uint8
+	Fscod2      uint8
+	Numblkscod  uint8
+	Acmod       uint8
+	LfeOn       bool
+	Bsid        uint8
+
+	// present when Strmtyp is EAC3StreamTypeDependent
+	Chanmape bool
+	Chanmap  uint16
+}
+
+// Unmarshal decodes an EAC3BSI.
+// The buffer must start with the sync word.
+func (b *EAC3BSI) Unmarshal(frame []byte) error {
+	if len(frame) < 6 {
+		return fmt.Errorf("not enough bits")
+	}
+
+	if frame[0] != 0x0B || frame[1] != 0x77 {
+		return fmt.Errorf("invalid sync word")
+	}
+
+	buf := frame[2:]
+	pos := 0
+
+	b.Strmtyp = EAC3StreamType(bits.ReadBitsUnsafe(buf, &pos, 2))
+	if b.Strmtyp > EAC3StreamTypeAC3Convert {
+		return fmt.Errorf("invalid strmtyp")
+	}
+
+	b.Substreamid = uint8(bits.ReadBitsUnsafe(buf, &pos, 3))
+	b.Frmsiz = uint16(bits.ReadBitsUnsafe(buf, &pos, 11))
+	if b.FrameSize() < 6 {
+		return fmt.Errorf("invalid frmsiz")
+	}
+
+	b.Fscod = uint8(bits.ReadBitsUnsafe(buf, &pos, 2))
+
+	if b.Fscod == 3 {
+		b.Fscod2 = uint8(bits.ReadBitsUnsafe(buf, &pos, 2))
+		if b.Fscod2 == 3 {
+			return fmt.Errorf("invalid fscod2")
+		}
+		b.Numblkscod = 3
+	} else {
+		b.Fscod2 = 0
+		b.Numblkscod = uint8(bits.ReadBitsUnsafe(buf, &pos, 2))
+	}
+
+	b.Acmod = uint8(bits.ReadBitsUnsafe(buf, &pos, 3))
+	b.LfeOn = bits.ReadFlagUnsafe(buf, &pos)
+
+	b.Bsid = uint8(bits.ReadBitsUnsafe(buf, &pos, 5))
+	if b.Bsid <= 10 || b.Bsid > 16 {
+		return fmt.Errorf("invalid bsid")
+	}
+
+	b.Chanmape = false
+	b.Chanmap = 0
+
+	if b.Strmtyp == EAC3StreamTypeDependent {
+		return b.unmarshalChanmap(buf, pos)
+	}
+
+	return nil
+}
+
+func (b *EAC3BSI) unmarshalChanmap(buf []byte, pos int) error {
+	n := 1
+	if b.Acmod == 0 {
+		n = 2
+	}
+
+	for i := 0; i < n; i++ {
+		err := bits.HasSpace(buf, pos, 6)
+		if err != nil {
+			return err
+		}
+
+		bits.ReadBitsUnsafe(buf, &pos, 5) // dialnorm
+		compre := bits.ReadFlagUnsafe(buf, &pos)
+
+		if compre {
+			_, err = bits.ReadBits(buf, &pos, 8) // compr
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	var err error
+	b.Chanmape, err = bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if b.Chanmape {
+		var tmp uint64
+		tmp, err = bits.ReadBits(buf, &pos, 16)
+		if err != nil {
+			return err
+		}
+		b.Chanmap = uint16(tmp)
+	}
+
+	return nil
+}
+
+// FrameSize returns the frame size.
+func (b EAC3BSI) FrameSize() int {
+	return (int(b.Frmsiz) + 1) * 2
+}
+
+// SampleRate returns the frame sample rate.
+func (b EAC3BSI) SampleRate() int {
+	switch b.Fscod {
+	case 0:
+		return 48000
+	case 1:
+		return 44100
+	case 2:
+		return 32000
+	}
+
+	switch b.Fscod2 {
+	case 0:
+		return 24000
+	case 1:
+		return 22050
+	default:
+		return 16000
+	}
+}
+
+// SamplesPerFrame returns the number of samples contained in the frame.
+func (b EAC3BSI) SamplesPerFrame() int {
+	switch b.Numblkscod {
+	case 0:
+		return 256
+	case 1:
+		return 2 * 256
+	case 2:
+		return 3 * 256
+	default:
+		return 6 * 256
+	}
+}
+
+// Bitrate returns the bitrate, in bits per second.
+func (b EAC3BSI) Bitrate() int {
+	return b.FrameSize() * 8 * b.SampleRate() / b.SamplesPerFrame()
+}
+
+// ChannelCount returns the channel count.
+func (b EAC3BSI) ChannelCount() int {
+	return channelCount(b.Acmod, b.LfeOn)
+}
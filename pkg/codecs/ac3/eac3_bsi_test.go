@@ -0,0 +1,165 @@
+package ac3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var eac3Cases = []struct {
+	name            string
+	enc             []byte
+	bsi             EAC3BSI
+	frameSize       int
+	sampleRate      int
+	samplesPerFrame int
+	bitrate         int
+	channelCount    int
+}{
+	{
+		"5.1 independent",
+		[]byte{0x0b, 0x77, 0x00, 0x3f, 0x3f, 0x80},
+		EAC3BSI{
+			Strmtyp:    EAC3StreamTypeIndependent,
+			Frmsiz:     0x3f,
+			Numblkscod: 3,
+			Acmod:      7,
+			LfeOn:      true,
+			Bsid:       16,
+		},
+		128,
+		48000,
+		1536,
+		32000,
+		6,
+	},
+	{
+		"stereo dependent",
+		[]byte{0x0b, 0x77, 0x40, 0x1f, 0x34, 0x87, 0xd0, 0x20, 0x00},
+		EAC3BSI{
+			Strmtyp:    EAC3StreamTypeDependent,
+			Frmsiz:     0x1f,
+			Numblkscod: 3,
+			Acmod:      2,
+			Bsid:       16,
+			Chanmape:   true,
+			Chanmap:    0x0200,
+		},
+		64,
+		48000,
+		1536,
+		16000,
+		2,
+	},
+	{
+		"reduced sample rate",
+		[]byte{0x0b, 0x77, 0x00, 0x17, 0xd4, 0x80},
+		EAC3BSI{
+			Strmtyp:    EAC3StreamTypeIndependent,
+			Frmsiz:     0x17,
+			Fscod:      3,
+			Fscod2:     1,
+			Numblkscod: 3,
+			Acmod:      2,
+			Bsid:       16,
+		},
+		48,
+		22050,
+		1536,
+		5512,
+		2,
+	},
+	{
+		"ac-3 convert, 1 block",
+		[]byte{0x0b, 0x77, 0x80, 0x0f, 0x42, 0x80},
+		EAC3BSI{
+			Strmtyp: EAC3StreamTypeAC3Convert,
+			Frmsiz:  0x0f,
+			Fscod:   1,
+			Acmod:   1,
+			Bsid:    16,
+		},
+		32,
+		44100,
+		256,
+		44100,
+		1,
+	},
+}
+
+func TestEAC3BSIUnmarshal(t *testing.T) {
+	for _, ca := range eac3Cases {
+		t.Run(ca.name, func(t *testing.T) {
+			var bsi EAC3BSI
+			err := bsi.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.bsi, bsi)
+			require.Equal(t, ca.frameSize, bsi.FrameSize())
+			require.Equal(t, ca.sampleRate, bsi.SampleRate())
+			require.Equal(t, ca.samplesPerFrame, bsi.SamplesPerFrame())
+			require.Equal(t, ca.bitrate, bsi.Bitrate())
+			require.Equal(t, ca.channelCount, bsi.ChannelCount())
+		})
+	}
+}
+
+func TestEAC3BSIUnmarshalErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		enc  []byte
+		err  string
+	}{
+		{
+			"short",
+			[]byte{0x0b, 0x77, 0x00, 0x3f, 0x3f},
+			"not enough bits",
+		},
+		{
+			"sync word",
+			[]byte{0x0b, 0x78, 0x00, 0x3f, 0x3f, 0x80},
+			"invalid sync word",
+		},
+		{
+			"strmtyp",
+			[]byte{0x0b, 0x77, 0xc0, 0x3f, 0x3f, 0x80},
+			"invalid strmtyp",
+		},
+		{
+			"frmsiz",
+			[]byte{0x0b, 0x77, 0x00, 0x01, 0x3f, 0x80},
+			"invalid frmsiz",
+		},
+		{
+			"fscod2",
+			[]byte{0x0b, 0x77, 0x00, 0x17, 0xf4, 0x80},
+			"invalid fscod2",
+		},
+		{
+			"dependent short",
+			[]byte{0x0b, 0x77, 0x40, 0x1f, 0x34, 0x87, 0xd0},
+			"not enough bits",
+		},
+		{
+			"ac-3 bsid",
+			[]byte{0x0b, 0x77, 0x00, 0x0f, 0x34, 0x30},
+			"invalid bsid",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var bsi EAC3BSI
+			err := bsi.Unmarshal(ca.enc)
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func FuzzEAC3BSIUnmarshal(f *testing.F) {
+	for _, ca := range eac3Cases {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var bsi EAC3BSI
+		bsi.Unmarshal(b) //nolint:errcheck
+	})
+}
@@ -0,0 +1,59 @@
+package ac3
+
+import (
+	"fmt"
+)
+
+// EAC3Frame is an E-AC-3 frame, made of an independent substream
+// followed by other independent substreams and by dependent substreams.
+type EAC3Frame struct {
+	// BSIs of the substreams.
+	BSIs []EAC3BSI
+
+	// substreams.
+	Substreams [][]byte
+}
+
+// SampleRate returns the frame sample rate.
+func (f EAC3Frame) SampleRate() int {
+	return f.BSIs[0].SampleRate()
+}
+
+// SamplesPerFrame returns the number of samples contained in the frame.
+func (f EAC3Frame) SamplesPerFrame() int {
+	return f.BSIs[0].SamplesPerFrame()
+}
+
+// EAC3SplitFrames splits a sequence of E-AC-3 sync frames into frames.
+// A frame begins with independent substream 0 and contains every
+// following substream, until the next independent substream 0.
+// Returned substreams point to the input buffer.
+func EAC3SplitFrames(buf []byte) ([]EAC3Frame, error) {
+	var frames []EAC3Frame
+
+	for len(buf) > 0 {
+		var bsi EAC3BSI
+		err := bsi.Unmarshal(buf)
+		if err != nil {
+			return nil, err
+		}
+
+		size := bsi.FrameSize()
+		if len(buf) < size {
+			return nil, fmt.Errorf("buffer is too short")
+		}
+
+		if bsi.Strmtyp.IsIndependent() && bsi.Substreamid == 0 {
+			frames = append(frames, EAC3Frame{})
+		} else if len(frames) == 0 {
+			return nil, fmt.Errorf("first substream is not independent substream 0")
+		}
+
+		cur := &frames[len(frames)-1]
+		cur.BSIs = append(cur.BSIs, bsi)
+		cur.Substreams = append(cur.Substreams, buf[:size])
+		buf = buf[size:]
+	}
+
+	return frames, nil
+}
@@ -0,0 +1,50 @@
+package ac3
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEAC3SplitFrames(t *testing.T) {
+	ind := append([]byte{0x0b, 0x77, 0x00, 0x3f, 0x3f, 0x80}, bytes.Repeat([]byte{1}, 122)...)
+	dep := append([]byte{0x0b, 0x77, 0x40, 0x1f, 0x34, 0x87, 0xd0, 0x20, 0x00}, bytes.Repeat([]byte{2}, 55)...)
+
+	var buf []byte
+	buf = append(buf, ind...)
+	buf = append(buf, dep...)
+	buf = append(buf, ind...)
+
+	frames, err := EAC3SplitFrames(buf)
+	require.NoError(t, err)
+	require.Equal(t, []EAC3Frame{
+		{
+			BSIs:       []EAC3BSI{eac3Cases[0].bsi, eac3Cases[1].bsi},
+			Substreams: [][]byte{ind, dep},
+		},
+		{
+			BSIs:       []EAC3BSI{eac3Cases[0].bsi},
+			Substreams: [][]byte{ind},
+		},
+	}, frames)
+	require.Equal(t, 48000, frames[0].SampleRate())
+	require.Equal(t, 1536, frames[0].SamplesPerFrame())
+
+	_, err = EAC3SplitFrames(append(append([]byte(nil), ind...), dep[:30]...))
+	require.EqualError(t, err, "buffer is too short")
+
+	_, err = EAC3SplitFrames(dep)
+	require.EqualError(t, err, "first substream is not independent substream 0")
+
+	_, err = EAC3SplitFrames(append(append([]byte(nil), ind...), 1, 2, 3, 4, 5, 6))
+	require.EqualError(t, err, "invalid sync word")
+}
+
+func FuzzEAC3SplitFrames(f *testing.F) {
+	f.Add([]byte{0x0b, 0x77, 0x00, 0x02, 0x3f, 0x80})
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		EAC3SplitFrames(b) //nolint:errcheck
+	})
+}
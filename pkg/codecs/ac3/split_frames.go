@@ -0,0 +1,28 @@
+package ac3
+
+import (
+	"fmt"
+)
+
+// SplitFrames splits a sequence of AC-3 frames into individual frames.
+func SplitFrames(buf []byte) ([][]byte, error) {
+	var frames [][]byte
+
+	for len(buf) > 0 {
+		var syncInfo SyncInfo
+		err := syncInfo.Unmarshal(buf)
+		if err != nil {
+			return nil, err
+		}
+
+		size := syncInfo.FrameSize()
+		if len(buf) < size {
+			return nil, fmt.Errorf("buffer is too short")
+		}
+
+		frames = append(frames, buf[:size])
+		buf = buf[size:]
+	}
+
+	return frames, nil
+}
@@ -0,0 +1,31 @@
+package ac3
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitFrames(t *testing.T) {
+	frame1 := append([]byte{0x0b, 0x77, 0x00, 0x00, 0x00}, bytes.Repeat([]byte{1}, 123)...)
+	frame2 := append([]byte{0x0b, 0x77, 0x00, 0x00, 0x42}, bytes.Repeat([]byte{2}, 169)...)
+
+	frames, err := SplitFrames(append(append([]byte(nil), frame1...), frame2...))
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{frame1, frame2}, frames)
+
+	_, err = SplitFrames(append(append([]byte(nil), frame1...), frame2[:100]...))
+	require.EqualError(t, err, "buffer is too short")
+
+	_, err = SplitFrames(append(append([]byte(nil), frame1...), 1, 2, 3, 4, 5))
+	require.EqualError(t, err, "invalid sync word")
+}
+
+func FuzzSplitFrames(f *testing.F) {
+	f.Add([]byte{0x0b, 0x77, 0x00, 0x00, 0x00})
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		SplitFrames(b) //nolint:errcheck
+	})
+}
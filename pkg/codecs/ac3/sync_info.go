@@ -46,6 +46,12 @@ var frameSizes = [][]int{
 	{1280, 1394, 1920},
 }
 
+// ATSC, AC-3, Table 5.18
+var bitrates = []int{
+	32, 40, 48, 56, 64, 80, 96, 112, 128, 160,
+	192, 224, 256, 320, 384, 448, 512, 576, 640,
+}
+
 // SyncInfo is a synchronization information.
 // Specification: ATSC, AC-3, Table 5.1
 type SyncInfo struct {
@@ -92,3 +98,8 @@ func (s SyncInfo) SampleRate() int {
 		return 32000
 	}
 }
+
+// Bitrate returns the nominal bitrate, in bits per second.
+func (s SyncInfo) Bitrate() int {
+	return bitrates[s.Frmsizecod/2] * 1000
+}
@@ -11,6 +11,7 @@ var ac3Cases = []struct {
 	enc        []byte
 	syncInfo   SyncInfo
 	sampleRate int
+	bitrate    int
 	bsi        BSI
 }{
 	{
@@ -69,6 +70,7 @@ var ac3Cases = []struct {
 			Frmsizecod: 12,
 		},
 		48000,
+		96000,
 		BSI{
 			Bsid:  8,
 			Acmod: 1,
@@ -306,6 +308,7 @@ var ac3Cases = []struct {
 			Frmsizecod: 30,
 		},
 		48000,
+		448000,
 		BSI{
 			Bsid:  8,
 			Acmod: 7,
@@ -322,6 +325,8 @@ func TestSyncInfoUnmarshal(t *testing.T) {
 			require.NoError(t, err)
 			require.Equal(t, ca.syncInfo, syncInfo)
 			require.Equal(t, len(ca.enc), syncInfo.FrameSize())
+			require.Equal(t, ca.sampleRate, syncInfo.SampleRate())
+			require.Equal(t, ca.bitrate, syncInfo.Bitrate())
 		})
 	}
 }
@@ -336,7 +341,8 @@ func FuzzSyncInfoUnmarshal(f *testing.F) {
 		err := syncInfo.Unmarshal(b)
 		if err == nil {
 			syncInfo.FrameSize()
-			syncInfo.SampleRate() //nolint:staticcheck
+			syncInfo.SampleRate()
+			syncInfo.Bitrate() //nolint:staticcheck
 		}
 	})
 }
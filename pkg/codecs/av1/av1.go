@@ -5,6 +5,9 @@ const (
 	// MaxTemporalUnitSize is the maximum size of a temporal unit.
 	MaxTemporalUnitSize = 3 * 1024 * 1024
 
+	// MaxOBUSize is the maximum size of a OBU, including header and size field.
+	MaxOBUSize = MaxTemporalUnitSize
+
 	// MaxOBUsPerTemporalUnit is the maximum number of OBUs per temporal unit.
 	MaxOBUsPerTemporalUnit = 10
 )
@@ -1,13 +1,20 @@
 package av1
 
 import (
+	"errors"
 	"fmt"
 )
 
-func obuRemoveSize(h *OBUHeader, sizeN int, ob []byte) []byte {
+// ErrOBUSizeMissing is returned when an OBU of a bitstream doesn't contain the size field,
+// therefore the boundary with the following OBU can't be found.
+// The size field can be added with SetHasSize.
+var ErrOBUSizeMissing = errors.New("OBU size not present")
+
+func obuRemoveSize(headerN int, sizeN int, ob []byte) []byte {
 	newOBU := make([]byte, len(ob)-sizeN)
-	newOBU[0] = (byte(h.Type) << 3)
-	copy(newOBU[1:], ob[1+sizeN:])
+	copy(newOBU, ob[:headerN])
+	newOBU[0] &^= 0b00000010
+	copy(newOBU[headerN:], ob[headerN+sizeN:])
 	return newOBU
 }
 
@@ -15,33 +22,55 @@ func obuRemoveSize(h *OBUHeader, sizeN int, ob []byte) []byte {
 // Optionally, it also removes the size field from OBUs.
 // Specification: https://aomediacodec.github.io/av1-spec/#low-overhead-bitstream-format
 func BitstreamUnmarshal(bs []byte, removeSizeField bool) ([][]byte, error) {
+	return bitstreamUnmarshal(bs, removeSizeField, false)
+}
+
+// ReadTemporalUnit is the bounded variant of BitstreamUnmarshal(buf, false).
+// It returns the same OBUs, including the size field, pointing to buf,
+// but it rejects OBUs bigger than MaxOBUSize and temporal units with more
+// than MaxOBUsPerTemporalUnit OBUs, as needed with untrusted input like RTP streams.
+// Specification: https://aomediacodec.github.io/av1-spec/#low-overhead-bitstream-format
+func ReadTemporalUnit(buf []byte) ([][]byte, error) {
+	return bitstreamUnmarshal(buf, false, true)
+}
+
+func bitstreamUnmarshal(bs []byte, removeSizeField bool, bounded bool) ([][]byte, error) {
 	var ret [][]byte
 
 	for {
 		var h OBUHeader
-		err := h.Unmarshal(bs)
+		headerN, err := h.unmarshal(bs)
 		if err != nil {
 			return nil, err
 		}
 
 		if !h.HasSize {
-			return nil, fmt.Errorf("OBU size not present")
+			return nil, ErrOBUSizeMissing
 		}
 
-		size, sizeN, err := LEB128Unmarshal(bs[1:])
+		size, sizeN, err := LEB128Unmarshal(bs[headerN:])
 		if err != nil {
 			return nil, err
 		}
 
-		obuLen := 1 + sizeN + int(size)
+		obuLen := headerN + sizeN + int(size)
+		if bounded && obuLen > MaxOBUSize {
+			return nil, fmt.Errorf("OBU size (%d) is too big, maximum is %d", obuLen, MaxOBUSize)
+		}
+
 		if len(bs) < obuLen {
 			return nil, fmt.Errorf("not enough bytes")
 		}
 
+		if bounded && (len(ret)+1) > MaxOBUsPerTemporalUnit {
+			return nil, fmt.Errorf("OBU count (%d) exceeds maximum allowed (%d)",
+				len(ret)+1, MaxOBUsPerTemporalUnit)
+		}
+
 		obu := bs[:obuLen]
 
 		if removeSizeField {
-			obu = obuRemoveSize(&h, sizeN, obu)
+			obu = obuRemoveSize(headerN, sizeN, obu)
 		}
 
 		ret = append(ret, obu)
@@ -55,6 +84,31 @@ func BitstreamUnmarshal(bs []byte, removeSizeField bool) ([][]byte, error) {
 	return ret, nil
 }
 
+// SetHasSize adds the size field to an OBU, that is needed to put the OBU
+// into a bitstream or into a MP4 sample.
+// OBUs that already contain the size field are returned as they are.
+func SetHasSize(obu []byte) ([]byte, error) {
+	var h OBUHeader
+	headerN, err := h.unmarshal(obu)
+	if err != nil {
+		return nil, err
+	}
+
+	if h.HasSize {
+		return obu, nil
+	}
+
+	size := uint(len(obu) - headerN)
+	buf := make([]byte, len(obu)+LEB128MarshalSize(size))
+
+	n := copy(buf, obu[:headerN])
+	buf[0] |= 0b00000010
+	n += LEB128MarshalTo(size, buf[n:])
+	copy(buf[n:], obu[headerN:])
+
+	return buf, nil
+}
+
 // BitstreamMarshal encodes a temporal unit into a bitstream.
 // Specification: https://aomediacodec.github.io/av1-spec/#low-overhead-bitstream-format
 func BitstreamMarshal(tu [][]byte) ([]byte, error) {
@@ -64,13 +118,13 @@ func BitstreamMarshal(tu [][]byte) ([]byte, error) {
 		n += len(obu)
 
 		var h OBUHeader
-		err := h.Unmarshal(obu)
+		headerN, err := h.unmarshal(obu)
 		if err != nil {
 			return nil, err
 		}
 
 		if !h.HasSize {
-			size := len(obu) - 1
+			size := len(obu) - headerN
 			n += LEB128MarshalSize(uint(size))
 		}
 	}
@@ -80,14 +134,16 @@ func BitstreamMarshal(tu [][]byte) ([]byte, error) {
 
 	for _, obu := range tu {
 		var h OBUHeader
-		h.Unmarshal(obu) //nolint:errcheck
+		headerN, _ := h.unmarshal(obu)
 
 		if !h.HasSize {
-			buf[n] = obu[0] | 0b00000010
-			n++
-			size := len(obu) - 1
+			n += copy(buf[n:], obu[:headerN])
+			buf[n-headerN] |= 0b00000010
+			size := len(obu) - headerN
 			n += LEB128MarshalTo(uint(size), buf[n:])
-			n += copy(buf[n:], obu[1:])
+			n += copy(buf[n:], obu[headerN:])
+		} else {
+			n += copy(buf[n:], obu)
 		}
 	}
 
@@ -1,6 +1,7 @@
 package av1
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -30,6 +31,15 @@ var casesBitstream = []struct {
 			},
 		},
 	},
+	{
+		"extension header",
+		[]byte{
+			0x36, 0x48, 0x03, 0x01, 0x02, 0x03,
+		},
+		[][]byte{
+			{0x34, 0x48, 0x01, 0x02, 0x03},
+		},
+	},
 }
 
 func TestBitstreamUnmarshal(t *testing.T) {
@@ -52,6 +62,116 @@ func TestBitstreamMarshal(t *testing.T) {
 	}
 }
 
+func TestBitstreamMarshalWithSize(t *testing.T) {
+	enc, err := BitstreamMarshal([][]byte{
+		{0x12, 0x00},
+		{0x34, 0x48, 0x01, 0x02, 0x03},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x12, 0x00, 0x36, 0x48, 0x03, 0x01, 0x02, 0x03}, enc)
+}
+
+func TestSetHasSize(t *testing.T) {
+	for _, ca := range casesBitstream {
+		t.Run(ca.name, func(t *testing.T) {
+			var enc []byte
+			for _, obu := range ca.dec {
+				obu2, err := SetHasSize(obu)
+				require.NoError(t, err)
+				enc = append(enc, obu2...)
+			}
+			require.Equal(t, ca.enc, enc)
+
+			// OBUs with size are returned as they are
+			tu, err := ReadTemporalUnit(ca.enc)
+			require.NoError(t, err)
+
+			for _, obu := range tu {
+				obu2, err := SetHasSize(obu)
+				require.NoError(t, err)
+				require.Equal(t, obu, obu2)
+			}
+		})
+	}
+}
+
+func TestReadTemporalUnit(t *testing.T) {
+	for _, ca := range casesBitstream {
+		t.Run(ca.name, func(t *testing.T) {
+			tu, err := ReadTemporalUnit(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, len(ca.dec), len(tu))
+
+			n := 0
+			for _, obu := range tu {
+				require.Equal(t, ca.enc[n:n+len(obu)], obu)
+				n += len(obu)
+			}
+			require.Equal(t, len(ca.enc), n)
+		})
+	}
+}
+
+func TestReadTemporalUnitErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		byts []byte
+		err  string
+	}{
+		{
+			"empty",
+			[]byte{},
+			"not enough bytes",
+		},
+		{
+			"missing size",
+			[]byte{0x08, 0x01},
+			"OBU size not present",
+		},
+		{
+			"truncated payload",
+			[]byte{0x0a, 0x03, 0x01},
+			"not enough bytes",
+		},
+		{
+			"OBU too big",
+			[]byte{0x0a, 0x80, 0x80, 0x80, 0x80, 0x01},
+			"OBU size (268435462) is too big, maximum is 3145728",
+		},
+		{
+			"too many OBUs",
+			bytes.Repeat([]byte{0x12, 0x00}, MaxOBUsPerTemporalUnit+1),
+			"OBU count (11) exceeds maximum allowed (10)",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			_, err := ReadTemporalUnit(ca.byts)
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func TestBitstreamUnmarshalUnbounded(t *testing.T) {
+	tu, err := BitstreamUnmarshal(bytes.Repeat([]byte{0x12, 0x00}, MaxOBUsPerTemporalUnit+1), false)
+	require.NoError(t, err)
+	require.Equal(t, MaxOBUsPerTemporalUnit+1, len(tu))
+}
+
+func TestReadTemporalUnitSizeMissing(t *testing.T) {
+	_, err := ReadTemporalUnit([]byte{0x12, 0x00, 0x30, 0x01, 0x02})
+	require.ErrorIs(t, err, ErrOBUSizeMissing)
+}
+
+func FuzzReadTemporalUnit(f *testing.F) {
+	for _, ca := range casesBitstream {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		ReadTemporalUnit(b) //nolint:errcheck
+	})
+}
+
 func FuzzBitstreamUnmarshal(f *testing.F) {
 	for _, ca := range casesBitstream {
 		f.Add(ca.enc)
@@ -64,3 +184,15 @@ func FuzzBitstreamUnmarshal(f *testing.F) {
 		}
 	})
 }
+
+func FuzzSetHasSize(f *testing.F) {
+	for _, ca := range casesBitstream {
+		for _, obu := range ca.dec {
+			f.Add(obu)
+		}
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		SetHasSize(b) //nolint:errcheck
+	})
+}
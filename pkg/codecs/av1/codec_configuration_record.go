@@ -0,0 +1,201 @@
+package av1
+
+import (
+	"fmt"
+)
+
+// CodecConfigurationRecord is a AV1CodecConfigurationRecord.
+// Specification: https://aomediacodec.github.io/av1-isobmff/#av1codecconfigurationbox-syntax
+type CodecConfigurationRecord struct {
+	SeqProfile                      uint8
+	SeqLevelIdx0                    uint8
+	SeqTier0                        bool
+	HighBitdepth                    bool
+	TwelveBit                       bool
+	MonoChrome                      bool
+	ChromaSubsamplingX              bool
+	ChromaSubsamplingY              bool
+	ChromaSamplePosition            uint8
+	InitialPresentationDelayPresent bool
+	InitialPresentationDelayMinus1  uint8
+
+	// OBUs in low overhead bitstream format.
+	ConfigOBUs []byte
+}
+
+// Unmarshal decodes a CodecConfigurationRecord.
+func (r *CodecConfigurationRecord) Unmarshal(buf []byte) error {
+	if len(buf) < 4 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	marker := buf[0] >> 7
+	if marker != 1 {
+		return fmt.Errorf("invalid marker")
+	}
+
+	version := buf[0] & 0b01111111
+	if version != 1 {
+		return fmt.Errorf("unsupported version: %d", version)
+	}
+
+	r.SeqProfile = buf[1] >> 5
+	r.SeqLevelIdx0 = buf[1] & 0b11111
+	r.SeqTier0 = ((buf[2] >> 7) & 0b1) != 0
+	r.HighBitdepth = ((buf[2] >> 6) & 0b1) != 0
+	r.TwelveBit = ((buf[2] >> 5) & 0b1) != 0
+	r.MonoChrome = ((buf[2] >> 4) & 0b1) != 0
+	r.ChromaSubsamplingX = ((buf[2] >> 3) & 0b1) != 0
+	r.ChromaSubsamplingY = ((buf[2] >> 2) & 0b1) != 0
+	r.ChromaSamplePosition = buf[2] & 0b11
+	r.InitialPresentationDelayPresent = ((buf[3] >> 4) & 0b1) != 0
+
+	if r.InitialPresentationDelayPresent {
+		r.InitialPresentationDelayMinus1 = buf[3] & 0b1111
+	} else {
+		r.InitialPresentationDelayMinus1 = 0
+	}
+
+	if len(buf) > 4 {
+		r.ConfigOBUs = buf[4:]
+	} else {
+		r.ConfigOBUs = nil
+	}
+
+	return nil
+}
+
+// SequenceHeader returns the sequence header contained into ConfigOBUs, if present.
+func (r CodecConfigurationRecord) SequenceHeader() (*SequenceHeader, error) {
+	if len(r.ConfigOBUs) == 0 {
+		return nil, nil
+	}
+
+	tu, err := BitstreamUnmarshal(r.ConfigOBUs, false)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, obu := range tu {
+		var h OBUHeader
+		err = h.Unmarshal(obu)
+		if err != nil {
+			return nil, err
+		}
+
+		if h.Type == OBUTypeSequenceHeader {
+			var sh SequenceHeader
+			err = sh.Unmarshal(obu)
+			if err != nil {
+				return nil, err
+			}
+			return &sh, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Validate checks that the record is consistent with the sequence header.
+// It is not called by Unmarshal or Marshal, since many muxers write records
+// whose fields differ from the sequence header.
+func (r CodecConfigurationRecord) Validate() error {
+	sh, err := r.SequenceHeader()
+	if err != nil {
+		return err
+	}
+
+	if sh == nil {
+		return nil
+	}
+
+	if sh.SeqProfile != r.SeqProfile ||
+		sh.SeqLevelIdx[0] != r.SeqLevelIdx0 ||
+		sh.SeqTier[0] != r.SeqTier0 ||
+		sh.ColorConfig.HighBitDepth != r.HighBitdepth ||
+		sh.ColorConfig.TwelveBit != r.TwelveBit ||
+		sh.ColorConfig.MonoChrome != r.MonoChrome ||
+		sh.ColorConfig.SubsamplingX != r.ChromaSubsamplingX ||
+		sh.ColorConfig.SubsamplingY != r.ChromaSubsamplingY ||
+		uint8(sh.ColorConfig.ChromaSamplePosition) != r.ChromaSamplePosition {
+		return fmt.Errorf("codec configuration record doesn't match sequence header")
+	}
+
+	return nil
+}
+
+func boolToUint8(v bool) uint8 {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+// Marshal encodes a CodecConfigurationRecord.
+func (r CodecConfigurationRecord) Marshal() ([]byte, error) {
+	if r.SeqProfile > 0b111 {
+		return nil, fmt.Errorf("invalid seq_profile: %d", r.SeqProfile)
+	}
+
+	if r.SeqLevelIdx0 > 0b11111 {
+		return nil, fmt.Errorf("invalid seq_level_idx_0: %d", r.SeqLevelIdx0)
+	}
+
+	if r.ChromaSamplePosition > 0b11 {
+		return nil, fmt.Errorf("invalid chroma_sample_position: %d", r.ChromaSamplePosition)
+	}
+
+	if r.InitialPresentationDelayMinus1 > 0b1111 {
+		return nil, fmt.Errorf("invalid initial_presentation_delay_minus_one: %d", r.InitialPresentationDelayMinus1)
+	}
+
+	buf := make([]byte, 4+len(r.ConfigOBUs))
+
+	buf[0] = 0b10000001
+	buf[1] = r.SeqProfile<<5 | r.SeqLevelIdx0
+	buf[2] = boolToUint8(r.SeqTier0)<<7 |
+		boolToUint8(r.HighBitdepth)<<6 |
+		boolToUint8(r.TwelveBit)<<5 |
+		boolToUint8(r.MonoChrome)<<4 |
+		boolToUint8(r.ChromaSubsamplingX)<<3 |
+		boolToUint8(r.ChromaSubsamplingY)<<2 |
+		r.ChromaSamplePosition
+
+	if r.InitialPresentationDelayPresent {
+		buf[3] = 0b10000 | r.InitialPresentationDelayMinus1
+	}
+
+	copy(buf[4:], r.ConfigOBUs)
+
+	return buf, nil
+}
+
+// Clone returns a copy of the CodecConfigurationRecord that doesn't share memory with the original.
+func (r CodecConfigurationRecord) Clone() CodecConfigurationRecord {
+	if r.ConfigOBUs != nil {
+		r.ConfigOBUs = append(make([]byte, 0, len(r.ConfigOBUs)), r.ConfigOBUs...)
+	}
+	return r
+}
+
+// CodecString returns the codec string of the video,
+// that is used in the codecs parameter of MIME types.
+// Optional fields are omitted, since the color configuration is not part of the record.
+// Use SequenceHeader().CodecString() to obtain all fields.
+// Specification: https://aomediacodec.github.io/av1-isobmff/#codecsparam
+func (r CodecConfigurationRecord) CodecString() string {
+	tier := "M"
+	if r.SeqTier0 {
+		tier = "H"
+	}
+
+	bitDepth := 8
+	switch {
+	case r.TwelveBit:
+		bitDepth = 12
+	case r.HighBitdepth:
+		bitDepth = 10
+	}
+
+	return fmt.Sprintf("av01.%d.%02d%s.%02d", r.SeqProfile, r.SeqLevelIdx0, tier, bitDepth)
+}
@@ -0,0 +1,128 @@
+package av1
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesCodecConfigurationRecord = []struct {
+	name string
+	byts []byte
+	rec  CodecConfigurationRecord
+}{
+	{
+		"standard",
+		[]byte{
+			0x81, 0x08, 0x0c, 0x00, 0x0a, 0x0b, 0x00, 0x00,
+			0x00, 0x42, 0xa7, 0xbf, 0xe6, 0x2e, 0xdf, 0xc8,
+			0x42,
+		},
+		CodecConfigurationRecord{
+			SeqLevelIdx0:       8,
+			ChromaSubsamplingX: true,
+			ChromaSubsamplingY: true,
+			ConfigOBUs: []byte{
+				0x0a, 0x0b, 0x00, 0x00, 0x00, 0x42, 0xa7, 0xbf,
+				0xe6, 0x2e, 0xdf, 0xc8, 0x42,
+			},
+		},
+	},
+	{
+		"no config OBUs, initial presentation delay",
+		[]byte{0x81, 0x4d, 0xe0, 0x13},
+		CodecConfigurationRecord{
+			SeqProfile:                      2,
+			SeqLevelIdx0:                    13,
+			SeqTier0:                        true,
+			HighBitdepth:                    true,
+			TwelveBit:                       true,
+			InitialPresentationDelayPresent: true,
+			InitialPresentationDelayMinus1:  3,
+		},
+	},
+}
+
+func TestCodecConfigurationRecordUnmarshal(t *testing.T) {
+	for _, ca := range casesCodecConfigurationRecord {
+		t.Run(ca.name, func(t *testing.T) {
+			var rec CodecConfigurationRecord
+			err := rec.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.rec, rec)
+		})
+	}
+}
+
+func TestCodecConfigurationRecordMarshal(t *testing.T) {
+	for _, ca := range casesCodecConfigurationRecord {
+		t.Run(ca.name, func(t *testing.T) {
+			byts, err := ca.rec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.byts, byts)
+		})
+	}
+}
+
+func TestCodecConfigurationRecordClone(t *testing.T) {
+	for _, ca := range casesCodecConfigurationRecord {
+		t.Run(ca.name, func(t *testing.T) {
+			var orig CodecConfigurationRecord
+			err := orig.Unmarshal(ca.byts)
+			require.NoError(t, err)
+
+			c := orig.Clone()
+			require.Equal(t, orig, c)
+
+			for i := range c.ConfigOBUs {
+				c.ConfigOBUs[i]++
+			}
+			require.Equal(t, ca.rec, orig)
+		})
+	}
+}
+
+func TestCodecConfigurationRecordCodecString(t *testing.T) {
+	rec := casesCodecConfigurationRecord[0].rec
+	sh, err := rec.SequenceHeader()
+	require.NoError(t, err)
+	require.Equal(t, "av01.0.08M.08", rec.CodecString())
+	require.Equal(t, true, strings.HasPrefix(sh.CodecString(), rec.CodecString()+"."))
+
+	require.Equal(t, "av01.2.13H.12", casesCodecConfigurationRecord[1].rec.CodecString())
+}
+
+func TestCodecConfigurationRecordMismatch(t *testing.T) {
+	byts := []byte{
+		0x81, 0x09, 0x0c, 0x00, 0x0a, 0x0b, 0x00, 0x00,
+		0x00, 0x42, 0xa7, 0xbf, 0xe6, 0x2e, 0xdf, 0xc8,
+		0x42,
+	}
+
+	var rec CodecConfigurationRecord
+	err := rec.Unmarshal(byts)
+	require.NoError(t, err)
+
+	err = rec.Validate()
+	require.EqualError(t, err, "codec configuration record doesn't match sequence header")
+
+	// records that don't match their sequence header are still written as they are.
+	enc, err := rec.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, byts, enc)
+}
+
+func FuzzCodecConfigurationRecordUnmarshal(f *testing.F) {
+	for _, ca := range casesCodecConfigurationRecord {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var rec CodecConfigurationRecord
+		err := rec.Unmarshal(b)
+		if err == nil {
+			rec.Marshal() //nolint:errcheck
+		}
+	})
+}
@@ -0,0 +1,408 @@
+package av1
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/pkg/bits"
+)
+
+const (
+	frameHeaderNumRefFrames  = 8
+	frameHeaderRefsPerFrame  = 7
+	frameHeaderAllFrames     = (1 << frameHeaderNumRefFrames) - 1
+	frameHeaderSuperresNum   = 8
+	frameHeaderSuperresDenom = 9
+)
+
+// FrameHeader_FrameType is a FrameType value.
+type FrameHeader_FrameType uint8 //nolint:revive
+
+const (
+	FrameHeader_FrameType_KEY_FRAME        FrameHeader_FrameType = 0 //nolint:revive
+	FrameHeader_FrameType_INTER_FRAME      FrameHeader_FrameType = 1 //nolint:revive
+	FrameHeader_FrameType_INTRA_ONLY_FRAME FrameHeader_FrameType = 2 //nolint:revive
+	FrameHeader_FrameType_SWITCH_FRAME     FrameHeader_FrameType = 3 //nolint:revive
+)
+
+// FrameHeader is the frame header contained in a AV1 Frame header OBU or Frame OBU.
+// Only fields up to the frame size are decoded.
+// Specification: https://aomediacodec.github.io/av1-spec/#uncompressed-header-syntax
+type FrameHeader struct {
+	ShowExistingFrame bool
+
+	// present when ShowExistingFrame is true.
+	// In this case, the following fields are not decoded.
+	FrameToShowMapIdx uint8
+
+	FrameType               FrameHeader_FrameType
+	ShowFrame               bool
+	ShowableFrame           bool
+	ErrorResilientMode      bool
+	DisableCdfUpdate        bool
+	AllowScreenContentTools bool
+	ForceIntegerMv          bool
+	CurrentFrameID          uint32
+	FrameSizeOverrideFlag   bool
+	OrderHint               uint32
+	RefreshFrameFlags       uint8
+	UseSuperres             bool
+
+	// whether the frame size is copied from a reference frame.
+	// In this case, FrameWidth, FrameHeight and UpscaledWidth are not available.
+	FrameSizeFromRef bool
+
+	FrameWidth    int
+	FrameHeight   int
+	UpscaledWidth int
+}
+
+func (h *FrameHeader) unmarshalFrameSize(sh *SequenceHeader, buf []byte, pos *int) error {
+	if h.FrameSizeOverrideFlag {
+		n1 := int(sh.FrameWidthBitsMinus1) + 1
+		n2 := int(sh.FrameHeightBitsMinus1) + 1
+
+		err := bits.HasSpace(buf, *pos, n1+n2)
+		if err != nil {
+			return err
+		}
+
+		h.FrameWidth = int(bits.ReadBitsUnsafe(buf, pos, n1)) + 1
+		h.FrameHeight = int(bits.ReadBitsUnsafe(buf, pos, n2)) + 1
+	} else {
+		h.FrameWidth = int(sh.MaxFrameWidthMinus1) + 1
+		h.FrameHeight = int(sh.MaxFrameHeightMinus1) + 1
+	}
+
+	// superres_params()
+	h.UpscaledWidth = h.FrameWidth
+
+	if sh.EnableSuperRes {
+		var err error
+		h.UseSuperres, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		h.UseSuperres = false
+	}
+
+	if h.UseSuperres {
+		codedDenom, err := bits.ReadBits(buf, pos, 3)
+		if err != nil {
+			return err
+		}
+
+		superresDenom := int(codedDenom) + frameHeaderSuperresDenom
+		h.FrameWidth = (h.UpscaledWidth*frameHeaderSuperresNum + (superresDenom / 2)) / superresDenom
+	}
+
+	return nil
+}
+
+func (h *FrameHeader) unmarshalFrameRefs(sh *SequenceHeader, buf []byte, pos *int) error {
+	frameRefsShortSignaling := false
+
+	if sh.EnableOrderHint {
+		var err error
+		frameRefsShortSignaling, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if frameRefsShortSignaling {
+			// last_frame_idx, gold_frame_idx
+			err = bits.HasSpace(buf, *pos, 6)
+			if err != nil {
+				return err
+			}
+			*pos += 6
+		}
+	}
+
+	n := 0
+	if !frameRefsShortSignaling {
+		n += 3
+	}
+	if sh.FrameIDNumbersPresentFlag {
+		n += int(sh.DeltaFrameIDLengthMinus2) + 2
+	}
+
+	// ref_frame_idx[], delta_frame_id_minus_1
+	err := bits.HasSpace(buf, *pos, n*frameHeaderRefsPerFrame)
+	if err != nil {
+		return err
+	}
+	*pos += n * frameHeaderRefsPerFrame
+
+	return nil
+}
+
+// Unmarshal decodes a FrameHeader.
+// The sequence header that is active for the frame is required.
+func (h *FrameHeader) Unmarshal(buf []byte, sh *SequenceHeader) error {
+	oh, buf, err := obuPayload(buf)
+	if err != nil {
+		return err
+	}
+
+	if oh.Type != OBUTypeFrameHeader && oh.Type != OBUTypeFrame {
+		return fmt.Errorf("OBU type is not frame header or frame: %d", oh.Type)
+	}
+
+	pos := 0
+
+	idLen := 0
+	if sh.FrameIDNumbersPresentFlag {
+		idLen = int(sh.AdditionalFrameIDLengthMinus1) + int(sh.DeltaFrameIDLengthMinus2) + 3
+	}
+
+	temporalPointInfoPresent := sh.DecoderModelInfoPresentFlag && sh.DecoderModelInfo != nil &&
+		sh.TimingInfo != nil && !sh.TimingInfo.EqualPictureInterval
+
+	if sh.ReducedStillPictureHeader {
+		h.ShowExistingFrame = false
+		h.FrameType = FrameHeader_FrameType_KEY_FRAME
+		h.ShowFrame = true
+		h.ShowableFrame = false
+		h.ErrorResilientMode = true
+	} else {
+		h.ShowExistingFrame, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		if h.ShowExistingFrame {
+			var tmp uint64
+			tmp, err = bits.ReadBits(buf, &pos, 3)
+			if err != nil {
+				return err
+			}
+			h.FrameToShowMapIdx = uint8(tmp)
+			return nil
+		}
+
+		err = bits.HasSpace(buf, pos, 3)
+		if err != nil {
+			return err
+		}
+
+		h.FrameType = FrameHeader_FrameType(bits.ReadBitsUnsafe(buf, &pos, 2))
+		h.ShowFrame = bits.ReadFlagUnsafe(buf, &pos)
+
+		if h.ShowFrame && temporalPointInfoPresent {
+			// frame_presentation_time
+			n := int(sh.DecoderModelInfo.FramePresentationTimeLengthMinus1) + 1
+			err = bits.HasSpace(buf, pos, n)
+			if err != nil {
+				return err
+			}
+			pos += n
+		}
+
+		if h.ShowFrame {
+			h.ShowableFrame = (h.FrameType != FrameHeader_FrameType_KEY_FRAME)
+		} else {
+			h.ShowableFrame, err = bits.ReadFlag(buf, &pos)
+			if err != nil {
+				return err
+			}
+		}
+
+		if h.FrameType == FrameHeader_FrameType_SWITCH_FRAME ||
+			(h.FrameType == FrameHeader_FrameType_KEY_FRAME && h.ShowFrame) {
+			h.ErrorResilientMode = true
+		} else {
+			h.ErrorResilientMode, err = bits.ReadFlag(buf, &pos)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	frameIsIntra := (h.FrameType == FrameHeader_FrameType_INTRA_ONLY_FRAME ||
+		h.FrameType == FrameHeader_FrameType_KEY_FRAME)
+
+	h.DisableCdfUpdate, err = bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if sh.SeqForceScreenContentTools == SequenceHeader_SeqForceScreenContentTools_SELECT_SCREEN_CONTENT_TOOLS {
+		h.AllowScreenContentTools, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		h.AllowScreenContentTools = (sh.SeqForceScreenContentTools != 0)
+	}
+
+	if h.AllowScreenContentTools {
+		if sh.SeqForceIntegerMv == SequenceHeader_SeqForceIntegerMv_SELECT_INTEGER_MV {
+			h.ForceIntegerMv, err = bits.ReadFlag(buf, &pos)
+			if err != nil {
+				return err
+			}
+		} else {
+			h.ForceIntegerMv = (sh.SeqForceIntegerMv != 0)
+		}
+	} else {
+		h.ForceIntegerMv = false
+	}
+
+	if frameIsIntra {
+		h.ForceIntegerMv = true
+	}
+
+	if sh.FrameIDNumbersPresentFlag {
+		var tmp uint64
+		tmp, err = bits.ReadBits(buf, &pos, idLen)
+		if err != nil {
+			return err
+		}
+		h.CurrentFrameID = uint32(tmp)
+	} else {
+		h.CurrentFrameID = 0
+	}
+
+	switch {
+	case h.FrameType == FrameHeader_FrameType_SWITCH_FRAME:
+		h.FrameSizeOverrideFlag = true
+
+	case sh.ReducedStillPictureHeader:
+		h.FrameSizeOverrideFlag = false
+
+	default:
+		h.FrameSizeOverrideFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	orderHintBits := 0
+	if sh.EnableOrderHint {
+		orderHintBits = int(sh.OrderHintBitsMinus1) + 1
+	}
+
+	var tmp uint64
+
+	if orderHintBits != 0 {
+		tmp, err = bits.ReadBits(buf, &pos, orderHintBits)
+		if err != nil {
+			return err
+		}
+		h.OrderHint = uint32(tmp)
+	} else {
+		h.OrderHint = 0
+	}
+
+	if !frameIsIntra && !h.ErrorResilientMode {
+		// primary_ref_frame
+		err = bits.HasSpace(buf, pos, 3)
+		if err != nil {
+			return err
+		}
+		pos += 3
+	}
+
+	if sh.DecoderModelInfoPresentFlag && sh.DecoderModelInfo != nil {
+		err = skipBufferRemovalTimes(sh, oh, buf, &pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	if h.FrameType == FrameHeader_FrameType_SWITCH_FRAME ||
+		(h.FrameType == FrameHeader_FrameType_KEY_FRAME && h.ShowFrame) {
+		h.RefreshFrameFlags = frameHeaderAllFrames
+	} else {
+		tmp, err = bits.ReadBits(buf, &pos, 8)
+		if err != nil {
+			return err
+		}
+		h.RefreshFrameFlags = uint8(tmp)
+	}
+
+	if (!frameIsIntra || h.RefreshFrameFlags != frameHeaderAllFrames) &&
+		h.ErrorResilientMode && sh.EnableOrderHint {
+		// ref_order_hint[]
+		n := orderHintBits * frameHeaderNumRefFrames
+		err = bits.HasSpace(buf, pos, n)
+		if err != nil {
+			return err
+		}
+		pos += n
+	}
+
+	h.FrameSizeFromRef = false
+
+	if frameIsIntra {
+		return h.unmarshalFrameSize(sh, buf, &pos)
+	}
+
+	err = h.unmarshalFrameRefs(sh, buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if h.FrameSizeOverrideFlag && !h.ErrorResilientMode {
+		// frame_size_with_refs()
+		for i := 0; i < frameHeaderRefsPerFrame; i++ {
+			var foundRef bool
+			foundRef, err = bits.ReadFlag(buf, &pos)
+			if err != nil {
+				return err
+			}
+
+			if foundRef {
+				h.FrameSizeFromRef = true
+				h.FrameWidth = 0
+				h.FrameHeight = 0
+				h.UpscaledWidth = 0
+				return nil
+			}
+		}
+	}
+
+	return h.unmarshalFrameSize(sh, buf, &pos)
+}
+
+func skipBufferRemovalTimes(sh *SequenceHeader, oh OBUHeader, buf []byte, pos *int) error {
+	bufferRemovalTimePresentFlag, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if !bufferRemovalTimePresentFlag {
+		return nil
+	}
+
+	n := int(sh.DecoderModelInfo.BufferRemovalTimeLengthMinus1) + 1
+
+	for opNum := 0; opNum <= int(sh.OperatingPointsCntMinus1); opNum++ {
+		if opNum >= len(sh.DecoderModelPresentForThisOp) || opNum >= len(sh.OperatingPointIdc) {
+			return fmt.Errorf("invalid sequence header")
+		}
+
+		if sh.DecoderModelPresentForThisOp[opNum] {
+			opPtIdc := sh.OperatingPointIdc[opNum]
+			inTemporalLayer := ((opPtIdc >> oh.TemporalID) & 1) != 0
+			inSpatialLayer := ((opPtIdc >> (oh.SpatialID + 8)) & 1) != 0
+
+			if opPtIdc == 0 || (inTemporalLayer && inSpatialLayer) {
+				// buffer_removal_time
+				err = bits.HasSpace(buf, *pos, n)
+				if err != nil {
+					return err
+				}
+				*pos += n
+			}
+		}
+	}
+
+	return nil
+}
+
+// IsKeyFrame returns whether the frame is a key frame.
+func (h FrameHeader) IsKeyFrame() bool {
+	return !h.ShowExistingFrame && h.FrameType == FrameHeader_FrameType_KEY_FRAME
+}
@@ -0,0 +1,148 @@
+package av1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var frameHeaderTestSequenceHeader = []byte{
+	8, 0, 0, 0, 66, 167, 191, 228, 96, 13, 0, 64,
+}
+
+var casesFrameHeader = []struct {
+	name string
+	sh   *SequenceHeader
+	byts []byte
+	fh   FrameHeader
+}{
+	{
+		"key frame",
+		nil,
+		[]byte{0x1a, 0x02, 0x10, 0x00},
+		FrameHeader{
+			FrameType:          FrameHeader_FrameType_KEY_FRAME,
+			ShowFrame:          true,
+			ErrorResilientMode: true,
+			ForceIntegerMv:     true,
+			RefreshFrameFlags:  0xff,
+			FrameWidth:         1920,
+			FrameHeight:        804,
+			UpscaledWidth:      1920,
+		},
+	},
+	{
+		"inter frame",
+		nil,
+		[]byte{0x32, 0x05, 0x30, 0x00, 0x20, 0x00, 0x00},
+		FrameHeader{
+			FrameType:         FrameHeader_FrameType_INTER_FRAME,
+			ShowFrame:         true,
+			ShowableFrame:     true,
+			RefreshFrameFlags: 0x01,
+			FrameWidth:        1920,
+			FrameHeight:       804,
+			UpscaledWidth:     1920,
+		},
+	},
+	{
+		"inter frame with size from reference",
+		nil,
+		[]byte{0x32, 0x06, 0x31, 0x00, 0x20, 0x00, 0x00, 0x80},
+		FrameHeader{
+			FrameType:             FrameHeader_FrameType_INTER_FRAME,
+			ShowFrame:             true,
+			ShowableFrame:         true,
+			FrameSizeOverrideFlag: true,
+			RefreshFrameFlags:     0x01,
+			FrameSizeFromRef:      true,
+		},
+	},
+	{
+		"intra only frame with superres",
+		&SequenceHeader{
+			FrameWidthBitsMinus1:  10,
+			FrameHeightBitsMinus1: 9,
+			MaxFrameWidthMinus1:   1919,
+			MaxFrameHeightMinus1:  1079,
+			SeqForceIntegerMv:     SequenceHeader_SeqForceIntegerMv_SELECT_INTEGER_MV,
+			EnableSuperRes:        true,
+		},
+		[]byte{0x1a, 0x05, 0x52, 0x02, 0xef, 0xe1, 0xbf},
+		FrameHeader{
+			FrameType:             FrameHeader_FrameType_INTRA_ONLY_FRAME,
+			ShowFrame:             true,
+			ShowableFrame:         true,
+			ForceIntegerMv:        true,
+			FrameSizeOverrideFlag: true,
+			RefreshFrameFlags:     0x01,
+			UseSuperres:           true,
+			FrameWidth:            480,
+			FrameHeight:           540,
+			UpscaledWidth:         960,
+		},
+	},
+	{
+		"show existing frame",
+		nil,
+		[]byte{0x1a, 0x01, 0xa0},
+		FrameHeader{
+			ShowExistingFrame: true,
+			FrameToShowMapIdx: 2,
+		},
+	},
+}
+
+func TestFrameHeaderUnmarshal(t *testing.T) {
+	for _, ca := range casesFrameHeader {
+		t.Run(ca.name, func(t *testing.T) {
+			sh := ca.sh
+			if sh == nil {
+				sh = &SequenceHeader{}
+				err := sh.Unmarshal(frameHeaderTestSequenceHeader)
+				require.NoError(t, err)
+			}
+
+			var fh FrameHeader
+			err := fh.Unmarshal(ca.byts, sh)
+			require.NoError(t, err)
+			require.Equal(t, ca.fh, fh)
+		})
+	}
+}
+
+func TestFrameHeaderIsKeyFrame(t *testing.T) {
+	require.True(t, casesFrameHeader[0].fh.IsKeyFrame())
+	require.False(t, casesFrameHeader[1].fh.IsKeyFrame())
+	require.False(t, casesFrameHeader[4].fh.IsKeyFrame())
+}
+
+func TestFrameHeaderUnmarshalErrors(t *testing.T) {
+	var sh SequenceHeader
+	err := sh.Unmarshal(frameHeaderTestSequenceHeader)
+	require.NoError(t, err)
+
+	var fh FrameHeader
+	err = fh.Unmarshal(frameHeaderTestSequenceHeader, &sh)
+	require.EqualError(t, err, "OBU type is not frame header or frame: 1")
+
+	err = fh.Unmarshal([]byte{0x32, 0x01, 0x30}, &sh)
+	require.EqualError(t, err, "not enough bits")
+}
+
+func FuzzFrameHeaderUnmarshal(f *testing.F) {
+	for _, ca := range casesFrameHeader {
+		f.Add(ca.byts)
+	}
+
+	var sh SequenceHeader
+	err := sh.Unmarshal(frameHeaderTestSequenceHeader)
+	if err != nil {
+		panic(err)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var fh FrameHeader
+		fh.Unmarshal(b, &sh) //nolint:errcheck
+	})
+}
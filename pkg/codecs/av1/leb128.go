@@ -4,30 +4,53 @@ import (
 	"fmt"
 )
 
+// LEB128UnmarshalOptions contains options of LEB128UnmarshalWithOptions.
+type LEB128UnmarshalOptions struct {
+	// reject values that are not encoded with the minimum number of bytes.
+	Strict bool
+}
+
 // LEB128Unmarshal decodes an unsigned integer from the LEB128 format.
 // Specification: https://aomediacodec.github.io/av1-spec/#leb128
 func LEB128Unmarshal(buf []byte) (uint, int, error) {
-	v := uint(0)
+	return LEB128UnmarshalWithOptions(buf, LEB128UnmarshalOptions{})
+}
+
+// LEB128UnmarshalWithOptions decodes an unsigned integer from the LEB128 format.
+// Specification: https://aomediacodec.github.io/av1-spec/#leb128
+func LEB128UnmarshalWithOptions(buf []byte, opts LEB128UnmarshalOptions) (uint, int, error) {
+	v := uint64(0)
 	n := 0
 
-	for i := 0; i < 8; i++ {
+	for i := 0; ; i++ {
+		if i == 8 {
+			return 0, 0, fmt.Errorf("LEB128 value is longer than 8 bytes")
+		}
+
 		if len(buf) == 0 {
 			return 0, 0, fmt.Errorf("not enough bytes")
 		}
 
 		b := buf[0]
 
-		v |= (uint(b&0b01111111) << (i * 7))
+		v |= (uint64(b&0b01111111) << (i * 7))
 		n++
 
 		if (b & 0b10000000) == 0 {
+			if opts.Strict && i != 0 && b == 0 {
+				return 0, 0, fmt.Errorf("LEB128 value is not minimally encoded")
+			}
 			break
 		}
 
 		buf = buf[1:]
 	}
 
-	return v, n, nil
+	if v > 0xFFFFFFFF {
+		return 0, 0, fmt.Errorf("LEB128 value is too big")
+	}
+
+	return uint(v), n, nil
 }
 
 // LEB128MarshalSize returns the marshal size of an unsigned integer in LEB128 format.
@@ -69,3 +92,11 @@ func LEB128MarshalTo(v uint, buf []byte) int {
 
 	return n
 }
+
+// LEB128Marshal encodes an unsigned integer with the LEB128 format.
+// Specification: https://aomediacodec.github.io/av1-spec/#leb128
+func LEB128Marshal(v uint) []byte {
+	buf := make([]byte, LEB128MarshalSize(v))
+	LEB128MarshalTo(v, buf)
+	return buf
+}
@@ -26,6 +26,11 @@ var casesLEB128 = []struct {
 		651321342,
 		[]byte{0xfe, 0xbf, 0xc9, 0xb6, 0x2},
 	},
+	{
+		"max",
+		0xFFFFFFFF,
+		[]byte{0xff, 0xff, 0xff, 0xff, 0x0f},
+	},
 }
 
 func TestLEB128Unmarshal(t *testing.T) {
@@ -46,6 +51,57 @@ func TestLEB128Marshal(t *testing.T) {
 			n := LEB128MarshalTo(ca.dec, enc)
 			require.Equal(t, ca.enc, enc)
 			require.Equal(t, len(ca.enc), n)
+
+			require.Equal(t, ca.enc, LEB128Marshal(ca.dec))
+		})
+	}
+}
+
+func TestLEB128UnmarshalNonMinimal(t *testing.T) {
+	dec, n, err := LEB128Unmarshal([]byte{0xff, 0x80, 0x00})
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	require.Equal(t, uint(127), dec)
+
+	_, _, err = LEB128UnmarshalWithOptions([]byte{0xff, 0x80, 0x00}, LEB128UnmarshalOptions{Strict: true})
+	require.EqualError(t, err, "LEB128 value is not minimally encoded")
+
+	dec, n, err = LEB128UnmarshalWithOptions([]byte{0x00}, LEB128UnmarshalOptions{Strict: true})
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.Equal(t, uint(0), dec)
+}
+
+func TestLEB128UnmarshalErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		byts []byte
+		err  string
+	}{
+		{
+			"empty",
+			[]byte{},
+			"not enough bytes",
+		},
+		{
+			"truncated",
+			[]byte{0x80, 0x80},
+			"not enough bytes",
+		},
+		{
+			"too long",
+			[]byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x00},
+			"LEB128 value is longer than 8 bytes",
+		},
+		{
+			"too big",
+			[]byte{0xff, 0xff, 0xff, 0xff, 0x10},
+			"LEB128 value is too big",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			_, _, err := LEB128Unmarshal(ca.byts)
+			require.EqualError(t, err, ca.err)
 		})
 	}
 }
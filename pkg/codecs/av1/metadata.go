@@ -0,0 +1,178 @@
+package av1
+
+import (
+	"fmt"
+)
+
+// MetadataType is a metadata type.
+type MetadataType uint32
+
+// metadata types.
+const (
+	MetadataTypeHDRCLL      MetadataType = 1
+	MetadataTypeHDRMDCV     MetadataType = 2
+	MetadataTypeScalability MetadataType = 3
+	MetadataTypeITUTT35     MetadataType = 4
+	MetadataTypeTimecode    MetadataType = 5
+)
+
+// Metadata_HDRCLL is a high dynamic range content light level metadata.
+// Specification: https://aomediacodec.github.io/av1-spec/#metadata-high-dynamic-range-content-light-level-syntax
+type Metadata_HDRCLL struct { //nolint:revive
+	MaxCLL  uint16
+	MaxFALL uint16
+}
+
+func (m *Metadata_HDRCLL) unmarshal(buf []byte) error {
+	if len(buf) < 4 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	m.MaxCLL = uint16(buf[0])<<8 | uint16(buf[1])
+	m.MaxFALL = uint16(buf[2])<<8 | uint16(buf[3])
+
+	return nil
+}
+
+// Metadata_HDRMDCV is a high dynamic range mastering display color volume metadata.
+// Specification: https://aomediacodec.github.io/av1-spec/#metadata-high-dynamic-range-mastering-display-color-volume-syntax
+type Metadata_HDRMDCV struct { //nolint:revive
+	// chromaticity coordinates of the red, green and blue primaries, in 0.16 fixed-point format.
+	PrimaryChromaticityX [3]uint16
+	PrimaryChromaticityY [3]uint16
+
+	// chromaticity coordinates of the white point, in 0.16 fixed-point format.
+	WhitePointChromaticityX uint16
+	WhitePointChromaticityY uint16
+
+	// luminance in candelas per square meter, in 24.8 fixed-point format.
+	LuminanceMax uint32
+
+	// luminance in candelas per square meter, in 18.14 fixed-point format.
+	LuminanceMin uint32
+}
+
+func (m *Metadata_HDRMDCV) unmarshal(buf []byte) error {
+	if len(buf) < 24 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	for i := 0; i < 3; i++ {
+		m.PrimaryChromaticityX[i] = uint16(buf[i*4])<<8 | uint16(buf[i*4+1])
+		m.PrimaryChromaticityY[i] = uint16(buf[i*4+2])<<8 | uint16(buf[i*4+3])
+	}
+
+	m.WhitePointChromaticityX = uint16(buf[12])<<8 | uint16(buf[13])
+	m.WhitePointChromaticityY = uint16(buf[14])<<8 | uint16(buf[15])
+	m.LuminanceMax = uint32(buf[16])<<24 | uint32(buf[17])<<16 | uint32(buf[18])<<8 | uint32(buf[19])
+	m.LuminanceMin = uint32(buf[20])<<24 | uint32(buf[21])<<16 | uint32(buf[22])<<8 | uint32(buf[23])
+
+	return nil
+}
+
+// Metadata_ITUTT35 is a ITU-T T.35 metadata.
+// Specification: https://aomediacodec.github.io/av1-spec/#metadata-itut-t35-syntax
+type Metadata_ITUTT35 struct { //nolint:revive
+	CountryCode uint8
+
+	// present when CountryCode is 0xFF.
+	CountryCodeExtensionByte uint8
+
+	// payload, without trailing bits.
+	Payload []byte
+}
+
+func (m *Metadata_ITUTT35) unmarshal(buf []byte) error {
+	if len(buf) < 1 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	m.CountryCode = buf[0]
+	buf = buf[1:]
+
+	if m.CountryCode == 0xFF {
+		if len(buf) < 1 {
+			return fmt.Errorf("not enough bytes")
+		}
+
+		m.CountryCodeExtensionByte = buf[0]
+		buf = buf[1:]
+	} else {
+		m.CountryCodeExtensionByte = 0
+	}
+
+	m.Payload = removeTrailingBits(buf)
+
+	return nil
+}
+
+// removeTrailingBits removes byte-aligned trailing bits, that are a 0x80 byte followed by zero bytes.
+// Specification: https://aomediacodec.github.io/av1-spec/#trailing-bits-syntax
+func removeTrailingBits(buf []byte) []byte {
+	i := len(buf) - 1
+	for i >= 0 && buf[i] == 0 {
+		i--
+	}
+
+	if i < 0 || buf[i] != 0x80 {
+		return buf
+	}
+
+	return buf[:i]
+}
+
+// Metadata is a metadata OBU.
+// Specification: https://aomediacodec.github.io/av1-spec/#metadata-obu-syntax
+type Metadata struct {
+	Type MetadataType
+
+	// decoded content of known metadata types.
+	HDRCLL  *Metadata_HDRCLL
+	HDRMDCV *Metadata_HDRMDCV
+	ITUTT35 *Metadata_ITUTT35
+
+	// content of metadata types that are not decoded, including trailing bits.
+	Data []byte
+}
+
+// Unmarshal decodes a Metadata.
+func (m *Metadata) Unmarshal(buf []byte) error {
+	oh, buf, err := obuPayload(buf)
+	if err != nil {
+		return err
+	}
+
+	if oh.Type != OBUTypeMetadata {
+		return fmt.Errorf("OBU type is not metadata: %d", oh.Type)
+	}
+
+	typ, n, err := LEB128Unmarshal(buf)
+	if err != nil {
+		return err
+	}
+	m.Type = MetadataType(typ)
+	buf = buf[n:]
+
+	m.HDRCLL = nil
+	m.HDRMDCV = nil
+	m.ITUTT35 = nil
+	m.Data = nil
+
+	switch m.Type {
+	case MetadataTypeHDRCLL:
+		m.HDRCLL = &Metadata_HDRCLL{}
+		return m.HDRCLL.unmarshal(buf)
+
+	case MetadataTypeHDRMDCV:
+		m.HDRMDCV = &Metadata_HDRMDCV{}
+		return m.HDRMDCV.unmarshal(buf)
+
+	case MetadataTypeITUTT35:
+		m.ITUTT35 = &Metadata_ITUTT35{}
+		return m.ITUTT35.unmarshal(buf)
+
+	default:
+		m.Data = buf
+		return nil
+	}
+}
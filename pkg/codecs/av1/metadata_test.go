@@ -0,0 +1,119 @@
+package av1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesMetadata = []struct {
+	name string
+	byts []byte
+	m    Metadata
+}{
+	{
+		"hdr cll",
+		[]byte{
+			0x2a, 0x06, 0x01, 0x03, 0xe8, 0x01, 0x90, 0x80,
+		},
+		Metadata{
+			Type: MetadataTypeHDRCLL,
+			HDRCLL: &Metadata_HDRCLL{
+				MaxCLL:  1000,
+				MaxFALL: 400,
+			},
+		},
+	},
+	{
+		"hdr mdcv",
+		[]byte{
+			0x2a, 0x1a, 0x02, 0xb5, 0x3f, 0x4a, 0xc0, 0x2b,
+			0x85, 0xcc, 0x08, 0x21, 0x89, 0x0b, 0xc6, 0x50,
+			0x0d, 0x54, 0x39, 0x00, 0x03, 0xe8, 0x00, 0x00,
+			0x00, 0x00, 0x52, 0x80,
+		},
+		Metadata{
+			Type: MetadataTypeHDRMDCV,
+			HDRMDCV: &Metadata_HDRMDCV{
+				PrimaryChromaticityX:    [3]uint16{46399, 11141, 8585},
+				PrimaryChromaticityY:    [3]uint16{19136, 52232, 3014},
+				WhitePointChromaticityX: 20493,
+				WhitePointChromaticityY: 21561,
+				LuminanceMax:            256000,
+				LuminanceMin:            82,
+			},
+		},
+	},
+	{
+		"itu-t t.35",
+		[]byte{
+			0x2a, 0x08, 0x04, 0xb5, 0x00, 0x3c, 0x00, 0x01,
+			0x04, 0x80,
+		},
+		Metadata{
+			Type: MetadataTypeITUTT35,
+			ITUTT35: &Metadata_ITUTT35{
+				CountryCode: 0xb5,
+				Payload:     []byte{0x00, 0x3c, 0x00, 0x01, 0x04},
+			},
+		},
+	},
+	{
+		"itu-t t.35 with country code extension",
+		[]byte{
+			0x2a, 0x06, 0x04, 0xff, 0x01, 0x02, 0x80, 0x00,
+		},
+		Metadata{
+			Type: MetadataTypeITUTT35,
+			ITUTT35: &Metadata_ITUTT35{
+				CountryCode:              0xff,
+				CountryCodeExtensionByte: 0x01,
+				Payload:                  []byte{0x02},
+			},
+		},
+	},
+	{
+		"timecode",
+		[]byte{
+			0x28, 0x05, 0x01, 0x02, 0x03,
+		},
+		Metadata{
+			Type: MetadataTypeTimecode,
+			Data: []byte{0x01, 0x02, 0x03},
+		},
+	},
+}
+
+func TestMetadataUnmarshal(t *testing.T) {
+	for _, ca := range casesMetadata {
+		t.Run(ca.name, func(t *testing.T) {
+			var m Metadata
+			err := m.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.m, m)
+		})
+	}
+}
+
+func TestMetadataUnmarshalErrors(t *testing.T) {
+	var m Metadata
+	err := m.Unmarshal([]byte{0x0a, 0x01, 0x01})
+	require.EqualError(t, err, "OBU type is not metadata: 1")
+
+	err = m.Unmarshal([]byte{0x2a, 0x03, 0x01, 0x03, 0xe8})
+	require.EqualError(t, err, "not enough bytes")
+
+	err = m.Unmarshal([]byte{0x2a, 0x02, 0x04, 0xff})
+	require.EqualError(t, err, "not enough bytes")
+}
+
+func FuzzMetadataUnmarshal(f *testing.F) {
+	for _, ca := range casesMetadata {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var m Metadata
+		m.Unmarshal(b) //nolint:errcheck
+	})
+}
@@ -0,0 +1,68 @@
+package av1
+
+import (
+	"fmt"
+)
+
+// OBUSize returns the size that an OBU has when it contains the size field,
+// that is the size of the header, of the size field and of the payload.
+// If the OBU contains the size field, the size is read from it,
+// and the buffer can contain additional data after the OBU.
+// Otherwise, the whole buffer is considered to be the OBU.
+// The size field encodes the size of the payload only, therefore
+// its length doesn't depend on itself.
+// Specification: https://aomediacodec.github.io/av1-spec/#obu-syntax
+func OBUSize(obu []byte) (int, error) {
+	var h OBUHeader
+	headerN, err := h.unmarshal(obu)
+	if err != nil {
+		return 0, err
+	}
+
+	if h.HasSize {
+		size, sizeN, err2 := LEB128Unmarshal(obu[headerN:])
+		if err2 != nil {
+			return 0, err2
+		}
+
+		n := headerN + sizeN + int(size)
+		if len(obu) < n {
+			return 0, fmt.Errorf("not enough bytes")
+		}
+
+		return n, nil
+	}
+
+	size := len(obu) - headerN
+
+	return headerN + LEB128MarshalSize(uint(size)) + size, nil
+}
+
+// MarshalOBU encodes an OBU made of a header and a payload.
+// The size field is written when HasSize is true.
+// Specification: https://aomediacodec.github.io/av1-spec/#obu-syntax
+func MarshalOBU(header OBUHeader, payload []byte) ([]byte, error) {
+	n := header.marshalSize() + len(payload)
+	if header.HasSize {
+		n += LEB128MarshalSize(uint(len(payload)))
+	}
+
+	if n > MaxOBUSize {
+		return nil, fmt.Errorf("OBU size (%d) is too big, maximum is %d", n, MaxOBUSize)
+	}
+
+	buf := make([]byte, n)
+
+	n, err := header.marshalTo(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	if header.HasSize {
+		n += LEB128MarshalTo(uint(len(payload)), buf[n:])
+	}
+
+	copy(buf[n:], payload)
+
+	return buf, nil
+}
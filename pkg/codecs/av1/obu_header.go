@@ -9,27 +9,126 @@ import (
 type OBUHeader struct {
 	Type    OBUType
 	HasSize bool
+
+	// fields of the extension header.
+	TemporalID uint8
+	SpatialID  uint8
 }
 
 // Unmarshal decodes a OBUHeader.
 func (h *OBUHeader) Unmarshal(buf []byte) error {
+	_, err := h.unmarshal(buf)
+	return err
+}
+
+// unmarshal decodes a OBUHeader and returns its size.
+func (h *OBUHeader) unmarshal(buf []byte) (int, error) {
 	if len(buf) < 1 {
-		return fmt.Errorf("not enough bytes")
+		return 0, fmt.Errorf("not enough bytes")
 	}
 
 	forbidden := (buf[0] >> 7) != 0
 	if forbidden {
-		return fmt.Errorf("forbidden bit is set")
+		return 0, fmt.Errorf("forbidden bit is set")
 	}
 
 	h.Type = OBUType(buf[0] >> 3)
 
 	extensionFlag := ((buf[0] >> 2) & 0b1) != 0
-	if extensionFlag {
-		return fmt.Errorf("extension flag is not supported yet")
-	}
 
 	h.HasSize = ((buf[0] >> 1) & 0b1) != 0
 
-	return nil
+	if !extensionFlag {
+		h.TemporalID = 0
+		h.SpatialID = 0
+		return 1, nil
+	}
+
+	if len(buf) < 2 {
+		return 0, fmt.Errorf("not enough bytes")
+	}
+
+	h.TemporalID = buf[1] >> 5
+	h.SpatialID = (buf[1] >> 3) & 0b11
+
+	return 2, nil
+}
+
+// obuPayload decodes the header of a OBU and returns the OBU payload,
+// that follows the header and the optional size field.
+func obuPayload(buf []byte) (OBUHeader, []byte, error) {
+	var h OBUHeader
+	headerN, err := h.unmarshal(buf)
+	if err != nil {
+		return OBUHeader{}, nil, err
+	}
+	buf = buf[headerN:]
+
+	if h.HasSize {
+		var size uint
+		var sizeN int
+		size, sizeN, err = LEB128Unmarshal(buf)
+		if err != nil {
+			return OBUHeader{}, nil, err
+		}
+
+		buf = buf[sizeN:]
+		if len(buf) != int(size) {
+			return OBUHeader{}, nil, fmt.Errorf("wrong buffer size: expected %d, got %d", size, len(buf))
+		}
+	}
+
+	return h, buf, nil
+}
+
+func (h OBUHeader) hasExtension() bool {
+	return h.TemporalID != 0 || h.SpatialID != 0
+}
+
+func (h OBUHeader) marshalSize() int {
+	if h.hasExtension() {
+		return 2
+	}
+	return 1
+}
+
+func (h OBUHeader) marshalTo(buf []byte) (int, error) {
+	if h.Type > 0b1111 {
+		return 0, fmt.Errorf("invalid OBU type: %d", h.Type)
+	}
+
+	if h.TemporalID > 0b111 {
+		return 0, fmt.Errorf("invalid temporal ID: %d", h.TemporalID)
+	}
+
+	if h.SpatialID > 0b11 {
+		return 0, fmt.Errorf("invalid spatial ID: %d", h.SpatialID)
+	}
+
+	// forbidden and reserved bits are left to zero
+	buf[0] = byte(h.Type) << 3
+
+	if h.HasSize {
+		buf[0] |= 0b10
+	}
+
+	if !h.hasExtension() {
+		return 1, nil
+	}
+
+	buf[0] |= 0b100
+	buf[1] = h.TemporalID<<5 | h.SpatialID<<3
+
+	return 2, nil
+}
+
+// Marshal encodes a OBUHeader.
+// The extension header is written when TemporalID or SpatialID are set.
+func (h OBUHeader) Marshal() ([]byte, error) {
+	buf := make([]byte, h.marshalSize())
+	_, err := h.marshalTo(buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf, nil
 }
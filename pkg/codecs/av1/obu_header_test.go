@@ -22,6 +22,16 @@ var casesOBUHeader = []struct {
 			HasSize: true,
 		},
 	},
+	{
+		"extension",
+		[]byte{0x36, 0x48},
+		OBUHeader{
+			Type:       6,
+			HasSize:    true,
+			TemporalID: 2,
+			SpatialID:  1,
+		},
+	},
 }
 
 func TestOBUHeaderUnmarshal(t *testing.T) {
@@ -35,6 +45,45 @@ func TestOBUHeaderUnmarshal(t *testing.T) {
 	}
 }
 
+func TestOBUHeaderMarshal(t *testing.T) {
+	for _, ca := range casesOBUHeader {
+		t.Run(ca.name, func(t *testing.T) {
+			byts, err := ca.h.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.byts[:len(byts)], byts)
+		})
+	}
+}
+
+func TestOBUHeaderMarshalErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		h    OBUHeader
+		err  string
+	}{
+		{
+			"invalid type",
+			OBUHeader{Type: 16},
+			"invalid OBU type: 16",
+		},
+		{
+			"invalid temporal ID",
+			OBUHeader{Type: 1, TemporalID: 8},
+			"invalid temporal ID: 8",
+		},
+		{
+			"invalid spatial ID",
+			OBUHeader{Type: 1, SpatialID: 4},
+			"invalid spatial ID: 4",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			_, err := ca.h.Marshal()
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
 func FuzzOBUHeaderUnmarshal(f *testing.F) {
 	for _, ca := range casesOBUHeader {
 		f.Add(ca.byts)
@@ -42,6 +91,9 @@ func FuzzOBUHeaderUnmarshal(f *testing.F) {
 
 	f.Fuzz(func(_ *testing.T, b []byte) {
 		var h OBUHeader
-		h.Unmarshal(b) //nolint:errcheck
+		err := h.Unmarshal(b)
+		if err == nil {
+			h.Marshal() //nolint:errcheck
+		}
 	})
 }
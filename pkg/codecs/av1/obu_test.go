@@ -0,0 +1,147 @@
+package av1
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesOBU = []struct {
+	name    string
+	header  OBUHeader
+	payload []byte
+	enc     []byte
+}{
+	{
+		"temporal delimiter",
+		OBUHeader{
+			Type:    OBUTypeTemporalDelimiter,
+			HasSize: true,
+		},
+		[]byte{},
+		[]byte{0x12, 0x00},
+	},
+	{
+		"without size",
+		OBUHeader{
+			Type: OBUTypeTemporalDelimiter,
+		},
+		[]byte{0x01, 0x02},
+		[]byte{0x10, 0x01, 0x02},
+	},
+	{
+		"extension",
+		OBUHeader{
+			Type:       OBUTypeFrame,
+			HasSize:    true,
+			TemporalID: 2,
+			SpatialID:  1,
+		},
+		[]byte{0x01, 0x02, 0x03},
+		[]byte{0x36, 0x48, 0x03, 0x01, 0x02, 0x03},
+	},
+	{
+		"two-byte size",
+		OBUHeader{
+			Type:    OBUTypeFrame,
+			HasSize: true,
+		},
+		bytes.Repeat([]byte{0x01}, 128),
+		append([]byte{0x32, 0x80, 0x01}, bytes.Repeat([]byte{0x01}, 128)...),
+	},
+}
+
+func TestMarshalOBU(t *testing.T) {
+	for _, ca := range casesOBU {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := MarshalOBU(ca.header, ca.payload)
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func TestMarshalOBUMaxSize(t *testing.T) {
+	header := OBUHeader{
+		Type:    OBUTypeFrame,
+		HasSize: true,
+	}
+
+	// header is 1 byte and size field is 4 bytes
+	obu, err := MarshalOBU(header, make([]byte, MaxOBUSize-5))
+	require.NoError(t, err)
+	require.Equal(t, MaxOBUSize, len(obu))
+
+	_, err = ReadTemporalUnit(obu)
+	require.NoError(t, err)
+
+	tu, err := JoinTemporalUnit([][]byte{obu})
+	require.NoError(t, err)
+
+	_, err = SplitTemporalUnit(tu)
+	require.NoError(t, err)
+
+	_, err = MarshalOBU(header, make([]byte, MaxOBUSize-4))
+	require.EqualError(t, err, "OBU size (3145729) is too big, maximum is 3145728")
+
+	obu = append(obu, 0x00)
+	obu[1]++ // increase first byte of size field, that has no carry
+	_, err = ReadTemporalUnit(obu)
+	require.EqualError(t, err, "OBU size (3145729) is too big, maximum is 3145728")
+
+	_, err = JoinTemporalUnit([][]byte{obu})
+	require.EqualError(t, err, "OBU size (3145729) is too big, maximum is 3145728")
+}
+
+func TestOBUSize(t *testing.T) {
+	for _, ca := range casesOBU {
+		t.Run(ca.name, func(t *testing.T) {
+			h := ca.header
+			h.HasSize = true
+			withSize, err := MarshalOBU(h, ca.payload)
+			require.NoError(t, err)
+
+			size, err := OBUSize(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, len(withSize), size)
+
+			// OBUs with the size field can be followed by other data
+			if ca.header.HasSize {
+				size, err = OBUSize(append(append([]byte(nil), ca.enc...), 0x12, 0x00))
+				require.NoError(t, err)
+				require.Equal(t, len(withSize), size)
+			}
+		})
+	}
+}
+
+func TestOBUSizeSizeFieldBoundary(t *testing.T) {
+	// payload size is 127, size field is 1 byte and OBU is 129 bytes
+	size, err := OBUSize(append([]byte{0x30}, bytes.Repeat([]byte{0x01}, 127)...))
+	require.NoError(t, err)
+	require.Equal(t, 1+1+127, size)
+
+	// payload size is 128, size field is 2 bytes
+	size, err = OBUSize(append([]byte{0x30}, bytes.Repeat([]byte{0x01}, 128)...))
+	require.NoError(t, err)
+	require.Equal(t, 1+2+128, size)
+}
+
+func TestOBUSizeErrors(t *testing.T) {
+	_, err := OBUSize([]byte{0x12, 0x05, 0x01})
+	require.EqualError(t, err, "not enough bytes")
+
+	_, err = OBUSize([]byte{0x92})
+	require.EqualError(t, err, "forbidden bit is set")
+}
+
+func FuzzOBUSize(f *testing.F) {
+	for _, ca := range casesOBU {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		OBUSize(b) //nolint:errcheck
+	})
+}
@@ -0,0 +1,19 @@
+package av1
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOBUType(t *testing.T) {
+	require.Equal(t, "TemporalDelimiter", OBUTypeTemporalDelimiter.String())
+	require.Equal(t, true, strings.HasPrefix(OBUType(9).String(), "unknown"))
+}
+
+func TestOBUTypeIsTemporalDelimiter(t *testing.T) {
+	require.Equal(t, true, OBUTypeTemporalDelimiter.IsTemporalDelimiter())
+	require.Equal(t, false, OBUTypeSequenceHeader.IsTemporalDelimiter())
+	require.Equal(t, false, OBUTypePadding.IsTemporalDelimiter())
+}
@@ -184,6 +184,80 @@ func (c *SequenceHeader_ColorConfig) unmarshal(seqProfile uint8, buf []byte, pos
 	return nil
 }
 
+// SequenceHeader_TimingInfo is a timing info of a sequence header.
+type SequenceHeader_TimingInfo struct { //nolint:revive
+	NumUnitsInDisplayTick    uint32
+	TimeScale                uint32
+	EqualPictureInterval     bool
+	NumTicksPerPictureMinus1 uint32
+}
+
+func (t *SequenceHeader_TimingInfo) unmarshal(buf []byte, pos *int) error {
+	err := bits.HasSpace(buf, *pos, 65)
+	if err != nil {
+		return err
+	}
+
+	t.NumUnitsInDisplayTick = uint32(bits.ReadBitsUnsafe(buf, pos, 32))
+	t.TimeScale = uint32(bits.ReadBitsUnsafe(buf, pos, 32))
+	t.EqualPictureInterval = bits.ReadFlagUnsafe(buf, pos)
+
+	if t.EqualPictureInterval {
+		t.NumTicksPerPictureMinus1, err = bits.ReadUVLC(buf, pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		t.NumTicksPerPictureMinus1 = 0
+	}
+
+	return nil
+}
+
+// SequenceHeader_DecoderModelInfo is a decoder model info of a sequence header.
+type SequenceHeader_DecoderModelInfo struct { //nolint:revive
+	BufferDelayLengthMinus1           uint8
+	NumUnitsInDecodingTick            uint32
+	BufferRemovalTimeLengthMinus1     uint8
+	FramePresentationTimeLengthMinus1 uint8
+}
+
+func (d *SequenceHeader_DecoderModelInfo) unmarshal(buf []byte, pos *int) error {
+	err := bits.HasSpace(buf, *pos, 47)
+	if err != nil {
+		return err
+	}
+
+	d.BufferDelayLengthMinus1 = uint8(bits.ReadBitsUnsafe(buf, pos, 5))
+	d.NumUnitsInDecodingTick = uint32(bits.ReadBitsUnsafe(buf, pos, 32))
+	d.BufferRemovalTimeLengthMinus1 = uint8(bits.ReadBitsUnsafe(buf, pos, 5))
+	d.FramePresentationTimeLengthMinus1 = uint8(bits.ReadBitsUnsafe(buf, pos, 5))
+
+	return nil
+}
+
+// SequenceHeader_OperatingParametersInfo is a operating parameters info of a sequence header.
+type SequenceHeader_OperatingParametersInfo struct { //nolint:revive
+	DecoderBufferDelay uint32
+	EncoderBufferDelay uint32
+	LowDelayModeFlag   bool
+}
+
+func (o *SequenceHeader_OperatingParametersInfo) unmarshal(bufferDelayLengthMinus1 uint8, buf []byte, pos *int) error {
+	n := int(bufferDelayLengthMinus1) + 1
+
+	err := bits.HasSpace(buf, *pos, n*2+1)
+	if err != nil {
+		return err
+	}
+
+	o.DecoderBufferDelay = uint32(bits.ReadBitsUnsafe(buf, pos, n))
+	o.EncoderBufferDelay = uint32(bits.ReadBitsUnsafe(buf, pos, n))
+	o.LowDelayModeFlag = bits.ReadFlagUnsafe(buf, pos)
+
+	return nil
+}
+
 // SequenceHeader is a AV1 Sequence header OBU.
 // Specification: https://aomediacodec.github.io/av1-spec/#sequence-header-obu-syntax
 type SequenceHeader struct {
@@ -191,18 +265,25 @@ type SequenceHeader struct {
 	StillPicture                   bool
 	ReducedStillPictureHeader      bool
 	TimingInfoPresentFlag          bool
+	TimingInfo                     *SequenceHeader_TimingInfo
 	DecoderModelInfoPresentFlag    bool
+	DecoderModelInfo               *SequenceHeader_DecoderModelInfo
 	InitialDisplayDelayPresentFlag bool
 	OperatingPointsCntMinus1       uint8
 	OperatingPointIdc              []uint16
 	SeqLevelIdx                    []uint8
 	SeqTier                        []bool
 	DecoderModelPresentForThisOp   []bool
+	OperatingParametersInfo        []*SequenceHeader_OperatingParametersInfo
 	InitialDisplayPresentForThisOp []bool
 	InitialDisplayDelayMinus1      []uint8
+	FrameWidthBitsMinus1           uint8
+	FrameHeightBitsMinus1          uint8
 	MaxFrameWidthMinus1            uint32
 	MaxFrameHeightMinus1           uint32
 	FrameIDNumbersPresentFlag      bool
+	DeltaFrameIDLengthMinus2       uint8
+	AdditionalFrameIDLengthMinus1  uint8
 	Use128x128Superblock           bool
 	EnableFilterIntra              bool
 	EnableIntraEdgeFilter          bool
@@ -226,26 +307,10 @@ type SequenceHeader struct {
 
 // Unmarshal decodes a SequenceHeader.
 func (h *SequenceHeader) Unmarshal(buf []byte) error {
-	var oh OBUHeader
-	err := oh.Unmarshal(buf)
+	_, buf, err := obuPayload(buf)
 	if err != nil {
 		return err
 	}
-	buf = buf[1:]
-
-	if oh.HasSize {
-		var size uint
-		var sizeN int
-		size, sizeN, err = LEB128Unmarshal(buf)
-		if err != nil {
-			return err
-		}
-
-		buf = buf[sizeN:]
-		if len(buf) != int(size) {
-			return fmt.Errorf("wrong buffer size: expected %d, got %d", size, len(buf))
-		}
-	}
 
 	pos := 0
 
@@ -260,7 +325,9 @@ func (h *SequenceHeader) Unmarshal(buf []byte) error {
 
 	if h.ReducedStillPictureHeader {
 		h.TimingInfoPresentFlag = false
+		h.TimingInfo = nil
 		h.DecoderModelInfoPresentFlag = false
+		h.DecoderModelInfo = nil
 		h.InitialDisplayDelayPresentFlag = false
 		h.OperatingPointsCntMinus1 = 0
 		h.OperatingPointIdc = []uint16{0}
@@ -273,6 +340,7 @@ func (h *SequenceHeader) Unmarshal(buf []byte) error {
 		h.SeqLevelIdx = []uint8{uint8(bits.ReadBitsUnsafe(buf, &pos, 5))}
 		h.SeqTier = []bool{false}
 		h.DecoderModelPresentForThisOp = []bool{false}
+		h.OperatingParametersInfo = []*SequenceHeader_OperatingParametersInfo{nil}
 		h.InitialDisplayPresentForThisOp = []bool{false}
 	} else {
 		h.TimingInfoPresentFlag, err = bits.ReadFlag(buf, &pos)
@@ -281,9 +349,31 @@ func (h *SequenceHeader) Unmarshal(buf []byte) error {
 		}
 
 		if h.TimingInfoPresentFlag {
-			return fmt.Errorf("timing_info_present_flag is not supported yet")
+			h.TimingInfo = &SequenceHeader_TimingInfo{}
+			err = h.TimingInfo.unmarshal(buf, &pos)
+			if err != nil {
+				return err
+			}
+
+			h.DecoderModelInfoPresentFlag, err = bits.ReadFlag(buf, &pos)
+			if err != nil {
+				return err
+			}
+
+			if h.DecoderModelInfoPresentFlag {
+				h.DecoderModelInfo = &SequenceHeader_DecoderModelInfo{}
+				err = h.DecoderModelInfo.unmarshal(buf, &pos)
+				if err != nil {
+					return err
+				}
+			} else {
+				h.DecoderModelInfo = nil
+			}
+		} else {
+			h.TimingInfo = nil
+			h.DecoderModelInfoPresentFlag = false
+			h.DecoderModelInfo = nil
 		}
-		h.DecoderModelInfoPresentFlag = false
 
 		err = bits.HasSpace(buf, pos, 6)
 		if err != nil {
@@ -297,6 +387,7 @@ func (h *SequenceHeader) Unmarshal(buf []byte) error {
 		h.SeqLevelIdx = make([]uint8, h.OperatingPointsCntMinus1+1)
 		h.SeqTier = make([]bool, h.OperatingPointsCntMinus1+1)
 		h.DecoderModelPresentForThisOp = make([]bool, h.OperatingPointsCntMinus1+1)
+		h.OperatingParametersInfo = make([]*SequenceHeader_OperatingParametersInfo, h.OperatingPointsCntMinus1+1)
 		h.InitialDisplayPresentForThisOp = make([]bool, h.OperatingPointsCntMinus1+1)
 		h.InitialDisplayDelayMinus1 = make([]uint8, h.OperatingPointsCntMinus1+1)
 
@@ -319,9 +410,21 @@ func (h *SequenceHeader) Unmarshal(buf []byte) error {
 			}
 
 			if h.DecoderModelInfoPresentFlag {
-				return fmt.Errorf("decoder_model_info_present_flag is not supported yet")
+				h.DecoderModelPresentForThisOp[i], err = bits.ReadFlag(buf, &pos)
+				if err != nil {
+					return err
+				}
+
+				if h.DecoderModelPresentForThisOp[i] {
+					h.OperatingParametersInfo[i] = &SequenceHeader_OperatingParametersInfo{}
+					err = h.OperatingParametersInfo[i].unmarshal(h.DecoderModelInfo.BufferDelayLengthMinus1, buf, &pos)
+					if err != nil {
+						return err
+					}
+				}
+			} else {
+				h.DecoderModelPresentForThisOp[i] = false
 			}
-			h.DecoderModelPresentForThisOp[i] = false
 
 			if h.InitialDisplayDelayPresentFlag {
 				h.InitialDisplayPresentForThisOp[i], err = bits.ReadFlag(buf, &pos)
@@ -337,7 +440,6 @@ func (h *SequenceHeader) Unmarshal(buf []byte) error {
 					}
 					h.InitialDisplayDelayMinus1[i] = uint8(tmp)
 				}
-				return fmt.Errorf("initial_display_delay_present_flag is not supported yet")
 			}
 		}
 	}
@@ -347,11 +449,11 @@ func (h *SequenceHeader) Unmarshal(buf []byte) error {
 		return err
 	}
 
-	frameWidthBitsMinus1 := int(bits.ReadBitsUnsafe(buf, &pos, 4))
-	frameHeightBitsMinus1 := int(bits.ReadBitsUnsafe(buf, &pos, 4))
+	h.FrameWidthBitsMinus1 = uint8(bits.ReadBitsUnsafe(buf, &pos, 4))
+	h.FrameHeightBitsMinus1 = uint8(bits.ReadBitsUnsafe(buf, &pos, 4))
 
-	n1 := (frameWidthBitsMinus1 + 1)
-	n2 := (frameHeightBitsMinus1 + 1)
+	n1 := int(h.FrameWidthBitsMinus1) + 1
+	n2 := int(h.FrameHeightBitsMinus1) + 1
 
 	err = bits.HasSpace(buf, pos, n1+n2)
 	if err != nil {
@@ -370,7 +472,13 @@ func (h *SequenceHeader) Unmarshal(buf []byte) error {
 		}
 
 		if h.FrameIDNumbersPresentFlag {
-			return fmt.Errorf("frame_id_numbers_present_flag is not supported yet")
+			err = bits.HasSpace(buf, pos, 7)
+			if err != nil {
+				return err
+			}
+
+			h.DeltaFrameIDLengthMinus2 = uint8(bits.ReadBitsUnsafe(buf, &pos, 4))
+			h.AdditionalFrameIDLengthMinus1 = uint8(bits.ReadBitsUnsafe(buf, &pos, 3))
 		}
 	}
 
@@ -482,3 +590,40 @@ func (h SequenceHeader) Width() int {
 func (h SequenceHeader) Height() int {
 	return int(h.MaxFrameHeightMinus1 + 1)
 }
+
+func boolToInt(v bool) int {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+// CodecString returns the codec string of the sequence,
+// that is used in the codecs parameter of MIME types.
+// All optional fields are included.
+// Specification: https://aomediacodec.github.io/av1-isobmff/#codecsparam
+func (h SequenceHeader) CodecString() string {
+	var seqLevelIdx uint8
+	if len(h.SeqLevelIdx) != 0 {
+		seqLevelIdx = h.SeqLevelIdx[0]
+	}
+
+	tier := "M"
+	if len(h.SeqTier) != 0 && h.SeqTier[0] {
+		tier = "H"
+	}
+
+	return fmt.Sprintf("av01.%d.%02d%s.%02d.%d.%d%d%d.%02d.%02d.%02d.%d",
+		h.SeqProfile,
+		seqLevelIdx,
+		tier,
+		h.ColorConfig.BitDepth,
+		boolToInt(h.ColorConfig.MonoChrome),
+		boolToInt(h.ColorConfig.SubsamplingX),
+		boolToInt(h.ColorConfig.SubsamplingY),
+		h.ColorConfig.ChromaSamplePosition,
+		h.ColorConfig.ColorPrimaries,
+		h.ColorConfig.TransferCharacteristics,
+		h.ColorConfig.MatrixCoefficients,
+		boolToInt(h.ColorConfig.ColorRange))
+}
@@ -7,11 +7,12 @@ import (
 )
 
 var casesSequenceHeader = []struct {
-	name   string
-	byts   []byte
-	sh     SequenceHeader
-	width  int
-	height int
+	name        string
+	byts        []byte
+	sh          SequenceHeader
+	width       int
+	height      int
+	codecString string
 }{
 	{
 		"chrome webrtc",
@@ -23,8 +24,11 @@ var casesSequenceHeader = []struct {
 			SeqLevelIdx:                    []uint8{8},
 			SeqTier:                        []bool{false},
 			DecoderModelPresentForThisOp:   []bool{false},
+			OperatingParametersInfo:        []*SequenceHeader_OperatingParametersInfo{nil},
 			InitialDisplayPresentForThisOp: []bool{false},
 			InitialDisplayDelayMinus1:      []uint8{0},
+			FrameWidthBitsMinus1:           10,
+			FrameHeightBitsMinus1:          9,
 			MaxFrameWidthMinus1:            1919,
 			MaxFrameHeightMinus1:           803,
 			SeqChooseScreenContentTools:    true,
@@ -43,6 +47,7 @@ var casesSequenceHeader = []struct {
 		},
 		1920,
 		804,
+		"av01.0.08M.08.0.110.02.02.02.0",
 	},
 	{
 		"av1 sample",
@@ -54,8 +59,11 @@ var casesSequenceHeader = []struct {
 			SeqLevelIdx:                    []uint8{8},
 			SeqTier:                        []bool{false},
 			DecoderModelPresentForThisOp:   []bool{false},
+			OperatingParametersInfo:        []*SequenceHeader_OperatingParametersInfo{nil},
 			InitialDisplayPresentForThisOp: []bool{false},
 			InitialDisplayDelayMinus1:      []uint8{0},
+			FrameWidthBitsMinus1:           10,
+			FrameHeightBitsMinus1:          9,
 			MaxFrameWidthMinus1:            1919,
 			MaxFrameHeightMinus1:           817,
 			Use128x128Superblock:           true,
@@ -84,6 +92,7 @@ var casesSequenceHeader = []struct {
 		},
 		1920,
 		818,
+		"av01.0.08M.08.0.110.02.02.02.1",
 	},
 	{
 		"libsvtav1",
@@ -95,8 +104,11 @@ var casesSequenceHeader = []struct {
 			SeqLevelIdx:                    []uint8{8},
 			SeqTier:                        []bool{false},
 			DecoderModelPresentForThisOp:   []bool{false},
+			OperatingParametersInfo:        []*SequenceHeader_OperatingParametersInfo{nil},
 			InitialDisplayPresentForThisOp: []bool{false},
 			InitialDisplayDelayMinus1:      []uint8{0},
+			FrameWidthBitsMinus1:           10,
+			FrameHeightBitsMinus1:          10,
 			MaxFrameWidthMinus1:            1919,
 			MaxFrameHeightMinus1:           1079,
 			EnableIntraEdgeFilter:          true,
@@ -122,6 +134,99 @@ var casesSequenceHeader = []struct {
 		},
 		1920,
 		1080,
+		"av01.0.08M.08.0.110.02.02.02.0",
+	},
+	{
+		"timing info and decoder model",
+		[]byte{
+			0x08, 0x04, 0x00, 0x00, 0x00, 0x04, 0x00, 0x00,
+			0x00, 0x7b, 0xa4, 0x00, 0x00, 0x00, 0x05, 0x29,
+			0x80, 0x00, 0x10, 0x8c, 0x86, 0x43, 0x35, 0x53,
+			0xfd, 0x67, 0xf1, 0x00, 0x68, 0x08,
+		},
+		SequenceHeader{
+			TimingInfoPresentFlag: true,
+			TimingInfo: &SequenceHeader_TimingInfo{
+				NumUnitsInDisplayTick: 1,
+				TimeScale:             30,
+				EqualPictureInterval:  true,
+			},
+			DecoderModelInfoPresentFlag: true,
+			DecoderModelInfo: &SequenceHeader_DecoderModelInfo{
+				BufferDelayLengthMinus1:           9,
+				NumUnitsInDecodingTick:            1,
+				BufferRemovalTimeLengthMinus1:     9,
+				FramePresentationTimeLengthMinus1: 9,
+			},
+			InitialDisplayDelayPresentFlag: true,
+			OperatingPointIdc:              []uint16{0},
+			SeqLevelIdx:                    []uint8{8},
+			SeqTier:                        []bool{false},
+			DecoderModelPresentForThisOp:   []bool{true},
+			OperatingParametersInfo: []*SequenceHeader_OperatingParametersInfo{{
+				DecoderBufferDelay: 100,
+				EncoderBufferDelay: 200,
+			}},
+			InitialDisplayPresentForThisOp: []bool{true},
+			InitialDisplayDelayMinus1:      []uint8{9},
+			FrameWidthBitsMinus1:           10,
+			FrameHeightBitsMinus1:          10,
+			MaxFrameWidthMinus1:            1279,
+			MaxFrameHeightMinus1:           719,
+			FrameIDNumbersPresentFlag:      true,
+			DeltaFrameIDLengthMinus2:       12,
+			AdditionalFrameIDLengthMinus1:  2,
+			SeqChooseScreenContentTools:    true,
+			SeqForceScreenContentTools:     2,
+			SeqChooseIntegerMv:             true,
+			SeqForceIntegerMv:              2,
+			EnableCdef:                     true,
+			ColorConfig: SequenceHeader_ColorConfig{
+				BitDepth:                8,
+				ColorPrimaries:          2,
+				TransferCharacteristics: 2,
+				MatrixCoefficients:      2,
+				SubsamplingX:            true,
+				SubsamplingY:            true,
+			},
+		},
+		1280,
+		720,
+		"av01.0.08M.08.0.110.02.02.02.0",
+	},
+	{
+		"reduced still picture header",
+		[]byte{
+			0x08, 0x19, 0x5d, 0xff, 0xff, 0xc0, 0x12,
+		},
+		SequenceHeader{
+			StillPicture:                   true,
+			ReducedStillPictureHeader:      true,
+			OperatingPointIdc:              []uint16{0},
+			SeqLevelIdx:                    []uint8{5},
+			SeqTier:                        []bool{false},
+			DecoderModelPresentForThisOp:   []bool{false},
+			OperatingParametersInfo:        []*SequenceHeader_OperatingParametersInfo{nil},
+			InitialDisplayPresentForThisOp: []bool{false},
+			FrameWidthBitsMinus1:           7,
+			FrameHeightBitsMinus1:          7,
+			MaxFrameWidthMinus1:            255,
+			MaxFrameHeightMinus1:           255,
+			SeqForceScreenContentTools:     2,
+			SeqForceIntegerMv:              2,
+			ColorConfig: SequenceHeader_ColorConfig{
+				BitDepth:                8,
+				ColorPrimaries:          2,
+				TransferCharacteristics: 2,
+				MatrixCoefficients:      2,
+				ColorRange:              true,
+				SubsamplingX:            true,
+				SubsamplingY:            true,
+			},
+		},
+		256,
+		256,
+		"av01.0.05M.08.0.110.02.02.02.1",
 	},
 }
 
@@ -134,10 +239,31 @@ func TestSequenceHeaderUnmarshal(t *testing.T) {
 			require.Equal(t, ca.sh, sh)
 			require.Equal(t, ca.width, sh.Width())
 			require.Equal(t, ca.height, sh.Height())
+			require.Equal(t, ca.codecString, sh.CodecString())
 		})
 	}
 }
 
+func TestSequenceHeaderCodecString(t *testing.T) {
+	sh := SequenceHeader{
+		SeqProfile:  0,
+		SeqLevelIdx: []uint8{13},
+		SeqTier:     []bool{true},
+		ColorConfig: SequenceHeader_ColorConfig{
+			HighBitDepth:                true,
+			BitDepth:                    10,
+			ColorDescriptionPresentFlag: true,
+			ColorPrimaries:              9,
+			TransferCharacteristics:     16,
+			MatrixCoefficients:          9,
+			SubsamplingX:                true,
+			SubsamplingY:                true,
+			ChromaSamplePosition:        1,
+		},
+	}
+	require.Equal(t, "av01.0.13H.10.0.111.09.16.09.0", sh.CodecString())
+}
+
 func FuzzSequenceHeaderUnmarshal(f *testing.F) {
 	for _, ca := range casesSequenceHeader {
 		f.Add(ca.byts)
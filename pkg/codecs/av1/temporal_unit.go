@@ -0,0 +1,85 @@
+package av1
+
+import (
+	"fmt"
+)
+
+// SplitTemporalUnit splits a sequence of OBUs, in which each OBU is prefixed by its length
+// encoded with LEB128, into OBUs.
+// This is not the length-delimited bitstream format of Annex B of the AV1 specification,
+// that adds temporal_unit_size and frame_unit_size fields, that are not supported.
+func SplitTemporalUnit(buf []byte) ([][]byte, error) {
+	var ret [][]byte
+
+	for len(buf) != 0 {
+		obuLen, n, err := LEB128Unmarshal(buf)
+		if err != nil {
+			return nil, err
+		}
+		buf = buf[n:]
+
+		if obuLen == 0 {
+			return nil, fmt.Errorf("invalid OBU length")
+		}
+
+		if obuLen > MaxOBUSize {
+			return nil, fmt.Errorf("OBU size (%d) is too big, maximum is %d", obuLen, MaxOBUSize)
+		}
+
+		if uint(len(buf)) < obuLen {
+			return nil, fmt.Errorf("not enough bytes")
+		}
+
+		if (len(ret) + 1) > MaxOBUsPerTemporalUnit {
+			return nil, fmt.Errorf("OBU count (%d) exceeds maximum allowed (%d)",
+				len(ret)+1, MaxOBUsPerTemporalUnit)
+		}
+
+		ret = append(ret, buf[:obuLen])
+		buf = buf[obuLen:]
+	}
+
+	if ret == nil {
+		return nil, fmt.Errorf("temporal unit is empty")
+	}
+
+	return ret, nil
+}
+
+// JoinTemporalUnit joins OBUs into a sequence of OBUs, in which each OBU is prefixed by its length
+// encoded with LEB128.
+// The output is not in the length-delimited bitstream format of Annex B of the AV1 specification.
+func JoinTemporalUnit(obus [][]byte) ([]byte, error) {
+	if len(obus) == 0 {
+		return nil, fmt.Errorf("temporal unit is empty")
+	}
+
+	if len(obus) > MaxOBUsPerTemporalUnit {
+		return nil, fmt.Errorf("OBU count (%d) exceeds maximum allowed (%d)",
+			len(obus), MaxOBUsPerTemporalUnit)
+	}
+
+	n := 0
+
+	for _, obu := range obus {
+		if len(obu) == 0 {
+			return nil, fmt.Errorf("invalid OBU length")
+		}
+
+		if len(obu) > MaxOBUSize {
+			return nil, fmt.Errorf("OBU size (%d) is too big, maximum is %d", len(obu), MaxOBUSize)
+		}
+
+		n += LEB128MarshalSize(uint(len(obu))) + len(obu)
+	}
+
+	buf := make([]byte, n)
+	n = 0
+
+	for _, obu := range obus {
+		n += LEB128MarshalTo(uint(len(obu)), buf[n:])
+		n += copy(buf[n:], obu)
+	}
+
+	return buf, nil
+}
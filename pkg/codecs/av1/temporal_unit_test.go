@@ -0,0 +1,105 @@
+package av1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesTemporalUnit = []struct {
+	name string
+	enc  []byte
+	dec  [][]byte
+}{
+	{
+		"standard",
+		[]byte{
+			0x0f, 0x08, 0x00, 0x00, 0x00, 0x4a, 0xab, 0xbf,
+			0xc3, 0x77, 0x6b, 0xe4, 0x40, 0x40, 0x40, 0x41,
+			0x03, 0x30, 0x01, 0x02,
+		},
+		[][]byte{
+			{
+				0x08, 0x00, 0x00, 0x00, 0x4a, 0xab, 0xbf, 0xc3,
+				0x77, 0x6b, 0xe4, 0x40, 0x40, 0x40, 0x41,
+			},
+			{0x30, 0x01, 0x02},
+		},
+	},
+}
+
+func TestSplitTemporalUnit(t *testing.T) {
+	for _, ca := range casesTemporalUnit {
+		t.Run(ca.name, func(t *testing.T) {
+			dec, err := SplitTemporalUnit(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestJoinTemporalUnit(t *testing.T) {
+	for _, ca := range casesTemporalUnit {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := JoinTemporalUnit(ca.dec)
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func TestSplitTemporalUnitErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		byts []byte
+		err  string
+	}{
+		{
+			"empty",
+			[]byte{},
+			"temporal unit is empty",
+		},
+		{
+			"zero length",
+			[]byte{0x00},
+			"invalid OBU length",
+		},
+		{
+			"not enough bytes",
+			[]byte{0x03, 0x30, 0x01},
+			"not enough bytes",
+		},
+		{
+			"too big",
+			[]byte{0x81, 0x80, 0xc0, 0x01},
+			"OBU size (3145729) is too big, maximum is 3145728",
+		},
+		{
+			"too many OBUs",
+			[]byte{
+				0x01, 0x10, 0x01, 0x10, 0x01, 0x10, 0x01, 0x10,
+				0x01, 0x10, 0x01, 0x10, 0x01, 0x10, 0x01, 0x10,
+				0x01, 0x10, 0x01, 0x10, 0x01, 0x10,
+			},
+			"OBU count (11) exceeds maximum allowed (10)",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			_, err := SplitTemporalUnit(ca.byts)
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func FuzzSplitTemporalUnit(f *testing.F) {
+	for _, ca := range casesTemporalUnit {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		obus, err := SplitTemporalUnit(b)
+		if err == nil {
+			JoinTemporalUnit(obus) //nolint:errcheck
+		}
+	})
+}
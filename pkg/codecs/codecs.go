@@ -0,0 +1,19 @@
+// Package codecs contains definitions shared by codecs.
+package codecs
+
+// Config is a codec configuration that can be stored into a container,
+// i.e. a decoder configuration record or an AudioSpecificConfig.
+// It is implemented by:
+//   - av1.CodecConfigurationRecord
+//   - h264.DecoderConfigurationRecord
+//   - h265.DecoderConfigurationRecord
+//   - mpeg4audio.AudioSpecificConfig
+//   - vp9.CodecConfigurationRecord
+type Config interface {
+	// Marshal encodes the configuration.
+	Marshal() ([]byte, error)
+
+	// CodecString returns the codec string,
+	// that is used in the codecs parameter of MIME types.
+	CodecString() string
+}
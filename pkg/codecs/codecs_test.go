@@ -0,0 +1,51 @@
+package codecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bluenviron/mediacommon/pkg/codecs/av1"
+	"github.com/bluenviron/mediacommon/pkg/codecs/h264"
+	"github.com/bluenviron/mediacommon/pkg/codecs/h265"
+	"github.com/bluenviron/mediacommon/pkg/codecs/mpeg4audio"
+	"github.com/bluenviron/mediacommon/pkg/codecs/vp9"
+)
+
+func TestConfig(t *testing.T) {
+	configs := []Config{
+		&av1.CodecConfigurationRecord{SeqLevelIdx0: 8},
+		&h264.DecoderConfigurationRecord{
+			AVCProfileIndication: 100,
+			AVCLevelIndication:   40,
+		},
+		&h265.DecoderConfigurationRecord{
+			GeneralProfileIdc:               1,
+			GeneralProfileCompatibilityFlag: [32]bool{false, true, true},
+			GeneralLevelIdc:                 93,
+		},
+		&mpeg4audio.AudioSpecificConfig{
+			Type:         mpeg4audio.ObjectTypeAACLC,
+			SampleRate:   48000,
+			ChannelCount: 2,
+		},
+		&vp9.CodecConfigurationRecord{
+			Level:             10,
+			BitDepth:          8,
+			ChromaSubsampling: 1,
+		},
+	}
+
+	codecStrings := make([]string, len(configs))
+	for i, c := range configs {
+		codecStrings[i] = c.CodecString()
+	}
+
+	require.Equal(t, []string{
+		"av01.0.08M.08",
+		"avc1.640028",
+		"hvc1.1.6.L93",
+		"mp4a.40.2",
+		"vp09.00.10.08.01.00.00.00.00",
+	}, codecStrings)
+}
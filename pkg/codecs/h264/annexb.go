@@ -138,22 +138,72 @@ outer:
 	return ret, nil
 }
 
-func annexBMarshalSize(au [][]byte) int {
+// AnnexBStartCodeLength is the layout of start codes written by AnnexBMarshalWithOptions.
+type AnnexBStartCodeLength int
+
+// start code layouts.
+const (
+	// 4-byte start code (0x00000001) before the first NALU of the access unit,
+	// 3-byte start code (0x000001) before the following ones.
+	// The specification requires the zero_byte, that is the 4-byte form,
+	// also before parameter sets that don't start the access unit,
+	// therefore access units should begin with them.
+	AnnexBStartCodeLengthAUStart AnnexBStartCodeLength = iota
+
+	// 4-byte start code before every NALU.
+	// It is accepted by every decoder, including picky hardware ones.
+	AnnexBStartCodeLength4
+)
+
+// AnnexBMarshalOptions contains options of AnnexBMarshalWithOptions.
+type AnnexBMarshalOptions struct {
+	// layout of start codes.
+	// It defaults to AnnexBStartCodeLengthAUStart.
+	StartCodeLength AnnexBStartCodeLength
+}
+
+func annexBStartCodeSize(i int, l AnnexBStartCodeLength) int {
+	if i == 0 || l == AnnexBStartCodeLength4 {
+		return 4
+	}
+	return 3
+}
+
+func annexBMarshalSize(au [][]byte, l AnnexBStartCodeLength) int {
 	n := 0
-	for _, nalu := range au {
-		n += 4 + len(nalu)
+	for i, nalu := range au {
+		n += annexBStartCodeSize(i, l) + len(nalu)
 	}
 	return n
 }
 
 // AnnexBMarshal encodes an access unit into the Annex-B stream format.
+// Every NALU is preceded by a 4-byte start code.
 // Specification: ITU-T Rec. H.264, Annex B
 func AnnexBMarshal(au [][]byte) ([]byte, error) {
-	buf := make([]byte, annexBMarshalSize(au))
+	return AnnexBMarshalWithOptions(au, AnnexBMarshalOptions{
+		StartCodeLength: AnnexBStartCodeLength4,
+	})
+}
+
+// AnnexBMarshalWithOptions encodes an access unit into the Annex-B stream format.
+// Specification: ITU-T Rec. H.264, Annex B
+func AnnexBMarshalWithOptions(au [][]byte, opts AnnexBMarshalOptions) ([]byte, error) {
+	switch opts.StartCodeLength {
+	case AnnexBStartCodeLengthAUStart, AnnexBStartCodeLength4:
+	default:
+		return nil, fmt.Errorf("invalid start code length: %d", opts.StartCodeLength)
+	}
+
+	buf := make([]byte, annexBMarshalSize(au, opts.StartCodeLength))
 	pos := 0
 
-	for _, nalu := range au {
-		pos += copy(buf[pos:], []byte{0x00, 0x00, 0x00, 0x01})
+	for i, nalu := range au {
+		if annexBStartCodeSize(i, opts.StartCodeLength) == 4 {
+			pos += copy(buf[pos:], []byte{0x00, 0x00, 0x00, 0x01})
+		} else {
+			pos += copy(buf[pos:], []byte{0x00, 0x00, 0x01})
+		}
 		pos += copy(buf[pos:], nalu)
 	}
 
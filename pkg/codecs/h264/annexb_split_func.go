@@ -0,0 +1,128 @@
+package h264
+
+import (
+	"bufio"
+	"fmt"
+)
+
+// AnnexBSplitFunc returns a bufio.SplitFunc that splits an Annex-B stream into NALUs.
+// It allows to read NALUs from an io.Reader through a bufio.Scanner,
+// without loading the whole stream into memory. Since the format of the stream
+// doesn't depend on the codec, it can be used with H264 and H265 streams.
+//
+// NALUs bigger than maxNALUSize are rejected.
+// The buffer of the bufio.Scanner must be able to contain a NALU and the following delimiter:
+//
+//	scanner := bufio.NewScanner(r)
+//	scanner.Buffer(nil, maxNALUSize+4)
+//	scanner.Split(h264.AnnexBSplitFunc(maxNALUSize))
+//
+// The returned function keeps a state and must be used by a single bufio.Scanner.
+// Specification: ITU-T Rec. H.264, Annex B
+func AnnexBSplitFunc(maxNALUSize int) bufio.SplitFunc {
+	initialDelimiterFound := false
+
+	// when more data is needed, the search of the next delimiter is resumed
+	// from where it stopped, since data always begins with the current NALU.
+	scanned := 0
+	zeroCount := 0
+	delimStart := 0
+
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		if !initialDelimiterFound {
+			n, err := annexBInitialDelimiterSize(data, atEOF)
+			if err != nil || n == 0 {
+				return 0, nil, err
+			}
+
+			initialDelimiterFound = true
+			return n, nil, nil
+		}
+
+		for i := scanned; i < len(data); i++ {
+			switch data[i] {
+			case 0:
+				if zeroCount == 0 {
+					delimStart = i
+				}
+				zeroCount++
+
+			case 1:
+				if zeroCount == 2 || zeroCount == 3 {
+					if delimStart > maxNALUSize {
+						return 0, nil, fmt.Errorf("NALU size (%d) is too big, maximum is %d", delimStart, maxNALUSize)
+					}
+
+					scanned = 0
+					zeroCount = 0
+
+					// skip empty NALUs
+					if delimStart == 0 {
+						return i + 1, nil, nil
+					}
+
+					return i + 1, data[:delimStart], nil
+				}
+				zeroCount = 0
+
+			default:
+				zeroCount = 0
+			}
+		}
+
+		if !atEOF {
+			// trailing zeros may belong to the next delimiter
+			l := len(data) - zeroCount
+			if l > maxNALUSize {
+				return 0, nil, fmt.Errorf("NALU size (%d) is too big, maximum is %d", l, maxNALUSize)
+			}
+
+			scanned = len(data)
+			return 0, nil, nil
+		}
+
+		scanned = 0
+		zeroCount = 0
+
+		if len(data) > maxNALUSize {
+			return 0, nil, fmt.Errorf("NALU size (%d) is too big, maximum is %d", len(data), maxNALUSize)
+		}
+
+		if len(data) == 0 {
+			return 0, nil, nil
+		}
+
+		return len(data), data, nil
+	}
+}
+
+// annexBInitialDelimiterSize returns the size of the delimiter at the beginning of a stream,
+// or zero if more data is needed.
+func annexBInitialDelimiterSize(data []byte, atEOF bool) (int, error) {
+	for i := 0; i < 4; i++ {
+		if i >= len(data) {
+			if atEOF {
+				if len(data) == 0 {
+					return 0, nil
+				}
+				return 0, fmt.Errorf("initial delimiter not found")
+			}
+			return 0, nil
+		}
+
+		switch {
+		case data[i] == 0:
+			if i == 3 {
+				return 0, fmt.Errorf("initial delimiter not found")
+			}
+
+		case data[i] == 1 && i >= 2:
+			return i + 1, nil
+
+		default:
+			return 0, fmt.Errorf("initial delimiter not found")
+		}
+	}
+
+	return 0, fmt.Errorf("initial delimiter not found")
+}
@@ -0,0 +1,125 @@
+package h264
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func scanAnnexB(t *testing.T, buf []byte, maxNALUSize int) ([][]byte, error) {
+	scanner := bufio.NewScanner(iotest.OneByteReader(bytes.NewReader(buf)))
+	scanner.Buffer(nil, maxNALUSize+4)
+	scanner.Split(AnnexBSplitFunc(maxNALUSize))
+
+	var ret [][]byte
+	for scanner.Scan() {
+		ret = append(ret, append([]byte(nil), scanner.Bytes()...))
+	}
+
+	err := scanner.Err()
+	require.False(t, scanner.Scan())
+	return ret, err
+}
+
+func TestAnnexBSplitFunc(t *testing.T) {
+	for _, ca := range casesAnnexB {
+		t.Run(ca.name, func(t *testing.T) {
+			dec, err := scanAnnexB(t, ca.encin, 1024)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestAnnexBSplitFuncEmptyNALUs(t *testing.T) {
+	dec, err := scanAnnexB(t, []byte{0, 0, 0, 1, 0, 0, 0, 1, 1}, 1024)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{1}}, dec)
+
+	dec, err = scanAnnexB(t, []byte{}, 1024)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte(nil), dec)
+}
+
+func TestAnnexBSplitFuncResume(t *testing.T) {
+	split := AnnexBSplitFunc(1024)
+
+	n, nalu, err := split([]byte{0, 0, 0, 1}, false)
+	require.NoError(t, err)
+	require.Equal(t, 4, n)
+	require.Equal(t, []byte(nil), nalu)
+
+	// the delimiter is split across calls
+	for _, data := range [][]byte{
+		{1, 2},
+		{1, 2, 0},
+		{1, 2, 0, 0},
+	} {
+		n, nalu, err = split(data, false)
+		require.NoError(t, err)
+		require.Equal(t, 0, n)
+		require.Equal(t, []byte(nil), nalu)
+	}
+
+	n, nalu, err = split([]byte{1, 2, 0, 0, 1, 3}, false)
+	require.NoError(t, err)
+	require.Equal(t, 5, n)
+	require.Equal(t, []byte{1, 2}, nalu)
+
+	n, nalu, err = split([]byte{3}, true)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.Equal(t, []byte{3}, nalu)
+}
+
+func TestAnnexBSplitFuncErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		byts []byte
+		err  string
+	}{
+		{
+			"invalid initial delimiter",
+			[]byte{0, 1, 2, 3},
+			"initial delimiter not found",
+		},
+		{
+			"too many initial zeros",
+			[]byte{0, 0, 0, 0, 1, 2},
+			"initial delimiter not found",
+		},
+		{
+			"truncated initial delimiter",
+			[]byte{0, 0},
+			"initial delimiter not found",
+		},
+		{
+			"NALU too big",
+			[]byte{0, 0, 0, 1, 1, 2, 3, 4, 5, 0, 0, 0, 1, 1},
+			"NALU size (5) is too big, maximum is 4",
+		},
+		{
+			"last NALU too big",
+			[]byte{0, 0, 0, 1, 1, 2, 3, 4, 5},
+			"NALU size (5) is too big, maximum is 4",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			_, err := scanAnnexB(t, ca.byts, 4)
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func FuzzAnnexBSplitFunc(f *testing.F) {
+	for _, ca := range casesAnnexB {
+		f.Add(ca.encin)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		scanAnnexB(t, b, 1024) //nolint:errcheck
+	})
+}
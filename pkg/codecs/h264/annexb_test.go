@@ -105,6 +105,37 @@ func TestAnnexBMarshal(t *testing.T) {
 	}
 }
 
+func TestAnnexBMarshalWithOptions(t *testing.T) {
+	au := [][]byte{
+		{0xaa, 0xbb},
+		{0xcc, 0xdd},
+		{0xee, 0xff},
+	}
+
+	enc, err := AnnexBMarshalWithOptions(au, AnnexBMarshalOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []byte{
+		0x00, 0x00, 0x00, 0x01, 0xaa, 0xbb,
+		0x00, 0x00, 0x01, 0xcc, 0xdd,
+		0x00, 0x00, 0x01, 0xee, 0xff,
+	}, enc)
+
+	enc, err = AnnexBMarshalWithOptions(au, AnnexBMarshalOptions{
+		StartCodeLength: AnnexBStartCodeLength4,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []byte{
+		0x00, 0x00, 0x00, 0x01, 0xaa, 0xbb,
+		0x00, 0x00, 0x00, 0x01, 0xcc, 0xdd,
+		0x00, 0x00, 0x00, 0x01, 0xee, 0xff,
+	}, enc)
+
+	_, err = AnnexBMarshalWithOptions(au, AnnexBMarshalOptions{
+		StartCodeLength: 5,
+	})
+	require.EqualError(t, err, "invalid start code length: 5")
+}
+
 func BenchmarkAnnexBUnmarshal(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		AnnexBUnmarshal([]byte{ //nolint:errcheck
@@ -0,0 +1,69 @@
+package h264
+
+import (
+	"fmt"
+)
+
+// AUD is a H264 access unit delimiter.
+// Specification: ITU-T Rec. H.264, 7.3.2.4
+type AUD struct {
+	// slice types that may be present in the access unit.
+	// Specification: ITU-T Rec. H.264, Table 7-5
+	PrimaryPicType uint8
+}
+
+// Unmarshal decodes an AUD.
+func (a *AUD) Unmarshal(buf []byte) error {
+	if len(buf) < 2 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	if NALUType(buf[0]&0x1F) != NALUTypeAccessUnitDelimiter {
+		return fmt.Errorf("not an AUD")
+	}
+
+	a.PrimaryPicType = buf[1] >> 5
+
+	return nil
+}
+
+// Marshal encodes an AUD.
+func (a AUD) Marshal() ([]byte, error) {
+	if a.PrimaryPicType > 0b111 {
+		return nil, fmt.Errorf("invalid primary_pic_type: %d", a.PrimaryPicType)
+	}
+
+	return []byte{
+		byte(NALUTypeAccessUnitDelimiter),
+		a.PrimaryPicType<<5 | 0b10000, // rbsp_trailing_bits()
+	}, nil
+}
+
+// IsAUD checks whether a NALU is an access unit delimiter.
+func IsAUD(nalu []byte) bool {
+	return len(nalu) != 0 && NALUType(nalu[0]&0x1F) == NALUTypeAccessUnitDelimiter
+}
+
+// StripAUD removes access unit delimiters from an access unit.
+// The input access unit is not modified.
+func StripAUD(au [][]byte) [][]byte {
+	n := 0
+	for _, nalu := range au {
+		if IsAUD(nalu) {
+			n++
+		}
+	}
+
+	if n == 0 {
+		return au
+	}
+
+	ret := make([][]byte, 0, len(au)-n)
+	for _, nalu := range au {
+		if !IsAUD(nalu) {
+			ret = append(ret, nalu)
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,99 @@
+package h264
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesAUD = []struct {
+	name string
+	byts []byte
+	aud  AUD
+}{
+	{
+		"i",
+		[]byte{0x09, 0x10},
+		AUD{
+			PrimaryPicType: 0,
+		},
+	},
+	{
+		"any",
+		[]byte{0x09, 0xf0},
+		AUD{
+			PrimaryPicType: 7,
+		},
+	},
+}
+
+func TestAUDUnmarshal(t *testing.T) {
+	for _, ca := range casesAUD {
+		t.Run(ca.name, func(t *testing.T) {
+			var aud AUD
+			err := aud.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.aud, aud)
+		})
+	}
+}
+
+func TestAUDMarshal(t *testing.T) {
+	for _, ca := range casesAUD {
+		t.Run(ca.name, func(t *testing.T) {
+			byts, err := ca.aud.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.byts, byts)
+		})
+	}
+}
+
+func TestAUDMarshalErrors(t *testing.T) {
+	_, err := AUD{PrimaryPicType: 8}.Marshal()
+	require.EqualError(t, err, "invalid primary_pic_type: 8")
+}
+
+func TestIsAUD(t *testing.T) {
+	require.Equal(t, true, IsAUD([]byte{0x09, 0xf0}))
+	require.Equal(t, false, IsAUD([]byte{0x05}))
+	require.Equal(t, false, IsAUD(nil))
+}
+
+func TestStripAUD(t *testing.T) {
+	au := [][]byte{
+		{0x09, 0xf0},
+		{0x07},
+		{0x08},
+		{0x05},
+	}
+
+	require.Equal(t, [][]byte{
+		{0x07},
+		{0x08},
+		{0x05},
+	}, StripAUD(au))
+
+	require.Equal(t, [][]byte{
+		{0x09, 0xf0},
+		{0x07},
+		{0x08},
+		{0x05},
+	}, au)
+
+	require.Equal(t, [][]byte{{0x01}}, StripAUD([][]byte{{0x01}}))
+}
+
+func FuzzAUDUnmarshal(f *testing.F) {
+	for _, ca := range casesAUD {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var aud AUD
+		err := aud.Unmarshal(b)
+		if err == nil {
+			_, err = aud.Marshal()
+			require.NoError(t, err)
+		}
+	})
+}
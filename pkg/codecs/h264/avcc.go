@@ -8,21 +8,67 @@ import (
 // ErrAVCCNoNALUs is returned by AVCCUnmarshal when no NALUs have been decoded.
 var ErrAVCCNoNALUs = errors.New("AVCC unit doesn't contain any NALU")
 
+// AVCCUnmarshalOptions contains options of AVCCUnmarshalWithOptions.
+type AVCCUnmarshalOptions struct {
+	// size of NALU lengths, in bytes (1, 2 or 4).
+	// It defaults to 4.
+	LengthSize int
+}
+
+// AVCCMarshalOptions contains options of AVCCMarshalWithOptions.
+type AVCCMarshalOptions struct {
+	// size of NALU lengths, in bytes (1, 2 or 4).
+	// It defaults to 4.
+	LengthSize int
+}
+
+func avccLengthSize(v int) (int, error) {
+	switch v {
+	case 0:
+		return 4, nil
+
+	case 1, 2, 4:
+		return v, nil
+
+	default:
+		return 0, fmt.Errorf("invalid length size (%d)", v)
+	}
+}
+
+func avccReadLength(buf []byte, lengthSize int) int {
+	l := 0
+	for i := 0; i < lengthSize; i++ {
+		l = l<<8 | int(buf[i])
+	}
+	return l
+}
+
 // AVCCUnmarshal decodes an access unit from the AVCC stream format.
 // Specification: ISO 14496-15, section 5.3.4.2.1
 func AVCCUnmarshal(buf []byte) ([][]byte, error) {
+	return AVCCUnmarshalWithOptions(buf, AVCCUnmarshalOptions{})
+}
+
+// AVCCUnmarshalWithOptions decodes an access unit from the AVCC stream format.
+// Specification: ISO 14496-15, section 5.3.4.2.1
+func AVCCUnmarshalWithOptions(buf []byte, opts AVCCUnmarshalOptions) ([][]byte, error) {
+	lengthSize, err := avccLengthSize(opts.LengthSize)
+	if err != nil {
+		return nil, err
+	}
+
 	bl := len(buf)
 	pos := 0
 	n := 0
 	auSize := 0
 
 	for {
-		if (bl - pos) < 4 {
+		if (bl - pos) < lengthSize {
 			return nil, fmt.Errorf("invalid length")
 		}
 
-		l := int(uint32(buf[pos])<<24 | uint32(buf[pos+1])<<16 | uint32(buf[pos+2])<<8 | uint32(buf[pos+3]))
-		pos += 4
+		l := avccReadLength(buf[pos:], lengthSize)
+		pos += lengthSize
 
 		if l != 0 {
 			if (auSize + l) > MaxAccessUnitSize {
@@ -56,8 +102,8 @@ func AVCCUnmarshal(buf []byte) ([][]byte, error) {
 	pos = 0
 
 	for i := 0; i < n; {
-		l := int(uint32(buf[pos])<<24 | uint32(buf[pos+1])<<16 | uint32(buf[pos+2])<<8 | uint32(buf[pos+3]))
-		pos += 4
+		l := avccReadLength(buf[pos:], lengthSize)
+		pos += lengthSize
 
 		if l != 0 {
 			ret[i] = buf[pos : pos+l]
@@ -69,10 +115,10 @@ func AVCCUnmarshal(buf []byte) ([][]byte, error) {
 	return ret, nil
 }
 
-func avccMarshalSize(au [][]byte) int {
+func avccMarshalSize(au [][]byte, lengthSize int) int {
 	n := 0
 	for _, nalu := range au {
-		n += 4 + len(nalu)
+		n += lengthSize + len(nalu)
 	}
 	return n
 }
@@ -80,16 +126,30 @@ func avccMarshalSize(au [][]byte) int {
 // AVCCMarshal encodes an access unit into the AVCC stream format.
 // Specification: ISO 14496-15, section 5.3.4.2.1
 func AVCCMarshal(au [][]byte) ([]byte, error) {
-	buf := make([]byte, avccMarshalSize(au))
+	return AVCCMarshalWithOptions(au, AVCCMarshalOptions{})
+}
+
+// AVCCMarshalWithOptions encodes an access unit into the AVCC stream format.
+// Specification: ISO 14496-15, section 5.3.4.2.1
+func AVCCMarshalWithOptions(au [][]byte, opts AVCCMarshalOptions) ([]byte, error) {
+	lengthSize, err := avccLengthSize(opts.LengthSize)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, avccMarshalSize(au, lengthSize))
 	pos := 0
 
 	for _, nalu := range au {
 		naluLen := len(nalu)
-		buf[pos] = byte(naluLen >> 24)
-		buf[pos+1] = byte(naluLen >> 16)
-		buf[pos+2] = byte(naluLen >> 8)
-		buf[pos+3] = byte(naluLen)
-		pos += 4
+		if lengthSize < 4 && naluLen >= (1<<(lengthSize*8)) {
+			return nil, fmt.Errorf("NALU size (%d) is too big, maximum is %d", naluLen, (1<<(lengthSize*8))-1)
+		}
+
+		for i := lengthSize - 1; i >= 0; i-- {
+			buf[pos] = byte(naluLen >> (i * 8))
+			pos++
+		}
 
 		pos += copy(buf[pos:], nalu)
 	}
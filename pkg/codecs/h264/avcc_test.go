@@ -39,6 +39,48 @@ var casesAVCC = []struct {
 	},
 }
 
+var casesAVCCWithOptions = []struct {
+	name       string
+	lengthSize int
+	enc        []byte
+	dec        [][]byte
+}{
+	{
+		"1-byte length",
+		1,
+		[]byte{
+			0x02, 0xaa, 0xbb,
+			0x01, 0xcc,
+		},
+		[][]byte{
+			{0xaa, 0xbb},
+			{0xcc},
+		},
+	},
+	{
+		"2-byte length",
+		2,
+		[]byte{
+			0x00, 0x02, 0xaa, 0xbb,
+			0x00, 0x01, 0xcc,
+		},
+		[][]byte{
+			{0xaa, 0xbb},
+			{0xcc},
+		},
+	},
+	{
+		"4-byte length",
+		4,
+		[]byte{
+			0x00, 0x00, 0x00, 0x02, 0xaa, 0xbb,
+		},
+		[][]byte{
+			{0xaa, 0xbb},
+		},
+	},
+}
+
 func TestAVCCUnmarshal(t *testing.T) {
 	for _, ca := range casesAVCC {
 		t.Run(ca.name, func(t *testing.T) {
@@ -78,6 +120,34 @@ func TestAVCCMarshal(t *testing.T) {
 	}
 }
 
+func TestAVCCUnmarshalWithOptions(t *testing.T) {
+	for _, ca := range casesAVCCWithOptions {
+		t.Run(ca.name, func(t *testing.T) {
+			dec, err := AVCCUnmarshalWithOptions(ca.enc, AVCCUnmarshalOptions{LengthSize: ca.lengthSize})
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestAVCCMarshalWithOptions(t *testing.T) {
+	for _, ca := range casesAVCCWithOptions {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := AVCCMarshalWithOptions(ca.dec, AVCCMarshalOptions{LengthSize: ca.lengthSize})
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func TestAVCCMarshalWithOptionsErrors(t *testing.T) {
+	_, err := AVCCMarshalWithOptions([][]byte{{1}}, AVCCMarshalOptions{LengthSize: 3})
+	require.EqualError(t, err, "invalid length size (3)")
+
+	_, err = AVCCMarshalWithOptions([][]byte{make([]byte, 256)}, AVCCMarshalOptions{LengthSize: 1})
+	require.EqualError(t, err, "NALU size (256) is too big, maximum is 255")
+}
+
 func FuzzAVCCUnmarshal(f *testing.F) {
 	for _, ca := range casesAVCC {
 		f.Add(ca.enc)
@@ -90,3 +160,16 @@ func FuzzAVCCUnmarshal(f *testing.F) {
 		}
 	})
 }
+
+func FuzzAVCCUnmarshalWithOptions(f *testing.F) {
+	for _, ca := range casesAVCCWithOptions {
+		f.Add(ca.lengthSize, ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, lengthSize int, b []byte) {
+		au, err := AVCCUnmarshalWithOptions(b, AVCCUnmarshalOptions{LengthSize: lengthSize})
+		if err == nil {
+			AVCCMarshalWithOptions(au, AVCCMarshalOptions{LengthSize: lengthSize}) //nolint:errcheck
+		}
+	})
+}
@@ -0,0 +1,311 @@
+package h264
+
+import (
+	"fmt"
+)
+
+// DecoderConfigurationRecord is a AVCDecoderConfigurationRecord.
+// Specification: ISO 14496-15, section 5.3.3.1
+type DecoderConfigurationRecord struct {
+	AVCProfileIndication uint8
+	ProfileCompatibility uint8
+	AVCLevelIndication   uint8
+	LengthSizeMinusOne   uint8
+	SPS                  [][]byte
+	PPS                  [][]byte
+
+	// profiles other than Baseline, Main and Extended
+	ChromaFormat         uint8
+	BitDepthLumaMinus8   uint8
+	BitDepthChromaMinus8 uint8
+	SPSExt               [][]byte
+}
+
+// NewDecoderConfigurationRecord allocates a DecoderConfigurationRecord
+// and fills it with values extracted from the given SPS and PPS NALUs.
+func NewDecoderConfigurationRecord(sps [][]byte, pps [][]byte) (*DecoderConfigurationRecord, error) {
+	if len(sps) == 0 {
+		return nil, fmt.Errorf("SPS is missing")
+	}
+
+	var s SPS
+	err := s.Unmarshal(sps[0])
+	if err != nil {
+		return nil, err
+	}
+
+	r := &DecoderConfigurationRecord{
+		AVCProfileIndication: sps[0][1],
+		ProfileCompatibility: sps[0][2],
+		AVCLevelIndication:   sps[0][3],
+		LengthSizeMinusOne:   3,
+		SPS:                  sps,
+		PPS:                  pps,
+	}
+
+	if r.hasExtension() {
+		r.ChromaFormat = uint8(s.ChromaFormatIdc)
+		r.BitDepthLumaMinus8 = uint8(s.BitDepthLumaMinus8)
+		r.BitDepthChromaMinus8 = uint8(s.BitDepthChromaMinus8)
+	}
+
+	return r, nil
+}
+
+func (r DecoderConfigurationRecord) hasExtension() bool {
+	switch r.AVCProfileIndication {
+	case 66, 77, 88:
+		return false
+	}
+	return true
+}
+
+func readParameterSets(buf []byte, pos *int, n int) ([][]byte, error) {
+	if n == 0 {
+		return nil, nil
+	}
+
+	ret := make([][]byte, n)
+
+	for i := 0; i < n; i++ {
+		if (len(buf) - *pos) < 2 {
+			return nil, fmt.Errorf("not enough bytes")
+		}
+
+		l := int(uint16(buf[*pos])<<8 | uint16(buf[*pos+1]))
+		*pos += 2
+
+		if (len(buf) - *pos) < l {
+			return nil, fmt.Errorf("not enough bytes")
+		}
+
+		ret[i] = buf[*pos : *pos+l]
+		*pos += l
+	}
+
+	return ret, nil
+}
+
+// Unmarshal decodes a DecoderConfigurationRecord.
+func (r *DecoderConfigurationRecord) Unmarshal(buf []byte) error {
+	if len(buf) < 6 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	configurationVersion := buf[0]
+	if configurationVersion != 1 {
+		return fmt.Errorf("unsupported configuration version: %d", configurationVersion)
+	}
+
+	r.AVCProfileIndication = buf[1]
+	r.ProfileCompatibility = buf[2]
+	r.AVCLevelIndication = buf[3]
+	r.LengthSizeMinusOne = buf[4] & 0b11
+
+	numOfSequenceParameterSets := int(buf[5] & 0b11111)
+	pos := 6
+
+	var err error
+	r.SPS, err = readParameterSets(buf, &pos, numOfSequenceParameterSets)
+	if err != nil {
+		return err
+	}
+
+	if (len(buf) - pos) < 1 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	numOfPictureParameterSets := int(buf[pos])
+	pos++
+
+	r.PPS, err = readParameterSets(buf, &pos, numOfPictureParameterSets)
+	if err != nil {
+		return err
+	}
+
+	r.ChromaFormat = 0
+	r.BitDepthLumaMinus8 = 0
+	r.BitDepthChromaMinus8 = 0
+	r.SPSExt = nil
+
+	if r.hasExtension() {
+		// the extension is omitted by some muxers.
+		// In this case, use the values inferred when chroma_format_idc is not present in the SPS.
+		if (len(buf) - pos) == 0 {
+			r.ChromaFormat = 1
+			return nil
+		}
+
+		if (len(buf) - pos) < 4 {
+			return fmt.Errorf("not enough bytes")
+		}
+
+		r.ChromaFormat = buf[pos] & 0b11
+		r.BitDepthLumaMinus8 = buf[pos+1] & 0b111
+		r.BitDepthChromaMinus8 = buf[pos+2] & 0b111
+		numOfSequenceParameterSetExt := int(buf[pos+3])
+		pos += 4
+
+		r.SPSExt, err = readParameterSets(buf, &pos, numOfSequenceParameterSetExt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validate checks that the record is consistent with SPS NALUs.
+// It is not called by Unmarshal or Marshal, since many muxers write records
+// whose profile, compatibility or level differ from the SPS.
+func (r DecoderConfigurationRecord) Validate() error {
+	for _, sps := range r.SPS {
+		if len(sps) < 4 {
+			return fmt.Errorf("invalid SPS")
+		}
+
+		if NALUType(sps[0]&0x1F) != NALUTypeSPS {
+			return fmt.Errorf("not a SPS")
+		}
+
+		if sps[1] != r.AVCProfileIndication ||
+			sps[2] != r.ProfileCompatibility ||
+			sps[3] != r.AVCLevelIndication {
+			return fmt.Errorf("decoder configuration record doesn't match SPS")
+		}
+	}
+
+	return nil
+}
+
+func parameterSetsMarshalSize(sets [][]byte) int {
+	n := 0
+	for _, set := range sets {
+		n += 2 + len(set)
+	}
+	return n
+}
+
+func (r DecoderConfigurationRecord) marshalSize() int {
+	n := 7 + parameterSetsMarshalSize(r.SPS) + parameterSetsMarshalSize(r.PPS)
+	if r.hasExtension() {
+		n += 4 + parameterSetsMarshalSize(r.SPSExt)
+	}
+	return n
+}
+
+func writeParameterSets(buf []byte, sets [][]byte) (int, error) {
+	pos := 0
+
+	for _, set := range sets {
+		if len(set) > 0xFFFF {
+			return 0, fmt.Errorf("parameter set size (%d) is too big", len(set))
+		}
+
+		buf[pos] = uint8(len(set) >> 8)
+		buf[pos+1] = uint8(len(set))
+		pos += 2
+
+		pos += copy(buf[pos:], set)
+	}
+
+	return pos, nil
+}
+
+// Marshal encodes a DecoderConfigurationRecord.
+func (r DecoderConfigurationRecord) Marshal() ([]byte, error) {
+	if r.LengthSizeMinusOne > 3 || r.LengthSizeMinusOne == 2 {
+		return nil, fmt.Errorf("invalid lengthSizeMinusOne: %d", r.LengthSizeMinusOne)
+	}
+
+	if len(r.SPS) > 0b11111 {
+		return nil, fmt.Errorf("too many SPS (%d)", len(r.SPS))
+	}
+
+	if len(r.PPS) > 0xFF {
+		return nil, fmt.Errorf("too many PPS (%d)", len(r.PPS))
+	}
+
+	if r.ChromaFormat > 0b11 {
+		return nil, fmt.Errorf("invalid chroma_format: %d", r.ChromaFormat)
+	}
+
+	if r.BitDepthLumaMinus8 > 0b111 {
+		return nil, fmt.Errorf("invalid bit_depth_luma_minus8: %d", r.BitDepthLumaMinus8)
+	}
+
+	if r.BitDepthChromaMinus8 > 0b111 {
+		return nil, fmt.Errorf("invalid bit_depth_chroma_minus8: %d", r.BitDepthChromaMinus8)
+	}
+
+	if len(r.SPSExt) > 0xFF {
+		return nil, fmt.Errorf("too many SPS extensions (%d)", len(r.SPSExt))
+	}
+
+	buf := make([]byte, r.marshalSize())
+
+	buf[0] = 1
+	buf[1] = r.AVCProfileIndication
+	buf[2] = r.ProfileCompatibility
+	buf[3] = r.AVCLevelIndication
+	buf[4] = 0b11111100 | r.LengthSizeMinusOne
+	buf[5] = 0b11100000 | uint8(len(r.SPS))
+	pos := 6
+
+	n, err := writeParameterSets(buf[pos:], r.SPS)
+	if err != nil {
+		return nil, err
+	}
+	pos += n
+
+	buf[pos] = uint8(len(r.PPS))
+	pos++
+
+	n, err = writeParameterSets(buf[pos:], r.PPS)
+	if err != nil {
+		return nil, err
+	}
+	pos += n
+
+	if r.hasExtension() {
+		buf[pos] = 0b11111100 | r.ChromaFormat
+		buf[pos+1] = 0b11111000 | r.BitDepthLumaMinus8
+		buf[pos+2] = 0b11111000 | r.BitDepthChromaMinus8
+		buf[pos+3] = uint8(len(r.SPSExt))
+		pos += 4
+
+		_, err = writeParameterSets(buf[pos:], r.SPSExt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return buf, nil
+}
+
+func cloneParameterSets(sets [][]byte) [][]byte {
+	if sets == nil {
+		return nil
+	}
+
+	ret := make([][]byte, len(sets))
+	for i, set := range sets {
+		ret[i] = cloneBytes(set)
+	}
+	return ret
+}
+
+// Clone returns a copy of the DecoderConfigurationRecord that doesn't share memory with the original.
+func (r DecoderConfigurationRecord) Clone() DecoderConfigurationRecord {
+	r.SPS = cloneParameterSets(r.SPS)
+	r.PPS = cloneParameterSets(r.PPS)
+	r.SPSExt = cloneParameterSets(r.SPSExt)
+	return r
+}
+
+// CodecString returns the codec string of the video, in the avc1.PPCCLL format,
+// that is used in the codecs parameter of MIME types.
+// Specification: RFC 6381, 3.3
+func (r DecoderConfigurationRecord) CodecString() string {
+	return fmt.Sprintf("avc1.%02x%02x%02x", r.AVCProfileIndication, r.ProfileCompatibility, r.AVCLevelIndication)
+}
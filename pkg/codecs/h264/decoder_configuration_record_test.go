@@ -0,0 +1,201 @@
+package h264
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesDecoderConfigurationRecord = []struct {
+	name string
+	enc  []byte
+	dec  DecoderConfigurationRecord
+}{
+	{
+		"baseline",
+		[]byte{
+			0x01, 0x42, 0xc0, 0x1e, 0xff, 0xe1, 0x00, 0x0f,
+			0x67, 0x42, 0xc0, 0x1e, 0x8c, 0x8d, 0x40, 0x50,
+			0x17, 0xfc, 0xb0, 0x0f, 0x08, 0x84, 0x6a, 0x01,
+			0x00, 0x04, 0x68, 0xce, 0x3c, 0x80,
+		},
+		DecoderConfigurationRecord{
+			AVCProfileIndication: 66,
+			ProfileCompatibility: 0xc0,
+			AVCLevelIndication:   30,
+			LengthSizeMinusOne:   3,
+			SPS: [][]byte{{
+				0x67, 0x42, 0xc0, 0x1e, 0x8c, 0x8d, 0x40, 0x50,
+				0x17, 0xfc, 0xb0, 0x0f, 0x08, 0x84, 0x6a,
+			}},
+			PPS: [][]byte{{
+				0x68, 0xce, 0x3c, 0x80,
+			}},
+		},
+	},
+	{
+		"high",
+		[]byte{
+			0x01, 0x64, 0x00, 0x28, 0xff, 0xe1, 0x00, 0x1a,
+			0x67, 0x64, 0x00, 0x28, 0xac, 0xd9, 0x40, 0x78,
+			0x02, 0x27, 0xe5, 0x84, 0x00, 0x00, 0x03, 0x00,
+			0x04, 0x00, 0x00, 0x03, 0x00, 0xf0, 0x3c, 0x60,
+			0xc6, 0x58, 0x01, 0x00, 0x06, 0x68, 0xeb, 0xe3,
+			0xcb, 0x22, 0xc0, 0xfd, 0xf8, 0xf8, 0x00,
+		},
+		DecoderConfigurationRecord{
+			AVCProfileIndication: 100,
+			ProfileCompatibility: 0,
+			AVCLevelIndication:   40,
+			LengthSizeMinusOne:   3,
+			SPS: [][]byte{{
+				0x67, 0x64, 0x00, 0x28, 0xac, 0xd9, 0x40, 0x78,
+				0x02, 0x27, 0xe5, 0x84, 0x00, 0x00, 0x03, 0x00,
+				0x04, 0x00, 0x00, 0x03, 0x00, 0xf0, 0x3c, 0x60,
+				0xc6, 0x58,
+			}},
+			PPS: [][]byte{{
+				0x68, 0xeb, 0xe3, 0xcb, 0x22, 0xc0,
+			}},
+			ChromaFormat: 1,
+		},
+	},
+	{
+		"multiple pps",
+		[]byte{
+			0x01, 0x42, 0xc0, 0x1e, 0xff, 0xe1, 0x00, 0x0f,
+			0x67, 0x42, 0xc0, 0x1e, 0x8c, 0x8d, 0x40, 0x50,
+			0x17, 0xfc, 0xb0, 0x0f, 0x08, 0x84, 0x6a, 0x02,
+			0x00, 0x04, 0x68, 0xce, 0x3c, 0x80, 0x00, 0x04,
+			0x68, 0x53, 0x8f, 0x20,
+		},
+		DecoderConfigurationRecord{
+			AVCProfileIndication: 66,
+			ProfileCompatibility: 0xc0,
+			AVCLevelIndication:   30,
+			LengthSizeMinusOne:   3,
+			SPS: [][]byte{{
+				0x67, 0x42, 0xc0, 0x1e, 0x8c, 0x8d, 0x40, 0x50,
+				0x17, 0xfc, 0xb0, 0x0f, 0x08, 0x84, 0x6a,
+			}},
+			PPS: [][]byte{
+				{0x68, 0xce, 0x3c, 0x80},
+				{0x68, 0x53, 0x8f, 0x20},
+			},
+		},
+	},
+}
+
+func TestDecoderConfigurationRecordUnmarshal(t *testing.T) {
+	for _, ca := range casesDecoderConfigurationRecord {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec DecoderConfigurationRecord
+			err := dec.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestDecoderConfigurationRecordMarshal(t *testing.T) {
+	for _, ca := range casesDecoderConfigurationRecord {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func TestNewDecoderConfigurationRecord(t *testing.T) {
+	for _, ca := range casesDecoderConfigurationRecord {
+		t.Run(ca.name, func(t *testing.T) {
+			r, err := NewDecoderConfigurationRecord(ca.dec.SPS, ca.dec.PPS)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, *r)
+		})
+	}
+}
+
+func TestDecoderConfigurationRecordClone(t *testing.T) {
+	for _, ca := range casesDecoderConfigurationRecord {
+		t.Run(ca.name, func(t *testing.T) {
+			var orig DecoderConfigurationRecord
+			err := orig.Unmarshal(ca.enc)
+			require.NoError(t, err)
+
+			c := orig.Clone()
+			require.Equal(t, orig, c)
+
+			c.SPS[0][1] = 0xff
+			c.PPS[0][1] = 0xff
+			for _, ext := range c.SPSExt {
+				ext[0] = 0xff
+			}
+			require.Equal(t, ca.dec, orig)
+		})
+	}
+}
+
+func TestDecoderConfigurationRecordCodecString(t *testing.T) {
+	for _, ca := range casesDecoderConfigurationRecord {
+		t.Run(ca.name, func(t *testing.T) {
+			var sps SPS
+			err := sps.Unmarshal(ca.dec.SPS[0])
+			require.NoError(t, err)
+			require.Equal(t, sps.CodecString(), ca.dec.CodecString())
+		})
+	}
+}
+
+func TestDecoderConfigurationRecordUnmarshalWithoutExtension(t *testing.T) {
+	var dec DecoderConfigurationRecord
+	err := dec.Unmarshal(casesDecoderConfigurationRecord[1].enc[:43])
+	require.NoError(t, err)
+	require.Equal(t, casesDecoderConfigurationRecord[1].dec, dec)
+
+	enc, err := dec.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, casesDecoderConfigurationRecord[1].enc, enc)
+}
+
+func TestDecoderConfigurationRecordUnmarshalMismatch(t *testing.T) {
+	enc := append([]byte(nil), casesDecoderConfigurationRecord[0].enc...)
+	enc[3] = 31
+
+	var dec DecoderConfigurationRecord
+	err := dec.Unmarshal(enc)
+	require.NoError(t, err)
+	require.Equal(t, uint8(31), dec.AVCLevelIndication)
+
+	err = dec.Validate()
+	require.EqualError(t, err, "decoder configuration record doesn't match SPS")
+}
+
+func TestDecoderConfigurationRecordMarshalMismatch(t *testing.T) {
+	enc := append([]byte(nil), casesDecoderConfigurationRecord[0].enc...)
+	enc[3] = 31
+
+	var dec DecoderConfigurationRecord
+	err := dec.Unmarshal(enc)
+	require.NoError(t, err)
+
+	// records that don't match their SPS are still written as they are.
+	enc2, err := dec.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, enc, enc2)
+}
+
+func FuzzDecoderConfigurationRecordUnmarshal(f *testing.F) {
+	for _, ca := range casesDecoderConfigurationRecord {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var r DecoderConfigurationRecord
+		err := r.Unmarshal(b)
+		if err == nil {
+			r.Marshal() //nolint:errcheck
+		}
+	})
+}
@@ -0,0 +1,39 @@
+package h264
+
+import (
+	"bytes"
+)
+
+func containsParameterSet(sets [][]byte, set []byte) bool {
+	for _, existing := range sets {
+		if bytes.Equal(existing, set) {
+			return true
+		}
+	}
+	return false
+}
+
+// DedupeParameterSets adds to existing parameter sets the incoming ones
+// that are not already present, comparing them byte by byte.
+// It returns the merged parameter sets and whether any parameter set has been added,
+// that means that the decoder configuration has changed.
+// Existing parameter sets are not modified.
+func DedupeParameterSets(existing [][]byte, incoming [][]byte) ([][]byte, bool) {
+	merged := existing
+	changed := false
+
+	for _, set := range incoming {
+		if len(set) == 0 || containsParameterSet(merged, set) {
+			continue
+		}
+
+		if !changed {
+			merged = append(make([][]byte, 0, len(existing)+1), existing...)
+			changed = true
+		}
+
+		merged = append(merged, set)
+	}
+
+	return merged, changed
+}
@@ -0,0 +1,69 @@
+package h264
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDedupeParameterSets(t *testing.T) {
+	for _, ca := range []struct {
+		name     string
+		existing [][]byte
+		incoming [][]byte
+		merged   [][]byte
+		changed  bool
+	}{
+		{
+			"empty",
+			nil,
+			[][]byte{{0x67, 0x42, 0xc0, 0x1e}},
+			[][]byte{{0x67, 0x42, 0xc0, 0x1e}},
+			true,
+		},
+		{
+			"repeated",
+			[][]byte{{0x67, 0x42, 0xc0, 0x1e}},
+			[][]byte{{0x67, 0x42, 0xc0, 0x1e}},
+			[][]byte{{0x67, 0x42, 0xc0, 0x1e}},
+			false,
+		},
+		{
+			"new",
+			[][]byte{{0x68, 0xce, 0x3c, 0x80}},
+			[][]byte{
+				{0x68, 0xce, 0x3c, 0x80},
+				{0x68, 0x53, 0x8f, 0x20},
+				{0x68, 0x53, 0x8f, 0x20},
+			},
+			[][]byte{
+				{0x68, 0xce, 0x3c, 0x80},
+				{0x68, 0x53, 0x8f, 0x20},
+			},
+			true,
+		},
+		{
+			"no incoming",
+			[][]byte{{0x67, 0x42, 0xc0, 0x1e}},
+			nil,
+			[][]byte{{0x67, 0x42, 0xc0, 0x1e}},
+			false,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			merged, changed := DedupeParameterSets(ca.existing, ca.incoming)
+			require.Equal(t, ca.merged, merged)
+			require.Equal(t, ca.changed, changed)
+		})
+	}
+}
+
+func TestDedupeParameterSetsNoModify(t *testing.T) {
+	existing := make([][]byte, 1, 2)
+	existing[0] = []byte{0x67, 0x42, 0xc0, 0x1e}
+
+	merged, changed := DedupeParameterSets(existing, [][]byte{{0x67, 0x42, 0xc0, 0x1f}})
+	require.Equal(t, true, changed)
+	require.Equal(t, 2, len(merged))
+	require.Equal(t, []byte(nil), existing[:2][1])
+}
@@ -32,3 +32,60 @@ func EmulationPreventionRemove(nalu []byte) []byte {
 
 	return ret
 }
+
+// EmulationPreventionAdd adds emulation prevention bytes to a NALU.
+// Specification: ITU-T Rec. H.264, 7.4.1 NAL unit semantics
+func EmulationPreventionAdd(nalu []byte) []byte {
+	// 0x00 0x00 0x00 -> 0x00 0x00 0x03 0x00
+	// 0x00 0x00 0x01 -> 0x00 0x00 0x03 0x01
+	// 0x00 0x00 0x02 -> 0x00 0x00 0x03 0x02
+	// 0x00 0x00 0x03 -> 0x00 0x00 0x03 0x03
+	// 0x00 0x00 (end) -> 0x00 0x00 0x03
+
+	n := len(nalu)
+	zeros := 0
+
+	for _, b := range nalu {
+		if zeros == 2 && b <= 3 {
+			n++
+			zeros = 0
+		}
+
+		if b == 0 {
+			zeros++
+		} else {
+			zeros = 0
+		}
+	}
+
+	if zeros == 2 {
+		n++
+	}
+
+	ret := make([]byte, n)
+	pos := 0
+	zeros = 0
+
+	for _, b := range nalu {
+		if zeros == 2 && b <= 3 {
+			ret[pos] = 3
+			pos++
+			zeros = 0
+		}
+
+		ret[pos] = b
+		pos++
+
+		if b == 0 {
+			zeros++
+		} else {
+			zeros = 0
+		}
+	}
+
+	if zeros == 2 {
+		ret[pos] = 3
+	}
+
+	return ret
+}
@@ -66,3 +66,83 @@ func FuzzEmulationPreventionRemove(f *testing.F) {
 		EmulationPreventionRemove(b)
 	})
 }
+
+var casesEmulationPreventionAdd = []struct {
+	name   string
+	unproc []byte
+	proc   []byte
+}{
+	{
+		"base",
+		[]byte{
+			0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x01,
+			0x02, 0x00, 0x00, 0x02,
+			0x04, 0x00, 0x00, 0x03,
+		},
+		[]byte{
+			0x00, 0x00, 0x03, 0x00,
+			0x01, 0x00, 0x00, 0x03, 0x01,
+			0x02, 0x00, 0x00, 0x03, 0x02,
+			0x04, 0x00, 0x00, 0x03, 0x03,
+		},
+	},
+	{
+		"no emulation byte",
+		[]byte{
+			0x00, 0x00, 0x04,
+			0x00, 0x01, 0x00,
+		},
+		[]byte{
+			0x00, 0x00, 0x04,
+			0x00, 0x01, 0x00,
+		},
+	},
+	{
+		"double emulation byte",
+		[]byte{
+			0x00, 0x00, 0x00,
+			0x00, 0x00,
+		},
+		[]byte{
+			0x00, 0x00, 0x03,
+			0x00, 0x00, 0x03, 0x00,
+		},
+	},
+	{
+		"terminal emulation byte",
+		[]byte{
+			0x00, 0x00,
+		},
+		[]byte{
+			0x00, 0x00, 0x03,
+		},
+	},
+}
+
+func TestEmulationPreventionAdd(t *testing.T) {
+	for _, ca := range casesEmulationPreventionAdd {
+		t.Run(ca.name, func(t *testing.T) {
+			proc := EmulationPreventionAdd(ca.unproc)
+			require.Equal(t, ca.proc, proc)
+		})
+	}
+}
+
+func FuzzEmulationPreventionAdd(f *testing.F) {
+	for _, ca := range casesEmulationPreventionAdd {
+		f.Add(ca.unproc)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		proc := EmulationPreventionAdd(b)
+
+		for i := 2; i < len(proc); i++ {
+			if proc[i-2] == 0 && proc[i-1] == 0 {
+				require.LessOrEqual(t, byte(3), proc[i])
+			}
+		}
+
+		require.Equal(t, b, EmulationPreventionRemove(proc))
+	})
+}
@@ -0,0 +1,61 @@
+package h264
+
+import (
+	"fmt"
+)
+
+func appendParameterSet(sets [][]byte, set []byte) [][]byte {
+	if containsParameterSet(sets, set) {
+		return sets
+	}
+	return append(sets, set)
+}
+
+// ExtractParameterSets scans an Annex-B stream and returns the SPS and PPS
+// that are needed to decode the first IDR.
+// Scanning stops at the first IDR that is preceded by at least a SPS and a PPS,
+// or at the end of the stream. Repeated parameter sets are returned once.
+// Returned parameter sets point to the input buffer.
+func ExtractParameterSets(annexb []byte) ([][]byte, [][]byte, error) {
+	split := AnnexBSplitFunc(MaxAccessUnitSize)
+	var sps [][]byte
+	var pps [][]byte
+
+	for len(annexb) != 0 {
+		n, nalu, err := split(annexb, true)
+		if err != nil {
+			return nil, nil, err
+		}
+		if n == 0 {
+			break
+		}
+		annexb = annexb[n:]
+
+		if len(nalu) == 0 {
+			continue
+		}
+
+		switch NALUType(nalu[0] & 0x1F) {
+		case NALUTypeSPS:
+			sps = appendParameterSet(sps, nalu)
+
+		case NALUTypePPS:
+			pps = appendParameterSet(pps, nalu)
+
+		case NALUTypeIDR:
+			if sps != nil && pps != nil {
+				return sps, pps, nil
+			}
+		}
+	}
+
+	if sps == nil {
+		return nil, nil, fmt.Errorf("SPS not found")
+	}
+
+	if pps == nil {
+		return nil, nil, fmt.Errorf("PPS not found")
+	}
+
+	return sps, pps, nil
+}
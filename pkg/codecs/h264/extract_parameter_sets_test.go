@@ -0,0 +1,115 @@
+package h264
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractParameterSets(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		byts []byte
+		sps  [][]byte
+		pps  [][]byte
+	}{
+		{
+			"idr",
+			[]byte{
+				0x00, 0x00, 0x00, 0x01, 0x09, 0xf0,
+				0x00, 0x00, 0x00, 0x01, 0x67, 0x42, 0xc0, 0x1e,
+				0x00, 0x00, 0x00, 0x01, 0x68, 0xce, 0x3c, 0x80,
+				0x00, 0x00, 0x01, 0x65, 0x88, 0x84,
+				0x00, 0x00, 0x01, 0x41, 0x9a, 0x02,
+				0x00, 0x00, 0x00, 0x01, 0x67, 0x42, 0xc0, 0x1f,
+				0x00, 0x00, 0x00, 0x01, 0x68, 0xce, 0x3c, 0x80,
+				0x00, 0x00, 0x01, 0x65, 0x88, 0x84,
+			},
+			[][]byte{{0x67, 0x42, 0xc0, 0x1e}},
+			[][]byte{{0x68, 0xce, 0x3c, 0x80}},
+		},
+		{
+			"stream starting without parameter sets",
+			[]byte{
+				0x00, 0x00, 0x00, 0x01, 0x65, 0x88, 0x84,
+				0x00, 0x00, 0x00, 0x01, 0x67, 0x42, 0xc0, 0x1e,
+				0x00, 0x00, 0x00, 0x01, 0x68, 0xce, 0x3c, 0x80,
+				0x00, 0x00, 0x00, 0x01, 0x68, 0x53, 0x8f, 0x20,
+				0x00, 0x00, 0x00, 0x01, 0x68, 0xce, 0x3c, 0x80,
+				0x00, 0x00, 0x01, 0x65, 0x88, 0x84,
+			},
+			[][]byte{{0x67, 0x42, 0xc0, 0x1e}},
+			[][]byte{
+				{0x68, 0xce, 0x3c, 0x80},
+				{0x68, 0x53, 0x8f, 0x20},
+			},
+		},
+		{
+			"no idr",
+			[]byte{
+				0x00, 0x00, 0x00, 0x01, 0x67, 0x42, 0xc0, 0x1e,
+				0x00, 0x00, 0x00, 0x01, 0x68, 0xce, 0x3c, 0x80,
+			},
+			[][]byte{{0x67, 0x42, 0xc0, 0x1e}},
+			[][]byte{{0x68, 0xce, 0x3c, 0x80}},
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			sps, pps, err := ExtractParameterSets(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.sps, sps)
+			require.Equal(t, ca.pps, pps)
+		})
+	}
+}
+
+func TestExtractParameterSetsErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		byts []byte
+		err  string
+	}{
+		{
+			"empty",
+			[]byte{},
+			"SPS not found",
+		},
+		{
+			"sps missing",
+			[]byte{
+				0x00, 0x00, 0x00, 0x01, 0x68, 0xce, 0x3c, 0x80,
+				0x00, 0x00, 0x01, 0x65, 0x88, 0x84,
+			},
+			"SPS not found",
+		},
+		{
+			"pps missing",
+			[]byte{
+				0x00, 0x00, 0x00, 0x01, 0x67, 0x42, 0xc0, 0x1e,
+				0x00, 0x00, 0x01, 0x65, 0x88, 0x84,
+			},
+			"PPS not found",
+		},
+		{
+			"invalid delimiter",
+			[]byte{0x01, 0x67, 0x42, 0xc0, 0x1e},
+			"initial delimiter not found",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			_, _, err := ExtractParameterSets(ca.byts)
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func FuzzExtractParameterSets(f *testing.F) {
+	f.Add([]byte{
+		0x00, 0x00, 0x00, 0x01, 0x67, 0x42, 0xc0, 0x1e,
+		0x00, 0x00, 0x00, 0x01, 0x68, 0xce, 0x3c, 0x80,
+	})
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		ExtractParameterSets(b) //nolint:errcheck
+	})
+}
@@ -82,3 +82,22 @@ func (nt NALUType) String() string {
 	}
 	return fmt.Sprintf("unknown (%d)", nt)
 }
+
+// IsSlice checks whether the NALU contains a slice or slice data partition of a coded picture.
+func (nt NALUType) IsSlice() bool {
+	return nt >= NALUTypeNonIDR && nt <= NALUTypeIDR
+}
+
+// IsKeyFrame checks whether the NALU contains a slice of an IDR picture.
+func (nt NALUType) IsKeyFrame() bool {
+	return nt == NALUTypeIDR
+}
+
+// IsParameterSet checks whether the NALU contains a sequence or picture parameter set.
+func (nt NALUType) IsParameterSet() bool {
+	switch nt {
+	case NALUTypeSPS, NALUTypePPS, NALUTypeSPSExtension, NALUTypeSubsetSPS:
+		return true
+	}
+	return false
+}
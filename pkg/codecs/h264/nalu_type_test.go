@@ -11,3 +11,28 @@ func TestNALUType(t *testing.T) {
 	require.NotEqual(t, true, strings.HasPrefix(NALUType(10).String(), "unknown"))
 	require.Equal(t, true, strings.HasPrefix(NALUType(50).String(), "unknown"))
 }
+
+func TestNALUTypePredicates(t *testing.T) {
+	for _, ca := range []struct {
+		typ            NALUType
+		isSlice        bool
+		isKeyFrame     bool
+		isParameterSet bool
+	}{
+		{NALUTypeNonIDR, true, false, false},
+		{NALUTypeDataPartitionA, true, false, false},
+		{NALUTypeIDR, true, true, false},
+		{NALUTypeSEI, false, false, false},
+		{NALUTypeSPS, false, false, true},
+		{NALUTypePPS, false, false, true},
+		{NALUTypeSubsetSPS, false, false, true},
+		{NALUTypeAccessUnitDelimiter, false, false, false},
+		{NALUTypeFUA, false, false, false},
+	} {
+		t.Run(ca.typ.String(), func(t *testing.T) {
+			require.Equal(t, ca.isSlice, ca.typ.IsSlice())
+			require.Equal(t, ca.isKeyFrame, ca.typ.IsKeyFrame())
+			require.Equal(t, ca.isParameterSet, ca.typ.IsParameterSet())
+		})
+	}
+}
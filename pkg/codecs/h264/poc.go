@@ -0,0 +1,238 @@
+package h264
+
+import (
+	"fmt"
+)
+
+// POCSliceInfo contains the fields of a slice header that are needed to compute the picture order count.
+type POCSliceInfo struct {
+	// nal_ref_idc of the NALU that contains the slice.
+	NALRefIdc uint8
+
+	// whether the slice belongs to an IDR picture.
+	IDR bool
+
+	FrameNum        uint32
+	FieldPicFlag    bool
+	BottomFieldFlag bool
+
+	// PicOrderCntType == 0
+	PicOrderCntLsb         uint32
+	DeltaPicOrderCntBottom int32
+
+	// PicOrderCntType == 1
+	DeltaPicOrderCnt [2]int32
+
+	// whether the slice contains a memory_management_control_operation equal to 5.
+	MemoryManagementControlOperation5 bool
+}
+
+// POCComputer computes the picture order count of pictures, given in decode order.
+// Specification: ITU-T Rec. H.264, 8.2.1
+type POCComputer struct {
+	prevPicOrderCntMsb int32
+	prevPicOrderCntLsb int32
+	prevFrameNumOffset int32
+	prevFrameNum       uint32
+	prevMMCO5          bool
+}
+
+// NewPOCComputer allocates a POCComputer.
+func NewPOCComputer() *POCComputer {
+	return &POCComputer{}
+}
+
+// Compute computes the picture order count of a picture.
+// Pictures must be provided in decode order.
+func (c *POCComputer) Compute(sps *SPS, info POCSliceInfo) (int32, error) {
+	var topFieldOrderCnt int32
+	var bottomFieldOrderCnt int32
+
+	switch sps.PicOrderCntType {
+	case 0:
+		topFieldOrderCnt, bottomFieldOrderCnt = c.computeType0(sps, info)
+
+	case 1:
+		topFieldOrderCnt, bottomFieldOrderCnt = c.computeType1(sps, info)
+
+	case 2:
+		topFieldOrderCnt, bottomFieldOrderCnt = c.computeType2(sps, info)
+
+	default:
+		return 0, fmt.Errorf("unsupported pic_order_cnt_type (%d)", sps.PicOrderCntType)
+	}
+
+	var poc int32
+	switch {
+	case !info.FieldPicFlag:
+		poc = topFieldOrderCnt
+		if bottomFieldOrderCnt < poc {
+			poc = bottomFieldOrderCnt
+		}
+
+	case info.BottomFieldFlag:
+		poc = bottomFieldOrderCnt
+
+	default:
+		poc = topFieldOrderCnt
+	}
+
+	c.prevMMCO5 = info.MemoryManagementControlOperation5
+
+	if sps.PicOrderCntType == 0 && info.NALRefIdc != 0 && info.MemoryManagementControlOperation5 {
+		// after memory_management_control_operation 5,
+		// the picture order count of the picture is reset to zero.
+		c.prevPicOrderCntMsb = 0
+		if !info.FieldPicFlag || !info.BottomFieldFlag {
+			c.prevPicOrderCntLsb = topFieldOrderCnt - poc
+		} else {
+			c.prevPicOrderCntLsb = 0
+		}
+	}
+
+	return poc, nil
+}
+
+func (c *POCComputer) computeType0(sps *SPS, info POCSliceInfo) (int32, int32) {
+	if info.IDR {
+		c.prevPicOrderCntMsb = 0
+		c.prevPicOrderCntLsb = 0
+	}
+
+	maxPicOrderCntLsb := int32(1) << (sps.Log2MaxPicOrderCntLsbMinus4 + 4)
+	picOrderCntLsb := int32(info.PicOrderCntLsb)
+
+	var picOrderCntMsb int32
+	switch {
+	case picOrderCntLsb < c.prevPicOrderCntLsb &&
+		(c.prevPicOrderCntLsb-picOrderCntLsb) >= (maxPicOrderCntLsb/2):
+		picOrderCntMsb = c.prevPicOrderCntMsb + maxPicOrderCntLsb
+
+	case picOrderCntLsb > c.prevPicOrderCntLsb &&
+		(picOrderCntLsb-c.prevPicOrderCntLsb) > (maxPicOrderCntLsb/2):
+		picOrderCntMsb = c.prevPicOrderCntMsb - maxPicOrderCntLsb
+
+	default:
+		picOrderCntMsb = c.prevPicOrderCntMsb
+	}
+
+	var topFieldOrderCnt int32
+	var bottomFieldOrderCnt int32
+
+	switch {
+	case !info.FieldPicFlag:
+		topFieldOrderCnt = picOrderCntMsb + picOrderCntLsb
+		bottomFieldOrderCnt = topFieldOrderCnt + info.DeltaPicOrderCntBottom
+
+	case info.BottomFieldFlag:
+		bottomFieldOrderCnt = picOrderCntMsb + picOrderCntLsb
+
+	default:
+		topFieldOrderCnt = picOrderCntMsb + picOrderCntLsb
+	}
+
+	// prevPicOrderCntMsb and prevPicOrderCntLsb refer to the previous reference picture
+	if info.NALRefIdc != 0 {
+		c.prevPicOrderCntMsb = picOrderCntMsb
+		c.prevPicOrderCntLsb = picOrderCntLsb
+	}
+
+	return topFieldOrderCnt, bottomFieldOrderCnt
+}
+
+func (c *POCComputer) computeFrameNumOffset(sps *SPS, info POCSliceInfo) int32 {
+	prevFrameNumOffset := c.prevFrameNumOffset
+	prevFrameNum := c.prevFrameNum
+
+	if c.prevMMCO5 {
+		prevFrameNumOffset = 0
+		prevFrameNum = 0
+	}
+
+	var frameNumOffset int32
+	switch {
+	case info.IDR:
+		frameNumOffset = 0
+
+	case prevFrameNum > info.FrameNum:
+		frameNumOffset = prevFrameNumOffset + (int32(1) << (sps.Log2MaxFrameNumMinus4 + 4))
+
+	default:
+		frameNumOffset = prevFrameNumOffset
+	}
+
+	c.prevFrameNumOffset = frameNumOffset
+	c.prevFrameNum = info.FrameNum
+
+	return frameNumOffset
+}
+
+func (c *POCComputer) computeType1(sps *SPS, info POCSliceInfo) (int32, int32) {
+	frameNumOffset := c.computeFrameNumOffset(sps, info)
+	numRefFramesInPicOrderCntCycle := int32(len(sps.OffsetForRefFrames))
+
+	var absFrameNum int32
+	if numRefFramesInPicOrderCntCycle != 0 {
+		absFrameNum = frameNumOffset + int32(info.FrameNum)
+	}
+
+	if info.NALRefIdc == 0 && absFrameNum > 0 {
+		absFrameNum--
+	}
+
+	var expectedPicOrderCnt int32
+
+	if absFrameNum > 0 {
+		picOrderCntCycleCnt := (absFrameNum - 1) / numRefFramesInPicOrderCntCycle
+		frameNumInPicOrderCntCycle := (absFrameNum - 1) % numRefFramesInPicOrderCntCycle
+
+		var expectedDeltaPerPicOrderCntCycle int32
+		for _, v := range sps.OffsetForRefFrames {
+			expectedDeltaPerPicOrderCntCycle += v
+		}
+
+		expectedPicOrderCnt = picOrderCntCycleCnt * expectedDeltaPerPicOrderCntCycle
+		for i := int32(0); i <= frameNumInPicOrderCntCycle; i++ {
+			expectedPicOrderCnt += sps.OffsetForRefFrames[i]
+		}
+	}
+
+	if info.NALRefIdc == 0 {
+		expectedPicOrderCnt += sps.OffsetForNonRefPic
+	}
+
+	var topFieldOrderCnt int32
+	var bottomFieldOrderCnt int32
+
+	switch {
+	case !info.FieldPicFlag:
+		topFieldOrderCnt = expectedPicOrderCnt + info.DeltaPicOrderCnt[0]
+		bottomFieldOrderCnt = topFieldOrderCnt + sps.OffsetForTopToBottomField + info.DeltaPicOrderCnt[1]
+
+	case info.BottomFieldFlag:
+		bottomFieldOrderCnt = expectedPicOrderCnt + sps.OffsetForTopToBottomField + info.DeltaPicOrderCnt[0]
+
+	default:
+		topFieldOrderCnt = expectedPicOrderCnt + info.DeltaPicOrderCnt[0]
+	}
+
+	return topFieldOrderCnt, bottomFieldOrderCnt
+}
+
+func (c *POCComputer) computeType2(sps *SPS, info POCSliceInfo) (int32, int32) {
+	frameNumOffset := c.computeFrameNumOffset(sps, info)
+
+	var tempPicOrderCnt int32
+	switch {
+	case info.IDR:
+		tempPicOrderCnt = 0
+
+	case info.NALRefIdc == 0:
+		tempPicOrderCnt = 2*(frameNumOffset+int32(info.FrameNum)) - 1
+
+	default:
+		tempPicOrderCnt = 2 * (frameNumOffset + int32(info.FrameNum))
+	}
+
+	return tempPicOrderCnt, tempPicOrderCnt
+}
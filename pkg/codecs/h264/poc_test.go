@@ -0,0 +1,113 @@
+package h264
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type pocSample struct {
+	info POCSliceInfo
+	poc  int32
+}
+
+var casesPOCComputer = []struct {
+	name     string
+	sps      SPS
+	sequence []pocSample
+}{
+	{
+		"type 0",
+		SPS{
+			PicOrderCntType:             0,
+			Log2MaxPicOrderCntLsbMinus4: 0,
+		},
+		[]pocSample{
+			{POCSliceInfo{NALRefIdc: 3, IDR: true, PicOrderCntLsb: 0}, 0},
+			{POCSliceInfo{NALRefIdc: 2, FrameNum: 1, PicOrderCntLsb: 4}, 4},
+			{POCSliceInfo{NALRefIdc: 0, FrameNum: 2, PicOrderCntLsb: 2}, 2},
+			{POCSliceInfo{NALRefIdc: 2, FrameNum: 2, PicOrderCntLsb: 8}, 8},
+			{POCSliceInfo{NALRefIdc: 2, FrameNum: 3, PicOrderCntLsb: 12}, 12},
+			{POCSliceInfo{NALRefIdc: 2, FrameNum: 4, PicOrderCntLsb: 0}, 16},
+			{POCSliceInfo{NALRefIdc: 0, FrameNum: 5, PicOrderCntLsb: 14}, 14},
+			{POCSliceInfo{NALRefIdc: 2, FrameNum: 5, PicOrderCntLsb: 4}, 20},
+			{POCSliceInfo{NALRefIdc: 3, IDR: true, PicOrderCntLsb: 2}, 2},
+		},
+	},
+	{
+		"type 0 with bottom field delta",
+		SPS{
+			PicOrderCntType:             0,
+			Log2MaxPicOrderCntLsbMinus4: 0,
+		},
+		[]pocSample{
+			{POCSliceInfo{NALRefIdc: 3, IDR: true, PicOrderCntLsb: 2, DeltaPicOrderCntBottom: -1}, 1},
+			{POCSliceInfo{NALRefIdc: 2, FrameNum: 1, PicOrderCntLsb: 6, DeltaPicOrderCntBottom: 1}, 6},
+		},
+	},
+	{
+		"type 0 with memory management control operation 5",
+		SPS{
+			PicOrderCntType:             0,
+			Log2MaxPicOrderCntLsbMinus4: 0,
+		},
+		[]pocSample{
+			{POCSliceInfo{NALRefIdc: 3, IDR: true, PicOrderCntLsb: 0}, 0},
+			{POCSliceInfo{NALRefIdc: 2, FrameNum: 1, PicOrderCntLsb: 6}, 6},
+			{POCSliceInfo{NALRefIdc: 2, FrameNum: 2, PicOrderCntLsb: 12, MemoryManagementControlOperation5: true}, 12},
+			{POCSliceInfo{NALRefIdc: 2, FrameNum: 1, PicOrderCntLsb: 4}, 4},
+		},
+	},
+	{
+		"type 1",
+		SPS{
+			PicOrderCntType:       1,
+			Log2MaxFrameNumMinus4: 0,
+			OffsetForNonRefPic:    -2,
+			OffsetForRefFrames:    []int32{4},
+		},
+		[]pocSample{
+			{POCSliceInfo{NALRefIdc: 3, IDR: true, FrameNum: 0}, 0},
+			{POCSliceInfo{NALRefIdc: 2, FrameNum: 1}, 4},
+			{POCSliceInfo{NALRefIdc: 0, FrameNum: 2}, 2},
+			{POCSliceInfo{NALRefIdc: 2, FrameNum: 2}, 8},
+			{POCSliceInfo{NALRefIdc: 2, FrameNum: 3, DeltaPicOrderCnt: [2]int32{1, 0}}, 13},
+		},
+	},
+	{
+		"type 2",
+		SPS{
+			PicOrderCntType:       2,
+			Log2MaxFrameNumMinus4: 0,
+		},
+		[]pocSample{
+			{POCSliceInfo{NALRefIdc: 3, IDR: true, FrameNum: 0}, 0},
+			{POCSliceInfo{NALRefIdc: 2, FrameNum: 1}, 2},
+			{POCSliceInfo{NALRefIdc: 0, FrameNum: 2}, 3},
+			{POCSliceInfo{NALRefIdc: 2, FrameNum: 2}, 4},
+			{POCSliceInfo{NALRefIdc: 2, FrameNum: 15}, 30},
+			{POCSliceInfo{NALRefIdc: 2, FrameNum: 0}, 32},
+			{POCSliceInfo{NALRefIdc: 2, FrameNum: 0, FieldPicFlag: true, BottomFieldFlag: true}, 32},
+		},
+	},
+}
+
+func TestPOCComputer(t *testing.T) {
+	for _, ca := range casesPOCComputer {
+		t.Run(ca.name, func(t *testing.T) {
+			c := NewPOCComputer()
+
+			for _, sample := range ca.sequence {
+				poc, err := c.Compute(&ca.sps, sample.info)
+				require.NoError(t, err)
+				require.Equal(t, sample.poc, poc)
+			}
+		})
+	}
+}
+
+func TestPOCComputerUnsupportedType(t *testing.T) {
+	c := NewPOCComputer()
+	_, err := c.Compute(&SPS{PicOrderCntType: 3}, POCSliceInfo{IDR: true})
+	require.EqualError(t, err, "unsupported pic_order_cnt_type (3)")
+}
@@ -0,0 +1,315 @@
+package h264
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/pkg/bits"
+)
+
+const (
+	maxSliceGroups = 8
+)
+
+// PPS is a H264 picture parameter set.
+// Specification: ITU-T Rec. H.264, 7.3.2.2
+type PPS struct {
+	ID                                    uint32
+	SPSID                                 uint32
+	EntropyCodingModeFlag                 bool
+	BottomFieldPicOrderInFramePresentFlag bool
+	NumSliceGroupsMinus1                  uint32
+
+	// NumSliceGroupsMinus1 > 0
+	SliceGroupMapType uint32
+
+	NumRefIdxL0DefaultActiveMinus1     uint32
+	NumRefIdxL1DefaultActiveMinus1     uint32
+	WeightedPredFlag                   bool
+	WeightedBipredIdc                  uint8
+	PicInitQpMinus26                   int32
+	PicInitQsMinus26                   int32
+	ChromaQpIndexOffset                int32
+	DeblockingFilterControlPresentFlag bool
+	ConstrainedIntraPredFlag           bool
+	RedundantPicCntPresentFlag         bool
+
+	// more_rbsp_data()
+	Transform8x8ModeFlag bool
+
+	// picScalingListPresentFlag == true
+	ScalingList4x4                 [][]int32
+	UseDefaultScalingMatrix4x4Flag []bool
+	ScalingList8x8                 [][]int32
+	UseDefaultScalingMatrix8x8Flag []bool
+
+	// when not present, it is equal to ChromaQpIndexOffset
+	SecondChromaQpIndexOffset int32
+}
+
+// PPSUnmarshalOptions contains options of PPS.UnmarshalWithOptions.
+type PPSUnmarshalOptions struct {
+	// SPS referenced by the PPS.
+	// It is needed to decode 8x8 scaling lists of streams with chroma_format_idc = 3.
+	// When it is nil, chroma_format_idc is assumed to be different than 3.
+	SPS *SPS
+}
+
+// moreRBSPData checks whether there's data before rbsp_trailing_bits().
+// Specification: ITU-T Rec. H.264, 7.2
+func moreRBSPData(buf []byte, pos int) bool {
+	for i := len(buf) - 1; i >= 0; i-- {
+		if buf[i] != 0 {
+			// position of rbsp_stop_one_bit
+			stopPos := i*8 + 7
+			for b := buf[i]; (b & 1) == 0; b >>= 1 {
+				stopPos--
+			}
+			return pos < stopPos
+		}
+	}
+	return false
+}
+
+// Unmarshal decodes a PPS.
+func (p *PPS) Unmarshal(buf []byte) error {
+	return p.UnmarshalWithOptions(buf, PPSUnmarshalOptions{})
+}
+
+// UnmarshalWithOptions decodes a PPS.
+func (p *PPS) UnmarshalWithOptions(buf []byte, opts PPSUnmarshalOptions) error {
+	if len(buf) < 1 {
+		return fmt.Errorf("not enough bits")
+	}
+
+	if NALUType(buf[0]&0x1F) != NALUTypePPS {
+		return fmt.Errorf("not a PPS")
+	}
+
+	buf = EmulationPreventionRemove(buf[1:])
+	pos := 0
+
+	var err error
+	p.ID, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	p.SPSID, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	err = bits.HasSpace(buf, pos, 2)
+	if err != nil {
+		return err
+	}
+
+	p.EntropyCodingModeFlag = bits.ReadFlagUnsafe(buf, &pos)
+	p.BottomFieldPicOrderInFramePresentFlag = bits.ReadFlagUnsafe(buf, &pos)
+
+	p.NumSliceGroupsMinus1, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if p.NumSliceGroupsMinus1 > 0 {
+		err = p.skipSliceGroups(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		p.SliceGroupMapType = 0
+	}
+
+	p.NumRefIdxL0DefaultActiveMinus1, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	p.NumRefIdxL1DefaultActiveMinus1, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	err = bits.HasSpace(buf, pos, 3)
+	if err != nil {
+		return err
+	}
+
+	p.WeightedPredFlag = bits.ReadFlagUnsafe(buf, &pos)
+	p.WeightedBipredIdc = uint8(bits.ReadBitsUnsafe(buf, &pos, 2))
+
+	p.PicInitQpMinus26, err = bits.ReadGolombSigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	p.PicInitQsMinus26, err = bits.ReadGolombSigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	p.ChromaQpIndexOffset, err = bits.ReadGolombSigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	err = bits.HasSpace(buf, pos, 3)
+	if err != nil {
+		return err
+	}
+
+	p.DeblockingFilterControlPresentFlag = bits.ReadFlagUnsafe(buf, &pos)
+	p.ConstrainedIntraPredFlag = bits.ReadFlagUnsafe(buf, &pos)
+	p.RedundantPicCntPresentFlag = bits.ReadFlagUnsafe(buf, &pos)
+
+	p.Transform8x8ModeFlag = false
+	p.ScalingList4x4 = nil
+	p.UseDefaultScalingMatrix4x4Flag = nil
+	p.ScalingList8x8 = nil
+	p.UseDefaultScalingMatrix8x8Flag = nil
+	p.SecondChromaQpIndexOffset = p.ChromaQpIndexOffset
+
+	if moreRBSPData(buf, pos) {
+		err = p.unmarshalExtension(buf, &pos, opts)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *PPS) unmarshalExtension(buf []byte, pos *int, opts PPSUnmarshalOptions) error {
+	err := bits.HasSpace(buf, *pos, 2)
+	if err != nil {
+		return err
+	}
+
+	p.Transform8x8ModeFlag = bits.ReadFlagUnsafe(buf, pos)
+	picScalingMatrixPresentFlag := bits.ReadFlagUnsafe(buf, pos)
+
+	if picScalingMatrixPresentFlag {
+		lim := 6
+		if p.Transform8x8ModeFlag {
+			if opts.SPS != nil && opts.SPS.ChromaFormatIdc == 3 {
+				lim += 6
+			} else {
+				lim += 2
+			}
+		}
+
+		for i := 0; i < lim; i++ {
+			var picScalingListPresentFlag bool
+			picScalingListPresentFlag, err = bits.ReadFlag(buf, pos)
+			if err != nil {
+				return err
+			}
+
+			if picScalingListPresentFlag {
+				if i < 6 {
+					var scalingList []int32
+					var useDefaultScalingMatrixFlag bool
+					scalingList, useDefaultScalingMatrixFlag, err = readScalingList(buf, pos, 16)
+					if err != nil {
+						return err
+					}
+
+					p.ScalingList4x4 = append(p.ScalingList4x4, scalingList)
+					p.UseDefaultScalingMatrix4x4Flag = append(p.UseDefaultScalingMatrix4x4Flag,
+						useDefaultScalingMatrixFlag)
+				} else {
+					var scalingList []int32
+					var useDefaultScalingMatrixFlag bool
+					scalingList, useDefaultScalingMatrixFlag, err = readScalingList(buf, pos, 64)
+					if err != nil {
+						return err
+					}
+
+					p.ScalingList8x8 = append(p.ScalingList8x8, scalingList)
+					p.UseDefaultScalingMatrix8x8Flag = append(p.UseDefaultScalingMatrix8x8Flag,
+						useDefaultScalingMatrixFlag)
+				}
+			}
+		}
+	}
+
+	p.SecondChromaQpIndexOffset, err = bits.ReadGolombSigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *PPS) skipSliceGroups(buf []byte, pos *int) error {
+	if p.NumSliceGroupsMinus1 >= maxSliceGroups {
+		return fmt.Errorf("num_slice_groups_minus1 exceeds %d", maxSliceGroups-1)
+	}
+
+	var err error
+	p.SliceGroupMapType, err = bits.ReadGolombUnsigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	switch p.SliceGroupMapType {
+	case 0:
+		for i := uint32(0); i <= p.NumSliceGroupsMinus1; i++ {
+			_, err = bits.ReadGolombUnsigned(buf, pos) // run_length_minus1
+			if err != nil {
+				return err
+			}
+		}
+
+	case 2:
+		for i := uint32(0); i < p.NumSliceGroupsMinus1; i++ {
+			_, err = bits.ReadGolombUnsigned(buf, pos) // top_left
+			if err != nil {
+				return err
+			}
+
+			_, err = bits.ReadGolombUnsigned(buf, pos) // bottom_right
+			if err != nil {
+				return err
+			}
+		}
+
+	case 3, 4, 5:
+		_, err = bits.ReadFlag(buf, pos) // slice_group_change_direction_flag
+		if err != nil {
+			return err
+		}
+
+		_, err = bits.ReadGolombUnsigned(buf, pos) // slice_group_change_rate_minus1
+		if err != nil {
+			return err
+		}
+
+	case 6:
+		var picSizeInMapUnitsMinus1 uint32
+		picSizeInMapUnitsMinus1, err = bits.ReadGolombUnsigned(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		// Ceil(Log2(num_slice_groups_minus1 + 1))
+		n := 0
+		for (1 << n) < (p.NumSliceGroupsMinus1 + 1) {
+			n++
+		}
+
+		err = bits.HasSpace(buf, *pos, (int(picSizeInMapUnitsMinus1)+1)*n)
+		if err != nil {
+			return err
+		}
+		*pos += (int(picSizeInMapUnitsMinus1) + 1) * n // slice_group_id
+
+	case 1:
+
+	default:
+		return fmt.Errorf("invalid slice_group_map_type (%d)", p.SliceGroupMapType)
+	}
+
+	return nil
+}
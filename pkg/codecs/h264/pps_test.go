@@ -0,0 +1,110 @@
+package h264
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesPPS = []struct {
+	name string
+	byts []byte
+	pps  PPS
+}{
+	{
+		"x264",
+		[]byte{0x68, 0xeb, 0xe3, 0xcb, 0x22, 0xc0},
+		PPS{
+			EntropyCodingModeFlag:              true,
+			NumRefIdxL0DefaultActiveMinus1:     2,
+			WeightedPredFlag:                   true,
+			WeightedBipredIdc:                  2,
+			PicInitQpMinus26:                   -3,
+			ChromaQpIndexOffset:                -2,
+			DeblockingFilterControlPresentFlag: true,
+			Transform8x8ModeFlag:               true,
+			SecondChromaQpIndexOffset:          -2,
+		},
+	},
+	{
+		"slice groups, redundant pic cnt",
+		[]byte{0x68, 0xd5, 0xf1, 0xec},
+		PPS{
+			BottomFieldPicOrderInFramePresentFlag: true,
+			NumSliceGroupsMinus1:                  1,
+			DeblockingFilterControlPresentFlag:    true,
+			RedundantPicCntPresentFlag:            true,
+		},
+	},
+	{
+		"scaling matrix",
+		[]byte{0x68, 0xee, 0x3c, 0xe1, 0x10, 0x42, 0x25},
+		PPS{
+			EntropyCodingModeFlag:              true,
+			DeblockingFilterControlPresentFlag: true,
+			Transform8x8ModeFlag:               true,
+			ScalingList4x4: [][]int32{
+				ppsTestFlatScalingList(16),
+			},
+			UseDefaultScalingMatrix4x4Flag: []bool{true},
+			ScalingList8x8: [][]int32{
+				ppsTestFlatScalingList(64),
+			},
+			UseDefaultScalingMatrix8x8Flag: []bool{true},
+			SecondChromaQpIndexOffset:      1,
+		},
+	},
+}
+
+func ppsTestFlatScalingList(size int) []int32 {
+	l := make([]int32, size)
+	for i := range l {
+		l[i] = 8
+	}
+	return l
+}
+
+func TestPPSUnmarshal(t *testing.T) {
+	for _, ca := range casesPPS {
+		t.Run(ca.name, func(t *testing.T) {
+			var pps PPS
+			err := pps.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.pps, pps)
+		})
+	}
+}
+
+func TestPPSUnmarshalChromaFormat444(t *testing.T) {
+	var pps PPS
+	err := pps.UnmarshalWithOptions([]byte{0x68, 0xee, 0x3c, 0xe1, 0x10, 0x42, 0x20, 0x50},
+		PPSUnmarshalOptions{
+			SPS: &SPS{ChromaFormatIdc: 3},
+		})
+	require.NoError(t, err)
+	require.Equal(t, PPS{
+		EntropyCodingModeFlag:              true,
+		DeblockingFilterControlPresentFlag: true,
+		Transform8x8ModeFlag:               true,
+		ScalingList4x4: [][]int32{
+			ppsTestFlatScalingList(16),
+		},
+		UseDefaultScalingMatrix4x4Flag: []bool{true},
+		ScalingList8x8: [][]int32{
+			ppsTestFlatScalingList(64),
+		},
+		UseDefaultScalingMatrix8x8Flag: []bool{true},
+		SecondChromaQpIndexOffset:      1,
+	}, pps)
+}
+
+func FuzzPPSUnmarshal(f *testing.F) {
+	for _, ca := range casesPPS {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var pps PPS
+		pps.Unmarshal(b) //nolint:errcheck
+	})
+}
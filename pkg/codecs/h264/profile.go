@@ -0,0 +1,110 @@
+package h264
+
+import (
+	"fmt"
+)
+
+// Profile is a H264 profile, identified by profile_idc and by constraint flags.
+// Specification: ITU-T Rec. H.264, Annex A
+type Profile int
+
+// profiles.
+const (
+	ProfileUnknown Profile = iota
+	ProfileConstrainedBaseline
+	ProfileBaseline
+	ProfileMain
+	ProfileExtended
+	ProfileHigh
+	ProfileProgressiveHigh
+	ProfileConstrainedHigh
+	ProfileHigh10
+	ProfileHigh10Intra
+	ProfileHigh422
+	ProfileHigh422Intra
+	ProfileHigh444Predictive
+	ProfileHigh444Intra
+	ProfileCAVLC444Intra
+)
+
+var profileLabels = map[Profile]string{
+	ProfileConstrainedBaseline: "ConstrainedBaseline",
+	ProfileBaseline:            "Baseline",
+	ProfileMain:                "Main",
+	ProfileExtended:            "Extended",
+	ProfileHigh:                "High",
+	ProfileProgressiveHigh:     "ProgressiveHigh",
+	ProfileConstrainedHigh:     "ConstrainedHigh",
+	ProfileHigh10:              "High10",
+	ProfileHigh10Intra:         "High10Intra",
+	ProfileHigh422:             "High422",
+	ProfileHigh422Intra:        "High422Intra",
+	ProfileHigh444Predictive:   "High444Predictive",
+	ProfileHigh444Intra:        "High444Intra",
+	ProfileCAVLC444Intra:       "CAVLC444Intra",
+}
+
+// String implements fmt.Stringer.
+func (p Profile) String() string {
+	if l, ok := profileLabels[p]; ok {
+		return l
+	}
+	return fmt.Sprintf("unknown (%d)", p)
+}
+
+// Profile returns the profile of the video.
+func (s SPS) Profile() Profile {
+	switch s.ProfileIdc {
+	case 66:
+		if s.ConstraintSet1Flag {
+			return ProfileConstrainedBaseline
+		}
+		return ProfileBaseline
+
+	case 77:
+		return ProfileMain
+
+	case 88:
+		return ProfileExtended
+
+	case 100:
+		switch {
+		case s.ConstraintSet4Flag && s.ConstraintSet5Flag:
+			return ProfileConstrainedHigh
+		case s.ConstraintSet4Flag:
+			return ProfileProgressiveHigh
+		}
+		return ProfileHigh
+
+	case 110:
+		if s.ConstraintSet3Flag {
+			return ProfileHigh10Intra
+		}
+		return ProfileHigh10
+
+	case 122:
+		if s.ConstraintSet3Flag {
+			return ProfileHigh422Intra
+		}
+		return ProfileHigh422
+
+	case 244:
+		if s.ConstraintSet3Flag {
+			return ProfileHigh444Intra
+		}
+		return ProfileHigh444Predictive
+
+	case 44:
+		return ProfileCAVLC444Intra
+	}
+
+	return ProfileUnknown
+}
+
+// IsBaselineCompatible checks whether the video can be decoded by a Baseline decoder,
+// that is, whether profile_idc is Baseline or constraint_set0_flag is set.
+// Use Profile() to check whether the video is also Constrained Baseline.
+// Specification: ITU-T Rec. H.264, A.2.1
+func (s SPS) IsBaselineCompatible() bool {
+	return s.ProfileIdc == 66 || s.ConstraintSet0Flag
+}
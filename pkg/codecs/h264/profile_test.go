@@ -0,0 +1,76 @@
+package h264
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfile(t *testing.T) {
+	require.Equal(t, "ConstrainedBaseline", ProfileConstrainedBaseline.String())
+	require.Equal(t, true, strings.HasPrefix(ProfileUnknown.String(), "unknown"))
+}
+
+func TestSPSProfile(t *testing.T) {
+	for _, ca := range []struct {
+		name               string
+		sps                SPS
+		profile            Profile
+		baselineCompatible bool
+	}{
+		{
+			"constrained baseline",
+			SPS{ProfileIdc: 66, ConstraintSet0Flag: true, ConstraintSet1Flag: true},
+			ProfileConstrainedBaseline,
+			true,
+		},
+		{
+			"baseline",
+			SPS{ProfileIdc: 66},
+			ProfileBaseline,
+			true,
+		},
+		{
+			"main baseline compatible",
+			SPS{ProfileIdc: 77, ConstraintSet0Flag: true, ConstraintSet1Flag: true},
+			ProfileMain,
+			true,
+		},
+		{
+			"main",
+			SPS{ProfileIdc: 77, ConstraintSet1Flag: true},
+			ProfileMain,
+			false,
+		},
+		{
+			"high",
+			SPS{ProfileIdc: 100},
+			ProfileHigh,
+			false,
+		},
+		{
+			"constrained high",
+			SPS{ProfileIdc: 100, ConstraintSet4Flag: true, ConstraintSet5Flag: true},
+			ProfileConstrainedHigh,
+			false,
+		},
+		{
+			"high 10 intra",
+			SPS{ProfileIdc: 110, ConstraintSet3Flag: true},
+			ProfileHigh10Intra,
+			false,
+		},
+		{
+			"unknown",
+			SPS{ProfileIdc: 1},
+			ProfileUnknown,
+			false,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			require.Equal(t, ca.profile, ca.sps.Profile())
+			require.Equal(t, ca.baselineCompatible, ca.sps.IsBaselineCompatible())
+		})
+	}
+}
@@ -0,0 +1,383 @@
+package h264
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/pkg/bits"
+	"github.com/bluenviron/mediacommon/pkg/codecs/internal/h26x"
+)
+
+// SEIPayloadType is the type of a SEI payload.
+// Specification: ITU-T Rec. H.264, Annex D
+type SEIPayloadType uint32
+
+// SEI payload types.
+const (
+	SEIPayloadTypeBufferingPeriod      SEIPayloadType = 0
+	SEIPayloadTypePicTiming            SEIPayloadType = 1
+	SEIPayloadTypeUserDataUnregistered SEIPayloadType = 5
+	SEIPayloadTypeRecoveryPoint        SEIPayloadType = 6
+)
+
+// SEIMessage is a SEI message.
+type SEIMessage interface {
+	PayloadType() SEIPayloadType
+}
+
+// SEIMessageUnknown is a SEI message that is not decoded.
+type SEIMessageUnknown struct {
+	Type    SEIPayloadType
+	Payload []byte
+}
+
+// PayloadType implements SEIMessage.
+func (m SEIMessageUnknown) PayloadType() SEIPayloadType {
+	return m.Type
+}
+
+// SEIMessageRecoveryPoint is a recovery point SEI message.
+// Specification: ITU-T Rec. H.264, D.1.8
+type SEIMessageRecoveryPoint struct {
+	RecoveryFrameCnt      uint32
+	ExactMatchFlag        bool
+	BrokenLinkFlag        bool
+	ChangingSliceGroupIdc uint8
+}
+
+// PayloadType implements SEIMessage.
+func (SEIMessageRecoveryPoint) PayloadType() SEIPayloadType {
+	return SEIPayloadTypeRecoveryPoint
+}
+
+func (m *SEIMessageRecoveryPoint) unmarshal(buf []byte) error {
+	pos := 0
+	var err error
+
+	m.RecoveryFrameCnt, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	err = bits.HasSpace(buf, pos, 4)
+	if err != nil {
+		return err
+	}
+
+	m.ExactMatchFlag = bits.ReadFlagUnsafe(buf, &pos)
+	m.BrokenLinkFlag = bits.ReadFlagUnsafe(buf, &pos)
+	m.ChangingSliceGroupIdc = uint8(bits.ReadBitsUnsafe(buf, &pos, 2))
+
+	return nil
+}
+
+// SEIMessagePicTiming_ClockTimestamp is a clock timestamp of a picture timing SEI message.
+type SEIMessagePicTiming_ClockTimestamp struct { //nolint:revive
+	CtType             uint8
+	NuitFieldBasedFlag bool
+	CountingType       uint8
+	FullTimestampFlag  bool
+	DiscontinuityFlag  bool
+	CntDroppedFlag     bool
+	NFrames            uint8
+	SecondsFlag        bool
+	SecondsValue       uint8
+	MinutesFlag        bool
+	MinutesValue       uint8
+	HoursFlag          bool
+	HoursValue         uint8
+	TimeOffset         int32
+}
+
+func (t *SEIMessagePicTiming_ClockTimestamp) unmarshal(buf []byte, pos *int, timeOffsetLength uint8) error {
+	err := bits.HasSpace(buf, *pos, 19)
+	if err != nil {
+		return err
+	}
+
+	t.CtType = uint8(bits.ReadBitsUnsafe(buf, pos, 2))
+	t.NuitFieldBasedFlag = bits.ReadFlagUnsafe(buf, pos)
+	t.CountingType = uint8(bits.ReadBitsUnsafe(buf, pos, 5))
+	t.FullTimestampFlag = bits.ReadFlagUnsafe(buf, pos)
+	t.DiscontinuityFlag = bits.ReadFlagUnsafe(buf, pos)
+	t.CntDroppedFlag = bits.ReadFlagUnsafe(buf, pos)
+	t.NFrames = uint8(bits.ReadBitsUnsafe(buf, pos, 8))
+
+	if t.FullTimestampFlag {
+		err = bits.HasSpace(buf, *pos, 17)
+		if err != nil {
+			return err
+		}
+
+		t.SecondsFlag = true
+		t.SecondsValue = uint8(bits.ReadBitsUnsafe(buf, pos, 6))
+		t.MinutesFlag = true
+		t.MinutesValue = uint8(bits.ReadBitsUnsafe(buf, pos, 6))
+		t.HoursFlag = true
+		t.HoursValue = uint8(bits.ReadBitsUnsafe(buf, pos, 5))
+	} else {
+		t.SecondsFlag, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if t.SecondsFlag {
+			err = bits.HasSpace(buf, *pos, 7)
+			if err != nil {
+				return err
+			}
+
+			t.SecondsValue = uint8(bits.ReadBitsUnsafe(buf, pos, 6))
+			t.MinutesFlag = bits.ReadFlagUnsafe(buf, pos)
+
+			if t.MinutesFlag {
+				err = bits.HasSpace(buf, *pos, 7)
+				if err != nil {
+					return err
+				}
+
+				t.MinutesValue = uint8(bits.ReadBitsUnsafe(buf, pos, 6))
+				t.HoursFlag = bits.ReadFlagUnsafe(buf, pos)
+
+				if t.HoursFlag {
+					var tmp uint64
+					tmp, err = bits.ReadBits(buf, pos, 5)
+					if err != nil {
+						return err
+					}
+					t.HoursValue = uint8(tmp)
+				}
+			}
+		}
+	}
+
+	if timeOffsetLength > 0 {
+		var tmp uint64
+		tmp, err = bits.ReadBits(buf, pos, int(timeOffsetLength))
+		if err != nil {
+			return err
+		}
+
+		// i(v): two's complement
+		if (tmp >> (timeOffsetLength - 1)) != 0 {
+			t.TimeOffset = int32(int64(tmp) - (1 << timeOffsetLength))
+		} else {
+			t.TimeOffset = int32(tmp)
+		}
+	}
+
+	return nil
+}
+
+// SEIMessagePicTiming is a picture timing SEI message.
+// Specification: ITU-T Rec. H.264, D.1.3
+type SEIMessagePicTiming struct {
+	// present when the SPS contains HRD parameters.
+	CpbRemovalDelay uint32
+	DpbOutputDelay  uint32
+
+	// present when PicStructPresentFlag is true.
+	PicStruct       uint8
+	ClockTimestamps []*SEIMessagePicTiming_ClockTimestamp
+}
+
+// PayloadType implements SEIMessage.
+func (SEIMessagePicTiming) PayloadType() SEIPayloadType {
+	return SEIPayloadTypePicTiming
+}
+
+// Specification: ITU-T Rec. H.264, Table D-1
+func picStructNumClockTS(picStruct uint8) (int, error) {
+	switch picStruct {
+	case 0, 1, 2:
+		return 1, nil
+
+	case 3, 4, 7:
+		return 2, nil
+
+	case 5, 6, 8:
+		return 3, nil
+	}
+
+	return 0, fmt.Errorf("invalid pic_struct (%d)", picStruct)
+}
+
+func (m *SEIMessagePicTiming) unmarshal(buf []byte, sps *SPS) error {
+	pos := 0
+
+	var hrd *SPS_HRD
+	if sps.VUI != nil {
+		if sps.VUI.NalHRD != nil {
+			hrd = sps.VUI.NalHRD
+		} else {
+			hrd = sps.VUI.VclHRD
+		}
+	}
+
+	if hrd != nil {
+		n1 := int(hrd.CpbRemovalDelayLengthMinus1) + 1
+		n2 := int(hrd.DpbOutputDelayLengthMinus1) + 1
+
+		err := bits.HasSpace(buf, pos, n1+n2)
+		if err != nil {
+			return err
+		}
+
+		m.CpbRemovalDelay = uint32(bits.ReadBitsUnsafe(buf, &pos, n1))
+		m.DpbOutputDelay = uint32(bits.ReadBitsUnsafe(buf, &pos, n2))
+	}
+
+	if sps.VUI != nil && sps.VUI.PicStructPresentFlag {
+		tmp, err := bits.ReadBits(buf, &pos, 4)
+		if err != nil {
+			return err
+		}
+		m.PicStruct = uint8(tmp)
+
+		numClockTS, err := picStructNumClockTS(m.PicStruct)
+		if err != nil {
+			return err
+		}
+
+		var timeOffsetLength uint8
+		if hrd != nil {
+			timeOffsetLength = hrd.TimeOffsetLength
+		}
+
+		m.ClockTimestamps = make([]*SEIMessagePicTiming_ClockTimestamp, numClockTS)
+
+		for i := 0; i < numClockTS; i++ {
+			var clockTimestampFlag bool
+			clockTimestampFlag, err = bits.ReadFlag(buf, &pos)
+			if err != nil {
+				return err
+			}
+
+			if clockTimestampFlag {
+				m.ClockTimestamps[i] = &SEIMessagePicTiming_ClockTimestamp{}
+				err = m.ClockTimestamps[i].unmarshal(buf, &pos, timeOffsetLength)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// SEI is a supplemental enhancement information NALU.
+// Specification: ITU-T Rec. H.264, 7.3.2.3
+type SEI struct {
+	// SPS that is used to decode picture timing messages.
+	// When it is nil, picture timing messages are returned as SEIMessageUnknown.
+	SPS *SPS
+
+	Messages []SEIMessage
+}
+
+// Unmarshal decodes a SEI.
+func (s *SEI) Unmarshal(buf []byte) error {
+	if len(buf) < 1 {
+		return fmt.Errorf("not enough bits")
+	}
+
+	if NALUType(buf[0]&0x1F) != NALUTypeSEI {
+		return fmt.Errorf("not a SEI")
+	}
+
+	buf = EmulationPreventionRemove(buf[1:])
+
+	s.Messages = nil
+
+	return h26x.UnmarshalSEIMessages(buf, func(payloadType uint32, payload []byte) error {
+		var msg SEIMessage
+
+		switch {
+		case SEIPayloadType(payloadType) == SEIPayloadTypeRecoveryPoint:
+			m := &SEIMessageRecoveryPoint{}
+			err := m.unmarshal(payload)
+			if err != nil {
+				return err
+			}
+			msg = m
+
+		case SEIPayloadType(payloadType) == SEIPayloadTypePicTiming && s.SPS != nil:
+			m := &SEIMessagePicTiming{}
+			err := m.unmarshal(payload, s.SPS)
+			if err != nil {
+				return err
+			}
+			msg = m
+
+		default:
+			msg = &SEIMessageUnknown{
+				Type:    SEIPayloadType(payloadType),
+				Payload: payload,
+			}
+		}
+
+		s.Messages = append(s.Messages, msg)
+		return nil
+	})
+}
+
+func (m SEIMessagePicTiming) clone() SEIMessagePicTiming {
+	if m.ClockTimestamps != nil {
+		tss := make([]*SEIMessagePicTiming_ClockTimestamp, len(m.ClockTimestamps))
+		for i, ts := range m.ClockTimestamps {
+			if ts != nil {
+				ts2 := *ts
+				tss[i] = &ts2
+			}
+		}
+		m.ClockTimestamps = tss
+	}
+	return m
+}
+
+func cloneSEIMessage(msg SEIMessage) SEIMessage {
+	switch m := msg.(type) {
+	case *SEIMessageUnknown:
+		ret := *m
+		ret.Payload = h26x.CloneSEIPayload(m.Payload)
+		return &ret
+
+	case SEIMessageUnknown:
+		m.Payload = h26x.CloneSEIPayload(m.Payload)
+		return m
+
+	case *SEIMessageRecoveryPoint:
+		ret := *m
+		return &ret
+
+	case *SEIMessagePicTiming:
+		ret := m.clone()
+		return &ret
+
+	case SEIMessagePicTiming:
+		return m.clone()
+
+	default:
+		// messages passed by value without references, or defined outside of this package
+		return msg
+	}
+}
+
+// Clone returns a copy of the SEI that doesn't share memory with the original.
+// Messages whose type is not defined in this package are shared.
+func (s SEI) Clone() SEI {
+	if s.SPS != nil {
+		sps := s.SPS.Clone()
+		s.SPS = &sps
+	}
+
+	if s.Messages != nil {
+		msgs := make([]SEIMessage, len(s.Messages))
+		for i, msg := range s.Messages {
+			msgs[i] = cloneSEIMessage(msg)
+		}
+		s.Messages = msgs
+	}
+
+	return s
+}
@@ -0,0 +1,145 @@
+package h264
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var seiTestSPS = &SPS{
+	VUI: &SPS_VUI{
+		NalHRD: &SPS_HRD{
+			CpbRemovalDelayLengthMinus1: 23,
+			DpbOutputDelayLengthMinus1:  23,
+		},
+		PicStructPresentFlag: true,
+	},
+}
+
+var casesSEI = []struct {
+	name string
+	byts []byte
+	sps  *SPS
+	sei  []SEIMessage
+}{
+	{
+		"recovery point and unknown",
+		[]byte{
+			0x06,
+			0x06, 0x01, 0xc0,
+			0x05, 0x03, 0x01, 0x02, 0x03,
+			0x80,
+		},
+		nil,
+		[]SEIMessage{
+			&SEIMessageRecoveryPoint{
+				ExactMatchFlag: true,
+			},
+			&SEIMessageUnknown{
+				Type:    SEIPayloadTypeUserDataUnregistered,
+				Payload: []byte{0x01, 0x02, 0x03},
+			},
+		},
+	},
+	{
+		"picture timing",
+		[]byte{
+			0x06,
+			0x01, 0x0c, 0x00, 0x00, 0x03, 0x01, 0x00, 0x00,
+			0x03, 0x02, 0x38, 0x04, 0x05, 0x29, 0x40, 0x80,
+			0x80,
+		},
+		seiTestSPS,
+		[]SEIMessage{
+			&SEIMessagePicTiming{
+				CpbRemovalDelay: 1,
+				DpbOutputDelay:  2,
+				PicStruct:       3,
+				ClockTimestamps: []*SEIMessagePicTiming_ClockTimestamp{
+					{
+						FullTimestampFlag: true,
+						NFrames:           5,
+						SecondsFlag:       true,
+						SecondsValue:      10,
+						MinutesFlag:       true,
+						MinutesValue:      20,
+						HoursFlag:         true,
+						HoursValue:        1,
+					},
+					nil,
+				},
+			},
+		},
+	},
+	{
+		"picture timing without SPS",
+		[]byte{
+			0x06,
+			0x01, 0x02, 0x01, 0x02,
+			0x80,
+		},
+		nil,
+		[]SEIMessage{
+			&SEIMessageUnknown{
+				Type:    SEIPayloadTypePicTiming,
+				Payload: []byte{0x01, 0x02},
+			},
+		},
+	},
+}
+
+func TestSEIUnmarshal(t *testing.T) {
+	for _, ca := range casesSEI {
+		t.Run(ca.name, func(t *testing.T) {
+			sei := SEI{SPS: ca.sps}
+			err := sei.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.sei, sei.Messages)
+		})
+	}
+}
+
+func TestSEIClone(t *testing.T) {
+	for _, ca := range casesSEI {
+		t.Run(ca.name, func(t *testing.T) {
+			orig := SEI{SPS: ca.sps}
+			err := orig.Unmarshal(ca.byts)
+			require.NoError(t, err)
+
+			c := orig.Clone()
+			require.Equal(t, orig, c)
+
+			for _, msg := range c.Messages {
+				switch m := msg.(type) {
+				case *SEIMessageUnknown:
+					m.Payload[0]++
+
+				case *SEIMessageRecoveryPoint:
+					m.RecoveryFrameCnt++
+
+				case *SEIMessagePicTiming:
+					m.ClockTimestamps[0].NFrames++
+				}
+			}
+			if c.SPS != nil {
+				c.SPS.VUI.PicStructPresentFlag = false
+			}
+
+			require.Equal(t, ca.sei, orig.Messages)
+			if ca.sps != nil {
+				require.True(t, ca.sps.VUI.PicStructPresentFlag)
+			}
+		})
+	}
+}
+
+func FuzzSEIUnmarshal(f *testing.F) {
+	for _, ca := range casesSEI {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		sei := SEI{SPS: seiTestSPS}
+		sei.Unmarshal(b) //nolint:errcheck
+	})
+}
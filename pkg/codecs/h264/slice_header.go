@@ -0,0 +1,228 @@
+package h264
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/pkg/bits"
+)
+
+// SliceType is a slice type.
+// Specification: ITU-T Rec. H.264, Table 7-6
+type SliceType uint8
+
+// slice types.
+const (
+	SliceTypeP  SliceType = 0
+	SliceTypeB  SliceType = 1
+	SliceTypeI  SliceType = 2
+	SliceTypeSP SliceType = 3
+	SliceTypeSI SliceType = 4
+)
+
+var sliceTypeLabels = map[SliceType]string{
+	SliceTypeP:  "P",
+	SliceTypeB:  "B",
+	SliceTypeI:  "I",
+	SliceTypeSP: "SP",
+	SliceTypeSI: "SI",
+}
+
+// String implements fmt.Stringer.
+func (t SliceType) String() string {
+	if l, ok := sliceTypeLabels[t]; ok {
+		return l
+	}
+	return fmt.Sprintf("unknown (%d)", t)
+}
+
+// SliceHeader is a slice header.
+// Fields that follow redundant_pic_cnt are not decoded.
+// Specification: ITU-T Rec. H.264, 7.3.3
+type SliceHeader struct {
+	// nal_ref_idc of the NALU that contains the slice.
+	NALRefIdc uint8
+
+	// whether the slice belongs to an IDR picture.
+	IDR bool
+
+	FirstMbInSlice uint32
+
+	// slice type. Values 5-9, that signal that all the slices
+	// of the picture have the same type, are mapped to 0-4.
+	SliceType SliceType
+
+	PicParameterSetID uint32
+
+	// SeparateColourPlaneFlag == true
+	ColourPlaneID uint8
+
+	FrameNum uint32
+
+	// FrameMbsOnlyFlag == false
+	FieldPicFlag    bool
+	BottomFieldFlag bool
+
+	// IDR == true
+	IdrPicID uint32
+
+	// PicOrderCntType == 0
+	PicOrderCntLsb         uint32
+	DeltaPicOrderCntBottom int32
+
+	// PicOrderCntType == 1
+	DeltaPicOrderCnt [2]int32
+
+	// RedundantPicCntPresentFlag == true
+	RedundantPicCnt uint32
+}
+
+// Unmarshal decodes a SliceHeader.
+// It requires the SPS and the PPS referenced by the slice.
+func (h *SliceHeader) Unmarshal(buf []byte, sps *SPS, pps *PPS) error {
+	if len(buf) < 1 {
+		return fmt.Errorf("not enough bits")
+	}
+
+	typ := NALUType(buf[0] & 0x1F)
+	if typ != NALUTypeNonIDR && typ != NALUTypeIDR {
+		return fmt.Errorf("not a slice")
+	}
+
+	h.NALRefIdc = (buf[0] >> 5) & 0b11
+	h.IDR = (typ == NALUTypeIDR)
+
+	buf = EmulationPreventionRemove(buf[1:])
+	pos := 0
+
+	var err error
+	h.FirstMbInSlice, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	sliceType, err := bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if sliceType > 9 {
+		return fmt.Errorf("invalid slice_type (%d)", sliceType)
+	}
+	h.SliceType = SliceType(sliceType % 5)
+
+	h.PicParameterSetID, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if h.PicParameterSetID != pps.ID {
+		return fmt.Errorf("slice references PPS %d, but PPS %d was provided", h.PicParameterSetID, pps.ID)
+	}
+
+	if pps.SPSID != sps.ID {
+		return fmt.Errorf("PPS references SPS %d, but SPS %d was provided", pps.SPSID, sps.ID)
+	}
+
+	if sps.SeparateColourPlaneFlag {
+		var tmp uint64
+		tmp, err = bits.ReadBits(buf, &pos, 2)
+		if err != nil {
+			return err
+		}
+		h.ColourPlaneID = uint8(tmp)
+	} else {
+		h.ColourPlaneID = 0
+	}
+
+	tmp, err := bits.ReadBits(buf, &pos, int(sps.Log2MaxFrameNumMinus4+4))
+	if err != nil {
+		return err
+	}
+	h.FrameNum = uint32(tmp)
+
+	h.FieldPicFlag = false
+	h.BottomFieldFlag = false
+
+	if !sps.FrameMbsOnlyFlag {
+		h.FieldPicFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		if h.FieldPicFlag {
+			h.BottomFieldFlag, err = bits.ReadFlag(buf, &pos)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if h.IDR {
+		h.IdrPicID, err = bits.ReadGolombUnsigned(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		h.IdrPicID = 0
+	}
+
+	h.PicOrderCntLsb = 0
+	h.DeltaPicOrderCntBottom = 0
+	h.DeltaPicOrderCnt = [2]int32{}
+
+	switch {
+	case sps.PicOrderCntType == 0:
+		tmp, err = bits.ReadBits(buf, &pos, int(sps.Log2MaxPicOrderCntLsbMinus4+4))
+		if err != nil {
+			return err
+		}
+		h.PicOrderCntLsb = uint32(tmp)
+
+		if pps.BottomFieldPicOrderInFramePresentFlag && !h.FieldPicFlag {
+			h.DeltaPicOrderCntBottom, err = bits.ReadGolombSigned(buf, &pos)
+			if err != nil {
+				return err
+			}
+		}
+
+	case sps.PicOrderCntType == 1 && !sps.DeltaPicOrderAlwaysZeroFlag:
+		h.DeltaPicOrderCnt[0], err = bits.ReadGolombSigned(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		if pps.BottomFieldPicOrderInFramePresentFlag && !h.FieldPicFlag {
+			h.DeltaPicOrderCnt[1], err = bits.ReadGolombSigned(buf, &pos)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if pps.RedundantPicCntPresentFlag {
+		h.RedundantPicCnt, err = bits.ReadGolombUnsigned(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		h.RedundantPicCnt = 0
+	}
+
+	return nil
+}
+
+// POCSliceInfo returns the fields needed to compute the picture order count.
+// Since dec_ref_pic_marking() is not decoded, MemoryManagementControlOperation5
+// is left to false and must be filled by the caller when needed.
+func (h SliceHeader) POCSliceInfo() POCSliceInfo {
+	return POCSliceInfo{
+		NALRefIdc:              h.NALRefIdc,
+		IDR:                    h.IDR,
+		FrameNum:               h.FrameNum,
+		FieldPicFlag:           h.FieldPicFlag,
+		BottomFieldFlag:        h.BottomFieldFlag,
+		PicOrderCntLsb:         h.PicOrderCntLsb,
+		DeltaPicOrderCntBottom: h.DeltaPicOrderCntBottom,
+		DeltaPicOrderCnt:       h.DeltaPicOrderCnt,
+	}
+}
@@ -0,0 +1,143 @@
+package h264
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var sliceHeaderTestSPS = []byte{
+	0x67, 0x64, 0x00, 0x28, 0xac, 0xd9, 0x40, 0x78,
+	0x02, 0x27, 0xe5, 0x84, 0x00, 0x00, 0x03, 0x00,
+	0x04, 0x00, 0x00, 0x03, 0x00, 0xf0, 0x3c, 0x60,
+	0xc6, 0x58,
+}
+
+var casesSliceHeader = []struct {
+	name string
+	pps  []byte
+	byts []byte
+	sh   SliceHeader
+}{
+	{
+		"idr",
+		casesPPS[0].byts,
+		[]byte{0x65, 0x88, 0x84, 0x00, 0x33, 0xff},
+		SliceHeader{
+			NALRefIdc: 3,
+			IDR:       true,
+			SliceType: SliceTypeI,
+		},
+	},
+	{
+		"p",
+		casesPPS[0].byts,
+		[]byte{0x41, 0x9a, 0x21, 0x6c, 0x45, 0xff},
+		SliceHeader{
+			NALRefIdc:      2,
+			SliceType:      SliceTypeP,
+			FrameNum:       1,
+			PicOrderCntLsb: 2,
+		},
+	},
+	{
+		"b reference",
+		casesPPS[0].byts,
+		[]byte{0x41, 0x9e, 0xa5, 0x42, 0x7f, 0xf9},
+		SliceHeader{
+			NALRefIdc:      2,
+			SliceType:      SliceTypeB,
+			FrameNum:       5,
+			PicOrderCntLsb: 10,
+		},
+	},
+	{
+		"b non-reference",
+		casesPPS[0].byts,
+		[]byte{0x01, 0x9e, 0xc4, 0x69, 0x13, 0xff},
+		SliceHeader{
+			SliceType:      SliceTypeB,
+			FrameNum:       6,
+			PicOrderCntLsb: 8,
+		},
+	},
+	{
+		"delta pic order cnt bottom, redundant pic cnt",
+		casesPPS[1].byts,
+		[]byte{0x41, 0x9a, 0x63, 0x35},
+		SliceHeader{
+			NALRefIdc:              2,
+			SliceType:              SliceTypeP,
+			FrameNum:               3,
+			PicOrderCntLsb:         6,
+			DeltaPicOrderCntBottom: -1,
+			RedundantPicCnt:        1,
+		},
+	},
+}
+
+func TestSliceHeaderUnmarshal(t *testing.T) {
+	var sps SPS
+	err := sps.Unmarshal(sliceHeaderTestSPS)
+	require.NoError(t, err)
+
+	for _, ca := range casesSliceHeader {
+		t.Run(ca.name, func(t *testing.T) {
+			var pps PPS
+			err = pps.Unmarshal(ca.pps)
+			require.NoError(t, err)
+
+			var sh SliceHeader
+			err = sh.Unmarshal(ca.byts, &sps, &pps)
+			require.NoError(t, err)
+			require.Equal(t, ca.sh, sh)
+		})
+	}
+}
+
+func TestSliceHeaderUnmarshalErrors(t *testing.T) {
+	var sps SPS
+	err := sps.Unmarshal(sliceHeaderTestSPS)
+	require.NoError(t, err)
+
+	pps := PPS{ID: 1}
+
+	var sh SliceHeader
+	err = sh.Unmarshal(casesSliceHeader[0].byts, &sps, &pps)
+	require.EqualError(t, err, "slice references PPS 0, but PPS 1 was provided")
+
+	err = sh.Unmarshal(sliceHeaderTestSPS, &sps, &pps)
+	require.EqualError(t, err, "not a slice")
+}
+
+func TestSliceHeaderPOCSliceInfo(t *testing.T) {
+	require.Equal(t, POCSliceInfo{
+		NALRefIdc:              2,
+		FrameNum:               3,
+		PicOrderCntLsb:         6,
+		DeltaPicOrderCntBottom: -1,
+	}, casesSliceHeader[4].sh.POCSliceInfo())
+}
+
+func FuzzSliceHeaderUnmarshal(f *testing.F) {
+	for _, ca := range casesSliceHeader {
+		f.Add(ca.byts)
+	}
+
+	var sps SPS
+	err := sps.Unmarshal(sliceHeaderTestSPS)
+	if err != nil {
+		panic(err)
+	}
+
+	var pps PPS
+	err = pps.Unmarshal(casesPPS[1].byts)
+	if err != nil {
+		panic(err)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var sh SliceHeader
+		sh.Unmarshal(b, &sps, &pps) //nolint:errcheck
+	})
+}
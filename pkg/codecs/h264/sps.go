@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/bluenviron/mediacommon/pkg/bits"
+	"github.com/bluenviron/mediacommon/pkg/codecs/internal/h26x"
 )
 
 const (
@@ -737,7 +738,7 @@ func (s SPS) Width() int {
 
 	var cropUnitX uint32
 	if chromaArrayType == 0 {
-		cropUnitX = 0
+		cropUnitX = 1
 	} else {
 		cropUnitX = subWidthC
 	}
@@ -796,6 +797,99 @@ func (s SPS) Height() int {
 	return int(picHeightInSamplesL)
 }
 
+func cloneBytes(s []byte) []byte {
+	if s == nil {
+		return nil
+	}
+	return append(make([]byte, 0, len(s)), s...)
+}
+
+func cloneBools(s []bool) []bool {
+	if s == nil {
+		return nil
+	}
+	return append(make([]bool, 0, len(s)), s...)
+}
+
+func cloneInt32s(s []int32) []int32 {
+	if s == nil {
+		return nil
+	}
+	return append(make([]int32, 0, len(s)), s...)
+}
+
+func cloneUint32s(s []uint32) []uint32 {
+	if s == nil {
+		return nil
+	}
+	return append(make([]uint32, 0, len(s)), s...)
+}
+
+func cloneScalingLists(s [][]int32) [][]int32 {
+	if s == nil {
+		return nil
+	}
+
+	ret := make([][]int32, len(s))
+	for i, l := range s {
+		ret[i] = cloneInt32s(l)
+	}
+	return ret
+}
+
+func (h *SPS_HRD) clone() *SPS_HRD {
+	if h == nil {
+		return nil
+	}
+
+	ret := *h
+	ret.BitRateValueMinus1 = cloneUint32s(h.BitRateValueMinus1)
+	ret.CpbSizeValueMinus1 = cloneUint32s(h.CpbSizeValueMinus1)
+	ret.CbrFlag = cloneBools(h.CbrFlag)
+	return &ret
+}
+
+func (v *SPS_VUI) clone() *SPS_VUI {
+	if v == nil {
+		return nil
+	}
+
+	ret := *v
+
+	if v.TimingInfo != nil {
+		ti := *v.TimingInfo
+		ret.TimingInfo = &ti
+	}
+
+	ret.NalHRD = v.NalHRD.clone()
+	ret.VclHRD = v.VclHRD.clone()
+
+	if v.BitstreamRestriction != nil {
+		br := *v.BitstreamRestriction
+		ret.BitstreamRestriction = &br
+	}
+
+	return &ret
+}
+
+// Clone returns a copy of the SPS that doesn't share memory with the original.
+func (s SPS) Clone() SPS {
+	s.ScalingList4x4 = cloneScalingLists(s.ScalingList4x4)
+	s.UseDefaultScalingMatrix4x4Flag = cloneBools(s.UseDefaultScalingMatrix4x4Flag)
+	s.ScalingList8x8 = cloneScalingLists(s.ScalingList8x8)
+	s.UseDefaultScalingMatrix8x8Flag = cloneBools(s.UseDefaultScalingMatrix8x8Flag)
+	s.OffsetForRefFrames = cloneInt32s(s.OffsetForRefFrames)
+
+	if s.FrameCropping != nil {
+		fc := *s.FrameCropping
+		s.FrameCropping = &fc
+	}
+
+	s.VUI = s.VUI.clone()
+
+	return s
+}
+
 // FPS returns the frames per second of the video.
 func (s SPS) FPS() float64 {
 	if s.VUI == nil || s.VUI.TimingInfo == nil {
@@ -804,3 +898,36 @@ func (s SPS) FPS() float64 {
 
 	return float64(s.VUI.TimingInfo.TimeScale) / (2 * float64(s.VUI.TimingInfo.NumUnitsInTick))
 }
+
+// FrameRate returns the frame rate of the video as a fraction, that is
+// time_scale / (2 * num_units_in_tick), reduced to lowest terms.
+// It returns false when timing info is not present.
+func (s SPS) FrameRate() (uint32, uint32, bool) {
+	if s.VUI == nil || s.VUI.TimingInfo == nil {
+		return 0, 0, false
+	}
+
+	return h26x.ReduceFrameRate(uint64(s.VUI.TimingInfo.TimeScale), 2*uint64(s.VUI.TimingInfo.NumUnitsInTick))
+}
+
+// CodecString returns the codec string of the video, in the avc1.PPCCLL format,
+// that is used in the codecs parameter of MIME types.
+// Specification: RFC 6381, 3.3
+func (s SPS) CodecString() string {
+	var constraints uint8
+	flags := []bool{
+		s.ConstraintSet0Flag,
+		s.ConstraintSet1Flag,
+		s.ConstraintSet2Flag,
+		s.ConstraintSet3Flag,
+		s.ConstraintSet4Flag,
+		s.ConstraintSet5Flag,
+	}
+	for i, f := range flags {
+		if f {
+			constraints |= 1 << (7 - i)
+		}
+	}
+
+	return fmt.Sprintf("avc1.%02x%02x%02x", s.ProfileIdc, constraints, s.LevelIdc)
+}
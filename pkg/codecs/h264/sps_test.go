@@ -7,12 +7,13 @@ import (
 )
 
 var casesSPS = []struct {
-	name   string
-	byts   []byte
-	sps    SPS
-	width  int
-	height int
-	fps    float64
+	name        string
+	byts        []byte
+	sps         SPS
+	width       int
+	height      int
+	fps         float64
+	codecString string
 }{
 	{
 		"352x288",
@@ -44,6 +45,7 @@ var casesSPS = []struct {
 		352,
 		288,
 		15,
+		"avc1.64000c",
 	},
 	{
 		"1280x720",
@@ -85,6 +87,7 @@ var casesSPS = []struct {
 		1280,
 		720,
 		30,
+		"avc1.64001f",
 	},
 	{
 		"1920x1080 baseline",
@@ -124,6 +127,7 @@ var casesSPS = []struct {
 		1920,
 		1080,
 		30,
+		"avc1.42c028",
 	},
 	{
 		"1920x1080 nvidia",
@@ -163,6 +167,7 @@ var casesSPS = []struct {
 		1920,
 		1080,
 		30,
+		"avc1.640028",
 	},
 	{
 		"1920x1080",
@@ -208,6 +213,7 @@ var casesSPS = []struct {
 		1920,
 		1080,
 		25,
+		"avc1.640029",
 	},
 	{
 		"hikvision",
@@ -234,6 +240,7 @@ var casesSPS = []struct {
 		1280,
 		960,
 		25,
+		"avc1.640020",
 	},
 	{
 		"scaling matrix",
@@ -301,6 +308,7 @@ var casesSPS = []struct {
 		2560,
 		1440,
 		20,
+		"avc1.640032",
 	},
 	{
 		"1920x1080 nvenc hrd",
@@ -354,6 +362,7 @@ var casesSPS = []struct {
 		1920,
 		1080,
 		60,
+		"avc1.64002a",
 	},
 	{
 		"1920x1080 hikvision nal hrd + vcl hrd",
@@ -423,6 +432,7 @@ var casesSPS = []struct {
 		1920,
 		1080,
 		25,
+		"avc1.4d0029",
 	},
 	{
 		"1920x1080 mbs_only_flag = 0",
@@ -479,6 +489,7 @@ var casesSPS = []struct {
 		1920,
 		1080,
 		25,
+		"avc1.4d4028",
 	},
 }
 
@@ -492,6 +503,43 @@ func TestSPSUnmarshal(t *testing.T) {
 			require.Equal(t, ca.width, sps.Width())
 			require.Equal(t, ca.height, sps.Height())
 			require.Equal(t, ca.fps, sps.FPS())
+			require.Equal(t, ca.codecString, sps.CodecString())
+		})
+	}
+}
+
+func TestSPSClone(t *testing.T) {
+	for _, ca := range casesSPS {
+		t.Run(ca.name, func(t *testing.T) {
+			var orig SPS
+			err := orig.Unmarshal(ca.byts)
+			require.NoError(t, err)
+
+			c := orig.Clone()
+			require.Equal(t, orig, c)
+
+			for _, l := range c.ScalingList4x4 {
+				for i := range l {
+					l[i]++
+				}
+			}
+			for i := range c.UseDefaultScalingMatrix8x8Flag {
+				c.UseDefaultScalingMatrix8x8Flag[i] = !c.UseDefaultScalingMatrix8x8Flag[i]
+			}
+			if c.FrameCropping != nil {
+				c.FrameCropping.LeftOffset++
+			}
+			if c.VUI != nil {
+				c.VUI.VideoFormat++
+				if c.VUI.TimingInfo != nil {
+					c.VUI.TimingInfo.TimeScale++
+				}
+				if c.VUI.NalHRD != nil {
+					c.VUI.NalHRD.CbrFlag[0] = !c.VUI.NalHRD.CbrFlag[0]
+				}
+			}
+
+			require.Equal(t, ca.sps, orig)
 		})
 	}
 }
@@ -511,6 +559,77 @@ func BenchmarkSPSUnmarshal(b *testing.B) {
 	}
 }
 
+func TestSPSSizeMonochromeCropping(t *testing.T) {
+	s := SPS{
+		ChromaFormatIdc:           0,
+		PicWidthInMbsMinus1:       119,
+		PicHeightInMapUnitsMinus1: 67,
+		FrameMbsOnlyFlag:          true,
+		FrameCropping: &SPS_FrameCropping{
+			LeftOffset:   2,
+			RightOffset:  2,
+			BottomOffset: 8,
+		},
+	}
+
+	require.Equal(t, 1916, s.Width())
+	require.Equal(t, 1080, s.Height())
+}
+
+func TestSPSFrameRate(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		sps  SPS
+		num  uint32
+		den  uint32
+		ok   bool
+	}{
+		{
+			"no vui",
+			SPS{},
+			0,
+			0,
+			false,
+		},
+		{
+			"ntsc",
+			SPS{VUI: &SPS_VUI{TimingInfo: &SPS_TimingInfo{
+				NumUnitsInTick: 1001,
+				TimeScale:      60000,
+			}}},
+			30000,
+			1001,
+			true,
+		},
+		{
+			"integer",
+			SPS{VUI: &SPS_VUI{TimingInfo: &SPS_TimingInfo{
+				NumUnitsInTick: 1,
+				TimeScale:      50,
+			}}},
+			25,
+			1,
+			true,
+		},
+		{
+			"zero num units in tick",
+			SPS{VUI: &SPS_VUI{TimingInfo: &SPS_TimingInfo{
+				TimeScale: 50,
+			}}},
+			0,
+			0,
+			false,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			num, den, ok := ca.sps.FrameRate()
+			require.Equal(t, ca.num, num)
+			require.Equal(t, ca.den, den)
+			require.Equal(t, ca.ok, ok)
+		})
+	}
+}
+
 func FuzzSPSUnmarshal(f *testing.F) {
 	for _, ca := range casesSPS {
 		f.Add(ca.byts)
@@ -0,0 +1,70 @@
+package h265
+
+import (
+	"fmt"
+)
+
+// AUD is a H265 access unit delimiter.
+// Specification: ITU-T Rec. H.265, 7.3.2.5
+type AUD struct {
+	// slice types that may be present in the access unit.
+	// Specification: ITU-T Rec. H.265, Table 7-2
+	PicType uint8
+}
+
+// Unmarshal decodes an AUD.
+func (a *AUD) Unmarshal(buf []byte) error {
+	if len(buf) < 3 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	if NALUType((buf[0]>>1)&0b111111) != NALUType_AUD_NUT {
+		return fmt.Errorf("not an AUD")
+	}
+
+	a.PicType = buf[2] >> 5
+
+	return nil
+}
+
+// Marshal encodes an AUD.
+func (a AUD) Marshal() ([]byte, error) {
+	if a.PicType > 0b111 {
+		return nil, fmt.Errorf("invalid pic_type: %d", a.PicType)
+	}
+
+	return []byte{
+		byte(NALUType_AUD_NUT) << 1,
+		1,                      // nuh_layer_id = 0, nuh_temporal_id_plus1 = 1
+		a.PicType<<5 | 0b10000, // rbsp_trailing_bits()
+	}, nil
+}
+
+// IsAUD checks whether a NALU is an access unit delimiter.
+func IsAUD(nalu []byte) bool {
+	return len(nalu) != 0 && NALUType((nalu[0]>>1)&0b111111) == NALUType_AUD_NUT
+}
+
+// StripAUD removes access unit delimiters from an access unit.
+// The input access unit is not modified.
+func StripAUD(au [][]byte) [][]byte {
+	n := 0
+	for _, nalu := range au {
+		if IsAUD(nalu) {
+			n++
+		}
+	}
+
+	if n == 0 {
+		return au
+	}
+
+	ret := make([][]byte, 0, len(au)-n)
+	for _, nalu := range au {
+		if !IsAUD(nalu) {
+			ret = append(ret, nalu)
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,102 @@
+package h265
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesAUD = []struct {
+	name string
+	byts []byte
+	aud  AUD
+}{
+	{
+		"i",
+		[]byte{0x46, 0x01, 0x10},
+		AUD{
+			PicType: 0,
+		},
+	},
+	{
+		"i, p, b",
+		[]byte{0x46, 0x01, 0x50},
+		AUD{
+			PicType: 2,
+		},
+	},
+}
+
+func TestAUDUnmarshal(t *testing.T) {
+	for _, ca := range casesAUD {
+		t.Run(ca.name, func(t *testing.T) {
+			var aud AUD
+			err := aud.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.aud, aud)
+		})
+	}
+}
+
+func TestAUDMarshal(t *testing.T) {
+	for _, ca := range casesAUD {
+		t.Run(ca.name, func(t *testing.T) {
+			byts, err := ca.aud.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.byts, byts)
+		})
+	}
+}
+
+func TestAUDMarshalErrors(t *testing.T) {
+	_, err := AUD{PicType: 8}.Marshal()
+	require.EqualError(t, err, "invalid pic_type: 8")
+}
+
+func TestIsAUD(t *testing.T) {
+	require.Equal(t, true, IsAUD([]byte{0x46, 0x01, 0x50}))
+	require.Equal(t, false, IsAUD([]byte{0x26, 0x01}))
+	require.Equal(t, false, IsAUD(nil))
+}
+
+func TestStripAUD(t *testing.T) {
+	au := [][]byte{
+		{0x46, 0x01, 0x50},
+		{0x40, 0x01},
+		{0x42, 0x01},
+		{0x44, 0x01},
+		{0x26, 0x01},
+	}
+
+	require.Equal(t, [][]byte{
+		{0x40, 0x01},
+		{0x42, 0x01},
+		{0x44, 0x01},
+		{0x26, 0x01},
+	}, StripAUD(au))
+
+	require.Equal(t, [][]byte{
+		{0x46, 0x01, 0x50},
+		{0x40, 0x01},
+		{0x42, 0x01},
+		{0x44, 0x01},
+		{0x26, 0x01},
+	}, au)
+
+	require.Equal(t, [][]byte{{0x02, 0x01}}, StripAUD([][]byte{{0x02, 0x01}}))
+}
+
+func FuzzAUDUnmarshal(f *testing.F) {
+	for _, ca := range casesAUD {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var aud AUD
+		err := aud.Unmarshal(b)
+		if err == nil {
+			_, err = aud.Marshal()
+			require.NoError(t, err)
+		}
+	})
+}
@@ -0,0 +1,338 @@
+package h265
+
+import (
+	"fmt"
+)
+
+// DecoderConfigurationRecord_NALUArray is an array of NALUs of a DecoderConfigurationRecord.
+type DecoderConfigurationRecord_NALUArray struct { //nolint:revive
+	ArrayCompleteness bool
+	NALUType          NALUType
+	NALUs             [][]byte
+}
+
+// DecoderConfigurationRecord is a HEVCDecoderConfigurationRecord.
+// Specification: ISO 14496-15, section 8.3.3.1
+type DecoderConfigurationRecord struct {
+	GeneralProfileSpace             uint8
+	GeneralTierFlag                 uint8
+	GeneralProfileIdc               uint8
+	GeneralProfileCompatibilityFlag [32]bool
+	GeneralConstraintIndicatorFlags [6]byte
+	GeneralLevelIdc                 uint8
+	MinSpatialSegmentationIdc       uint16
+	ParallelismType                 uint8
+	ChromaFormatIdc                 uint8
+	BitDepthLumaMinus8              uint8
+	BitDepthChromaMinus8            uint8
+	AvgFrameRate                    uint16
+	ConstantFrameRate               uint8
+	NumTemporalLayers               uint8
+	TemporalIDNested                bool
+	LengthSizeMinusOne              uint8
+	NALUArrays                      []DecoderConfigurationRecord_NALUArray
+}
+
+// NewDecoderConfigurationRecord allocates a DecoderConfigurationRecord
+// and fills it with values extracted from the given VPS, SPS and PPS NALUs.
+func NewDecoderConfigurationRecord(vps [][]byte, sps [][]byte, pps [][]byte) (*DecoderConfigurationRecord, error) {
+	if len(sps) == 0 {
+		return nil, fmt.Errorf("SPS is missing")
+	}
+
+	var s SPS
+	err := s.Unmarshal(sps[0])
+	if err != nil {
+		return nil, err
+	}
+
+	r := &DecoderConfigurationRecord{
+		GeneralProfileSpace:             s.ProfileTierLevel.GeneralProfileSpace,
+		GeneralTierFlag:                 s.ProfileTierLevel.GeneralTierFlag,
+		GeneralProfileIdc:               s.ProfileTierLevel.GeneralProfileIdc,
+		GeneralProfileCompatibilityFlag: s.ProfileTierLevel.GeneralProfileCompatibilityFlag,
+		GeneralConstraintIndicatorFlags: s.ProfileTierLevel.GeneralConstraintIndicatorFlags(),
+		GeneralLevelIdc:                 s.ProfileTierLevel.GeneralLevelIdc,
+		ChromaFormatIdc:                 uint8(s.ChromaFormatIdc),
+		BitDepthLumaMinus8:              uint8(s.BitDepthLumaMinus8),
+		BitDepthChromaMinus8:            uint8(s.BitDepthChromaMinus8),
+		NumTemporalLayers:               s.MaxSubLayersMinus1 + 1,
+		TemporalIDNested:                s.TemporalIDNestingFlag,
+		LengthSizeMinusOne:              3,
+	}
+
+	for _, entry := range []struct {
+		typ   NALUType
+		nalus [][]byte
+	}{
+		{NALUType_VPS_NUT, vps},
+		{NALUType_SPS_NUT, sps},
+		{NALUType_PPS_NUT, pps},
+	} {
+		if len(entry.nalus) != 0 {
+			r.NALUArrays = append(r.NALUArrays, DecoderConfigurationRecord_NALUArray{
+				ArrayCompleteness: true,
+				NALUType:          entry.typ,
+				NALUs:             entry.nalus,
+			})
+		}
+	}
+
+	return r, nil
+}
+
+// Unmarshal decodes a DecoderConfigurationRecord.
+func (r *DecoderConfigurationRecord) Unmarshal(buf []byte) error {
+	if len(buf) < 23 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	configurationVersion := buf[0]
+	if configurationVersion != 1 {
+		return fmt.Errorf("unsupported configuration version: %d", configurationVersion)
+	}
+
+	r.GeneralProfileSpace = buf[1] >> 6
+	r.GeneralTierFlag = (buf[1] >> 5) & 0b1
+	r.GeneralProfileIdc = buf[1] & 0b11111
+
+	for i := 0; i < 32; i++ {
+		r.GeneralProfileCompatibilityFlag[i] = ((buf[2+i/8] >> (7 - i%8)) & 0b1) != 0
+	}
+
+	copy(r.GeneralConstraintIndicatorFlags[:], buf[6:12])
+	r.GeneralLevelIdc = buf[12]
+	r.MinSpatialSegmentationIdc = uint16(buf[13]&0b1111)<<8 | uint16(buf[14])
+	r.ParallelismType = buf[15] & 0b11
+	r.ChromaFormatIdc = buf[16] & 0b11
+	r.BitDepthLumaMinus8 = buf[17] & 0b111
+	r.BitDepthChromaMinus8 = buf[18] & 0b111
+	r.AvgFrameRate = uint16(buf[19])<<8 | uint16(buf[20])
+	r.ConstantFrameRate = buf[21] >> 6
+	r.NumTemporalLayers = (buf[21] >> 3) & 0b111
+	r.TemporalIDNested = ((buf[21] >> 2) & 0b1) != 0
+	r.LengthSizeMinusOne = buf[21] & 0b11
+
+	numOfArrays := int(buf[22])
+	pos := 23
+
+	r.NALUArrays = nil
+
+	for i := 0; i < numOfArrays; i++ {
+		if (len(buf) - pos) < 3 {
+			return fmt.Errorf("not enough bytes")
+		}
+
+		arr := DecoderConfigurationRecord_NALUArray{
+			ArrayCompleteness: (buf[pos] >> 7) != 0,
+			NALUType:          NALUType(buf[pos] & 0b111111),
+		}
+		numNalus := int(uint16(buf[pos+1])<<8 | uint16(buf[pos+2]))
+		pos += 3
+
+		if numNalus != 0 {
+			arr.NALUs = make([][]byte, numNalus)
+		}
+
+		for j := 0; j < numNalus; j++ {
+			if (len(buf) - pos) < 2 {
+				return fmt.Errorf("not enough bytes")
+			}
+
+			l := int(uint16(buf[pos])<<8 | uint16(buf[pos+1]))
+			pos += 2
+
+			if (len(buf) - pos) < l {
+				return fmt.Errorf("not enough bytes")
+			}
+
+			arr.NALUs[j] = buf[pos : pos+l]
+			pos += l
+		}
+
+		r.NALUArrays = append(r.NALUArrays, arr)
+	}
+
+	return nil
+}
+
+// checkNALUArrays checks that NALUs have the type of the array that contains them.
+func (r DecoderConfigurationRecord) checkNALUArrays() error {
+	for _, arr := range r.NALUArrays {
+		for _, nalu := range arr.NALUs {
+			if len(nalu) < 2 {
+				return fmt.Errorf("invalid NALU")
+			}
+
+			if NALUType((nalu[0]>>1)&0b111111) != arr.NALUType {
+				return fmt.Errorf("NALU type doesn't match array type (%v)", arr.NALUType)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r DecoderConfigurationRecord) marshalSize() int {
+	n := 23
+	for _, arr := range r.NALUArrays {
+		n += 3
+		for _, nalu := range arr.NALUs {
+			n += 2 + len(nalu)
+		}
+	}
+	return n
+}
+
+func boolToUint8(v bool) uint8 {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+// Marshal encodes a DecoderConfigurationRecord.
+func (r DecoderConfigurationRecord) Marshal() ([]byte, error) {
+	if r.GeneralProfileSpace > 0b11 {
+		return nil, fmt.Errorf("invalid general_profile_space: %d", r.GeneralProfileSpace)
+	}
+
+	if r.GeneralTierFlag > 0b1 {
+		return nil, fmt.Errorf("invalid general_tier_flag: %d", r.GeneralTierFlag)
+	}
+
+	if r.GeneralProfileIdc > 0b11111 {
+		return nil, fmt.Errorf("invalid general_profile_idc: %d", r.GeneralProfileIdc)
+	}
+
+	if r.MinSpatialSegmentationIdc > 0xFFF {
+		return nil, fmt.Errorf("invalid min_spatial_segmentation_idc: %d", r.MinSpatialSegmentationIdc)
+	}
+
+	if r.ParallelismType > 0b11 {
+		return nil, fmt.Errorf("invalid parallelismType: %d", r.ParallelismType)
+	}
+
+	if r.ChromaFormatIdc > 0b11 {
+		return nil, fmt.Errorf("invalid chroma_format_idc: %d", r.ChromaFormatIdc)
+	}
+
+	if r.BitDepthLumaMinus8 > 0b111 {
+		return nil, fmt.Errorf("invalid bit_depth_luma_minus8: %d", r.BitDepthLumaMinus8)
+	}
+
+	if r.BitDepthChromaMinus8 > 0b111 {
+		return nil, fmt.Errorf("invalid bit_depth_chroma_minus8: %d", r.BitDepthChromaMinus8)
+	}
+
+	if r.ConstantFrameRate > 0b11 {
+		return nil, fmt.Errorf("invalid constantFrameRate: %d", r.ConstantFrameRate)
+	}
+
+	if r.NumTemporalLayers > 0b111 {
+		return nil, fmt.Errorf("invalid numTemporalLayers: %d", r.NumTemporalLayers)
+	}
+
+	if r.LengthSizeMinusOne > 3 || r.LengthSizeMinusOne == 2 {
+		return nil, fmt.Errorf("invalid lengthSizeMinusOne: %d", r.LengthSizeMinusOne)
+	}
+
+	if len(r.NALUArrays) > 0xFF {
+		return nil, fmt.Errorf("too many NALU arrays (%d)", len(r.NALUArrays))
+	}
+
+	for _, arr := range r.NALUArrays {
+		if arr.NALUType > 0b111111 {
+			return nil, fmt.Errorf("invalid NALU type: %d", arr.NALUType)
+		}
+
+		if len(arr.NALUs) > 0xFFFF {
+			return nil, fmt.Errorf("too many NALUs (%d)", len(arr.NALUs))
+		}
+
+		for _, nalu := range arr.NALUs {
+			if len(nalu) > 0xFFFF {
+				return nil, fmt.Errorf("NALU size (%d) is too big", len(nalu))
+			}
+		}
+	}
+
+	err := r.checkNALUArrays()
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, r.marshalSize())
+
+	buf[0] = 1
+	buf[1] = r.GeneralProfileSpace<<6 | r.GeneralTierFlag<<5 | r.GeneralProfileIdc
+
+	for i, v := range r.GeneralProfileCompatibilityFlag {
+		buf[2+i/8] |= boolToUint8(v) << (7 - i%8)
+	}
+
+	copy(buf[6:12], r.GeneralConstraintIndicatorFlags[:])
+	buf[12] = r.GeneralLevelIdc
+	buf[13] = 0b11110000 | uint8(r.MinSpatialSegmentationIdc>>8)
+	buf[14] = uint8(r.MinSpatialSegmentationIdc)
+	buf[15] = 0b11111100 | r.ParallelismType
+	buf[16] = 0b11111100 | r.ChromaFormatIdc
+	buf[17] = 0b11111000 | r.BitDepthLumaMinus8
+	buf[18] = 0b11111000 | r.BitDepthChromaMinus8
+	buf[19] = uint8(r.AvgFrameRate >> 8)
+	buf[20] = uint8(r.AvgFrameRate)
+	buf[21] = r.ConstantFrameRate<<6 | r.NumTemporalLayers<<3 | boolToUint8(r.TemporalIDNested)<<2 | r.LengthSizeMinusOne
+	buf[22] = uint8(len(r.NALUArrays))
+	pos := 23
+
+	for _, arr := range r.NALUArrays {
+		buf[pos] = boolToUint8(arr.ArrayCompleteness)<<7 | uint8(arr.NALUType)
+		buf[pos+1] = uint8(len(arr.NALUs) >> 8)
+		buf[pos+2] = uint8(len(arr.NALUs))
+		pos += 3
+
+		for _, nalu := range arr.NALUs {
+			buf[pos] = uint8(len(nalu) >> 8)
+			buf[pos+1] = uint8(len(nalu))
+			pos += 2
+
+			pos += copy(buf[pos:], nalu)
+		}
+	}
+
+	return buf, nil
+}
+
+// Clone returns a copy of the DecoderConfigurationRecord that doesn't share memory with the original.
+func (r DecoderConfigurationRecord) Clone() DecoderConfigurationRecord {
+	if r.NALUArrays != nil {
+		arrays := make([]DecoderConfigurationRecord_NALUArray, len(r.NALUArrays))
+		for i, arr := range r.NALUArrays {
+			arrays[i] = arr
+			if arr.NALUs != nil {
+				arrays[i].NALUs = make([][]byte, len(arr.NALUs))
+				for j, nalu := range arr.NALUs {
+					arrays[i].NALUs[j] = cloneBytes(nalu)
+				}
+			}
+		}
+		r.NALUArrays = arrays
+	}
+	return r
+}
+
+// CodecString returns the codec string of the video, in the hvc1 format,
+// that is used in the codecs parameter of MIME types.
+// When parameter sets are carried in-band, the hev1 format is used instead,
+// and can be obtained by replacing the prefix.
+// Specification: ISO 14496-15, E.3
+func (r DecoderConfigurationRecord) CodecString() string {
+	return formatCodecString(
+		"hvc1",
+		r.GeneralProfileSpace,
+		r.GeneralProfileIdc,
+		r.GeneralProfileCompatibilityFlag,
+		r.GeneralTierFlag,
+		r.GeneralLevelIdc,
+		r.GeneralConstraintIndicatorFlags)
+}
@@ -0,0 +1,183 @@
+package h265
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesDecoderConfigurationRecord = []struct {
+	name string
+	enc  []byte
+	dec  DecoderConfigurationRecord
+}{
+	{
+		"standard",
+		[]byte{
+			0x01, 0x01, 0x60, 0x00, 0x00, 0x00, 0x90, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x78, 0xf0, 0x00, 0xfc,
+			0xfd, 0xf8, 0xf8, 0x00, 0x00, 0x0f, 0x03, 0xa0,
+			0x00, 0x01, 0x00, 0x18, 0x40, 0x01, 0x0c, 0x01,
+			0xff, 0xff, 0x01, 0x60, 0x00, 0x00, 0x03, 0x00,
+			0x90, 0x00, 0x00, 0x03, 0x00, 0x00, 0x03, 0x00,
+			0x78, 0x99, 0x98, 0x09, 0xa1, 0x00, 0x01, 0x00,
+			0x2a, 0x42, 0x01, 0x01, 0x01, 0x60, 0x00, 0x00,
+			0x03, 0x00, 0x90, 0x00, 0x00, 0x03, 0x00, 0x00,
+			0x03, 0x00, 0x78, 0xa0, 0x03, 0xc0, 0x80, 0x10,
+			0xe5, 0x96, 0x66, 0x69, 0x24, 0xca, 0xe0, 0x10,
+			0x00, 0x00, 0x03, 0x00, 0x10, 0x00, 0x00, 0x03,
+			0x01, 0xe0, 0x80, 0xa2, 0x00, 0x01, 0x00, 0x07,
+			0x44, 0x01, 0xc1, 0x72, 0xb4, 0x62, 0x40,
+		},
+		DecoderConfigurationRecord{
+			GeneralProfileIdc: 1,
+			GeneralProfileCompatibilityFlag: [32]bool{
+				false, true, true, false, false, false, false, false,
+				false, false, false, false, false, false, false, false,
+				false, false, false, false, false, false, false, false,
+				false, false, false, false, false, false, false, false,
+			},
+			GeneralConstraintIndicatorFlags: [6]byte{0x90, 0x00, 0x00, 0x00, 0x00, 0x00},
+			GeneralLevelIdc:                 120,
+			ChromaFormatIdc:                 1,
+			NumTemporalLayers:               1,
+			TemporalIDNested:                true,
+			LengthSizeMinusOne:              3,
+			NALUArrays: []DecoderConfigurationRecord_NALUArray{
+				{
+					ArrayCompleteness: true,
+					NALUType:          NALUType_VPS_NUT,
+					NALUs: [][]byte{{
+						0x40, 0x01, 0x0c, 0x01, 0xff, 0xff, 0x01, 0x60,
+						0x00, 0x00, 0x03, 0x00, 0x90, 0x00, 0x00, 0x03,
+						0x00, 0x00, 0x03, 0x00, 0x78, 0x99, 0x98, 0x09,
+					}},
+				},
+				{
+					ArrayCompleteness: true,
+					NALUType:          NALUType_SPS_NUT,
+					NALUs: [][]byte{{
+						0x42, 0x01, 0x01, 0x01, 0x60, 0x00, 0x00, 0x03,
+						0x00, 0x90, 0x00, 0x00, 0x03, 0x00, 0x00, 0x03,
+						0x00, 0x78, 0xa0, 0x03, 0xc0, 0x80, 0x10, 0xe5,
+						0x96, 0x66, 0x69, 0x24, 0xca, 0xe0, 0x10, 0x00,
+						0x00, 0x03, 0x00, 0x10, 0x00, 0x00, 0x03, 0x01,
+						0xe0, 0x80,
+					}},
+				},
+				{
+					ArrayCompleteness: true,
+					NALUType:          NALUType_PPS_NUT,
+					NALUs: [][]byte{{
+						0x44, 0x01, 0xc1, 0x72, 0xb4, 0x62, 0x40,
+					}},
+				},
+			},
+		},
+	},
+}
+
+func TestDecoderConfigurationRecordUnmarshal(t *testing.T) {
+	for _, ca := range casesDecoderConfigurationRecord {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec DecoderConfigurationRecord
+			err := dec.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestDecoderConfigurationRecordClone(t *testing.T) {
+	for _, ca := range casesDecoderConfigurationRecord {
+		t.Run(ca.name, func(t *testing.T) {
+			var orig DecoderConfigurationRecord
+			err := orig.Unmarshal(ca.enc)
+			require.NoError(t, err)
+
+			c := orig.Clone()
+			require.Equal(t, orig, c)
+
+			for i := range c.NALUArrays {
+				c.NALUArrays[i].ArrayCompleteness = !c.NALUArrays[i].ArrayCompleteness
+				for _, nalu := range c.NALUArrays[i].NALUs {
+					nalu[2]++
+				}
+			}
+			require.Equal(t, ca.dec, orig)
+		})
+	}
+}
+
+func TestDecoderConfigurationRecordMarshal(t *testing.T) {
+	for _, ca := range casesDecoderConfigurationRecord {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func TestNewDecoderConfigurationRecord(t *testing.T) {
+	for _, ca := range casesDecoderConfigurationRecord {
+		t.Run(ca.name, func(t *testing.T) {
+			r, err := NewDecoderConfigurationRecord(
+				ca.dec.NALUArrays[0].NALUs,
+				ca.dec.NALUArrays[1].NALUs,
+				ca.dec.NALUArrays[2].NALUs,
+			)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, *r)
+		})
+	}
+}
+
+func TestDecoderConfigurationRecordCodecString(t *testing.T) {
+	for _, ca := range casesDecoderConfigurationRecord {
+		t.Run(ca.name, func(t *testing.T) {
+			var sps SPS
+			err := sps.Unmarshal(ca.dec.NALUArrays[1].NALUs[0])
+			require.NoError(t, err)
+			require.Equal(t, sps.CodecString(), ca.dec.CodecString())
+		})
+	}
+}
+
+func TestDecoderConfigurationRecordUnmarshalLengthSize(t *testing.T) {
+	enc := append([]byte(nil), casesDecoderConfigurationRecord[0].enc...)
+	enc[21] = enc[21]&^0b11 | 2
+
+	var dec DecoderConfigurationRecord
+	err := dec.Unmarshal(enc)
+	require.NoError(t, err)
+	require.Equal(t, uint8(2), dec.LengthSizeMinusOne)
+
+	_, err = dec.Marshal()
+	require.EqualError(t, err, "invalid lengthSizeMinusOne: 2")
+}
+
+func TestDecoderConfigurationRecordMarshalMismatch(t *testing.T) {
+	r := casesDecoderConfigurationRecord[0].dec
+	r.NALUArrays = []DecoderConfigurationRecord_NALUArray{{
+		NALUType: NALUType_VPS_NUT,
+		NALUs:    r.NALUArrays[1].NALUs,
+	}}
+
+	_, err := r.Marshal()
+	require.EqualError(t, err, "NALU type doesn't match array type (VPS_NUT)")
+}
+
+func FuzzDecoderConfigurationRecordUnmarshal(f *testing.F) {
+	for _, ca := range casesDecoderConfigurationRecord {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var r DecoderConfigurationRecord
+		err := r.Unmarshal(b)
+		if err == nil {
+			r.Marshal() //nolint:errcheck
+		}
+	})
+}
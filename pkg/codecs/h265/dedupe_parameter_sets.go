@@ -0,0 +1,14 @@
+package h265
+
+import (
+	"github.com/bluenviron/mediacommon/pkg/codecs/h264"
+)
+
+// DedupeParameterSets adds to existing parameter sets the incoming ones
+// that are not already present, comparing them byte by byte.
+// It returns the merged parameter sets and whether any parameter set has been added,
+// that means that the decoder configuration has changed.
+// Existing parameter sets are not modified.
+func DedupeParameterSets(existing [][]byte, incoming [][]byte) ([][]byte, bool) {
+	return h264.DedupeParameterSets(existing, incoming)
+}
@@ -0,0 +1,19 @@
+package h265
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDedupeParameterSets(t *testing.T) {
+	vps := []byte{0x40, 0x01, 0x0c, 0x01}
+
+	merged, changed := DedupeParameterSets([][]byte{vps}, [][]byte{vps})
+	require.Equal(t, [][]byte{vps}, merged)
+	require.Equal(t, false, changed)
+
+	merged, changed = DedupeParameterSets([][]byte{vps}, [][]byte{{0x40, 0x01, 0x0c, 0x02}})
+	require.Equal(t, [][]byte{vps, {0x40, 0x01, 0x0c, 0x02}}, merged)
+	require.Equal(t, true, changed)
+}
@@ -0,0 +1,76 @@
+package h265
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/pkg/codecs/h264"
+)
+
+func appendParameterSet(sets [][]byte, set []byte) [][]byte {
+	for _, existing := range sets {
+		if bytes.Equal(existing, set) {
+			return sets
+		}
+	}
+	return append(sets, set)
+}
+
+// ExtractParameterSets scans an Annex-B stream and returns the VPS, SPS and PPS
+// that are needed to decode the first random access picture.
+// Scanning stops at the first random access picture that is preceded by at least a VPS,
+// a SPS and a PPS, or at the end of the stream. Repeated parameter sets are returned once.
+// Returned parameter sets point to the input buffer.
+func ExtractParameterSets(annexb []byte) ([][]byte, [][]byte, [][]byte, error) {
+	split := h264.AnnexBSplitFunc(MaxAccessUnitSize)
+	var vps [][]byte
+	var sps [][]byte
+	var pps [][]byte
+
+	for len(annexb) != 0 {
+		n, nalu, err := split(annexb, true)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if n == 0 {
+			break
+		}
+		annexb = annexb[n:]
+
+		if len(nalu) < 2 {
+			continue
+		}
+
+		typ := NALUType((nalu[0] >> 1) & 0b111111)
+
+		switch typ {
+		case NALUType_VPS_NUT:
+			vps = appendParameterSet(vps, nalu)
+
+		case NALUType_SPS_NUT:
+			sps = appendParameterSet(sps, nalu)
+
+		case NALUType_PPS_NUT:
+			pps = appendParameterSet(pps, nalu)
+
+		default:
+			if typ.IsRandomAccess() && vps != nil && sps != nil && pps != nil {
+				return vps, sps, pps, nil
+			}
+		}
+	}
+
+	if vps == nil {
+		return nil, nil, nil, fmt.Errorf("VPS not found")
+	}
+
+	if sps == nil {
+		return nil, nil, nil, fmt.Errorf("SPS not found")
+	}
+
+	if pps == nil {
+		return nil, nil, nil, fmt.Errorf("PPS not found")
+	}
+
+	return vps, sps, pps, nil
+}
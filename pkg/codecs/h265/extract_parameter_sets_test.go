@@ -0,0 +1,76 @@
+package h265
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractParameterSets(t *testing.T) {
+	vps, sps, pps, err := ExtractParameterSets([]byte{
+		0x00, 0x00, 0x00, 0x01, 0x46, 0x01, 0x50,
+		0x00, 0x00, 0x00, 0x01, 0x40, 0x01, 0x0c, 0x01,
+		0x00, 0x00, 0x00, 0x01, 0x42, 0x01, 0x01, 0x01,
+		0x00, 0x00, 0x00, 0x01, 0x44, 0x01, 0xc1, 0x72,
+		0x00, 0x00, 0x01, 0x26, 0x01, 0xaf, 0x06,
+		0x00, 0x00, 0x01, 0x02, 0x01, 0xd0, 0x09,
+		0x00, 0x00, 0x00, 0x01, 0x40, 0x01, 0x0c, 0x01,
+		0x00, 0x00, 0x00, 0x01, 0x42, 0x01, 0x01, 0x02,
+		0x00, 0x00, 0x00, 0x01, 0x44, 0x01, 0xc1, 0x72,
+		0x00, 0x00, 0x01, 0x26, 0x01, 0xaf, 0x06,
+	})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{0x40, 0x01, 0x0c, 0x01}}, vps)
+	require.Equal(t, [][]byte{{0x42, 0x01, 0x01, 0x01}}, sps)
+	require.Equal(t, [][]byte{{0x44, 0x01, 0xc1, 0x72}}, pps)
+}
+
+func TestExtractParameterSetsErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		byts []byte
+		err  string
+	}{
+		{
+			"vps missing",
+			[]byte{
+				0x00, 0x00, 0x00, 0x01, 0x42, 0x01, 0x01, 0x01,
+				0x00, 0x00, 0x00, 0x01, 0x44, 0x01, 0xc1, 0x72,
+			},
+			"VPS not found",
+		},
+		{
+			"sps missing",
+			[]byte{
+				0x00, 0x00, 0x00, 0x01, 0x40, 0x01, 0x0c, 0x01,
+				0x00, 0x00, 0x00, 0x01, 0x44, 0x01, 0xc1, 0x72,
+			},
+			"SPS not found",
+		},
+		{
+			"pps missing",
+			[]byte{
+				0x00, 0x00, 0x00, 0x01, 0x40, 0x01, 0x0c, 0x01,
+				0x00, 0x00, 0x00, 0x01, 0x42, 0x01, 0x01, 0x01,
+			},
+			"PPS not found",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			_, _, _, err := ExtractParameterSets(ca.byts)
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func FuzzExtractParameterSets(f *testing.F) {
+	f.Add([]byte{
+		0x00, 0x00, 0x00, 0x01, 0x40, 0x01, 0x0c, 0x01,
+		0x00, 0x00, 0x00, 0x01, 0x42, 0x01, 0x01, 0x01,
+		0x00, 0x00, 0x00, 0x01, 0x44, 0x01, 0xc1, 0x72,
+	})
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		ExtractParameterSets(b) //nolint:errcheck
+	})
+}
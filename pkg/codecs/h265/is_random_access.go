@@ -4,8 +4,7 @@ package h265
 func IsRandomAccess(au [][]byte) bool {
 	for _, nalu := range au {
 		typ := NALUType((nalu[0] >> 1) & 0b111111)
-		switch typ {
-		case NALUType_IDR_W_RADL, NALUType_IDR_N_LP, NALUType_CRA_NUT:
+		if typ.IsRandomAccess() {
 			return true
 		}
 	}
@@ -10,6 +10,9 @@ func TestIsRandomAccess(t *testing.T) {
 	u := [][]byte{{byte(NALUType_IDR_W_RADL) << 1}}
 	require.Equal(t, true, IsRandomAccess(u))
 
+	u = [][]byte{{byte(NALUType_BLA_W_RADL) << 1}}
+	require.Equal(t, true, IsRandomAccess(u))
+
 	u = [][]byte{{byte(NALUType_TRAIL_N) << 1}}
 	require.Equal(t, false, IsRandomAccess(u))
 }
@@ -56,7 +56,7 @@ var naluTypeLabels = map[NALUType]string{
 	NALUType_TSA_N:          "TSA_N",
 	NALUType_TSA_R:          "TSA_R",
 	NALUType_STSA_N:         "STSA_N",
-	NALUType_STSA_R:         "STSA_R:",
+	NALUType_STSA_R:         "STSA_R",
 	NALUType_RADL_N:         "RADL_N",
 	NALUType_RADL_R:         "RADL_R",
 	NALUType_RASL_N:         "RASL_N",
@@ -98,3 +98,14 @@ func (nt NALUType) String() string {
 	}
 	return fmt.Sprintf("unknown (%d)", nt)
 }
+
+// IsRandomAccess checks whether the NALU contains a slice of a random access picture,
+// that is a BLA, IDR or CRA picture.
+func (nt NALUType) IsRandomAccess() bool {
+	switch nt {
+	case NALUType_BLA_W_LP, NALUType_BLA_W_RADL, NALUType_BLA_N_LP,
+		NALUType_IDR_W_RADL, NALUType_IDR_N_LP, NALUType_CRA_NUT:
+		return true
+	}
+	return false
+}
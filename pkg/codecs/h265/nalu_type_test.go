@@ -11,3 +11,27 @@ func TestNALUType(t *testing.T) {
 	require.NotEqual(t, true, strings.HasPrefix(NALUType(10).String(), "unknown"))
 	require.Equal(t, true, strings.HasPrefix(NALUType(60).String(), "unknown"))
 }
+
+func TestNALUTypeIsRandomAccess(t *testing.T) {
+	for _, typ := range []NALUType{
+		NALUType_BLA_W_LP,
+		NALUType_BLA_W_RADL,
+		NALUType_BLA_N_LP,
+		NALUType_IDR_W_RADL,
+		NALUType_IDR_N_LP,
+		NALUType_CRA_NUT,
+	} {
+		require.Equal(t, true, typ.IsRandomAccess(), typ.String())
+	}
+
+	for _, typ := range []NALUType{
+		NALUType_TRAIL_N,
+		NALUType_TRAIL_R,
+		NALUType_RASL_R,
+		NALUType_RSV_IRAP_VCL22,
+		NALUType_VPS_NUT,
+		NALUType_PREFIX_SEI_NUT,
+	} {
+		require.Equal(t, false, typ.IsRandomAccess(), typ.String())
+	}
+}
@@ -0,0 +1,420 @@
+package h265
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bluenviron/mediacommon/pkg/bits"
+)
+
+// SPS_ProfileTierLevel is a profile level tier of a SPS.
+//
+// Deprecated: replaced by ProfileTierLevel.
+type SPS_ProfileTierLevel = ProfileTierLevel //nolint:revive
+
+// ProfileTierLevel_SubLayer is a sub-layer of a ProfileTierLevel.
+type ProfileTierLevel_SubLayer struct { //nolint:revive
+	// SubLayerProfilePresentFlag == true
+	ProfileSpace                 uint8
+	TierFlag                     uint8
+	ProfileIdc                   uint8
+	ProfileCompatibilityFlag     [32]bool
+	ProgressiveSourceFlag        bool
+	InterlacedSourceFlag         bool
+	NonPackedConstraintFlag      bool
+	FrameOnlyConstraintFlag      bool
+	Max12bitConstraintFlag       bool
+	Max10bitConstraintFlag       bool
+	Max8bitConstraintFlag        bool
+	Max422ChromeConstraintFlag   bool
+	Max420ChromaConstraintFlag   bool
+	MaxMonochromeConstraintFlag  bool
+	IntraConstraintFlag          bool
+	OnePictureOnlyConstraintFlag bool
+	LowerBitRateConstraintFlag   bool
+	Max14BitConstraintFlag       bool
+
+	// SubLayerLevelPresentFlag == true
+	LevelIdc uint8
+}
+
+// ProfileTierLevel is a profile_tier_level structure, contained into VPS and SPS.
+// Specification: ITU-T Rec. H.265, 7.3.3
+type ProfileTierLevel struct {
+	GeneralProfileSpace                 uint8
+	GeneralTierFlag                     uint8
+	GeneralProfileIdc                   uint8
+	GeneralProfileCompatibilityFlag     [32]bool
+	GeneralProgressiveSourceFlag        bool
+	GeneralInterlacedSourceFlag         bool
+	GeneralNonPackedConstraintFlag      bool
+	GeneralFrameOnlyConstraintFlag      bool
+	GeneralMax12bitConstraintFlag       bool
+	GeneralMax10bitConstraintFlag       bool
+	GeneralMax8bitConstraintFlag        bool
+	GeneralMax422ChromeConstraintFlag   bool
+	GeneralMax420ChromaConstraintFlag   bool
+	GeneralMaxMonochromeConstraintFlag  bool
+	GeneralIntraConstraintFlag          bool
+	GeneralOnePictureOnlyConstraintFlag bool
+	GeneralLowerBitRateConstraintFlag   bool
+	GeneralMax14BitConstraintFlag       bool
+	GeneralLevelIdc                     uint8
+	SubLayerProfilePresentFlag          []bool
+	SubLayerLevelPresentFlag            []bool
+
+	// sub-layers, present when maxSubLayersMinus1 > 0.
+	SubLayers []ProfileTierLevel_SubLayer
+}
+
+// profile and constraint flags shared by the general profile and sub-layer profiles.
+type ptlProfile struct {
+	profileSpace             *uint8
+	tierFlag                 *uint8
+	profileIdc               *uint8
+	profileCompatibilityFlag *[32]bool
+
+	// from progressive_source_flag to lower_bit_rate_constraint_flag
+	constraintFlags []*bool
+
+	max14BitConstraintFlag *bool
+}
+
+func (p *ProfileTierLevel) generalProfile() ptlProfile {
+	return ptlProfile{
+		profileSpace:             &p.GeneralProfileSpace,
+		tierFlag:                 &p.GeneralTierFlag,
+		profileIdc:               &p.GeneralProfileIdc,
+		profileCompatibilityFlag: &p.GeneralProfileCompatibilityFlag,
+		constraintFlags: []*bool{
+			&p.GeneralProgressiveSourceFlag,
+			&p.GeneralInterlacedSourceFlag,
+			&p.GeneralNonPackedConstraintFlag,
+			&p.GeneralFrameOnlyConstraintFlag,
+			&p.GeneralMax12bitConstraintFlag,
+			&p.GeneralMax10bitConstraintFlag,
+			&p.GeneralMax8bitConstraintFlag,
+			&p.GeneralMax422ChromeConstraintFlag,
+			&p.GeneralMax420ChromaConstraintFlag,
+			&p.GeneralMaxMonochromeConstraintFlag,
+			&p.GeneralIntraConstraintFlag,
+			&p.GeneralOnePictureOnlyConstraintFlag,
+			&p.GeneralLowerBitRateConstraintFlag,
+		},
+		max14BitConstraintFlag: &p.GeneralMax14BitConstraintFlag,
+	}
+}
+
+func (l *ProfileTierLevel_SubLayer) profile() ptlProfile {
+	return ptlProfile{
+		profileSpace:             &l.ProfileSpace,
+		tierFlag:                 &l.TierFlag,
+		profileIdc:               &l.ProfileIdc,
+		profileCompatibilityFlag: &l.ProfileCompatibilityFlag,
+		constraintFlags: []*bool{
+			&l.ProgressiveSourceFlag,
+			&l.InterlacedSourceFlag,
+			&l.NonPackedConstraintFlag,
+			&l.FrameOnlyConstraintFlag,
+			&l.Max12bitConstraintFlag,
+			&l.Max10bitConstraintFlag,
+			&l.Max8bitConstraintFlag,
+			&l.Max422ChromeConstraintFlag,
+			&l.Max420ChromaConstraintFlag,
+			&l.MaxMonochromeConstraintFlag,
+			&l.IntraConstraintFlag,
+			&l.OnePictureOnlyConstraintFlag,
+			&l.LowerBitRateConstraintFlag,
+		},
+		max14BitConstraintFlag: &l.Max14BitConstraintFlag,
+	}
+}
+
+func (p ptlProfile) hasMax14BitConstraintFlag() bool {
+	return *p.profileIdc == 5 ||
+		*p.profileIdc == 9 ||
+		*p.profileIdc == 10 ||
+		*p.profileIdc == 11 ||
+		p.profileCompatibilityFlag[5] ||
+		p.profileCompatibilityFlag[9] ||
+		p.profileCompatibilityFlag[10] ||
+		p.profileCompatibilityFlag[11]
+}
+
+func (p ptlProfile) unmarshal(r *bits.Reader) error {
+	// profile_space, tier_flag, profile_idc, profile_compatibility_flag,
+	// constraint flags, reserved bits
+	if r.BitsLeft() < 8+32+48 {
+		return fmt.Errorf("not enough bits")
+	}
+
+	tmp, _ := r.ReadBits(2)
+	*p.profileSpace = uint8(tmp)
+	tmp, _ = r.ReadBits(1)
+	*p.tierFlag = uint8(tmp)
+	tmp, _ = r.ReadBits(5)
+	*p.profileIdc = uint8(tmp)
+
+	for j := 0; j < 32; j++ {
+		p.profileCompatibilityFlag[j], _ = r.ReadFlag()
+	}
+
+	for _, f := range p.constraintFlags {
+		*f, _ = r.ReadFlag()
+	}
+
+	if p.hasMax14BitConstraintFlag() {
+		*p.max14BitConstraintFlag, _ = r.ReadFlag()
+		r.SkipBits(34) //nolint:errcheck
+	} else {
+		*p.max14BitConstraintFlag = false
+		r.SkipBits(35) //nolint:errcheck
+	}
+
+	return nil
+}
+
+func (p ptlProfile) marshal(w *bits.Writer) {
+	w.WriteBits(uint64(*p.profileSpace), 2)
+	w.WriteBits(uint64(*p.tierFlag), 1)
+	w.WriteBits(uint64(*p.profileIdc), 5)
+
+	for j := 0; j < 32; j++ {
+		w.WriteFlag(p.profileCompatibilityFlag[j])
+	}
+
+	for _, f := range p.constraintFlags {
+		w.WriteFlag(*f)
+	}
+
+	if p.hasMax14BitConstraintFlag() {
+		w.WriteFlag(*p.max14BitConstraintFlag)
+		w.WriteBits(0, 34)
+	} else {
+		w.WriteBits(0, 35)
+	}
+}
+
+// Unmarshal decodes a ProfileTierLevel.
+// profilePresentFlag is assumed to be true, as in VPS and SPS.
+func (p *ProfileTierLevel) Unmarshal(r *bits.Reader, maxSubLayersMinus1 int) error {
+	if maxSubLayersMinus1 < 0 || maxSubLayersMinus1 > 7 {
+		return fmt.Errorf("invalid maxSubLayersMinus1 (%d)", maxSubLayersMinus1)
+	}
+
+	err := p.generalProfile().unmarshal(r)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := r.ReadBits(8)
+	if err != nil {
+		return err
+	}
+	p.GeneralLevelIdc = uint8(tmp)
+
+	if maxSubLayersMinus1 == 0 {
+		p.SubLayerProfilePresentFlag = nil
+		p.SubLayerLevelPresentFlag = nil
+		p.SubLayers = nil
+		return nil
+	}
+
+	// flags and reserved_zero_2bits
+	if r.BitsLeft() < 16 {
+		return fmt.Errorf("not enough bits")
+	}
+
+	p.SubLayerProfilePresentFlag = make([]bool, maxSubLayersMinus1)
+	p.SubLayerLevelPresentFlag = make([]bool, maxSubLayersMinus1)
+
+	for j := 0; j < maxSubLayersMinus1; j++ {
+		p.SubLayerProfilePresentFlag[j], _ = r.ReadFlag()
+		p.SubLayerLevelPresentFlag[j], _ = r.ReadFlag()
+	}
+
+	r.SkipBits((8 - maxSubLayersMinus1) * 2) //nolint:errcheck
+
+	p.SubLayers = make([]ProfileTierLevel_SubLayer, maxSubLayersMinus1)
+
+	for i := range p.SubLayers {
+		if p.SubLayerProfilePresentFlag[i] {
+			err = p.SubLayers[i].profile().unmarshal(r)
+			if err != nil {
+				return err
+			}
+		}
+
+		if p.SubLayerLevelPresentFlag[i] {
+			tmp, err = r.ReadBits(8)
+			if err != nil {
+				return err
+			}
+			p.SubLayers[i].LevelIdc = uint8(tmp)
+		}
+	}
+
+	return nil
+}
+
+func (p *ProfileTierLevel) unmarshal(buf []byte, pos *int, maxSubLayersMinus1 uint8) error {
+	r := bits.NewReader(buf)
+
+	err := r.SkipBits(*pos)
+	if err != nil {
+		return err
+	}
+
+	err = p.Unmarshal(r, int(maxSubLayersMinus1))
+	if err != nil {
+		return err
+	}
+
+	*pos = r.Pos()
+	return nil
+}
+
+// Marshal encodes a ProfileTierLevel.
+// maxSubLayersMinus1 is the length of SubLayerProfilePresentFlag.
+func (p ProfileTierLevel) Marshal() ([]byte, error) {
+	maxSubLayersMinus1 := len(p.SubLayerProfilePresentFlag)
+
+	if maxSubLayersMinus1 > 7 {
+		return nil, fmt.Errorf("invalid sub-layer count (%d)", maxSubLayersMinus1)
+	}
+
+	if len(p.SubLayerLevelPresentFlag) != maxSubLayersMinus1 ||
+		len(p.SubLayers) != maxSubLayersMinus1 {
+		return nil, fmt.Errorf("sub-layer flags and sub-layers have different lengths")
+	}
+
+	w := bits.NewWriter()
+
+	p.generalProfile().marshal(w)
+	w.WriteBits(uint64(p.GeneralLevelIdc), 8)
+
+	if maxSubLayersMinus1 > 0 {
+		for j := 0; j < maxSubLayersMinus1; j++ {
+			w.WriteFlag(p.SubLayerProfilePresentFlag[j])
+			w.WriteFlag(p.SubLayerLevelPresentFlag[j])
+		}
+
+		w.WriteBits(0, (8-maxSubLayersMinus1)*2)
+
+		for i := range p.SubLayers {
+			if p.SubLayerProfilePresentFlag[i] {
+				p.SubLayers[i].profile().marshal(w)
+			}
+
+			if p.SubLayerLevelPresentFlag[i] {
+				w.WriteBits(uint64(p.SubLayers[i].LevelIdc), 8)
+			}
+		}
+	}
+
+	return w.Bytes(), nil
+}
+
+// Clone returns a copy of the ProfileTierLevel that doesn't share memory with the original.
+func (p ProfileTierLevel) Clone() ProfileTierLevel {
+	p.SubLayerProfilePresentFlag = cloneBools(p.SubLayerProfilePresentFlag)
+	p.SubLayerLevelPresentFlag = cloneBools(p.SubLayerLevelPresentFlag)
+	if p.SubLayers != nil {
+		p.SubLayers = append(make([]ProfileTierLevel_SubLayer, 0, len(p.SubLayers)), p.SubLayers...)
+	}
+	return p
+}
+
+// GeneralConstraintIndicatorFlags returns the 6 bytes that contain general constraint flags,
+// starting from general_progressive_source_flag, as stored into
+// general_constraint_indicator_flags of hvcC. Reserved bits are set to zero.
+func (p ProfileTierLevel) GeneralConstraintIndicatorFlags() [6]byte {
+	flags := []bool{
+		p.GeneralProgressiveSourceFlag,
+		p.GeneralInterlacedSourceFlag,
+		p.GeneralNonPackedConstraintFlag,
+		p.GeneralFrameOnlyConstraintFlag,
+		p.GeneralMax12bitConstraintFlag,
+		p.GeneralMax10bitConstraintFlag,
+		p.GeneralMax8bitConstraintFlag,
+		p.GeneralMax422ChromeConstraintFlag,
+		p.GeneralMax420ChromaConstraintFlag,
+		p.GeneralMaxMonochromeConstraintFlag,
+		p.GeneralIntraConstraintFlag,
+		p.GeneralOnePictureOnlyConstraintFlag,
+		p.GeneralLowerBitRateConstraintFlag,
+		p.GeneralMax14BitConstraintFlag,
+	}
+
+	var ret [6]byte
+	for i, f := range flags {
+		if f {
+			ret[i/8] |= 1 << (7 - (i % 8))
+		}
+	}
+	return ret
+}
+
+// codecString returns the codec string of the profile, tier and level.
+// Specification: ISO 14496-15, E.3
+func (p ProfileTierLevel) codecString(sampleEntry string) string {
+	return formatCodecString(
+		sampleEntry,
+		p.GeneralProfileSpace,
+		p.GeneralProfileIdc,
+		p.GeneralProfileCompatibilityFlag,
+		p.GeneralTierFlag,
+		p.GeneralLevelIdc,
+		p.GeneralConstraintIndicatorFlags())
+}
+
+func formatCodecString(
+	sampleEntry string,
+	profileSpace uint8,
+	profileIdc uint8,
+	profileCompatibilityFlag [32]bool,
+	tierFlag uint8,
+	levelIdc uint8,
+	constraints [6]byte,
+) string {
+	var b strings.Builder
+
+	b.WriteString(sampleEntry)
+	b.WriteString(".")
+
+	if profileSpace >= 1 && profileSpace <= 3 {
+		b.WriteByte('A' + profileSpace - 1)
+	}
+	b.WriteString(strconv.FormatUint(uint64(profileIdc), 10))
+
+	// profile compatibility flags, in reverse bit order
+	var compat uint32
+	for j, f := range profileCompatibilityFlag {
+		if f {
+			compat |= 1 << j
+		}
+	}
+	b.WriteString(".")
+	b.WriteString(strings.ToUpper(strconv.FormatUint(uint64(compat), 16)))
+
+	if tierFlag != 0 {
+		b.WriteString(".H")
+	} else {
+		b.WriteString(".L")
+	}
+	b.WriteString(strconv.FormatUint(uint64(levelIdc), 10))
+
+	// trailing zero bytes are omitted
+	n := len(constraints)
+	for n > 0 && constraints[n-1] == 0 {
+		n--
+	}
+
+	for _, c := range constraints[:n] {
+		b.WriteString(".")
+		b.WriteString(strings.ToUpper(strconv.FormatUint(uint64(c), 16)))
+	}
+
+	return b.String()
+}
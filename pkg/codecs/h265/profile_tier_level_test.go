@@ -0,0 +1,135 @@
+package h265
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bluenviron/mediacommon/pkg/bits"
+)
+
+var casesProfileTierLevel = []struct {
+	name               string
+	byts               []byte
+	maxSubLayersMinus1 int
+	ptl                ProfileTierLevel
+}{
+	{
+		"main",
+		[]byte{
+			0x01, 0x60, 0x00, 0x00, 0x00, 0x90, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x78,
+		},
+		0,
+		ProfileTierLevel{
+			GeneralProfileIdc: 1,
+			GeneralProfileCompatibilityFlag: [32]bool{
+				false, true, true, false, false, false, false, false,
+				false, false, false, false, false, false, false, false,
+				false, false, false, false, false, false, false, false,
+				false, false, false, false, false, false, false, false,
+			},
+			GeneralProgressiveSourceFlag:   true,
+			GeneralFrameOnlyConstraintFlag: true,
+			GeneralLevelIdc:                120,
+		},
+	},
+	{
+		"sub-layers",
+		[]byte{
+			0x01, 0x60, 0x00, 0x00, 0x00, 0x90, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x78, 0xd0, 0x00, 0x01, 0x60,
+			0x00, 0x00, 0x00, 0x90, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x5a, 0x3c,
+		},
+		2,
+		ProfileTierLevel{
+			GeneralProfileIdc: 1,
+			GeneralProfileCompatibilityFlag: [32]bool{
+				false, true, true, false, false, false, false, false,
+				false, false, false, false, false, false, false, false,
+				false, false, false, false, false, false, false, false,
+				false, false, false, false, false, false, false, false,
+			},
+			GeneralProgressiveSourceFlag:   true,
+			GeneralFrameOnlyConstraintFlag: true,
+			GeneralLevelIdc:                120,
+			SubLayerProfilePresentFlag:     []bool{true, false},
+			SubLayerLevelPresentFlag:       []bool{true, true},
+			SubLayers: []ProfileTierLevel_SubLayer{
+				{
+					ProfileIdc: 1,
+					ProfileCompatibilityFlag: [32]bool{
+						false, true, true, false, false, false, false, false,
+						false, false, false, false, false, false, false, false,
+						false, false, false, false, false, false, false, false,
+						false, false, false, false, false, false, false, false,
+					},
+					ProgressiveSourceFlag:   true,
+					FrameOnlyConstraintFlag: true,
+					LevelIdc:                90,
+				},
+				{
+					LevelIdc: 60,
+				},
+			},
+		},
+	},
+}
+
+func TestProfileTierLevelUnmarshal(t *testing.T) {
+	for _, ca := range casesProfileTierLevel {
+		t.Run(ca.name, func(t *testing.T) {
+			var ptl ProfileTierLevel
+			r := bits.NewReader(ca.byts)
+			err := ptl.Unmarshal(r, ca.maxSubLayersMinus1)
+			require.NoError(t, err)
+			require.Equal(t, ca.ptl, ptl)
+			require.Equal(t, 0, r.BitsLeft())
+		})
+	}
+}
+
+func TestProfileTierLevelMarshal(t *testing.T) {
+	for _, ca := range casesProfileTierLevel {
+		t.Run(ca.name, func(t *testing.T) {
+			byts, err := ca.ptl.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.byts, byts)
+		})
+	}
+}
+
+func TestProfileTierLevelClone(t *testing.T) {
+	for _, ca := range casesProfileTierLevel {
+		t.Run(ca.name, func(t *testing.T) {
+			var orig ProfileTierLevel
+			err := orig.Unmarshal(bits.NewReader(ca.byts), ca.maxSubLayersMinus1)
+			require.NoError(t, err)
+
+			c := orig.Clone()
+			require.Equal(t, orig, c)
+
+			for i := range c.SubLayers {
+				c.SubLayerLevelPresentFlag[i] = !c.SubLayerLevelPresentFlag[i]
+				c.SubLayers[i].LevelIdc++
+			}
+
+			require.Equal(t, ca.ptl, orig)
+		})
+	}
+}
+
+func FuzzProfileTierLevelUnmarshal(f *testing.F) {
+	for _, ca := range casesProfileTierLevel {
+		f.Add(ca.byts, uint8(ca.maxSubLayersMinus1))
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte, maxSubLayersMinus1 uint8) {
+		var ptl ProfileTierLevel
+		err := ptl.Unmarshal(bits.NewReader(b), int(maxSubLayersMinus1))
+		if err == nil {
+			ptl.Marshal() //nolint:errcheck
+		}
+	})
+}
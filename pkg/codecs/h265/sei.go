@@ -0,0 +1,217 @@
+package h265
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/pkg/codecs/h264"
+	"github.com/bluenviron/mediacommon/pkg/codecs/internal/h26x"
+)
+
+// SEIPayloadType is the type of a SEI payload.
+// Specification: ITU-T Rec. H.265, Annex D
+type SEIPayloadType uint32
+
+// SEI payload types.
+const (
+	SEIPayloadTypeMasteringDisplayColourVolume       SEIPayloadType = 137
+	SEIPayloadTypeContentLightLevelInfo              SEIPayloadType = 144
+	SEIPayloadTypeAlternativeTransferCharacteristics SEIPayloadType = 147
+)
+
+// SEIMessage is a SEI message.
+type SEIMessage interface {
+	PayloadType() SEIPayloadType
+}
+
+// SEIMessageUnknown is a SEI message that is not decoded.
+type SEIMessageUnknown struct {
+	Type    SEIPayloadType
+	Payload []byte
+}
+
+// PayloadType implements SEIMessage.
+func (m SEIMessageUnknown) PayloadType() SEIPayloadType {
+	return m.Type
+}
+
+// SEIMessageMasteringDisplayColourVolume is a mastering display colour volume SEI message.
+// Specification: ITU-T Rec. H.265, D.2.28
+type SEIMessageMasteringDisplayColourVolume struct {
+	DisplayPrimariesX            [3]uint16
+	DisplayPrimariesY            [3]uint16
+	WhitePointX                  uint16
+	WhitePointY                  uint16
+	MaxDisplayMasteringLuminance uint32
+	MinDisplayMasteringLuminance uint32
+}
+
+// PayloadType implements SEIMessage.
+func (SEIMessageMasteringDisplayColourVolume) PayloadType() SEIPayloadType {
+	return SEIPayloadTypeMasteringDisplayColourVolume
+}
+
+func (m *SEIMessageMasteringDisplayColourVolume) unmarshal(buf []byte) error {
+	if len(buf) < 24 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	for c := 0; c < 3; c++ {
+		m.DisplayPrimariesX[c] = uint16(buf[c*4])<<8 | uint16(buf[c*4+1])
+		m.DisplayPrimariesY[c] = uint16(buf[c*4+2])<<8 | uint16(buf[c*4+3])
+	}
+
+	m.WhitePointX = uint16(buf[12])<<8 | uint16(buf[13])
+	m.WhitePointY = uint16(buf[14])<<8 | uint16(buf[15])
+	m.MaxDisplayMasteringLuminance = uint32(buf[16])<<24 | uint32(buf[17])<<16 | uint32(buf[18])<<8 | uint32(buf[19])
+	m.MinDisplayMasteringLuminance = uint32(buf[20])<<24 | uint32(buf[21])<<16 | uint32(buf[22])<<8 | uint32(buf[23])
+
+	return nil
+}
+
+// SEIMessageContentLightLevelInfo is a content light level information SEI message.
+// Specification: ITU-T Rec. H.265, D.2.35
+type SEIMessageContentLightLevelInfo struct {
+	MaxContentLightLevel    uint16
+	MaxPicAverageLightLevel uint16
+}
+
+// PayloadType implements SEIMessage.
+func (SEIMessageContentLightLevelInfo) PayloadType() SEIPayloadType {
+	return SEIPayloadTypeContentLightLevelInfo
+}
+
+func (m *SEIMessageContentLightLevelInfo) unmarshal(buf []byte) error {
+	if len(buf) < 4 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	m.MaxContentLightLevel = uint16(buf[0])<<8 | uint16(buf[1])
+	m.MaxPicAverageLightLevel = uint16(buf[2])<<8 | uint16(buf[3])
+
+	return nil
+}
+
+// SEIMessageAlternativeTransferCharacteristics is a alternative transfer characteristics SEI message.
+// Specification: ITU-T Rec. H.265, D.2.38
+type SEIMessageAlternativeTransferCharacteristics struct {
+	// 18 means HLG (ARIB STD-B67).
+	PreferredTransferCharacteristics uint8
+}
+
+// PayloadType implements SEIMessage.
+func (SEIMessageAlternativeTransferCharacteristics) PayloadType() SEIPayloadType {
+	return SEIPayloadTypeAlternativeTransferCharacteristics
+}
+
+func (m *SEIMessageAlternativeTransferCharacteristics) unmarshal(buf []byte) error {
+	if len(buf) < 1 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	m.PreferredTransferCharacteristics = buf[0]
+
+	return nil
+}
+
+// SEI is a supplemental enhancement information NALU, either prefix or suffix.
+// Specification: ITU-T Rec. H.265, 7.3.2.4
+type SEI struct {
+	Messages []SEIMessage
+}
+
+// Unmarshal decodes a SEI.
+func (s *SEI) Unmarshal(buf []byte) error {
+	if len(buf) < 2 {
+		return fmt.Errorf("not enough bits")
+	}
+
+	typ := NALUType((buf[0] >> 1) & 0b111111)
+	if typ != NALUType_PREFIX_SEI_NUT && typ != NALUType_SUFFIX_SEI_NUT {
+		return fmt.Errorf("not a SEI")
+	}
+
+	buf = h264.EmulationPreventionRemove(buf[2:])
+
+	s.Messages = nil
+
+	return h26x.UnmarshalSEIMessages(buf, func(payloadType uint32, payload []byte) error {
+		var msg SEIMessage
+
+		switch SEIPayloadType(payloadType) {
+		case SEIPayloadTypeMasteringDisplayColourVolume:
+			m := &SEIMessageMasteringDisplayColourVolume{}
+			err := m.unmarshal(payload)
+			if err != nil {
+				return err
+			}
+			msg = m
+
+		case SEIPayloadTypeContentLightLevelInfo:
+			m := &SEIMessageContentLightLevelInfo{}
+			err := m.unmarshal(payload)
+			if err != nil {
+				return err
+			}
+			msg = m
+
+		case SEIPayloadTypeAlternativeTransferCharacteristics:
+			m := &SEIMessageAlternativeTransferCharacteristics{}
+			err := m.unmarshal(payload)
+			if err != nil {
+				return err
+			}
+			msg = m
+
+		default:
+			msg = &SEIMessageUnknown{
+				Type:    SEIPayloadType(payloadType),
+				Payload: payload,
+			}
+		}
+
+		s.Messages = append(s.Messages, msg)
+		return nil
+	})
+}
+
+func cloneSEIMessage(msg SEIMessage) SEIMessage {
+	switch m := msg.(type) {
+	case *SEIMessageUnknown:
+		ret := *m
+		ret.Payload = h26x.CloneSEIPayload(m.Payload)
+		return &ret
+
+	case SEIMessageUnknown:
+		m.Payload = h26x.CloneSEIPayload(m.Payload)
+		return m
+
+	case *SEIMessageMasteringDisplayColourVolume:
+		ret := *m
+		return &ret
+
+	case *SEIMessageContentLightLevelInfo:
+		ret := *m
+		return &ret
+
+	case *SEIMessageAlternativeTransferCharacteristics:
+		ret := *m
+		return &ret
+
+	default:
+		// messages passed by value without references, or defined outside of this package
+		return msg
+	}
+}
+
+// Clone returns a copy of the SEI that doesn't share memory with the original.
+// Messages whose type is not defined in this package are shared.
+func (s SEI) Clone() SEI {
+	if s.Messages != nil {
+		msgs := make([]SEIMessage, len(s.Messages))
+		for i, msg := range s.Messages {
+			msgs[i] = cloneSEIMessage(msg)
+		}
+		s.Messages = msgs
+	}
+	return s
+}
@@ -0,0 +1,112 @@
+package h265
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesSEI = []struct {
+	name string
+	byts []byte
+	sei  []SEIMessage
+}{
+	{
+		"hdr",
+		[]byte{
+			0x4e, 0x01,
+			0x89, 0x18,
+			0x33, 0xc2, 0x86, 0xc4, 0x1d, 0x4c, 0x0b, 0xb8,
+			0x84, 0xd0, 0x3e, 0x80, 0x3d, 0x13, 0x40, 0x42,
+			0x00, 0x98, 0x96, 0x80, 0x00, 0x00, 0x03, 0x00,
+			0x32,
+			0x90, 0x04, 0x03, 0xe8, 0x01, 0x90,
+			0x80,
+		},
+		[]SEIMessage{
+			&SEIMessageMasteringDisplayColourVolume{
+				DisplayPrimariesX:            [3]uint16{13250, 7500, 34000},
+				DisplayPrimariesY:            [3]uint16{34500, 3000, 16000},
+				WhitePointX:                  15635,
+				WhitePointY:                  16450,
+				MaxDisplayMasteringLuminance: 10000000,
+				MinDisplayMasteringLuminance: 50,
+			},
+			&SEIMessageContentLightLevelInfo{
+				MaxContentLightLevel:    1000,
+				MaxPicAverageLightLevel: 400,
+			},
+		},
+	},
+	{
+		"hlg",
+		[]byte{
+			0x4e, 0x01,
+			0x93, 0x01, 0x12,
+			0x05, 0x02, 0x01, 0x02,
+			0x80,
+		},
+		[]SEIMessage{
+			&SEIMessageAlternativeTransferCharacteristics{
+				PreferredTransferCharacteristics: 18,
+			},
+			&SEIMessageUnknown{
+				Type:    5,
+				Payload: []byte{0x01, 0x02},
+			},
+		},
+	},
+}
+
+func TestSEIUnmarshal(t *testing.T) {
+	for _, ca := range casesSEI {
+		t.Run(ca.name, func(t *testing.T) {
+			var sei SEI
+			err := sei.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.sei, sei.Messages)
+		})
+	}
+}
+
+func TestSEIClone(t *testing.T) {
+	for _, ca := range casesSEI {
+		t.Run(ca.name, func(t *testing.T) {
+			var orig SEI
+			err := orig.Unmarshal(ca.byts)
+			require.NoError(t, err)
+
+			c := orig.Clone()
+			require.Equal(t, orig, c)
+
+			for _, msg := range c.Messages {
+				switch m := msg.(type) {
+				case *SEIMessageUnknown:
+					m.Payload[0]++
+
+				case *SEIMessageMasteringDisplayColourVolume:
+					m.WhitePointX++
+
+				case *SEIMessageContentLightLevelInfo:
+					m.MaxContentLightLevel++
+
+				case *SEIMessageAlternativeTransferCharacteristics:
+					m.PreferredTransferCharacteristics++
+				}
+			}
+
+			require.Equal(t, ca.sei, orig.Messages)
+		})
+	}
+}
+
+func FuzzSEIUnmarshal(f *testing.F) {
+	for _, ca := range casesSEI {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var sei SEI
+		sei.Unmarshal(b) //nolint:errcheck
+	})
+}
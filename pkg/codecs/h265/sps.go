@@ -5,6 +5,7 @@ import (
 
 	"github.com/bluenviron/mediacommon/pkg/bits"
 	"github.com/bluenviron/mediacommon/pkg/codecs/h264"
+	"github.com/bluenviron/mediacommon/pkg/codecs/internal/h26x"
 )
 
 const (
@@ -39,9 +40,15 @@ type SPS_ScalingListData struct { //nolint:revive
 	ScalingListPredModeFlag      [4][6]bool
 	ScalingListPredmatrixIDDelta [4][6]uint32
 	ScalingListDcCoefMinus8      [4][6]int32
+
+	// ScalingListPredModeFlag == true
+	// coefficients of the scaling list, in up-right diagonal scan order.
+	ScalingList [4][6][]int32
 }
 
 func (d *SPS_ScalingListData) unmarshal(buf []byte, pos *int) error {
+	d.ScalingList = [4][6][]int32{}
+
 	for sizeID := 0; sizeID < 4; sizeID++ {
 		var matrixIDIncr int
 		if sizeID == 3 {
@@ -64,19 +71,28 @@ func (d *SPS_ScalingListData) unmarshal(buf []byte, pos *int) error {
 				}
 			} else {
 				coefNum := min(64, 1<<(4+(sizeID<<1)))
+				nextCoef := int32(8)
 
 				if sizeID > 1 {
 					d.ScalingListDcCoefMinus8[sizeID-2][matrixID], err = bits.ReadGolombSigned(buf, pos)
 					if err != nil {
 						return err
 					}
+
+					nextCoef = d.ScalingListDcCoefMinus8[sizeID-2][matrixID] + 8
 				}
 
+				d.ScalingList[sizeID][matrixID] = make([]int32, coefNum)
+
 				for i := 0; i < coefNum; i++ {
-					_, err = bits.ReadGolombSigned(buf, pos) // scalingListDeltaCoef
+					var scalingListDeltaCoef int32
+					scalingListDeltaCoef, err = bits.ReadGolombSigned(buf, pos)
 					if err != nil {
 						return err
 					}
+
+					nextCoef = (nextCoef + scalingListDeltaCoef + 256) % 256
+					d.ScalingList[sizeID][matrixID][i] = nextCoef
 				}
 			}
 		}
@@ -119,6 +135,7 @@ func (w *SPS_DefaultDisplayWindow) unmarshal(buf []byte, pos *int) error {
 }
 
 // SPS_TimingInfo is a timing info.
+// It is used by VPS too.
 type SPS_TimingInfo struct { //nolint:revive
 	NumUnitsInTick              uint32
 	TimeScale                   uint32
@@ -316,112 +333,6 @@ func (v *SPS_VUI) unmarshal(buf []byte, pos *int) error {
 	return nil
 }
 
-// SPS_ProfileTierLevel is a profile level tier of a SPS.
-type SPS_ProfileTierLevel struct { //nolint:revive
-	GeneralProfileSpace                 uint8
-	GeneralTierFlag                     uint8
-	GeneralProfileIdc                   uint8
-	GeneralProfileCompatibilityFlag     [32]bool
-	GeneralProgressiveSourceFlag        bool
-	GeneralInterlacedSourceFlag         bool
-	GeneralNonPackedConstraintFlag      bool
-	GeneralFrameOnlyConstraintFlag      bool
-	GeneralMax12bitConstraintFlag       bool
-	GeneralMax10bitConstraintFlag       bool
-	GeneralMax8bitConstraintFlag        bool
-	GeneralMax422ChromeConstraintFlag   bool
-	GeneralMax420ChromaConstraintFlag   bool
-	GeneralMaxMonochromeConstraintFlag  bool
-	GeneralIntraConstraintFlag          bool
-	GeneralOnePictureOnlyConstraintFlag bool
-	GeneralLowerBitRateConstraintFlag   bool
-	GeneralMax14BitConstraintFlag       bool
-	GeneralLevelIdc                     uint8
-	SubLayerProfilePresentFlag          []bool
-	SubLayerLevelPresentFlag            []bool
-}
-
-func (p *SPS_ProfileTierLevel) unmarshal(buf []byte, pos *int, maxSubLayersMinus1 uint8) error {
-	err := bits.HasSpace(buf, *pos, 8+32+12+34+8)
-	if err != nil {
-		return err
-	}
-
-	p.GeneralProfileSpace = uint8(bits.ReadBitsUnsafe(buf, pos, 2))
-	p.GeneralTierFlag = uint8(bits.ReadBitsUnsafe(buf, pos, 1))
-	p.GeneralProfileIdc = uint8(bits.ReadBitsUnsafe(buf, pos, 5))
-
-	for j := 0; j < 32; j++ {
-		p.GeneralProfileCompatibilityFlag[j] = bits.ReadFlagUnsafe(buf, pos)
-	}
-
-	p.GeneralProgressiveSourceFlag = bits.ReadFlagUnsafe(buf, pos)
-	p.GeneralInterlacedSourceFlag = bits.ReadFlagUnsafe(buf, pos)
-	p.GeneralNonPackedConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
-	p.GeneralFrameOnlyConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
-	p.GeneralMax12bitConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
-	p.GeneralMax10bitConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
-	p.GeneralMax8bitConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
-	p.GeneralMax422ChromeConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
-	p.GeneralMax420ChromaConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
-	p.GeneralMaxMonochromeConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
-	p.GeneralIntraConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
-	p.GeneralOnePictureOnlyConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
-	p.GeneralLowerBitRateConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
-
-	if p.GeneralProfileIdc == 5 ||
-		p.GeneralProfileIdc == 9 ||
-		p.GeneralProfileIdc == 10 ||
-		p.GeneralProfileIdc == 11 ||
-		p.GeneralProfileCompatibilityFlag[5] ||
-		p.GeneralProfileCompatibilityFlag[9] ||
-		p.GeneralProfileCompatibilityFlag[10] ||
-		p.GeneralProfileCompatibilityFlag[11] {
-		p.GeneralMax14BitConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
-		*pos += 34
-	} else {
-		*pos += 35
-	}
-
-	p.GeneralLevelIdc = uint8(bits.ReadBitsUnsafe(buf, pos, 8))
-
-	if maxSubLayersMinus1 > 0 {
-		p.SubLayerProfilePresentFlag = make([]bool, maxSubLayersMinus1)
-		p.SubLayerLevelPresentFlag = make([]bool, maxSubLayersMinus1)
-
-		err := bits.HasSpace(buf, *pos, int(2*maxSubLayersMinus1))
-		if err != nil {
-			return err
-		}
-
-		for j := uint8(0); j < maxSubLayersMinus1; j++ {
-			p.SubLayerProfilePresentFlag[j] = bits.ReadFlagUnsafe(buf, pos)
-			p.SubLayerLevelPresentFlag[j] = bits.ReadFlagUnsafe(buf, pos)
-		}
-	}
-
-	if maxSubLayersMinus1 > 0 {
-		err := bits.HasSpace(buf, *pos, int(8-maxSubLayersMinus1)*2)
-		if err != nil {
-			return err
-		}
-
-		*pos += int(8-maxSubLayersMinus1) * 2
-	}
-
-	for i := uint8(0); i < maxSubLayersMinus1; i++ {
-		if p.SubLayerProfilePresentFlag[i] {
-			return fmt.Errorf("SubLayerProfilePresentFlag not supported yet")
-		}
-
-		if p.SubLayerLevelPresentFlag[i] {
-			return fmt.Errorf("SubLayerLevelPresentFlag not supported yet")
-		}
-	}
-
-	return nil
-}
-
 // SPS_ConformanceWindow is a conformance window of a SPS.
 type SPS_ConformanceWindow struct { //nolint:revive
 	LeftOffset   uint32
@@ -588,6 +499,14 @@ func (r *SPS_ShortTermRefPicSet) unmarshal(buf []byte, pos *int, stRpsIdx uint32
 		}
 
 		r.NumPositivePics = i
+
+		if r.NumNegativePics > maxNegativePics {
+			return fmt.Errorf("num_negative_pics exceeds %d", maxNegativePics)
+		}
+
+		if r.NumPositivePics > maxPositivePics {
+			return fmt.Errorf("num_positive_pics exceeds %d", maxPositivePics)
+		}
 	} else {
 		r.NumNegativePics, err = bits.ReadGolombUnsigned(buf, pos)
 		if err != nil {
@@ -663,7 +582,7 @@ type SPS struct {
 	VPSID                                uint8
 	MaxSubLayersMinus1                   uint8
 	TemporalIDNestingFlag                bool
-	ProfileTierLevel                     SPS_ProfileTierLevel
+	ProfileTierLevel                     ProfileTierLevel
 	ID                                   uint8
 	ChromaFormatIdc                      uint32
 	SeparateColourPlaneFlag              bool
@@ -985,6 +904,103 @@ func (s *SPS) Unmarshal(buf []byte) error {
 	return nil
 }
 
+func cloneBytes(s []byte) []byte {
+	if s == nil {
+		return nil
+	}
+	return append(make([]byte, 0, len(s)), s...)
+}
+
+func cloneBools(s []bool) []bool {
+	if s == nil {
+		return nil
+	}
+	return append(make([]bool, 0, len(s)), s...)
+}
+
+func cloneInt32s(s []int32) []int32 {
+	if s == nil {
+		return nil
+	}
+	return append(make([]int32, 0, len(s)), s...)
+}
+
+func cloneUint32s(s []uint32) []uint32 {
+	if s == nil {
+		return nil
+	}
+	return append(make([]uint32, 0, len(s)), s...)
+}
+
+func (r *SPS_ShortTermRefPicSet) clone() *SPS_ShortTermRefPicSet {
+	if r == nil {
+		return nil
+	}
+
+	ret := *r
+	ret.DeltaPocS0 = cloneInt32s(r.DeltaPocS0)
+	ret.UsedByCurrPicS0Flag = cloneBools(r.UsedByCurrPicS0Flag)
+	ret.DeltaPocS1 = cloneInt32s(r.DeltaPocS1)
+	ret.UsedByCurrPicS1Flag = cloneBools(r.UsedByCurrPicS1Flag)
+	return &ret
+}
+
+func (v *SPS_VUI) clone() *SPS_VUI {
+	if v == nil {
+		return nil
+	}
+
+	ret := *v
+
+	if v.DefaultDisplayWindow != nil {
+		w := *v.DefaultDisplayWindow
+		ret.DefaultDisplayWindow = &w
+	}
+
+	if v.TimingInfo != nil {
+		ti := *v.TimingInfo
+		ret.TimingInfo = &ti
+	}
+
+	return &ret
+}
+
+// Clone returns a copy of the SPS that doesn't share memory with the original.
+func (s SPS) Clone() SPS {
+	s.ProfileTierLevel = s.ProfileTierLevel.Clone()
+
+	if s.ConformanceWindow != nil {
+		w := *s.ConformanceWindow
+		s.ConformanceWindow = &w
+	}
+
+	s.MaxDecPicBufferingMinus1 = cloneUint32s(s.MaxDecPicBufferingMinus1)
+	s.MaxNumReorderPics = cloneUint32s(s.MaxNumReorderPics)
+	s.MaxLatencyIncreasePlus1 = cloneUint32s(s.MaxLatencyIncreasePlus1)
+
+	if s.ScalingListData != nil {
+		d := *s.ScalingListData
+		for sizeID := range d.ScalingList {
+			for matrixID := range d.ScalingList[sizeID] {
+				d.ScalingList[sizeID][matrixID] = cloneInt32s(d.ScalingList[sizeID][matrixID])
+			}
+		}
+		s.ScalingListData = &d
+	}
+
+	if s.ShortTermRefPicSets != nil {
+		sets := make([]*SPS_ShortTermRefPicSet, len(s.ShortTermRefPicSets))
+		for i, set := range s.ShortTermRefPicSets {
+			sets[i] = set.clone()
+		}
+		s.ShortTermRefPicSets = sets
+	}
+
+	s.VUI = s.VUI.clone()
+
+	return s
+}
+
 // Width returns the video width.
 func (s SPS) Width() int {
 	width := s.PicWidthInLumaSamples
@@ -1017,3 +1033,24 @@ func (s SPS) FPS() float64 {
 
 	return float64(s.VUI.TimingInfo.TimeScale) / float64(s.VUI.TimingInfo.NumUnitsInTick)
 }
+
+// FrameRate returns the frame rate of the video as a fraction, that is
+// vui_time_scale / vui_num_units_in_tick, reduced to lowest terms.
+// It returns false when timing info is not present.
+// When the SPS doesn't contain timing info, VPS.FrameRate can be used.
+func (s SPS) FrameRate() (uint32, uint32, bool) {
+	if s.VUI == nil || s.VUI.TimingInfo == nil {
+		return 0, 0, false
+	}
+
+	return h26x.ReduceFrameRate(uint64(s.VUI.TimingInfo.TimeScale), uint64(s.VUI.TimingInfo.NumUnitsInTick))
+}
+
+// CodecString returns the codec string of the video, in the hvc1 format,
+// that is used in the codecs parameter of MIME types.
+// When parameter sets are carried in-band, the hev1 format is used instead,
+// and can be obtained by replacing the prefix.
+// Specification: ISO 14496-15, E.3
+func (s SPS) CodecString() string {
+	return s.ProfileTierLevel.codecString("hvc1")
+}
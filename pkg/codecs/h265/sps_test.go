@@ -7,12 +7,13 @@ import (
 )
 
 var casesSPS = []struct {
-	name   string
-	byts   []byte
-	sps    SPS
-	width  int
-	height int
-	fps    float64
+	name        string
+	byts        []byte
+	sps         SPS
+	width       int
+	height      int
+	fps         float64
+	codecString string
 }{
 	{
 		"1920x1080",
@@ -26,7 +27,7 @@ var casesSPS = []struct {
 		},
 		SPS{
 			TemporalIDNestingFlag: true,
-			ProfileTierLevel: SPS_ProfileTierLevel{
+			ProfileTierLevel: ProfileTierLevel{
 				GeneralProfileIdc: 1,
 				GeneralProfileCompatibilityFlag: [32]bool{
 					false, true, true, false, false, false, false, false,
@@ -61,6 +62,7 @@ var casesSPS = []struct {
 		1920,
 		1080,
 		30,
+		"hvc1.1.6.L120.90",
 	},
 	{
 		"1920x800",
@@ -74,7 +76,7 @@ var casesSPS = []struct {
 		},
 		SPS{
 			TemporalIDNestingFlag: true,
-			ProfileTierLevel: SPS_ProfileTierLevel{
+			ProfileTierLevel: ProfileTierLevel{
 				GeneralProfileIdc: 1,
 				GeneralProfileCompatibilityFlag: [32]bool{
 					false, true, true, false, false, false, false, false,
@@ -117,6 +119,7 @@ var casesSPS = []struct {
 		1920,
 		800,
 		23.976023976023978,
+		"hvc1.1.6.L120.90",
 	},
 	{
 		"1280x720",
@@ -131,7 +134,7 @@ var casesSPS = []struct {
 		},
 		SPS{
 			TemporalIDNestingFlag: true,
-			ProfileTierLevel: SPS_ProfileTierLevel{
+			ProfileTierLevel: ProfileTierLevel{
 				GeneralProfileIdc: 4,
 				GeneralProfileCompatibilityFlag: [32]bool{
 					false, false, false, false, true, false, false, false,
@@ -180,6 +183,7 @@ var casesSPS = []struct {
 		1280,
 		720,
 		30,
+		"hvc1.4.10.L93.98.8",
 	},
 	{
 		"10 bit",
@@ -193,7 +197,7 @@ var casesSPS = []struct {
 		},
 		SPS{
 			TemporalIDNestingFlag: true,
-			ProfileTierLevel: SPS_ProfileTierLevel{
+			ProfileTierLevel: ProfileTierLevel{
 				GeneralTierFlag:   1,
 				GeneralProfileIdc: 2,
 				GeneralProfileCompatibilityFlag: [32]bool{
@@ -233,6 +237,7 @@ var casesSPS = []struct {
 		1920,
 		1080,
 		29.97,
+		"hvc1.2.4.H120.90",
 	},
 	{
 		"nvenc",
@@ -248,7 +253,7 @@ var casesSPS = []struct {
 		},
 		SPS{
 			TemporalIDNestingFlag: true,
-			ProfileTierLevel: SPS_ProfileTierLevel{
+			ProfileTierLevel: ProfileTierLevel{
 				GeneralProfileIdc: 1,
 				GeneralProfileCompatibilityFlag: [32]bool{
 					false, true, false, false, false, false, false, false,
@@ -298,6 +303,7 @@ var casesSPS = []struct {
 		1920,
 		1080,
 		60,
+		"hvc1.1.2.L123",
 	},
 	{
 		"avigilon",
@@ -313,7 +319,7 @@ var casesSPS = []struct {
 		},
 		SPS{
 			TemporalIDNestingFlag: true,
-			ProfileTierLevel: SPS_ProfileTierLevel{
+			ProfileTierLevel: ProfileTierLevel{
 				GeneralProfileIdc: 1,
 				GeneralProfileCompatibilityFlag: [32]bool{
 					false, true, true, false, false, false, false, false,
@@ -368,6 +374,7 @@ var casesSPS = []struct {
 		3072,
 		1728,
 		17,
+		"hvc1.1.6.L150.80",
 	},
 	{
 		"long_term_ref_pics_present_flag",
@@ -381,7 +388,7 @@ var casesSPS = []struct {
 		},
 		SPS{
 			TemporalIDNestingFlag: true,
-			ProfileTierLevel: SPS_ProfileTierLevel{
+			ProfileTierLevel: ProfileTierLevel{
 				GeneralProfileIdc: 1,
 				GeneralProfileCompatibilityFlag: [32]bool{
 					false, true, true, false, false, false, false, false,
@@ -420,6 +427,7 @@ var casesSPS = []struct {
 		1280,
 		720,
 		50,
+		"hvc1.1.6.L93.B0",
 	},
 	{
 		"scaling list data",
@@ -473,7 +481,7 @@ var casesSPS = []struct {
 		},
 		SPS{
 			TemporalIDNestingFlag: true,
-			ProfileTierLevel: SPS_ProfileTierLevel{
+			ProfileTierLevel: ProfileTierLevel{
 				GeneralProfileIdc: 1,
 				GeneralProfileCompatibilityFlag: [32]bool{
 					false, true, true, false, false, false, false, false,
@@ -516,6 +524,98 @@ var casesSPS = []struct {
 					{0, 0, 0, 0, 0, 0},
 					{0, 0, 0, 0, 0, 0},
 				},
+				ScalingList: [4][6][]int32{
+					{
+						{
+							6, 10, 10, 14, 14, 14, 18, 18, 18, 18, 36, 36, 36, 72, 72, 127,
+						},
+						{
+							6, 16, 16, 30, 30, 30, 48, 48, 48, 48, 72, 72, 72, 127, 127, 127,
+						},
+						nil,
+						{
+							9, 15, 15, 20, 20, 20, 25, 25, 25, 25, 36, 36, 36, 96, 96, 127,
+						},
+						{
+							16, 32, 32, 52, 52, 52, 74, 74, 74, 74, 127, 127, 127, 127, 127, 127,
+						},
+						nil,
+					},
+					{
+						{
+							6, 8, 8, 10, 10, 10, 12, 12, 12, 12, 13, 13, 14, 14, 14, 14,
+							14, 15, 16, 16, 16, 16, 17, 18, 19, 19, 19, 19, 18, 19, 20, 21,
+							22, 22, 22, 22, 22, 24, 28, 32, 32, 28, 28, 30, 36, 42, 48, 42,
+							36, 42, 64, 96, 96, 64, 64, 96, 127, 96, 96, 127, 127, 127, 127, 127,
+						},
+						{
+							6, 12, 12, 16, 16, 16, 24, 24, 24, 24, 30, 30, 30, 30, 30, 36,
+							36, 36, 36, 36, 36, 43, 43, 43, 43, 43, 43, 43, 52, 52, 52, 52,
+							52, 52, 52, 52, 64, 64, 64, 64, 64, 64, 64, 72, 72, 72, 72, 72,
+							72, 96, 96, 96, 96, 96, 127, 127, 127, 127, 127, 127, 127, 127, 127, 127,
+						},
+						nil,
+						{
+							9, 13, 13, 15, 14, 15, 17, 17, 17, 17, 19, 19, 19, 19, 19, 21,
+							21, 22, 22, 21, 21, 23, 23, 24, 24, 24, 23, 23, 25, 25, 26, 27,
+							27, 26, 25, 25, 28, 28, 32, 32, 32, 28, 28, 32, 48, 64, 64, 48,
+							32, 64, 96, 96, 96, 64, 127, 127, 127, 127, 127, 127, 127, 127, 127, 127,
+						},
+						{
+							16, 24, 24, 32, 32, 32, 40, 40, 40, 40, 52, 52, 52, 52, 52, 60,
+							60, 60, 60, 60, 60, 68, 68, 68, 68, 68, 68, 68, 76, 76, 76, 76,
+							76, 76, 76, 76, 86, 86, 86, 86, 86, 86, 86, 96, 96, 96, 96, 96,
+							96, 127, 127, 127, 127, 127, 127, 127, 127, 127, 127, 127, 127, 127, 127, 127,
+						},
+						nil,
+					},
+					{
+						{
+							8, 8, 8, 10, 10, 10, 12, 12, 12, 12, 13, 13, 14, 14, 14, 14,
+							14, 15, 16, 16, 16, 16, 17, 18, 19, 19, 19, 19, 18, 19, 20, 21,
+							22, 22, 22, 22, 22, 24, 28, 32, 32, 28, 28, 30, 36, 42, 48, 42,
+							36, 42, 64, 96, 96, 64, 64, 96, 127, 96, 96, 127, 127, 127, 127, 127,
+						},
+						{
+							10, 12, 12, 16, 16, 16, 24, 24, 24, 24, 30, 30, 30, 30, 30, 36,
+							36, 36, 36, 36, 36, 43, 43, 43, 43, 43, 43, 43, 52, 52, 52, 52,
+							52, 52, 52, 52, 64, 64, 64, 64, 64, 64, 64, 72, 72, 72, 72, 72,
+							72, 96, 96, 96, 96, 96, 127, 127, 127, 127, 127, 127, 127, 127, 127, 127,
+						},
+						nil,
+						{
+							11, 13, 13, 15, 14, 15, 17, 17, 17, 17, 19, 19, 19, 19, 19, 21,
+							21, 22, 22, 21, 21, 23, 23, 24, 24, 24, 23, 23, 25, 25, 26, 27,
+							27, 26, 25, 25, 28, 28, 32, 32, 32, 28, 28, 32, 48, 64, 64, 48,
+							32, 64, 96, 96, 96, 64, 127, 127, 127, 127, 127, 127, 127, 127, 127, 127,
+						},
+						{
+							16, 24, 24, 32, 32, 32, 40, 40, 40, 40, 52, 52, 52, 52, 52, 60,
+							60, 60, 60, 60, 60, 68, 68, 68, 68, 68, 68, 68, 76, 76, 76, 76,
+							76, 76, 76, 76, 86, 86, 86, 86, 86, 86, 86, 96, 96, 96, 96, 96,
+							96, 127, 127, 127, 127, 127, 127, 127, 127, 127, 127, 127, 127, 127, 127, 127,
+						},
+						nil,
+					},
+					{
+						{
+							8, 8, 8, 10, 10, 10, 12, 12, 12, 12, 13, 13, 14, 14, 14, 14,
+							14, 15, 16, 16, 16, 16, 17, 18, 19, 19, 19, 19, 18, 19, 20, 21,
+							22, 22, 22, 22, 22, 24, 28, 32, 32, 28, 28, 30, 36, 42, 48, 42,
+							36, 42, 64, 96, 96, 64, 64, 96, 127, 96, 96, 127, 127, 127, 127, 127,
+						},
+						nil,
+						nil,
+						{
+							11, 13, 13, 15, 14, 15, 17, 17, 17, 17, 19, 19, 19, 19, 19, 21,
+							21, 22, 22, 21, 21, 23, 23, 24, 24, 24, 23, 23, 25, 25, 26, 27,
+							27, 26, 25, 25, 28, 28, 32, 32, 32, 28, 28, 32, 48, 64, 64, 48,
+							32, 64, 96, 96, 96, 64, 127, 127, 127, 127, 127, 127, 127, 127, 127, 127,
+						},
+						nil,
+						nil,
+					},
+				},
 			},
 			SampleAdaptiveOffsetEnabledFlag: true,
 			ShortTermRefPicSets: []*SPS_ShortTermRefPicSet{
@@ -542,6 +642,7 @@ var casesSPS = []struct {
 		2048,
 		1536,
 		30,
+		"hvc1.1.6.L150.90",
 	},
 }
 
@@ -555,10 +656,96 @@ func TestSPSUnmarshal(t *testing.T) {
 			require.Equal(t, ca.width, sps.Width())
 			require.Equal(t, ca.height, sps.Height())
 			require.Equal(t, ca.fps, sps.FPS())
+			require.Equal(t, ca.codecString, sps.CodecString())
+		})
+	}
+}
+
+func TestSPSClone(t *testing.T) {
+	for _, ca := range casesSPS {
+		t.Run(ca.name, func(t *testing.T) {
+			var orig SPS
+			err := orig.Unmarshal(ca.byts)
+			require.NoError(t, err)
+
+			c := orig.Clone()
+			require.Equal(t, orig, c)
+
+			c.ProfileTierLevel.GeneralProfileCompatibilityFlag[0] = !c.ProfileTierLevel.GeneralProfileCompatibilityFlag[0]
+			c.MaxDecPicBufferingMinus1[0]++
+			if c.ConformanceWindow != nil {
+				c.ConformanceWindow.LeftOffset++
+			}
+			if c.ScalingListData != nil {
+				c.ScalingListData.ScalingListDcCoefMinus8[0][0]++
+				c.ScalingListData.ScalingList[0][0][0]++
+			}
+			for _, set := range c.ShortTermRefPicSets {
+				for i := range set.DeltaPocS0 {
+					set.DeltaPocS0[i]++
+				}
+			}
+			if c.VUI != nil {
+				c.VUI.VideoFormat++
+				if c.VUI.TimingInfo != nil {
+					c.VUI.TimingInfo.TimeScale++
+				}
+			}
+
+			require.Equal(t, ca.sps, orig)
 		})
 	}
 }
 
+func TestSPSFrameRate(t *testing.T) {
+	num, den, ok := SPS{}.FrameRate()
+	require.Equal(t, false, ok)
+	require.Equal(t, uint32(0), num)
+	require.Equal(t, uint32(0), den)
+
+	num, den, ok = SPS{VUI: &SPS_VUI{TimingInfo: &SPS_TimingInfo{
+		NumUnitsInTick: 100,
+		TimeScale:      3000,
+	}}}.FrameRate()
+	require.Equal(t, true, ok)
+	require.Equal(t, uint32(30), num)
+	require.Equal(t, uint32(1), den)
+}
+
+func TestSPSShortTermRefPicSetsInterPrediction(t *testing.T) {
+	buf := []byte{0x6b, 0x54, 0xf3}
+	pos := 0
+	sets := make([]*SPS_ShortTermRefPicSet, 2)
+
+	for i := range sets {
+		sets[i] = &SPS_ShortTermRefPicSet{}
+		err := sets[i].unmarshal(buf, &pos, uint32(i), uint32(len(sets)), sets)
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, []*SPS_ShortTermRefPicSet{
+		{
+			NumNegativePics:     2,
+			NumPositivePics:     1,
+			DeltaPocS0:          []int32{-1, -3},
+			UsedByCurrPicS0Flag: []bool{true, true},
+			DeltaPocS1:          []int32{2},
+			UsedByCurrPicS1Flag: []bool{false},
+		},
+		{
+			InterRefPicSetPredictionFlag: true,
+			DeltaRpsSign:                 true,
+			NumNegativePics:              2,
+			NumPositivePics:              1,
+			DeltaPocS0:                   []int32{-1, -2},
+			UsedByCurrPicS0Flag:          []bool{true, true},
+			DeltaPocS1:                   []int32{1},
+			UsedByCurrPicS1Flag:          []bool{true},
+		},
+	}, sets)
+	require.Equal(t, 24, pos)
+}
+
 func FuzzSPSUnmarshal(f *testing.F) {
 	for _, ca := range casesSPS {
 		f.Add(ca.byts)
@@ -0,0 +1,178 @@
+package h265
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/pkg/bits"
+	"github.com/bluenviron/mediacommon/pkg/codecs/h264"
+	"github.com/bluenviron/mediacommon/pkg/codecs/internal/h26x"
+)
+
+// VPS is a H265 video parameter set.
+// Specification: ITU-T Rec. H.265, 7.3.2.1
+type VPS struct {
+	ID                              uint8
+	BaseLayerInternalFlag           bool
+	BaseLayerAvailableFlag          bool
+	MaxLayersMinus1                 uint8
+	MaxSubLayersMinus1              uint8
+	TemporalIDNestingFlag           bool
+	ProfileTierLevel                ProfileTierLevel
+	SubLayerOrderingInfoPresentFlag bool
+	MaxDecPicBufferingMinus1        []uint32
+	MaxNumReorderPics               []uint32
+	MaxLatencyIncreasePlus1         []uint32
+	MaxLayerID                      uint8
+	NumLayerSetsMinus1              uint32
+	LayerIDIncludedFlag             [][]bool
+	TimingInfo                      *SPS_TimingInfo
+}
+
+// Unmarshal decodes a VPS.
+func (v *VPS) Unmarshal(buf []byte) error {
+	if len(buf) < 2 {
+		return fmt.Errorf("not enough bits")
+	}
+
+	if NALUType((buf[0]>>1)&0b111111) != NALUType_VPS_NUT {
+		return fmt.Errorf("not a VPS")
+	}
+
+	buf = h264.EmulationPreventionRemove(buf[1:])
+	pos := 8
+
+	err := bits.HasSpace(buf, pos, 32)
+	if err != nil {
+		return err
+	}
+
+	v.ID = uint8(bits.ReadBitsUnsafe(buf, &pos, 4))
+	v.BaseLayerInternalFlag = bits.ReadFlagUnsafe(buf, &pos)
+	v.BaseLayerAvailableFlag = bits.ReadFlagUnsafe(buf, &pos)
+	v.MaxLayersMinus1 = uint8(bits.ReadBitsUnsafe(buf, &pos, 6))
+	v.MaxSubLayersMinus1 = uint8(bits.ReadBitsUnsafe(buf, &pos, 3))
+	v.TemporalIDNestingFlag = bits.ReadFlagUnsafe(buf, &pos)
+	pos += 16 // vps_reserved_0xffff_16bits
+
+	err = v.ProfileTierLevel.unmarshal(buf, &pos, v.MaxSubLayersMinus1)
+	if err != nil {
+		return err
+	}
+
+	v.SubLayerOrderingInfoPresentFlag, err = bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	var start uint8
+	if v.SubLayerOrderingInfoPresentFlag {
+		start = 0
+	} else {
+		start = v.MaxSubLayersMinus1
+	}
+
+	v.MaxDecPicBufferingMinus1 = make([]uint32, v.MaxSubLayersMinus1+1)
+	v.MaxNumReorderPics = make([]uint32, v.MaxSubLayersMinus1+1)
+	v.MaxLatencyIncreasePlus1 = make([]uint32, v.MaxSubLayersMinus1+1)
+
+	for i := start; i <= v.MaxSubLayersMinus1; i++ {
+		v.MaxDecPicBufferingMinus1[i], err = bits.ReadGolombUnsigned(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		v.MaxNumReorderPics[i], err = bits.ReadGolombUnsigned(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		v.MaxLatencyIncreasePlus1[i], err = bits.ReadGolombUnsigned(buf, &pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	tmp, err := bits.ReadBits(buf, &pos, 6)
+	if err != nil {
+		return err
+	}
+	v.MaxLayerID = uint8(tmp)
+
+	v.NumLayerSetsMinus1, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	// vps_num_layer_sets_minus1 is in range 0..1023
+	if v.NumLayerSetsMinus1 > 1023 {
+		return fmt.Errorf("invalid vps_num_layer_sets_minus1")
+	}
+
+	err = bits.HasSpace(buf, pos, int(v.NumLayerSetsMinus1)*(int(v.MaxLayerID)+1))
+	if err != nil {
+		return err
+	}
+
+	v.LayerIDIncludedFlag = nil
+
+	for i := uint32(1); i <= v.NumLayerSetsMinus1; i++ {
+		flags := make([]bool, v.MaxLayerID+1)
+		for j := range flags {
+			flags[j] = bits.ReadFlagUnsafe(buf, &pos)
+		}
+		v.LayerIDIncludedFlag = append(v.LayerIDIncludedFlag, flags)
+	}
+
+	timingInfoPresentFlag, err := bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if timingInfoPresentFlag {
+		v.TimingInfo = &SPS_TimingInfo{}
+		err = v.TimingInfo.unmarshal(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		v.TimingInfo = nil
+	}
+
+	// HRD parameters and extensions are not parsed
+
+	return nil
+}
+
+// Clone returns a copy of the VPS that doesn't share memory with the original.
+func (v VPS) Clone() VPS {
+	v.ProfileTierLevel = v.ProfileTierLevel.Clone()
+	v.MaxDecPicBufferingMinus1 = cloneUint32s(v.MaxDecPicBufferingMinus1)
+	v.MaxNumReorderPics = cloneUint32s(v.MaxNumReorderPics)
+	v.MaxLatencyIncreasePlus1 = cloneUint32s(v.MaxLatencyIncreasePlus1)
+
+	if v.LayerIDIncludedFlag != nil {
+		flags := make([][]bool, len(v.LayerIDIncludedFlag))
+		for i, f := range v.LayerIDIncludedFlag {
+			flags[i] = cloneBools(f)
+		}
+		v.LayerIDIncludedFlag = flags
+	}
+
+	if v.TimingInfo != nil {
+		ti := *v.TimingInfo
+		v.TimingInfo = &ti
+	}
+
+	return v
+}
+
+// FrameRate returns the frame rate of the video as a fraction, that is
+// vps_time_scale / vps_num_units_in_tick, reduced to lowest terms.
+// It returns false when timing info is not present.
+func (v VPS) FrameRate() (uint32, uint32, bool) {
+	if v.TimingInfo == nil {
+		return 0, 0, false
+	}
+
+	return h26x.ReduceFrameRate(uint64(v.TimingInfo.TimeScale), uint64(v.TimingInfo.NumUnitsInTick))
+}
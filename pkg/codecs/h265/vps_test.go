@@ -0,0 +1,149 @@
+package h265
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesVPS = []struct {
+	name string
+	byts []byte
+	vps  VPS
+}{
+	{
+		"standard",
+		[]byte{
+			0x40, 0x01, 0x0c, 0x01, 0xff, 0xff, 0x01, 0x60,
+			0x00, 0x00, 0x03, 0x00, 0x90, 0x00, 0x00, 0x03,
+			0x00, 0x00, 0x03, 0x00, 0x78, 0x99, 0x98, 0x09,
+		},
+		VPS{
+			BaseLayerInternalFlag:  true,
+			BaseLayerAvailableFlag: true,
+			TemporalIDNestingFlag:  true,
+			ProfileTierLevel: ProfileTierLevel{
+				GeneralProfileIdc: 1,
+				GeneralProfileCompatibilityFlag: [32]bool{
+					false, true, true, false, false, false, false, false,
+					false, false, false, false, false, false, false, false,
+					false, false, false, false, false, false, false, false,
+					false, false, false, false, false, false, false, false,
+				},
+				GeneralProgressiveSourceFlag:   true,
+				GeneralFrameOnlyConstraintFlag: true,
+				GeneralLevelIdc:                120,
+			},
+			SubLayerOrderingInfoPresentFlag: true,
+			MaxDecPicBufferingMinus1:        []uint32{5},
+			MaxNumReorderPics:               []uint32{2},
+			MaxLatencyIncreasePlus1:         []uint32{5},
+		},
+	},
+	{
+		"layer sets and timing info",
+		[]byte{
+			0x40, 0x01, 0x1c, 0x01, 0xff, 0xff, 0x01, 0x60,
+			0x00, 0x00, 0x03, 0x00, 0x90, 0x00, 0x00, 0x03,
+			0x00, 0x00, 0x03, 0x00, 0x5d, 0x15, 0x41, 0x54,
+			0x00, 0x00, 0x0f, 0xa4, 0x00, 0x03, 0xa9, 0x83,
+			0xa0,
+		},
+		VPS{
+			ID:                     1,
+			BaseLayerInternalFlag:  true,
+			BaseLayerAvailableFlag: true,
+			TemporalIDNestingFlag:  true,
+			ProfileTierLevel: ProfileTierLevel{
+				GeneralProfileIdc: 1,
+				GeneralProfileCompatibilityFlag: [32]bool{
+					false, true, true, false, false, false, false, false,
+					false, false, false, false, false, false, false, false,
+					false, false, false, false, false, false, false, false,
+					false, false, false, false, false, false, false, false,
+				},
+				GeneralProgressiveSourceFlag:   true,
+				GeneralFrameOnlyConstraintFlag: true,
+				GeneralLevelIdc:                93,
+			},
+			MaxDecPicBufferingMinus1: []uint32{4},
+			MaxNumReorderPics:        []uint32{1},
+			MaxLatencyIncreasePlus1:  []uint32{0},
+			MaxLayerID:               1,
+			NumLayerSetsMinus1:       1,
+			LayerIDIncludedFlag:      [][]bool{{true, false}},
+			TimingInfo: &SPS_TimingInfo{
+				NumUnitsInTick:              1001,
+				TimeScale:                   60000,
+				POCProportionalToTimingFlag: true,
+			},
+		},
+	},
+}
+
+func TestVPSUnmarshal(t *testing.T) {
+	for _, ca := range casesVPS {
+		t.Run(ca.name, func(t *testing.T) {
+			var vps VPS
+			err := vps.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.vps, vps)
+		})
+	}
+}
+
+func TestVPSUnmarshalHRDParameters(t *testing.T) {
+	byts := append([]byte(nil), casesVPS[1].byts...)
+	byts[len(byts)-1] = 0x40 // vps_num_hrd_parameters = 1
+
+	var vps VPS
+	err := vps.Unmarshal(byts)
+	require.NoError(t, err)
+	require.Equal(t, casesVPS[1].vps, vps)
+}
+
+func TestVPSFrameRate(t *testing.T) {
+	num, den, ok := casesVPS[len(casesVPS)-1].vps.FrameRate()
+	require.Equal(t, true, ok)
+	require.Equal(t, uint32(60000), num)
+	require.Equal(t, uint32(1001), den)
+
+	_, _, ok = VPS{}.FrameRate()
+	require.Equal(t, false, ok)
+}
+
+func TestVPSClone(t *testing.T) {
+	for _, ca := range casesVPS {
+		t.Run(ca.name, func(t *testing.T) {
+			var orig VPS
+			err := orig.Unmarshal(ca.byts)
+			require.NoError(t, err)
+
+			c := orig.Clone()
+			require.Equal(t, orig, c)
+
+			c.MaxDecPicBufferingMinus1[0]++
+			for _, flags := range c.LayerIDIncludedFlag {
+				for i := range flags {
+					flags[i] = !flags[i]
+				}
+			}
+			if c.TimingInfo != nil {
+				c.TimingInfo.TimeScale++
+			}
+
+			require.Equal(t, ca.vps, orig)
+		})
+	}
+}
+
+func FuzzVPSUnmarshal(f *testing.F) {
+	for _, ca := range casesVPS {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var vps VPS
+		vps.Unmarshal(b) //nolint:errcheck
+	})
+}
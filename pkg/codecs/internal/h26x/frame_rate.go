@@ -0,0 +1,26 @@
+package h26x
+
+import (
+	"math"
+)
+
+// ReduceFrameRate reduces a frame rate expressed as a fraction to lowest terms.
+// It returns false when the fraction is invalid or doesn't fit into 32 bits.
+func ReduceFrameRate(num uint64, den uint64) (uint32, uint32, bool) {
+	if num == 0 || den == 0 {
+		return 0, 0, false
+	}
+
+	a, b := num, den
+	for b != 0 {
+		a, b = b, a%b
+	}
+	num /= a
+	den /= a
+
+	if num > math.MaxUint32 || den > math.MaxUint32 {
+		return 0, 0, false
+	}
+
+	return uint32(num), uint32(den), true
+}
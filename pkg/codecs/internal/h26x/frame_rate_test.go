@@ -0,0 +1,32 @@
+package h26x
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReduceFrameRate(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		num  uint64
+		den  uint64
+		rNum uint32
+		rDen uint32
+		ok   bool
+	}{
+		{"integer", 60, 2, 30, 1, true},
+		{"ntsc", 60000, 2002, 30000, 1001, true},
+		{"zero numerator", 0, 2, 0, 0, false},
+		{"zero denominator", 60, 0, 0, 0, false},
+		{"overflow", math.MaxUint32 + 1, 3, 0, 0, false},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			num, den, ok := ReduceFrameRate(ca.num, ca.den)
+			require.Equal(t, ca.ok, ok)
+			require.Equal(t, ca.rNum, num)
+			require.Equal(t, ca.rDen, den)
+		})
+	}
+}
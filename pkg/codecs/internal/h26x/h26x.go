@@ -0,0 +1,2 @@
+// Package h26x contains utilities shared by the H264 and H265 codecs.
+package h26x
@@ -0,0 +1,72 @@
+package h26x
+
+import (
+	"fmt"
+)
+
+// ReadSEIValue reads a payloadType or payloadSize value of a SEI message,
+// that is coded as a sequence of 0xFF bytes followed by a last byte.
+// It returns the value and the number of bytes read.
+func ReadSEIValue(buf []byte) (uint32, int, error) {
+	v := uint32(0)
+	n := 0
+
+	for {
+		if len(buf) <= n {
+			return 0, 0, fmt.Errorf("not enough bytes")
+		}
+
+		b := buf[n]
+		n++
+		v += uint32(b)
+
+		if b != 0xFF {
+			break
+		}
+	}
+
+	return v, n, nil
+}
+
+// UnmarshalSEIMessages decodes the sei_message() structures of a SEI RBSP,
+// until rbsp_trailing_bits(), and calls onMessage with the type and payload of each message.
+// Specification: ITU-T Rec. H.264, 7.3.2.3.1 and ITU-T Rec. H.265, 7.3.5
+func UnmarshalSEIMessages(buf []byte, onMessage func(payloadType uint32, payload []byte) error) error {
+	for {
+		// rbsp_trailing_bits()
+		if len(buf) == 0 || (len(buf) == 1 && buf[0] == 0x80) {
+			return nil
+		}
+
+		payloadType, n, err := ReadSEIValue(buf)
+		if err != nil {
+			return err
+		}
+		buf = buf[n:]
+
+		payloadSize, n, err := ReadSEIValue(buf)
+		if err != nil {
+			return err
+		}
+		buf = buf[n:]
+
+		if uint32(len(buf)) < payloadSize {
+			return fmt.Errorf("not enough bytes")
+		}
+
+		err = onMessage(payloadType, buf[:payloadSize])
+		if err != nil {
+			return err
+		}
+
+		buf = buf[payloadSize:]
+	}
+}
+
+// CloneSEIPayload returns a copy of the payload of a SEI message.
+func CloneSEIPayload(payload []byte) []byte {
+	if payload == nil {
+		return nil
+	}
+	return append(make([]byte, 0, len(payload)), payload...)
+}
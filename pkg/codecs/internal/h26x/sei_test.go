@@ -0,0 +1,71 @@
+package h26x
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadSEIValue(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		buf  []byte
+		v    uint32
+		n    int
+	}{
+		{"single byte", []byte{0x05, 0x01}, 5, 1},
+		{"extended", []byte{0xff, 0xff, 0x02}, 512, 3},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			v, n, err := ReadSEIValue(ca.buf)
+			require.NoError(t, err)
+			require.Equal(t, ca.v, v)
+			require.Equal(t, ca.n, n)
+		})
+	}
+
+	_, _, err := ReadSEIValue([]byte{0xff})
+	require.EqualError(t, err, "not enough bytes")
+}
+
+func TestUnmarshalSEIMessages(t *testing.T) {
+	type message struct {
+		typ     uint32
+		payload []byte
+	}
+
+	var msgs []message
+	err := UnmarshalSEIMessages([]byte{
+		0x06, 0x01, 0xc0,
+		0xff, 0x05, 0x02, 0x01, 0x02,
+		0x80,
+	}, func(payloadType uint32, payload []byte) error {
+		msgs = append(msgs, message{payloadType, payload})
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []message{
+		{6, []byte{0xc0}},
+		{260, []byte{0x01, 0x02}},
+	}, msgs)
+
+	err = UnmarshalSEIMessages([]byte{0x06, 0x02, 0xc0}, func(uint32, []byte) error {
+		return nil
+	})
+	require.EqualError(t, err, "not enough bytes")
+
+	err = UnmarshalSEIMessages([]byte{0x06, 0x01, 0xc0}, func(uint32, []byte) error {
+		return fmt.Errorf("invalid message")
+	})
+	require.EqualError(t, err, "invalid message")
+}
+
+func TestCloneSEIPayload(t *testing.T) {
+	require.Nil(t, CloneSEIPayload(nil))
+
+	orig := []byte{1, 2, 3}
+	c := CloneSEIPayload(orig)
+	c[0]++
+	require.Equal(t, []byte{1, 2, 3}, orig)
+}
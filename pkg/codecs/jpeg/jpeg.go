@@ -8,6 +8,8 @@ const (
 	MarkerDefineHuffmanTable      = 0xC4
 	MarkerDefineRestartInterval   = 0xDD
 	MarkerStartOfFrame1           = 0xC0
+	MarkerStartOfFrameExtended    = 0xC1
+	MarkerStartOfFrameProgressive = 0xC2
 	MarkerStartOfScan             = 0xDA
 	MarkerEndOfImage              = 0xD9
 	MarkerComment                 = 0xFE
@@ -0,0 +1,132 @@
+package jpeg
+
+import (
+	"fmt"
+)
+
+// StartOfFrame_Component is a component of a StartOfFrame.
+type StartOfFrame_Component struct { //nolint:revive
+	ID                        uint8
+	HorizontalSamplingFactor  uint8
+	VerticalSamplingFactor    uint8
+	QuantizationTableSelector uint8
+}
+
+// StartOfFrame is a generic SOFn marker.
+// Unlike StartOfFrame1, it supports any precision, component count and sampling factor.
+// Specification: ITU T.81, B.2.2
+type StartOfFrame struct {
+	// marker of the frame, that identifies the coding process.
+	Marker     uint8
+	Precision  uint8
+	Height     int
+	Width      int
+	Components []StartOfFrame_Component
+}
+
+// Unmarshal decodes the marker.
+// The buffer must not contain the marker and the length.
+func (m *StartOfFrame) Unmarshal(buf []byte) error {
+	if len(buf) < 6 {
+		return fmt.Errorf("unsupported SOF size of %d", len(buf))
+	}
+
+	m.Precision = buf[0]
+	m.Height = int(buf[1])<<8 | int(buf[2])
+	m.Width = int(buf[3])<<8 | int(buf[4])
+
+	componentCount := int(buf[5])
+	if componentCount == 0 {
+		return fmt.Errorf("number of components = %d is not supported", componentCount)
+	}
+
+	if len(buf) != (6 + componentCount*3) {
+		return fmt.Errorf("unsupported SOF size of %d", len(buf))
+	}
+
+	m.Components = make([]StartOfFrame_Component, componentCount)
+
+	for i := range m.Components {
+		b := buf[6+i*3:]
+		m.Components[i] = StartOfFrame_Component{
+			ID:                        b[0],
+			HorizontalSamplingFactor:  b[1] >> 4,
+			VerticalSamplingFactor:    b[1] & 0x0F,
+			QuantizationTableSelector: b[2],
+		}
+	}
+
+	return nil
+}
+
+func isStartOfFrame(marker uint8) bool {
+	switch marker {
+	case MarkerStartOfFrame1, MarkerStartOfFrameExtended, MarkerStartOfFrameProgressive:
+		return true
+	}
+	return false
+}
+
+// FindStartOfFrame scans the markers of a JPEG image and returns its SOF marker.
+// Supported SOF markers are baseline (SOF0), extended (SOF1) and progressive (SOF2).
+func FindStartOfFrame(buf []byte) (*StartOfFrame, error) {
+	if len(buf) < 2 || buf[0] != 0xFF || buf[1] != MarkerStartOfImage {
+		return nil, fmt.Errorf("SOI not found")
+	}
+
+	pos := 2
+
+	for {
+		if pos >= len(buf) {
+			return nil, fmt.Errorf("SOF not found")
+		}
+
+		if buf[pos] != 0xFF {
+			return nil, fmt.Errorf("invalid marker")
+		}
+
+		// markers can be preceded by fill bytes
+		for pos < len(buf) && buf[pos] == 0xFF {
+			pos++
+		}
+
+		if pos >= len(buf) {
+			return nil, fmt.Errorf("SOF not found")
+		}
+
+		marker := buf[pos]
+		pos++
+
+		switch {
+		case marker == MarkerStartOfScan || marker == MarkerEndOfImage:
+			return nil, fmt.Errorf("SOF not found")
+
+		case marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7):
+			// TEM and RSTn don't have a payload
+			continue
+		}
+
+		if (pos + 2) > len(buf) {
+			return nil, fmt.Errorf("buffer is too short")
+		}
+
+		le := int(buf[pos])<<8 | int(buf[pos+1])
+		if le < 2 || (pos+le) > len(buf) {
+			return nil, fmt.Errorf("invalid marker length (%d)", le)
+		}
+
+		if isStartOfFrame(marker) {
+			sof := &StartOfFrame{
+				Marker: marker,
+			}
+			err := sof.Unmarshal(buf[pos+2 : pos+le])
+			if err != nil {
+				return nil, err
+			}
+			return sof, nil
+		}
+
+		// skip APPn, COM, DQT, DHT, DRI and other markers
+		pos += le
+	}
+}
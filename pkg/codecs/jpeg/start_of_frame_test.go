@@ -0,0 +1,151 @@
+package jpeg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesStartOfFrame = []struct {
+	name string
+	enc  []byte
+	dec  StartOfFrame
+}{
+	{
+		"baseline",
+		[]byte{
+			0xff, 0xd8, 0xff, 0xc0, 0x00, 0x11, 0x08, 0x02,
+			0x58, 0x03, 0x20, 0x03, 0x00, 0x22, 0x00, 0x01,
+			0x11, 0x01, 0x02, 0x11, 0x01,
+		},
+		StartOfFrame{
+			Marker:    MarkerStartOfFrame1,
+			Precision: 8,
+			Width:     800,
+			Height:    600,
+			Components: []StartOfFrame_Component{
+				{
+					ID:                       0,
+					HorizontalSamplingFactor: 2,
+					VerticalSamplingFactor:   2,
+				},
+				{
+					ID:                        1,
+					HorizontalSamplingFactor:  1,
+					VerticalSamplingFactor:    1,
+					QuantizationTableSelector: 1,
+				},
+				{
+					ID:                        2,
+					HorizontalSamplingFactor:  1,
+					VerticalSamplingFactor:    1,
+					QuantizationTableSelector: 1,
+				},
+			},
+		},
+	},
+	{
+		"progressive after app0 and com",
+		[]byte{
+			0xff, 0xd8, 0xff, 0xe0, 0x00, 0x10, 0x4a, 0x46,
+			0x49, 0x46, 0x00, 0x01, 0x01, 0x00, 0x00, 0x01,
+			0x00, 0x01, 0x00, 0x00, 0xff, 0xfe, 0x00, 0x05,
+			0x61, 0x62, 0x63, 0xff, 0xff, 0xc2, 0x00, 0x11,
+			0x08, 0x00, 0xf0, 0x01, 0x40, 0x03, 0x01, 0x21,
+			0x00, 0x02, 0x11, 0x01, 0x03, 0x11, 0x01, 0xff,
+			0xda,
+		},
+		StartOfFrame{
+			Marker:    MarkerStartOfFrameProgressive,
+			Precision: 8,
+			Width:     320,
+			Height:    240,
+			Components: []StartOfFrame_Component{
+				{
+					ID:                       1,
+					HorizontalSamplingFactor: 2,
+					VerticalSamplingFactor:   1,
+				},
+				{
+					ID:                        2,
+					HorizontalSamplingFactor:  1,
+					VerticalSamplingFactor:    1,
+					QuantizationTableSelector: 1,
+				},
+				{
+					ID:                        3,
+					HorizontalSamplingFactor:  1,
+					VerticalSamplingFactor:    1,
+					QuantizationTableSelector: 1,
+				},
+			},
+		},
+	},
+	{
+		"grayscale 12 bit",
+		[]byte{
+			0xff, 0xd8, 0xff, 0xc1, 0x00, 0x0b, 0x0c, 0x00,
+			0x10, 0x00, 0x20, 0x01, 0x01, 0x11, 0x00,
+		},
+		StartOfFrame{
+			Marker:    MarkerStartOfFrameExtended,
+			Precision: 12,
+			Width:     32,
+			Height:    16,
+			Components: []StartOfFrame_Component{{
+				ID:                       1,
+				HorizontalSamplingFactor: 1,
+				VerticalSamplingFactor:   1,
+			}},
+		},
+	},
+}
+
+func TestFindStartOfFrame(t *testing.T) {
+	for _, ca := range casesStartOfFrame {
+		t.Run(ca.name, func(t *testing.T) {
+			sof, err := FindStartOfFrame(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, &ca.dec, sof)
+		})
+	}
+}
+
+func TestFindStartOfFrameError(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		enc  []byte
+		err  string
+	}{
+		{
+			"missing soi",
+			[]byte{0xff, 0xc0, 0x00, 0x11},
+			"SOI not found",
+		},
+		{
+			"sos before sof",
+			[]byte{0xff, 0xd8, 0xff, 0xda, 0x00, 0x02},
+			"SOF not found",
+		},
+		{
+			"invalid length",
+			[]byte{0xff, 0xd8, 0xff, 0xe0, 0x00, 0x10, 0x4a},
+			"invalid marker length (16)",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			_, err := FindStartOfFrame(ca.enc)
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func FuzzFindStartOfFrame(f *testing.F) {
+	for _, ca := range casesStartOfFrame {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		FindStartOfFrame(b) //nolint:errcheck
+	})
+}
@@ -1,15 +1,35 @@
 package mpeg1audio
 
 import (
+	"errors"
 	"fmt"
 )
 
+// ErrFreeFormat is returned when a frame header uses the free format,
+// that is not associated with a bitrate (bitrate_index = 0).
+var ErrFreeFormat = errors.New("free format is not supported")
+
 // http://www.mp3-tech.org/programmer/frame_header.html
 var bitrates = [][][]int{
 	// MPEG-1
 	{
 		// layer 1
-		{},
+		{
+			32000,
+			64000,
+			96000,
+			128000,
+			160000,
+			192000,
+			224000,
+			256000,
+			288000,
+			320000,
+			352000,
+			384000,
+			416000,
+			448000,
+		},
 		// layer 2
 		{
 			32000,
@@ -48,7 +68,22 @@ var bitrates = [][][]int{
 	// MPEG-2
 	{
 		// layer 1
-		{},
+		{
+			32000,
+			48000,
+			56000,
+			64000,
+			80000,
+			96000,
+			112000,
+			128000,
+			144000,
+			160000,
+			176000,
+			192000,
+			224000,
+			256000,
+		},
 		// layer 2
 		{
 			8000,
@@ -159,12 +194,15 @@ func (h *FrameHeader) Unmarshal(buf []byte) error {
 	}
 
 	h.Layer = 4 - ((buf[1] >> 1) & 0b11)
-	if h.Layer <= 1 || h.Layer >= 4 {
+	if h.Layer >= 4 {
 		return fmt.Errorf("unsupported MPEG layer: %v", h.Layer)
 	}
 
 	bitrateIndex := (buf[2] >> 4)
-	if bitrateIndex == 0 || bitrateIndex >= 15 {
+	if bitrateIndex == 0 {
+		return ErrFreeFormat
+	}
+	if bitrateIndex >= 15 {
 		return fmt.Errorf("invalid bitrate")
 	}
 	h.Bitrate = bitrates[mpegIndex][h.Layer-1][bitrateIndex-1]
@@ -182,11 +220,25 @@ func (h *FrameHeader) Unmarshal(buf []byte) error {
 }
 
 // FrameLen returns the length of the frame associated with the header.
+// Specification: ISO 11172-3, 2.4.3.1
+// Specification: ISO 13818-3, 2.4.3.1
 func (h FrameHeader) FrameLen() int {
+	var padding int
 	if h.Padding {
-		return (144 * h.Bitrate / h.SampleRate) + 1
+		padding = 1
+	}
+
+	switch {
+	case h.Layer == 1:
+		// slots are 4 bytes long
+		return (12*h.Bitrate/h.SampleRate + padding) * 4
+
+	case h.Layer == 3 && h.MPEG2:
+		return 72*h.Bitrate/h.SampleRate + padding
+
+	default:
+		return 144*h.Bitrate/h.SampleRate + padding
 	}
-	return (144 * h.Bitrate / h.SampleRate)
 }
 
 // SampleCount returns the number of samples contained into the frame.
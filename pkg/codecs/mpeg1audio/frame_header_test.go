@@ -99,6 +99,36 @@ var casesFrameHeader = []struct {
 		576,
 		1152,
 	},
+	{
+		"mpeg-1 layer 1 44.1k",
+		[]byte{
+			0xff, 0xff, 0xc0, 0x00, 0x00,
+		},
+		FrameHeader{
+			Layer:       1,
+			Bitrate:     384000,
+			SampleRate:  44100,
+			ChannelMode: ChannelModeStereo,
+		},
+		416,
+		384,
+	},
+	{
+		"mpeg-2 layer 3 24k",
+		[]byte{
+			0xff, 0xf3, 0x86, 0x40, 0x00,
+		},
+		FrameHeader{
+			MPEG2:       true,
+			Layer:       3,
+			Bitrate:     64000,
+			SampleRate:  24000,
+			Padding:     true,
+			ChannelMode: ChannelModeJointStereo,
+		},
+		193,
+		576,
+	},
 }
 
 func TestFrameHeaderUnmarshal(t *testing.T) {
@@ -114,6 +144,12 @@ func TestFrameHeaderUnmarshal(t *testing.T) {
 	}
 }
 
+func TestFrameHeaderUnmarshalFreeFormat(t *testing.T) {
+	var h FrameHeader
+	err := h.Unmarshal([]byte{0xff, 0xfb, 0x04, 0x64, 0x00})
+	require.ErrorIs(t, err, ErrFreeFormat)
+}
+
 func FuzzFrameHeaderUnmarshal(f *testing.F) {
 	for _, ca := range casesFrameHeader {
 		f.Add(ca.enc)
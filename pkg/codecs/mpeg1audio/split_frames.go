@@ -0,0 +1,28 @@
+package mpeg1audio
+
+import (
+	"fmt"
+)
+
+// SplitFrames splits a sequence of MPEG-1/2 audio frames into individual frames.
+func SplitFrames(buf []byte) ([][]byte, error) {
+	var frames [][]byte
+
+	for len(buf) > 0 {
+		var h FrameHeader
+		err := h.Unmarshal(buf)
+		if err != nil {
+			return nil, err
+		}
+
+		fl := h.FrameLen()
+		if len(buf) < fl {
+			return nil, fmt.Errorf("buffer is too short")
+		}
+
+		frames = append(frames, buf[:fl])
+		buf = buf[fl:]
+	}
+
+	return frames, nil
+}
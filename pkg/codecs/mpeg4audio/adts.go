@@ -1,23 +1,152 @@
 package mpeg4audio
 
 import (
+	"errors"
 	"fmt"
 )
 
+var (
+	// ErrADTSFrameTooBig is returned when the length of an ADTS frame
+	// exceeds ADTSUnmarshalOptions.MaxFrameSize.
+	// In a stream, it usually means that a false syncword has been found.
+	ErrADTSFrameTooBig = errors.New("ADTS frame is too big")
+
+	// ErrADTSFrameTruncated is returned when the length of an ADTS frame
+	// exceeds the available data.
+	ErrADTSFrameTruncated = errors.New("ADTS frame is truncated")
+)
+
 // ADTSPacket is an ADTS packet.
 // Specification: ISO 14496-3, Table 1.A.5
 type ADTSPacket struct {
 	Type         ObjectType
 	SampleRate   int
 	ChannelCount int
-	AU           []byte
+
+	// whether the packet is protected by a CRC (protection_absent = 0).
+	CRCPresent bool
+
+	AU []byte
+
+	// access units of packets that contain multiple raw data blocks.
+	// When set, it is used in place of AU.
+	// Packets with multiple raw data blocks must be protected by a CRC.
+	// When it contains a single access unit, the packet is encoded as if AU was used.
+	AUs [][]byte
 }
 
 // ADTSPackets is a group of ADTS packets.
 type ADTSPackets []*ADTSPacket
 
+// ADTSUnmarshalOptions contains options of ADTSPackets.UnmarshalWithOptions.
+type ADTSUnmarshalOptions struct {
+	// verify the CRC of packets that carry one.
+	VerifyCRC bool
+
+	// maximum value of aac_frame_length, that includes the header.
+	// Frames that are bigger are rejected with ErrADTSFrameTooBig.
+	// It defaults to no limit besides the size of the buffer.
+	MaxFrameSize int
+}
+
+// Specification: ISO 11172-3, 2.4.3.1
+func adtsCRCUpdate(crc uint16, buf []byte) uint16 {
+	for _, b := range buf {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if (crc & 0x8000) != 0 {
+				crc = (crc << 1) ^ 0x8005
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// adtsRawDataBlockCRCUpdate updates a CRC with the first 192 bits of a raw data block,
+// that is the region protected in single channel and channel pair elements.
+// Shorter raw data blocks are padded with zeroes.
+func adtsRawDataBlockCRCUpdate(crc uint16, au []byte) uint16 {
+	var region [192 / 8]byte
+	copy(region[:], au)
+	return adtsCRCUpdate(crc, region[:])
+}
+
+// AccessUnits returns the access units contained in the packet.
+func (p ADTSPacket) AccessUnits() [][]byte {
+	if p.AUs != nil {
+		return p.AUs
+	}
+	return [][]byte{p.AU}
+}
+
+// AccessUnits returns the access units contained in all packets.
+func (ps ADTSPackets) AccessUnits() [][]byte {
+	aus := make([][]byte, 0, len(ps))
+	for _, p := range ps {
+		aus = append(aus, p.AccessUnits()...)
+	}
+	return aus
+}
+
+// ToAudioSpecificConfig returns the AudioSpecificConfig that describes the packet.
+func (p ADTSPacket) ToAudioSpecificConfig() AudioSpecificConfig {
+	return AudioSpecificConfig{
+		Type:         p.Type,
+		SampleRate:   p.SampleRate,
+		ChannelCount: p.ChannelCount,
+	}
+}
+
+// ToADTS returns an ADTS packet, without access units, whose header describes the config.
+// ADTS headers can't signal SBR and PS extensions: these are mapped to the base object type
+// and sample rate, and decoders are expected to detect them implicitly,
+// doubling the sample rate when they are present.
+func (c AudioSpecificConfig) ToADTS() (*ADTSPacket, error) {
+	switch c.Type {
+	case ObjectTypeAACMain, ObjectTypeAACLC, ObjectTypeAACSSR, ObjectTypeAACLTP:
+	default:
+		return nil, fmt.Errorf("object type %v can't be described by ADTS", c.Type)
+	}
+
+	if _, ok := reverseSampleRates[c.SampleRate]; !ok {
+		return nil, fmt.Errorf("sample rate %d can't be described by ADTS", c.SampleRate)
+	}
+
+	if c.ProgramConfigElement != nil {
+		return nil, fmt.Errorf("program config elements can't be described by ADTS")
+	}
+
+	switch {
+	case c.ChannelCount >= 1 && c.ChannelCount <= 6:
+	case c.ChannelCount == 8:
+	default:
+		return nil, fmt.Errorf("invalid channel count (%d)", c.ChannelCount)
+	}
+
+	if c.FrameLengthFlag {
+		return nil, fmt.Errorf("frame length of 960 samples can't be described by ADTS")
+	}
+
+	if c.DependsOnCoreCoder {
+		return nil, fmt.Errorf("core coders can't be described by ADTS")
+	}
+
+	return &ADTSPacket{
+		Type:         c.Type,
+		SampleRate:   c.SampleRate,
+		ChannelCount: c.ChannelCount,
+	}, nil
+}
+
 // Unmarshal decodes an ADTS stream into ADTS packets.
 func (ps *ADTSPackets) Unmarshal(buf []byte) error {
+	return ps.UnmarshalWithOptions(buf, ADTSUnmarshalOptions{})
+}
+
+// UnmarshalWithOptions decodes an ADTS stream into ADTS packets.
+func (ps *ADTSPackets) UnmarshalWithOptions(buf []byte, opts ADTSUnmarshalOptions) error {
 	// refs: https://wiki.multimedia.cx/index.php/ADTS
 
 	bl := len(buf)
@@ -33,19 +162,14 @@ func (ps *ADTSPackets) Unmarshal(buf []byte) error {
 			return fmt.Errorf("invalid syncword")
 		}
 
-		protectionAbsent := buf[pos+1] & 0x01
-		if protectionAbsent != 1 {
-			return fmt.Errorf("CRC is not supported")
-		}
-
 		pkt := &ADTSPacket{}
 
+		protectionAbsent := buf[pos+1] & 0x01
+		pkt.CRCPresent = (protectionAbsent == 0)
+
+		// profile is object type minus one, therefore it can describe
+		// AAC Main, AAC LC, AAC SSR and AAC LTP.
 		pkt.Type = ObjectType((buf[pos+2] >> 6) + 1)
-		switch pkt.Type {
-		case ObjectTypeAACLC:
-		default:
-			return fmt.Errorf("unsupported audio type: %d", pkt.Type)
-		}
 
 		sampleRateIndex := (buf[pos+2] >> 2) & 0x0F
 		switch {
@@ -68,29 +192,66 @@ func (ps *ADTSPackets) Unmarshal(buf []byte) error {
 			return fmt.Errorf("invalid channel configuration: %d", channelConfig)
 		}
 
-		frameLen := int(((uint16(buf[pos+3])&0x03)<<11)|
-			(uint16(buf[pos+4])<<3)|
-			((uint16(buf[pos+5])>>5)&0x07)) - 7
+		frameCount := int(buf[pos+6]&0x03) + 1
+		if frameCount != 1 && !pkt.CRCPresent {
+			return fmt.Errorf("multiple raw data blocks without CRC are not supported")
+		}
+
+		headerLen := 7
+		if pkt.CRCPresent {
+			// raw_data_block_position[], crc_check
+			headerLen += (frameCount-1)*2 + 2
+
+			if (bl - pos) < headerLen {
+				return fmt.Errorf("invalid length")
+			}
+		}
+
+		aacFrameLength := int(((uint16(buf[pos+3]) & 0x03) << 11) |
+			(uint16(buf[pos+4]) << 3) |
+			((uint16(buf[pos+5]) >> 5) & 0x07))
+
+		if opts.MaxFrameSize > 0 && aacFrameLength > opts.MaxFrameSize {
+			return fmt.Errorf("%w: frame length is %d, maximum is %d",
+				ErrADTSFrameTooBig, aacFrameLength, opts.MaxFrameSize)
+		}
+
+		frameLen := aacFrameLength - headerLen
 
 		if frameLen <= 0 {
 			return fmt.Errorf("invalid FrameLen")
 		}
 
-		if frameLen > MaxAccessUnitSize {
-			return fmt.Errorf("access unit size (%d) is too big, maximum is %d", frameLen, MaxAccessUnitSize)
+		if len(buf[pos+headerLen:]) < frameLen {
+			return fmt.Errorf("%w: frame length is %d, but %d bytes are available",
+				ErrADTSFrameTruncated, aacFrameLength, bl-pos)
 		}
 
-		frameCount := buf[pos+6] & 0x03
-		if frameCount != 0 {
-			return fmt.Errorf("frame count greater than 1 is not supported")
-		}
+		payload := buf[pos+headerLen : pos+headerLen+frameLen]
+
+		if frameCount == 1 {
+			if frameLen > MaxAccessUnitSize {
+				return fmt.Errorf("access unit size (%d) is too big, maximum is %d", frameLen, MaxAccessUnitSize)
+			}
+
+			pkt.AU = payload
 
-		if len(buf[pos+7:]) < frameLen {
-			return fmt.Errorf("invalid frame length")
+			if pkt.CRCPresent && opts.VerifyCRC {
+				crc := uint16(buf[pos+7])<<8 | uint16(buf[pos+8])
+				computed := adtsRawDataBlockCRCUpdate(adtsCRCUpdate(0xFFFF, buf[pos:pos+7]), pkt.AU)
+
+				if crc != computed {
+					return fmt.Errorf("CRC mismatch: expected %.4x, got %.4x", computed, crc)
+				}
+			}
+		} else {
+			err := pkt.unmarshalRawDataBlocks(buf[pos:pos+headerLen], payload, frameCount, opts)
+			if err != nil {
+				return err
+			}
 		}
 
-		pkt.AU = buf[pos+7 : pos+7+frameLen]
-		pos += 7 + frameLen
+		pos += headerLen + frameLen
 
 		*ps = append(*ps, pkt)
 
@@ -102,10 +263,93 @@ func (ps *ADTSPackets) Unmarshal(buf []byte) error {
 	return nil
 }
 
+func (p *ADTSPacket) unmarshalRawDataBlocks(
+	header []byte,
+	payload []byte,
+	frameCount int,
+	opts ADTSUnmarshalOptions,
+) error {
+	if opts.VerifyCRC {
+		crc := uint16(header[len(header)-2])<<8 | uint16(header[len(header)-1])
+		computed := adtsCRCUpdate(0xFFFF, header[:len(header)-2])
+
+		if crc != computed {
+			return fmt.Errorf("header CRC mismatch: expected %.4x, got %.4x", computed, crc)
+		}
+	}
+
+	// positions are relative to the first raw data block
+	positions := make([]int, frameCount+1)
+	for i := 1; i < frameCount; i++ {
+		positions[i] = int(uint16(header[7+(i-1)*2])<<8 | uint16(header[8+(i-1)*2]))
+	}
+	positions[frameCount] = len(payload)
+
+	p.AUs = make([][]byte, frameCount)
+
+	for i := 0; i < frameCount; i++ {
+		// each raw data block is followed by a CRC
+		end := positions[i+1] - 2
+
+		if end <= positions[i] || positions[i+1] > len(payload) {
+			return fmt.Errorf("invalid raw data block position")
+		}
+
+		au := payload[positions[i]:end]
+
+		if len(au) > MaxAccessUnitSize {
+			return fmt.Errorf("access unit size (%d) is too big, maximum is %d", len(au), MaxAccessUnitSize)
+		}
+
+		if opts.VerifyCRC {
+			crc := uint16(payload[end])<<8 | uint16(payload[end+1])
+			computed := adtsRawDataBlockCRCUpdate(0xFFFF, au)
+
+			if crc != computed {
+				return fmt.Errorf("raw data block CRC mismatch: expected %.4x, got %.4x", computed, crc)
+			}
+		}
+
+		p.AUs[i] = au
+	}
+
+	return nil
+}
+
+// hasRawDataBlocks checks whether the packet is encoded with the multiple raw data block syntax.
+// Packets whose AUs contains a single access unit use the single raw data block syntax.
+func (p ADTSPacket) hasRawDataBlocks() bool {
+	return p.AUs != nil && len(p.AUs) != 1
+}
+
+// singleAU returns the access unit of a packet with a single raw data block.
+func (p ADTSPacket) singleAU() []byte {
+	if len(p.AUs) == 1 {
+		return p.AUs[0]
+	}
+	return p.AU
+}
+
+func (p ADTSPacket) marshalSize() int {
+	if p.hasRawDataBlocks() {
+		n := 7 + len(p.AUs)*2
+		for _, au := range p.AUs {
+			n += len(au) + 2
+		}
+		return n
+	}
+
+	n := 7 + len(p.singleAU())
+	if p.CRCPresent {
+		n += 2
+	}
+	return n
+}
+
 func (ps ADTSPackets) marshalSize() int {
 	n := 0
 	for _, pkt := range ps {
-		n += 7 + len(pkt.AU)
+		n += pkt.marshalSize()
 	}
 	return n
 }
@@ -116,6 +360,12 @@ func (ps ADTSPackets) Marshal() ([]byte, error) {
 	pos := 0
 
 	for _, pkt := range ps {
+		switch pkt.Type {
+		case ObjectTypeAACMain, ObjectTypeAACLC, ObjectTypeAACSSR, ObjectTypeAACLTP:
+		default:
+			return nil, fmt.Errorf("unsupported audio type: %d", pkt.Type)
+		}
+
 		sampleRateIndex, ok := reverseSampleRates[pkt.SampleRate]
 		if !ok {
 			return nil, fmt.Errorf("invalid sample rate: %d", pkt.SampleRate)
@@ -133,20 +383,78 @@ func (ps ADTSPackets) Marshal() ([]byte, error) {
 			return nil, fmt.Errorf("invalid channel count (%d)", pkt.ChannelCount)
 		}
 
-		frameLen := len(pkt.AU) + 7
+		frameCount := 1
+		if pkt.hasRawDataBlocks() {
+			frameCount = len(pkt.AUs)
+
+			if frameCount < 1 || frameCount > 4 {
+				return nil, fmt.Errorf("invalid raw data block count (%d)", frameCount)
+			}
+
+			if !pkt.CRCPresent {
+				return nil, fmt.Errorf("multiple raw data blocks require a CRC")
+			}
+		}
+
+		headerLen := 7
+		if pkt.CRCPresent {
+			headerLen += (frameCount-1)*2 + 2
+		}
+
+		frameLen := pkt.marshalSize()
+		if frameLen > 0x1FFF {
+			return nil, fmt.Errorf("frame size (%d) is too big", frameLen)
+		}
 
 		fullness := 0x07FF // like ffmpeg does
 
-		buf[pos+0] = 0xFF
-		buf[pos+1] = 0xF1
-		buf[pos+2] = uint8((int(pkt.Type-1) << 6) | (sampleRateIndex << 2) | ((channelConfig >> 2) & 0x01))
-		buf[pos+3] = uint8((channelConfig&0x03)<<6 | (frameLen>>11)&0x03)
-		buf[pos+4] = uint8((frameLen >> 3) & 0xFF)
-		buf[pos+5] = uint8((frameLen&0x07)<<5 | ((fullness >> 6) & 0x1F))
-		buf[pos+6] = uint8((fullness & 0x3F) << 2)
-		pos += 7
+		header := buf[pos : pos+headerLen]
+
+		header[0] = 0xFF
+		if pkt.CRCPresent {
+			header[1] = 0xF0
+		} else {
+			header[1] = 0xF1
+		}
+		header[2] = uint8((int(pkt.Type-1) << 6) | (sampleRateIndex << 2) | ((channelConfig >> 2) & 0x01))
+		header[3] = uint8((channelConfig&0x03)<<6 | (frameLen>>11)&0x03)
+		header[4] = uint8((frameLen >> 3) & 0xFF)
+		header[5] = uint8((frameLen&0x07)<<5 | ((fullness >> 6) & 0x1F))
+		header[6] = uint8((fullness&0x3F)<<2 | (frameCount - 1))
+		pos += headerLen
+
+		if !pkt.hasRawDataBlocks() {
+			au := pkt.singleAU()
+
+			if pkt.CRCPresent {
+				crc := adtsRawDataBlockCRCUpdate(adtsCRCUpdate(0xFFFF, header[:7]), au)
+				header[7] = uint8(crc >> 8)
+				header[8] = uint8(crc)
+			}
+
+			pos += copy(buf[pos:], au)
+			continue
+		}
+
+		position := 0
+		for i, au := range pkt.AUs {
+			if i != 0 {
+				header[7+(i-1)*2] = uint8(position >> 8)
+				header[8+(i-1)*2] = uint8(position)
+			}
+			position += len(au) + 2
+
+			pos += copy(buf[pos:], au)
+
+			crc := adtsRawDataBlockCRCUpdate(0xFFFF, au)
+			buf[pos] = uint8(crc >> 8)
+			buf[pos+1] = uint8(crc)
+			pos += 2
+		}
 
-		pos += copy(buf[pos:], pkt.AU)
+		crc := adtsCRCUpdate(0xFFFF, header[:headerLen-2])
+		header[headerLen-2] = uint8(crc >> 8)
+		header[headerLen-1] = uint8(crc)
 	}
 
 	return buf, nil
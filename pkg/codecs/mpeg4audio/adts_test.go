@@ -45,6 +45,36 @@ var casesADTS = []struct {
 			},
 		},
 	},
+	{
+		"crc",
+		[]byte{0xff, 0xf0, 0x4c, 0x80, 0x1, 0x7f, 0xfc, 0x8e, 0x36, 0xaa, 0xbb},
+		ADTSPackets{
+			{
+				Type:         ObjectTypeAACLC,
+				SampleRate:   48000,
+				ChannelCount: 2,
+				CRCPresent:   true,
+				AU:           []byte{0xaa, 0xbb},
+			},
+		},
+	},
+	{
+		"multiple raw data blocks",
+		[]byte{
+			0xff, 0xf0, 0x4c, 0x80, 0x02, 0x9f, 0xfd, 0x00,
+			0x04, 0x38, 0x9c, 0xaa, 0xbb, 0xc5, 0xa6, 0xcc,
+			0xdd, 0xee, 0xe1, 0xc0,
+		},
+		ADTSPackets{
+			{
+				Type:         ObjectTypeAACLC,
+				SampleRate:   48000,
+				ChannelCount: 2,
+				CRCPresent:   true,
+				AUs:          [][]byte{{0xaa, 0xbb}, {0xcc, 0xdd, 0xee}},
+			},
+		},
+	},
 }
 
 func TestADTSUnmarshal(t *testing.T) {
@@ -58,6 +88,57 @@ func TestADTSUnmarshal(t *testing.T) {
 	}
 }
 
+func TestADTSAccessUnits(t *testing.T) {
+	require.Equal(t, [][]byte{{0xaa, 0xbb}, {0xcc, 0xdd}}, casesADTS[1].pkts.AccessUnits())
+	require.Equal(t, [][]byte{{0xaa, 0xbb}, {0xcc, 0xdd, 0xee}}, casesADTS[3].pkts.AccessUnits())
+}
+
+func TestADTSUnmarshalVerifyCRC(t *testing.T) {
+	for _, ca := range casesADTS {
+		t.Run(ca.name, func(t *testing.T) {
+			var pkts ADTSPackets
+			err := pkts.UnmarshalWithOptions(ca.byts, ADTSUnmarshalOptions{VerifyCRC: true})
+			require.NoError(t, err)
+			require.Equal(t, ca.pkts, pkts)
+		})
+	}
+
+	var pkts ADTSPackets
+	err := pkts.UnmarshalWithOptions(
+		[]byte{0xff, 0xf0, 0x4c, 0x80, 0x1, 0x7f, 0xfc, 0x8e, 0x36, 0xaa, 0xbc},
+		ADTSUnmarshalOptions{VerifyCRC: true})
+	require.EqualError(t, err, "CRC mismatch: expected ed04, got 8e36")
+
+	err = pkts.UnmarshalWithOptions([]byte{
+		0xff, 0xf0, 0x4c, 0x80, 0x02, 0x9f, 0xfd, 0x00,
+		0x04, 0x38, 0x9c, 0xaa, 0xbb, 0xc5, 0xa6, 0xcc,
+		0xdd, 0xef, 0xe1, 0xc0,
+	}, ADTSUnmarshalOptions{VerifyCRC: true})
+	require.EqualError(t, err, "raw data block CRC mismatch: expected f0d6, got e1c0")
+}
+
+func TestADTSUnmarshalErrors(t *testing.T) {
+	var pkts ADTSPackets
+	err := pkts.Unmarshal([]byte{0xff, 0xf1, 0x4c, 0x80, 0x1, 0x3f, 0xfd, 0xaa, 0xbb})
+	require.EqualError(t, err, "multiple raw data blocks without CRC are not supported")
+}
+
+func TestADTSUnmarshalMaxFrameSize(t *testing.T) {
+	var pkts ADTSPackets
+	err := pkts.UnmarshalWithOptions(casesADTS[0].byts, ADTSUnmarshalOptions{MaxFrameSize: 9})
+	require.NoError(t, err)
+	require.Equal(t, casesADTS[0].pkts, pkts)
+
+	pkts = nil
+	err = pkts.UnmarshalWithOptions(casesADTS[0].byts, ADTSUnmarshalOptions{MaxFrameSize: 8})
+	require.ErrorIs(t, err, ErrADTSFrameTooBig)
+	require.EqualError(t, err, "ADTS frame is too big: frame length is 9, maximum is 8")
+
+	err = pkts.Unmarshal(casesADTS[0].byts[:8])
+	require.ErrorIs(t, err, ErrADTSFrameTruncated)
+	require.EqualError(t, err, "ADTS frame is truncated: frame length is 9, but 8 bytes are available")
+}
+
 func TestADTSMarshal(t *testing.T) {
 	for _, ca := range casesADTS {
 		t.Run(ca.name, func(t *testing.T) {
@@ -68,6 +149,132 @@ func TestADTSMarshal(t *testing.T) {
 	}
 }
 
+func TestADTSMarshalSingleRawDataBlock(t *testing.T) {
+	byts, err := ADTSPackets{{
+		Type:         ObjectTypeAACLC,
+		SampleRate:   48000,
+		ChannelCount: 2,
+		CRCPresent:   true,
+		AUs:          [][]byte{{1, 2, 3, 4}},
+	}}.Marshal()
+	require.NoError(t, err)
+
+	var pkts ADTSPackets
+	err = pkts.UnmarshalWithOptions(byts, ADTSUnmarshalOptions{VerifyCRC: true})
+	require.NoError(t, err)
+	require.Equal(t, ADTSPackets{{
+		Type:         ObjectTypeAACLC,
+		SampleRate:   48000,
+		ChannelCount: 2,
+		CRCPresent:   true,
+		AU:           []byte{1, 2, 3, 4},
+	}}, pkts)
+}
+
+func TestADTSToAudioSpecificConfig(t *testing.T) {
+	for _, typ := range []ObjectType{
+		ObjectTypeAACMain,
+		ObjectTypeAACLC,
+		ObjectTypeAACSSR,
+		ObjectTypeAACLTP,
+	} {
+		t.Run(typ.String(), func(t *testing.T) {
+			pkt := ADTSPacket{
+				Type:         typ,
+				SampleRate:   48000,
+				ChannelCount: 2,
+			}
+
+			conf := pkt.ToAudioSpecificConfig()
+			require.Equal(t, AudioSpecificConfig{
+				Type:         typ,
+				SampleRate:   48000,
+				ChannelCount: 2,
+			}, conf)
+
+			pkt2, err := conf.ToADTS()
+			require.NoError(t, err)
+			require.Equal(t, &pkt, pkt2)
+
+			pkt2.AU = []byte{1, 2, 3}
+			byts, err := ADTSPackets{pkt2}.Marshal()
+			require.NoError(t, err)
+
+			var pkts ADTSPackets
+			err = pkts.Unmarshal(byts)
+			require.NoError(t, err)
+			require.Equal(t, ADTSPackets{pkt2}, pkts)
+			require.Equal(t, conf, pkts[0].ToAudioSpecificConfig())
+		})
+	}
+}
+
+func TestAudioSpecificConfigToADTS(t *testing.T) {
+	// SBR is mapped to the base object type and sample rate
+	pkt, err := AudioSpecificConfig{
+		Type:                ObjectTypeAACLC,
+		SampleRate:          24000,
+		ChannelCount:        2,
+		ExtensionType:       ObjectTypeSBR,
+		ExtensionSampleRate: 48000,
+	}.ToADTS()
+	require.NoError(t, err)
+	require.Equal(t, &ADTSPacket{
+		Type:         ObjectTypeAACLC,
+		SampleRate:   24000,
+		ChannelCount: 2,
+	}, pkt)
+
+	for _, ca := range []struct {
+		name string
+		conf AudioSpecificConfig
+		err  string
+	}{
+		{
+			"object type",
+			AudioSpecificConfig{
+				Type:         ObjectTypeERAACLC,
+				SampleRate:   48000,
+				ChannelCount: 2,
+			},
+			"object type ER-AAC-LC can't be described by ADTS",
+		},
+		{
+			"sample rate",
+			AudioSpecificConfig{
+				Type:         ObjectTypeAACLC,
+				SampleRate:   44000,
+				ChannelCount: 2,
+			},
+			"sample rate 44000 can't be described by ADTS",
+		},
+		{
+			"channel count",
+			AudioSpecificConfig{
+				Type:         ObjectTypeAACLC,
+				SampleRate:   48000,
+				ChannelCount: 7,
+			},
+			"invalid channel count (7)",
+		},
+		{
+			"frame length flag",
+			AudioSpecificConfig{
+				Type:            ObjectTypeAACLC,
+				SampleRate:      48000,
+				ChannelCount:    2,
+				FrameLengthFlag: true,
+			},
+			"frame length of 960 samples can't be described by ADTS",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			_, err := ca.conf.ToADTS()
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
 func FuzzADTSUnmarshal(f *testing.F) {
 	for _, ca := range casesADTS {
 		f.Add(ca.byts)
@@ -0,0 +1,74 @@
+package mpeg4audio
+
+import (
+	"fmt"
+)
+
+const (
+	loasSyncWord           = 0x2B7
+	loasMaxAudioMuxElement = 0x1FFF
+)
+
+// ADTSToLATM converts an ADTS stream into a LOAS stream,
+// that is a sequence of LATM AudioMuxElements framed by AudioSyncStream().
+// Each access unit is placed into a dedicated AudioMuxElement.
+// A StreamMuxConfig is placed into the first element and is emitted again
+// each time the configuration (object type, sample rate or channel count) changes.
+// Specification: ISO 14496-3, Table 1.28
+func ADTSToLATM(adts []byte) ([]byte, error) {
+	var pkts ADTSPackets
+	err := pkts.Unmarshal(adts)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []byte
+	var curConf *StreamMuxConfig
+
+	for _, pkt := range pkts {
+		asc := pkt.ToAudioSpecificConfig()
+
+		for _, au := range pkt.AccessUnits() {
+			e := AudioMuxElement{
+				MuxConfigPresent: true,
+				Payloads:         [][]byte{au},
+			}
+
+			if curConf != nil && curConf.Programs[0].Layers[0].AudioSpecificConfig.Equal(asc) {
+				e.UseSameStreamMux = true
+				e.StreamMuxConfig = curConf
+			} else {
+				ascCopy := asc
+				curConf = &StreamMuxConfig{
+					Programs: []*StreamMuxConfigProgram{{
+						Layers: []*StreamMuxConfigLayer{{
+							AudioSpecificConfig: &ascCopy,
+							LatmBufferFullness:  255,
+						}},
+					}},
+				}
+				e.StreamMuxConfig = curConf
+			}
+
+			var buf []byte
+			buf, err = e.Marshal()
+			if err != nil {
+				return nil, err
+			}
+
+			if len(buf) > loasMaxAudioMuxElement {
+				return nil, fmt.Errorf("AudioMuxElement size (%d) is too big, maximum is %d",
+					len(buf), loasMaxAudioMuxElement)
+			}
+
+			// AudioSyncStream()
+			ret = append(ret,
+				byte(loasSyncWord>>3),
+				byte((loasSyncWord&0x07)<<5)|byte(len(buf)>>8),
+				byte(len(buf)))
+			ret = append(ret, buf...)
+		}
+	}
+
+	return ret, nil
+}
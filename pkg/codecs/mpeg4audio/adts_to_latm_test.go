@@ -0,0 +1,76 @@
+package mpeg4audio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestADTSToLATM(t *testing.T) {
+	for _, ca := range casesADTS {
+		t.Run(ca.name, func(t *testing.T) {
+			loas, err := ADTSToLATM(ca.byts)
+			require.NoError(t, err)
+
+			var aus [][]byte
+			var confs []AudioSpecificConfig
+			var prevConf *StreamMuxConfig
+
+			for len(loas) > 0 {
+				require.GreaterOrEqual(t, len(loas), 3)
+				require.Equal(t, uint16(0x2B7), uint16(loas[0])<<3|uint16(loas[1])>>5)
+				le := int(loas[1]&0x1F)<<8 | int(loas[2])
+				require.GreaterOrEqual(t, len(loas), 3+le)
+
+				e := AudioMuxElement{
+					MuxConfigPresent: true,
+					StreamMuxConfig:  prevConf,
+				}
+				err = e.Unmarshal(loas[3 : 3+le])
+				require.NoError(t, err)
+
+				if !e.UseSameStreamMux {
+					confs = append(confs, *e.StreamMuxConfig.Programs[0].Layers[0].AudioSpecificConfig)
+				}
+
+				aus = append(aus, e.Payloads...)
+				prevConf = e.StreamMuxConfig
+				loas = loas[3+le:]
+			}
+
+			var expectedConfs []AudioSpecificConfig
+			for _, pkt := range ca.pkts {
+				conf := pkt.ToAudioSpecificConfig()
+				if len(expectedConfs) == 0 || !expectedConfs[len(expectedConfs)-1].Equal(conf) {
+					expectedConfs = append(expectedConfs, conf)
+				}
+			}
+
+			require.Equal(t, ca.pkts.AccessUnits(), aus)
+			require.Equal(t, expectedConfs, confs)
+		})
+	}
+}
+
+func TestADTSToLATMEncoding(t *testing.T) {
+	loas, err := ADTSToLATM(casesADTS[3].byts)
+	require.NoError(t, err)
+	require.Equal(t, []byte{
+		0x56, 0xe0, 0x09, 0x20, 0x00, 0x11, 0x90, 0x1f,
+		0xe0, 0x15, 0x55, 0xd8, 0x56, 0xe0, 0x05, 0x81,
+		0xe6, 0x6e, 0xf7, 0x00,
+	}, loas)
+
+	_, err = ADTSToLATM([]byte{1, 2, 3})
+	require.Error(t, err)
+}
+
+func FuzzADTSToLATM(f *testing.F) {
+	for _, ca := range casesADTS {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		ADTSToLATM(b) //nolint:errcheck
+	})
+}
@@ -0,0 +1,248 @@
+package mpeg4audio
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/pkg/bits"
+)
+
+// AudioMuxElement is an AudioMuxElement.
+// Specification: ISO 14496-3, Table 1.41
+type AudioMuxElement struct {
+	// whether the element may carry a StreamMuxConfig.
+	// It is true inside LOAS streams, and depends on the transport in other cases.
+	MuxConfigPresent bool
+
+	// whether the previous StreamMuxConfig is kept.
+	// It is used only when MuxConfigPresent is true.
+	UseSameStreamMux bool
+
+	// StreamMuxConfig.
+	// It must be filled before decoding when the element doesn't carry one,
+	// and is replaced when the element carries one.
+	StreamMuxConfig *StreamMuxConfig
+
+	// access units, in the order of subframes, programs and layers.
+	Payloads [][]byte
+}
+
+func readPayloadBytes(buf []byte, pos *int, n int) ([]byte, error) {
+	err := bits.HasSpace(buf, *pos, n*8)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]byte, n)
+
+	if (*pos % 8) == 0 {
+		copy(ret, buf[*pos/8:])
+		*pos += n * 8
+		return ret, nil
+	}
+
+	for i := range ret {
+		ret[i] = byte(bits.ReadBitsUnsafe(buf, pos, 8))
+	}
+
+	return ret, nil
+}
+
+// Unmarshal decodes an AudioMuxElement.
+func (e *AudioMuxElement) Unmarshal(buf []byte) error {
+	pos := 0
+
+	if e.MuxConfigPresent {
+		var err error
+		e.UseSameStreamMux, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		if !e.UseSameStreamMux {
+			e.StreamMuxConfig = &StreamMuxConfig{}
+			err = e.StreamMuxConfig.unmarshal(buf, &pos)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if e.StreamMuxConfig == nil {
+		return fmt.Errorf("StreamMuxConfig is missing")
+	}
+
+	conf := e.StreamMuxConfig
+	e.Payloads = nil
+
+	for i := uint(0); i <= conf.NumSubFrames; i++ {
+		// PayloadLengthInfo()
+		var lengths []int
+
+		for _, p := range conf.Programs {
+			for _, l := range p.Layers {
+				switch l.FrameLengthType {
+				case 0:
+					muxSlotLengthBytes := 0
+
+					for {
+						tmp, err := bits.ReadBits(buf, &pos, 8)
+						if err != nil {
+							return err
+						}
+						muxSlotLengthBytes += int(tmp)
+
+						if tmp != 255 {
+							break
+						}
+					}
+
+					lengths = append(lengths, muxSlotLengthBytes)
+
+				case 1:
+					lengths = append(lengths, int(l.FrameLength)+20)
+
+				default:
+					return fmt.Errorf("unsupported frameLengthType (%d)", l.FrameLengthType)
+				}
+			}
+		}
+
+		// PayloadMux()
+		for _, le := range lengths {
+			if le > MaxAccessUnitSize {
+				return fmt.Errorf("access unit size (%d) is too big, maximum is %d", le, MaxAccessUnitSize)
+			}
+
+			payload, err := readPayloadBytes(buf, &pos, le)
+			if err != nil {
+				return err
+			}
+
+			e.Payloads = append(e.Payloads, payload)
+		}
+	}
+
+	if conf.OtherDataPresent {
+		err := bits.HasSpace(buf, pos, int(conf.OtherDataLenBits))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e AudioMuxElement) marshalSizeBits() (int, error) {
+	if e.StreamMuxConfig == nil {
+		return 0, fmt.Errorf("StreamMuxConfig is missing")
+	}
+
+	conf := e.StreamMuxConfig
+	n := 0
+
+	if e.MuxConfigPresent {
+		n++
+
+		if !e.UseSameStreamMux {
+			n += conf.marshalSizeBits()
+		}
+	}
+
+	layerCount := 0
+	for _, p := range conf.Programs {
+		layerCount += len(p.Layers)
+	}
+
+	if len(e.Payloads) != (int(conf.NumSubFrames)+1)*layerCount {
+		return 0, fmt.Errorf("payload count (%d) doesn't match StreamMuxConfig", len(e.Payloads))
+	}
+
+	i := 0
+
+	for j := uint(0); j <= conf.NumSubFrames; j++ {
+		for _, p := range conf.Programs {
+			for _, l := range p.Layers {
+				le := len(e.Payloads[i])
+				i++
+
+				switch l.FrameLengthType {
+				case 0:
+					n += (le/255 + 1) * 8
+
+				case 1:
+					if le != (int(l.FrameLength) + 20) {
+						return 0, fmt.Errorf("payload size (%d) doesn't match frame length", le)
+					}
+
+				default:
+					return 0, fmt.Errorf("unsupported frameLengthType (%d)", l.FrameLengthType)
+				}
+
+				n += le * 8
+			}
+		}
+	}
+
+	if conf.OtherDataPresent {
+		n += int(conf.OtherDataLenBits)
+	}
+
+	return n, nil
+}
+
+// Marshal encodes an AudioMuxElement.
+// Other data, when present, is filled with zeros.
+func (e AudioMuxElement) Marshal() ([]byte, error) {
+	n, err := e.marshalSizeBits()
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, (n+7)/8)
+	pos := 0
+
+	if e.MuxConfigPresent {
+		if e.UseSameStreamMux {
+			bits.WriteBitsUnsafe(buf, &pos, 1, 1)
+		} else {
+			bits.WriteBitsUnsafe(buf, &pos, 0, 1)
+
+			err = e.StreamMuxConfig.marshalTo(buf, &pos)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	conf := e.StreamMuxConfig
+	layerCount := len(e.Payloads) / (int(conf.NumSubFrames) + 1)
+
+	for j := 0; j <= int(conf.NumSubFrames); j++ {
+		payloads := e.Payloads[j*layerCount : (j+1)*layerCount]
+		i := 0
+
+		// PayloadLengthInfo()
+		for _, p := range conf.Programs {
+			for _, l := range p.Layers {
+				if l.FrameLengthType == 0 {
+					le := len(payloads[i])
+					for le >= 255 {
+						bits.WriteBitsUnsafe(buf, &pos, 255, 8)
+						le -= 255
+					}
+					bits.WriteBitsUnsafe(buf, &pos, uint64(le), 8)
+				}
+				i++
+			}
+		}
+
+		// PayloadMux()
+		for _, payload := range payloads {
+			for _, b := range payload {
+				bits.WriteBitsUnsafe(buf, &pos, uint64(b), 8)
+			}
+		}
+	}
+
+	return buf, nil
+}
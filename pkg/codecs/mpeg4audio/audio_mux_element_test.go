@@ -0,0 +1,119 @@
+package mpeg4audio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var audioMuxElementTestConfig = &StreamMuxConfig{
+	Programs: []*StreamMuxConfigProgram{{
+		Layers: []*StreamMuxConfigLayer{{
+			AudioSpecificConfig: &AudioSpecificConfig{
+				Type:         2,
+				SampleRate:   24000,
+				ChannelCount: 2,
+			},
+			LatmBufferFullness: 255,
+		}},
+	}},
+}
+
+var audioMuxElementCases = []struct {
+	name string
+	enc  []byte
+	dec  AudioMuxElement
+}{
+	{
+		"without config",
+		[]byte{0x03, 0x01, 0x02, 0x03},
+		AudioMuxElement{
+			StreamMuxConfig: audioMuxElementTestConfig,
+			Payloads:        [][]byte{{1, 2, 3}},
+		},
+	},
+	{
+		"with config",
+		[]byte{0x20, 0x00, 0x13, 0x10, 0x1f, 0xe0, 0x18, 0x08, 0x10, 0x18},
+		AudioMuxElement{
+			MuxConfigPresent: true,
+			StreamMuxConfig:  audioMuxElementTestConfig,
+			Payloads:         [][]byte{{1, 2, 3}},
+		},
+	},
+	{
+		"same config",
+		[]byte{0x81, 0x80, 0x81, 0x01, 0x80},
+		AudioMuxElement{
+			MuxConfigPresent: true,
+			UseSameStreamMux: true,
+			StreamMuxConfig:  audioMuxElementTestConfig,
+			Payloads:         [][]byte{{1, 2, 3}},
+		},
+	},
+	{
+		"fixed frame length",
+		append([]byte{0x00}, make([]byte, 21)...),
+		AudioMuxElement{
+			StreamMuxConfig: &StreamMuxConfig{
+				Programs: []*StreamMuxConfigProgram{{
+					Layers: []*StreamMuxConfigLayer{{
+						AudioSpecificConfig: &AudioSpecificConfig{
+							Type:         2,
+							SampleRate:   24000,
+							ChannelCount: 2,
+						},
+						FrameLengthType: 1,
+						FrameLength:     2,
+					}},
+				}},
+			},
+			Payloads: [][]byte{make([]byte, 22)},
+		},
+	},
+}
+
+func TestAudioMuxElementUnmarshal(t *testing.T) {
+	for _, ca := range audioMuxElementCases {
+		t.Run(ca.name, func(t *testing.T) {
+			dec := AudioMuxElement{
+				MuxConfigPresent: ca.dec.MuxConfigPresent,
+			}
+			if !ca.dec.MuxConfigPresent || ca.dec.UseSameStreamMux {
+				dec.StreamMuxConfig = ca.dec.StreamMuxConfig
+			}
+
+			err := dec.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestAudioMuxElementMarshal(t *testing.T) {
+	for _, ca := range audioMuxElementCases {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func FuzzAudioMuxElementUnmarshal(f *testing.F) {
+	for _, ca := range audioMuxElementCases {
+		if ca.dec.MuxConfigPresent && !ca.dec.UseSameStreamMux {
+			f.Add(ca.enc)
+		}
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		e := AudioMuxElement{
+			MuxConfigPresent: true,
+		}
+		err := e.Unmarshal(b)
+		if err == nil {
+			e.Marshal() //nolint:errcheck
+		}
+	})
+}
@@ -1,7 +1,10 @@
 package mpeg4audio
 
 import (
+	"bytes"
 	"fmt"
+	"reflect"
+	"time"
 
 	"github.com/bluenviron/mediacommon/pkg/bits"
 )
@@ -16,52 +19,103 @@ type AudioSpecificConfig struct {
 	SampleRate   int
 	ChannelCount int
 
+	// whether the sample rate is encoded explicitly, with the 24-bit escape value,
+	// even though it has a sampling frequency index.
+	// It is filled by Unmarshal and honored by Marshal, in order to preserve the original encoding.
+	ExplicitSampleRate bool
+
+	// program config element, present when channel configuration is 0.
+	// When set, it is used in place of ChannelCount during encoding.
+	ProgramConfigElement *ProgramConfigElement
+
 	// SBR / PS specific
 	ExtensionType       ObjectType
 	ExtensionSampleRate int
 
+	// same as ExplicitSampleRate, applied to the extension sample rate.
+	ExplicitExtensionSampleRate bool
+
+	// whether the extension is signaled with the explicit backward-compatible method
+	// (i.e. through a sync extension placed after the GASpecificConfig)
+	// instead of the hierarchical one.
+	// Sync extensions are decoded and encoded by Unmarshal and Marshal only,
+	// since they require the AudioSpecificConfig to fill the whole buffer.
+	BackwardCompatibleSignaling bool
+
 	FrameLengthFlag    bool
 	DependsOnCoreCoder bool
 	CoreCoderDelay     uint16
+
+	// GASpecificConfig extension, present when extensionFlag is set.
+	// It contains the raw extension bits, aligned to the left.
+	ExtensionData []byte
+
+	// error resilient specific
+	EPConfig uint8
+}
+
+// AudioSpecificConfigUnmarshalOptions contains options of AudioSpecificConfig.UnmarshalWithOptions.
+type AudioSpecificConfigUnmarshalOptions struct {
+	// tolerate out-of-spec fields produced by some encoders:
+	// reserved channel configurations leave ChannelCount to zero
+	// and extensionFlag3 is stored into ExtensionData instead of being rejected.
+	Lenient bool
 }
 
 // Unmarshal decodes a Config.
 func (c *AudioSpecificConfig) Unmarshal(buf []byte) error {
+	return c.UnmarshalWithOptions(buf, AudioSpecificConfigUnmarshalOptions{})
+}
+
+// UnmarshalWithOptions decodes a Config.
+func (c *AudioSpecificConfig) UnmarshalWithOptions(buf []byte, opts AudioSpecificConfigUnmarshalOptions) error {
+	pos := 0
+	return c.unmarshal(buf, &pos, true, opts)
+}
+
+// UnmarshalPrefix decodes a Config placed at the beginning of a buffer that contains additional data.
+// It returns the number of bytes used by the Config, rounded up to the next byte.
+// Sync extensions are not decoded, since they are indistinguishable from trailing data.
+func (c *AudioSpecificConfig) UnmarshalPrefix(buf []byte) (int, error) {
 	pos := 0
-	return c.UnmarshalFromPos(buf, &pos)
+	err := c.UnmarshalFromPos(buf, &pos)
+	if err != nil {
+		return 0, err
+	}
+	return (pos + 7) / 8, nil
 }
 
-// UnmarshalFromPos decodes a Config.
+// UnmarshalFromPos decodes a Config starting at the bit position pointed by pos.
+// pos is advanced to the bit that follows the Config.
+// Sync extensions are not decoded, since they are indistinguishable from trailing data.
 func (c *AudioSpecificConfig) UnmarshalFromPos(buf []byte, pos *int) error {
+	return c.unmarshal(buf, pos, false, AudioSpecificConfigUnmarshalOptions{})
+}
+
+func (c *AudioSpecificConfig) unmarshal(
+	buf []byte,
+	pos *int,
+	syncExtension bool,
+	opts AudioSpecificConfigUnmarshalOptions,
+) error {
+	start := *pos
+
 	tmp, err := bits.ReadBits(buf, pos, 5)
 	if err != nil {
 		return err
 	}
 	c.Type = ObjectType(tmp)
 
-	switch c.Type {
-	case ObjectTypeAACLC, ObjectTypeSBR, ObjectTypePS:
-	default:
+	if !c.Type.isGeneralAudio() && c.Type != ObjectTypeSBR && c.Type != ObjectTypePS {
 		return fmt.Errorf("unsupported object type: %d", c.Type)
 	}
 
-	sampleRateIndex, err := bits.ReadBits(buf, pos, 4)
+	var sampleRateIndex uint64
+	c.SampleRate, c.ExplicitSampleRate, sampleRateIndex, err = readSampleRate(buf, pos)
 	if err != nil {
 		return err
 	}
-
-	switch {
-	case sampleRateIndex <= 12:
-		c.SampleRate = sampleRates[sampleRateIndex]
-
-	case sampleRateIndex == 0x0F:
-		tmp, err = bits.ReadBits(buf, pos, 24)
-		if err != nil {
-			return err
-		}
-		c.SampleRate = int(tmp)
-
-	default:
+	if c.SampleRate == 0 {
 		return fmt.Errorf("invalid sample rate index (%d)", sampleRateIndex)
 	}
 
@@ -72,7 +126,6 @@ func (c *AudioSpecificConfig) UnmarshalFromPos(buf []byte, pos *int) error {
 
 	switch {
 	case channelConfig == 0:
-		return fmt.Errorf("not yet supported")
 
 	case channelConfig >= 1 && channelConfig <= 6:
 		c.ChannelCount = int(channelConfig)
@@ -80,31 +133,25 @@ func (c *AudioSpecificConfig) UnmarshalFromPos(buf []byte, pos *int) error {
 	case channelConfig == 7:
 		c.ChannelCount = 8
 
+	case opts.Lenient:
+		c.ChannelCount = 0
+
 	default:
 		return fmt.Errorf("invalid channel configuration (%d)", channelConfig)
 	}
 
-	if c.Type == ObjectTypeSBR || c.Type == ObjectTypePS {
+	hierarchicalSignaling := (c.Type == ObjectTypeSBR || c.Type == ObjectTypePS)
+
+	if hierarchicalSignaling {
 		c.ExtensionType = c.Type
 
 		var extensionSamplingFrequencyIndex uint64
-		extensionSamplingFrequencyIndex, err = bits.ReadBits(buf, pos, 4)
+		c.ExtensionSampleRate, c.ExplicitExtensionSampleRate, extensionSamplingFrequencyIndex, err =
+			readSampleRate(buf, pos)
 		if err != nil {
 			return err
 		}
-
-		switch {
-		case extensionSamplingFrequencyIndex <= 12:
-			c.ExtensionSampleRate = sampleRates[extensionSamplingFrequencyIndex]
-
-		case extensionSamplingFrequencyIndex == 0x0F:
-			tmp, err = bits.ReadBits(buf, pos, 24)
-			if err != nil {
-				return err
-			}
-			c.ExtensionSampleRate = int(tmp)
-
-		default:
+		if c.ExtensionSampleRate == 0 {
 			return fmt.Errorf("invalid extension sample rate index (%d)", extensionSamplingFrequencyIndex)
 		}
 
@@ -114,7 +161,7 @@ func (c *AudioSpecificConfig) UnmarshalFromPos(buf []byte, pos *int) error {
 		}
 		c.Type = ObjectType(tmp)
 
-		if c.Type != ObjectTypeAACLC {
+		if !c.Type.isGeneralAudio() {
 			return fmt.Errorf("unsupported object type: %d", c.Type)
 		}
 	}
@@ -142,30 +189,180 @@ func (c *AudioSpecificConfig) UnmarshalFromPos(buf []byte, pos *int) error {
 		return err
 	}
 
+	if channelConfig == 0 {
+		c.ProgramConfigElement = &ProgramConfigElement{}
+		err = c.ProgramConfigElement.unmarshal(buf, pos, start)
+		if err != nil {
+			return err
+		}
+
+		c.ChannelCount = c.ProgramConfigElement.ChannelCount()
+		if c.ChannelCount == 0 {
+			return fmt.Errorf("program config element doesn't contain any channel")
+		}
+	}
+
 	if extensionFlag {
-		return fmt.Errorf("unsupported")
+		n := c.Type.extensionSizeBits()
+
+		tmp, err = bits.ReadBits(buf, pos, n)
+		if err != nil {
+			return err
+		}
+
+		extensionFlag3 := (tmp & 0b1) != 0
+		if extensionFlag3 && !opts.Lenient {
+			return fmt.Errorf("extensionFlag3 is not supported")
+		}
+
+		c.ExtensionData = make([]byte, (n+7)/8)
+		extPos := 0
+		err = bits.WriteBits(c.ExtensionData, &extPos, tmp, n)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.Type.isErrorResilient() {
+		tmp, err = bits.ReadBits(buf, pos, 2)
+		if err != nil {
+			return err
+		}
+		c.EPConfig = uint8(tmp)
+
+		if c.EPConfig == 2 || c.EPConfig == 3 {
+			return fmt.Errorf("epConfig %d is not supported", c.EPConfig)
+		}
+	}
+
+	if syncExtension && !hierarchicalSignaling {
+		return c.unmarshalSyncExtension(buf, pos)
 	}
 
 	return nil
 }
 
-func (c AudioSpecificConfig) marshalSizeBits() int {
-	n := 5 + 4 + 2 + 1
+func (c *AudioSpecificConfig) unmarshalSyncExtension(buf []byte, pos *int) error {
+	// sync extensions are optional: consume bits only when a sync word is found.
+	if bits.HasSpace(buf, *pos, 16) != nil {
+		return nil
+	}
 
-	_, ok := reverseSampleRates[c.SampleRate]
-	if !ok {
-		n += 28
-	} else {
-		n += 4
+	pos2 := *pos
+
+	syncExtensionType := bits.ReadBitsUnsafe(buf, &pos2, 11)
+	if syncExtensionType != 0x2B7 {
+		return nil
 	}
 
-	if c.ExtensionType == ObjectTypeSBR || c.ExtensionType == ObjectTypePS {
-		_, ok := reverseSampleRates[c.ExtensionSampleRate]
-		if !ok {
-			n += 28
-		} else {
-			n += 4
+	extensionType := ObjectType(bits.ReadBitsUnsafe(buf, &pos2, 5))
+	if extensionType != ObjectTypeSBR {
+		return nil
+	}
+
+	sbrPresentFlag, err := bits.ReadFlag(buf, &pos2)
+	if err != nil {
+		return err
+	}
+
+	if sbrPresentFlag {
+		var extensionSamplingFrequencyIndex uint64
+		c.ExtensionSampleRate, c.ExplicitExtensionSampleRate, extensionSamplingFrequencyIndex, err =
+			readSampleRate(buf, &pos2)
+		if err != nil {
+			return err
+		}
+		if c.ExtensionSampleRate == 0 {
+			return fmt.Errorf("invalid extension sample rate index (%d)", extensionSamplingFrequencyIndex)
+		}
+
+		c.ExtensionType = ObjectTypeSBR
+		c.BackwardCompatibleSignaling = true
+
+		if bits.HasSpace(buf, pos2, 12) == nil {
+			pos3 := pos2
+			syncExtensionType = bits.ReadBitsUnsafe(buf, &pos3, 11)
+
+			if syncExtensionType == 0x548 {
+				psPresentFlag := bits.ReadFlagUnsafe(buf, &pos3)
+				if psPresentFlag {
+					c.ExtensionType = ObjectTypePS
+				}
+				pos2 = pos3
+			}
+		}
+	}
+
+	*pos = pos2
+	return nil
+}
+
+// readSampleRate reads a sampling frequency index, followed by the 24-bit sample rate
+// when the index is the escape value.
+// It returns a zero sample rate, together with the index, when the index is invalid.
+func readSampleRate(buf []byte, pos *int) (int, bool, uint64, error) {
+	index, err := bits.ReadBits(buf, pos, 4)
+	if err != nil {
+		return 0, false, 0, err
+	}
+
+	switch {
+	case index <= 12:
+		return sampleRates[index], false, index, nil
+
+	case index == 0x0F:
+		tmp, err := bits.ReadBits(buf, pos, 24)
+		if err != nil {
+			return 0, false, 0, err
 		}
+
+		_, ok := reverseSampleRates[int(tmp)]
+		return int(tmp), ok, index, nil
+
+	default:
+		return 0, false, index, nil
+	}
+}
+
+func sampleRateSizeBits(sampleRate int, explicit bool) int {
+	_, ok := reverseSampleRates[sampleRate]
+	if !ok || explicit {
+		return 28
+	}
+	return 4
+}
+
+func writeSampleRate(buf []byte, pos *int, sampleRate int, explicit bool) error {
+	index, ok := reverseSampleRates[sampleRate]
+	if !ok || explicit {
+		err := bits.WriteBits(buf, pos, 0x0F, 4)
+		if err != nil {
+			return err
+		}
+
+		return bits.WriteBits(buf, pos, uint64(sampleRate), 24)
+	}
+
+	return bits.WriteBits(buf, pos, uint64(index), 4)
+}
+
+func (c AudioSpecificConfig) hasHierarchicalExtension() bool {
+	return (c.ExtensionType == ObjectTypeSBR || c.ExtensionType == ObjectTypePS) &&
+		!c.BackwardCompatibleSignaling
+}
+
+func (c AudioSpecificConfig) hasBackwardCompatibleExtension() bool {
+	return (c.ExtensionType == ObjectTypeSBR || c.ExtensionType == ObjectTypePS) &&
+		c.BackwardCompatibleSignaling
+}
+
+func (c AudioSpecificConfig) marshalSizeBits(syncExtension bool) int {
+	n := 5 + 4 + 2 + 1
+
+	n += sampleRateSizeBits(c.SampleRate, c.ExplicitSampleRate)
+
+	if c.hasHierarchicalExtension() {
+		n += sampleRateSizeBits(c.ExtensionSampleRate, c.ExplicitExtensionSampleRate)
 		n += 5
 	}
 
@@ -173,11 +370,37 @@ func (c AudioSpecificConfig) marshalSizeBits() int {
 		n += 14
 	}
 
+	if c.ProgramConfigElement != nil {
+		n += c.ProgramConfigElement.marshalSizeBits(n)
+	}
+
+	if c.ExtensionData != nil {
+		n += c.Type.extensionSizeBits()
+	}
+
+	if c.Type.isErrorResilient() {
+		n += 2
+	}
+
+	if syncExtension && c.hasBackwardCompatibleExtension() {
+		n += 11 + 5 + 1
+		n += sampleRateSizeBits(c.ExtensionSampleRate, c.ExplicitExtensionSampleRate)
+
+		if c.ExtensionType == ObjectTypePS {
+			n += 12
+		}
+	}
+
 	return n
 }
 
+// MarshalSizeBits returns the size in bits of the Config encoded by MarshalTo.
+func (c AudioSpecificConfig) MarshalSizeBits() int {
+	return c.marshalSizeBits(false)
+}
+
 func (c AudioSpecificConfig) marshalSize() int {
-	n := c.marshalSizeBits()
+	n := c.marshalSizeBits(true)
 
 	ret := n / 8
 	if (n % 8) != 0 {
@@ -187,12 +410,29 @@ func (c AudioSpecificConfig) marshalSize() int {
 	return ret
 }
 
+// AudioSpecificConfigMarshalOptions contains options of AudioSpecificConfig.MarshalWithOptions.
+type AudioSpecificConfigMarshalOptions struct {
+	// encode sample rates with their sampling frequency index whenever possible,
+	// ignoring ExplicitSampleRate and ExplicitExtensionSampleRate.
+	NormalizeSampleRates bool
+}
+
 // Marshal encodes a Config.
 func (c AudioSpecificConfig) Marshal() ([]byte, error) {
+	return c.MarshalWithOptions(AudioSpecificConfigMarshalOptions{})
+}
+
+// MarshalWithOptions encodes a Config.
+func (c AudioSpecificConfig) MarshalWithOptions(opts AudioSpecificConfigMarshalOptions) ([]byte, error) {
+	if opts.NormalizeSampleRates {
+		c.ExplicitSampleRate = false
+		c.ExplicitExtensionSampleRate = false
+	}
+
 	buf := make([]byte, c.marshalSize())
 	pos := 0
 
-	err := c.marshalTo(buf, &pos)
+	err := c.marshalTo(buf, &pos, true)
 	if err != nil {
 		return nil, err
 	}
@@ -200,23 +440,52 @@ func (c AudioSpecificConfig) Marshal() ([]byte, error) {
 	return buf, nil
 }
 
-func (c AudioSpecificConfig) marshalTo(buf []byte, pos *int) error {
-	if c.ExtensionType == ObjectTypeSBR || c.ExtensionType == ObjectTypePS {
-		bits.WriteBits(buf, pos, uint64(c.ExtensionType), 5)
-	} else {
-		bits.WriteBits(buf, pos, uint64(c.Type), 5)
+// MarshalTo encodes a Config into an existing buffer, starting at the bit position pointed by pos.
+// pos is advanced to the bit that follows the Config.
+// Sync extensions are not encoded, like in UnmarshalFromPos.
+func (c AudioSpecificConfig) MarshalTo(buf []byte, pos *int) error {
+	return c.marshalTo(buf, pos, false)
+}
+
+func (c AudioSpecificConfig) marshalTo(buf []byte, pos *int, syncExtension bool) error {
+	if c.SampleRate <= 0 || c.SampleRate > 0xFFFFFF {
+		return fmt.Errorf("invalid sample rate (%d)", c.SampleRate)
 	}
 
-	sampleRateIndex, ok := reverseSampleRates[c.SampleRate]
-	if !ok {
-		bits.WriteBits(buf, pos, uint64(15), 4)
-		bits.WriteBits(buf, pos, uint64(c.SampleRate), 24)
+	if (c.ExtensionType == ObjectTypeSBR || c.ExtensionType == ObjectTypePS) &&
+		(c.ExtensionSampleRate <= 0 || c.ExtensionSampleRate > 0xFFFFFF) {
+		return fmt.Errorf("invalid extension sample rate (%d)", c.ExtensionSampleRate)
+	}
+
+	start := *pos
+	var err error
+
+	if c.hasHierarchicalExtension() {
+		err = bits.WriteBits(buf, pos, uint64(c.ExtensionType), 5)
+		if err != nil {
+			return err
+		}
 	} else {
-		bits.WriteBits(buf, pos, uint64(sampleRateIndex), 4)
+		err = bits.WriteBits(buf, pos, uint64(c.Type), 5)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = writeSampleRate(buf, pos, c.SampleRate, c.ExplicitSampleRate)
+	if err != nil {
+		return err
 	}
 
 	var channelConfig int
 	switch {
+	case c.ProgramConfigElement != nil:
+		if c.ProgramConfigElement.ChannelCount() != c.ChannelCount {
+			return fmt.Errorf("channel count (%d) doesn't match program config element (%d)",
+				c.ChannelCount, c.ProgramConfigElement.ChannelCount())
+		}
+		channelConfig = 0
+
 	case c.ChannelCount >= 1 && c.ChannelCount <= 6:
 		channelConfig = c.ChannelCount
 
@@ -226,36 +495,238 @@ func (c AudioSpecificConfig) marshalTo(buf []byte, pos *int) error {
 	default:
 		return fmt.Errorf("invalid channel count (%d)", c.ChannelCount)
 	}
-	bits.WriteBits(buf, pos, uint64(channelConfig), 4)
+	err = bits.WriteBits(buf, pos, uint64(channelConfig), 4)
+	if err != nil {
+		return err
+	}
 
-	if c.ExtensionType == ObjectTypeSBR || c.ExtensionType == ObjectTypePS {
-		sampleRateIndex, ok := reverseSampleRates[c.ExtensionSampleRate]
-		if !ok {
-			bits.WriteBits(buf, pos, uint64(0x0F), 4)
-			bits.WriteBits(buf, pos, uint64(c.ExtensionSampleRate), 24)
-		} else {
-			bits.WriteBits(buf, pos, uint64(sampleRateIndex), 4)
+	if c.hasHierarchicalExtension() {
+		err = writeSampleRate(buf, pos, c.ExtensionSampleRate, c.ExplicitExtensionSampleRate)
+		if err != nil {
+			return err
+		}
+		err = bits.WriteBits(buf, pos, uint64(c.Type), 5)
+		if err != nil {
+			return err
 		}
-		bits.WriteBits(buf, pos, uint64(c.Type), 5)
 	}
 
 	if c.FrameLengthFlag {
-		bits.WriteBits(buf, pos, 1, 1)
+		err = bits.WriteBits(buf, pos, 1, 1)
+		if err != nil {
+			return err
+		}
 	} else {
-		bits.WriteBits(buf, pos, 0, 1)
+		err = bits.WriteBits(buf, pos, 0, 1)
+		if err != nil {
+			return err
+		}
 	}
 
 	if c.DependsOnCoreCoder {
-		bits.WriteBits(buf, pos, 1, 1)
+		err = bits.WriteBits(buf, pos, 1, 1)
+		if err != nil {
+			return err
+		}
 	} else {
-		bits.WriteBits(buf, pos, 0, 1)
+		err = bits.WriteBits(buf, pos, 0, 1)
+		if err != nil {
+			return err
+		}
 	}
 
 	if c.DependsOnCoreCoder {
-		bits.WriteBits(buf, pos, uint64(c.CoreCoderDelay), 14)
+		err = bits.WriteBits(buf, pos, uint64(c.CoreCoderDelay), 14)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.ExtensionData != nil {
+		err = bits.WriteBits(buf, pos, 1, 1)
+		if err != nil {
+			return err
+		}
+	} else {
+		err = bits.WriteBits(buf, pos, 0, 1)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.ProgramConfigElement != nil {
+		err = c.ProgramConfigElement.marshalTo(buf, pos, start)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.ExtensionData != nil {
+		n := c.Type.extensionSizeBits()
+		extPos := 0
+
+		tmp, err := bits.ReadBits(c.ExtensionData, &extPos, n)
+		if err != nil {
+			return fmt.Errorf("invalid extension data: %w", err)
+		}
+
+		err = bits.WriteBits(buf, pos, tmp, n)
+		if err != nil {
+			return err
+		}
 	}
 
-	*pos++ // extensionFlag
+	if c.Type.isErrorResilient() {
+		if c.EPConfig > 1 {
+			return fmt.Errorf("unsupported epConfig (%d)", c.EPConfig)
+		}
+		err = bits.WriteBits(buf, pos, uint64(c.EPConfig), 2)
+		if err != nil {
+			return err
+		}
+	}
+
+	if syncExtension && c.hasBackwardCompatibleExtension() {
+		err = bits.WriteBits(buf, pos, 0x2B7, 11)
+		if err != nil {
+			return err
+		}
+
+		err = bits.WriteBits(buf, pos, uint64(ObjectTypeSBR), 5)
+		if err != nil {
+			return err
+		}
+
+		err = bits.WriteBits(buf, pos, 1, 1) // sbrPresentFlag
+		if err != nil {
+			return err
+		}
+
+		err = writeSampleRate(buf, pos, c.ExtensionSampleRate, c.ExplicitExtensionSampleRate)
+		if err != nil {
+			return err
+		}
+
+		if c.ExtensionType == ObjectTypePS {
+			err = bits.WriteBits(buf, pos, 0x548, 11)
+			if err != nil {
+				return err
+			}
+
+			err = bits.WriteBits(buf, pos, 1, 1) // psPresentFlag
+			if err != nil {
+				return err
+			}
+		}
+	}
 
 	return nil
 }
+
+// coreSamplesPerAccessUnit returns the number of samples contained inside an access unit,
+// expressed with the core sample rate.
+func (c AudioSpecificConfig) coreSamplesPerAccessUnit() int {
+	if c.FrameLengthFlag {
+		return 960
+	}
+	return SamplesPerAccessUnit
+}
+
+// SamplesPerAccessUnit returns the number of samples contained inside an access unit.
+// When a SBR or PS extension doubles the sample rate, samples are expressed
+// with the extension sample rate, that is the output sample rate.
+func (c AudioSpecificConfig) SamplesPerAccessUnit() int {
+	n := c.coreSamplesPerAccessUnit()
+
+	if (c.ExtensionType == ObjectTypeSBR || c.ExtensionType == ObjectTypePS) &&
+		c.ExtensionSampleRate == (c.SampleRate*2) {
+		n *= 2
+	}
+
+	return n
+}
+
+// AccessUnitDuration returns the duration of an access unit.
+// It takes into account the frame length (960 or 1024 samples)
+// and doesn't depend on SBR and PS extensions.
+func (c AudioSpecificConfig) AccessUnitDuration() time.Duration {
+	if c.SampleRate <= 0 {
+		return 0
+	}
+	return time.Duration(c.coreSamplesPerAccessUnit()) * time.Second / time.Duration(c.SampleRate)
+}
+
+// PrimingSamples returns the typical number of priming samples, i.e. the encoder delay,
+// that are at the beginning of the stream and must not be presented.
+// It corresponds to two access units (2048 samples for AAC-LC)
+// and is expressed in the same unit as SamplesPerAccessUnit.
+// The actual value depends on the encoder and, when known, should be preferred.
+func (c AudioSpecificConfig) PrimingSamples() int {
+	return 2 * c.SamplesPerAccessUnit()
+}
+
+// CodecString returns the codec string of the stream, in the mp4a.40.N format,
+// as defined by RFC 6381 and used in DASH / HLS manifests.
+// When a SBR or PS extension is signaled, with either the hierarchical or the
+// backward-compatible method, N is the object type of the extension (5 or 29),
+// otherwise it is the object type of the stream (i.e. 2 for AAC-LC).
+// Extensions that are signaled implicitly, inside the bitstream only,
+// can't be detected from the AudioSpecificConfig.
+func (c AudioSpecificConfig) CodecString() string {
+	t := c.Type
+	if c.ExtensionType == ObjectTypeSBR || c.ExtensionType == ObjectTypePS {
+		t = c.ExtensionType
+	}
+	return fmt.Sprintf("mp4a.40.%d", int(t))
+}
+
+// Clone returns a copy of the Config that doesn't share memory with the original.
+func (c AudioSpecificConfig) Clone() AudioSpecificConfig {
+	if c.ProgramConfigElement != nil {
+		pce := c.ProgramConfigElement.Clone()
+		c.ProgramConfigElement = &pce
+	}
+	c.ExtensionData = cloneBytes(c.ExtensionData)
+	return c
+}
+
+func extensionDataIsEmpty(buf []byte) bool {
+	for _, b := range buf {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal checks whether two configs are semantically equal.
+// Encoding details that do not change the meaning of the config,
+// like the sample rate encoding or the SBR / PS signaling method, are ignored.
+func (c AudioSpecificConfig) Equal(other AudioSpecificConfig) bool {
+	if c.Type != other.Type ||
+		c.SampleRate != other.SampleRate ||
+		c.ChannelCount != other.ChannelCount ||
+		c.ExtensionType != other.ExtensionType ||
+		c.FrameLengthFlag != other.FrameLengthFlag ||
+		c.DependsOnCoreCoder != other.DependsOnCoreCoder ||
+		c.EPConfig != other.EPConfig {
+		return false
+	}
+
+	if (c.ExtensionType == ObjectTypeSBR || c.ExtensionType == ObjectTypePS) &&
+		c.ExtensionSampleRate != other.ExtensionSampleRate {
+		return false
+	}
+
+	if c.DependsOnCoreCoder && c.CoreCoderDelay != other.CoreCoderDelay {
+		return false
+	}
+
+	// extension bits that are not set are equivalent to a missing extension
+	if !(extensionDataIsEmpty(c.ExtensionData) && extensionDataIsEmpty(other.ExtensionData)) &&
+		!bytes.Equal(c.ExtensionData, other.ExtensionData) {
+		return false
+	}
+
+	return reflect.DeepEqual(c.ProgramConfigElement, other.ProgramConfigElement)
+}
@@ -2,6 +2,7 @@ package mpeg4audio
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -56,6 +57,16 @@ var audioSpecificConfigCases = []struct {
 			ChannelCount: 2,
 		},
 	},
+	{
+		"aac-lc 48khz stereo explicit sample rate",
+		[]byte{0x17, 0x80, 0x5d, 0xc0, 0x10},
+		AudioSpecificConfig{
+			Type:               ObjectTypeAACLC,
+			SampleRate:         48000,
+			ExplicitSampleRate: true,
+			ChannelCount:       2,
+		},
+	},
 	{
 		"aac-lc 96khz stereo delay",
 		[]byte{0x10, 0x12, 0x0c, 0x08},
@@ -76,6 +87,65 @@ var audioSpecificConfigCases = []struct {
 			ChannelCount: 8,
 		},
 	},
+	{
+		"aac-lc 48khz 5.1 program config element",
+		[]byte{0x11, 0x80, 0x04, 0xc8, 0x05, 0x00, 0x01, 0x08, 0x80, 0x00},
+		AudioSpecificConfig{
+			Type:         ObjectTypeAACLC,
+			SampleRate:   48000,
+			ChannelCount: 6,
+			ProgramConfigElement: &ProgramConfigElement{
+				ObjectType:             1,
+				SamplingFrequencyIndex: 3,
+				FrontElements: []ProgramConfigElementChannel{
+					{IsCPE: false, TagSelect: 0},
+					{IsCPE: true, TagSelect: 0},
+				},
+				BackElements: []ProgramConfigElementChannel{
+					{IsCPE: true, TagSelect: 1},
+				},
+				LFEElements: []uint8{0},
+			},
+		},
+	},
+	{
+		"aac-main 48khz stereo",
+		[]byte{0x09, 0x90},
+		AudioSpecificConfig{
+			Type:         ObjectTypeAACMain,
+			SampleRate:   48000,
+			ChannelCount: 2,
+		},
+	},
+	{
+		"aac-ltp 44.1khz stereo",
+		[]byte{0x22, 0x10},
+		AudioSpecificConfig{
+			Type:         ObjectTypeAACLTP,
+			SampleRate:   44100,
+			ChannelCount: 2,
+		},
+	},
+	{
+		"aac-lc 48khz stereo extension",
+		[]byte{0x11, 0x91, 0x00},
+		AudioSpecificConfig{
+			Type:          ObjectTypeAACLC,
+			SampleRate:    48000,
+			ChannelCount:  2,
+			ExtensionData: []byte{0x00},
+		},
+	},
+	{
+		"er aac-lc 48khz stereo",
+		[]byte{0x89, 0x91, 0xe0},
+		AudioSpecificConfig{
+			Type:          ObjectTypeERAACLC,
+			SampleRate:    48000,
+			ChannelCount:  2,
+			ExtensionData: []byte{0xe0},
+		},
+	},
 	{
 		"sbr (he-aac v1) 44.1khz mono",
 		[]byte{0x2b, 0x8a, 0x08, 0x00},
@@ -109,6 +179,30 @@ var audioSpecificConfigCases = []struct {
 			ExtensionType:       ObjectTypePS,
 		},
 	},
+	{
+		"sbr (he-aac v1) 48khz stereo backward compatible",
+		[]byte{0x13, 0x10, 0x56, 0xe5, 0x98},
+		AudioSpecificConfig{
+			Type:                        ObjectTypeAACLC,
+			SampleRate:                  24000,
+			ChannelCount:                2,
+			ExtensionType:               ObjectTypeSBR,
+			ExtensionSampleRate:         48000,
+			BackwardCompatibleSignaling: true,
+		},
+	},
+	{
+		"ps (he-aac v2) 48khz stereo backward compatible",
+		[]byte{0x13, 0x10, 0x56, 0xe5, 0x9d, 0x48, 0x80},
+		AudioSpecificConfig{
+			Type:                        ObjectTypeAACLC,
+			SampleRate:                  24000,
+			ChannelCount:                2,
+			ExtensionType:               ObjectTypePS,
+			ExtensionSampleRate:         48000,
+			BackwardCompatibleSignaling: true,
+		},
+	},
 }
 
 func TestAudioSpecificConfigUnmarshal(t *testing.T) {
@@ -122,6 +216,63 @@ func TestAudioSpecificConfigUnmarshal(t *testing.T) {
 	}
 }
 
+func TestAudioSpecificConfigUnmarshalErrors(t *testing.T) {
+	var dec AudioSpecificConfig
+	err := dec.Unmarshal([]byte{0x31, 0x90}) // AAC scalable
+	require.EqualError(t, err, "unsupported object type: 6")
+
+	err = dec.Unmarshal([]byte{0x11, 0x91, 0x80})
+	require.EqualError(t, err, "extensionFlag3 is not supported")
+
+	err = dec.Unmarshal([]byte{0x12, 0x40})
+	require.EqualError(t, err, "invalid channel configuration (8)")
+}
+
+func TestAudioSpecificConfigUnmarshalLenient(t *testing.T) {
+	var dec AudioSpecificConfig
+	err := dec.UnmarshalWithOptions([]byte{0x12, 0x40}, AudioSpecificConfigUnmarshalOptions{Lenient: true})
+	require.NoError(t, err)
+	require.Equal(t, AudioSpecificConfig{
+		Type:       ObjectTypeAACLC,
+		SampleRate: 44100,
+	}, dec)
+
+	dec = AudioSpecificConfig{}
+	err = dec.UnmarshalWithOptions([]byte{0x11, 0x91, 0x80}, AudioSpecificConfigUnmarshalOptions{Lenient: true})
+	require.NoError(t, err)
+	require.Equal(t, AudioSpecificConfig{
+		Type:          ObjectTypeAACLC,
+		SampleRate:    48000,
+		ChannelCount:  2,
+		ExtensionData: []byte{0x80},
+	}, dec)
+
+	enc, err := dec.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x11, 0x91, 0x80}, enc)
+}
+
+func TestAudioSpecificConfigUnmarshalPrefix(t *testing.T) {
+	var dec AudioSpecificConfig
+	n, err := dec.UnmarshalPrefix([]byte{0x12, 0x10, 0xaa, 0xbb})
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.Equal(t, AudioSpecificConfig{
+		Type:         ObjectTypeAACLC,
+		SampleRate:   44100,
+		ChannelCount: 2,
+	}, dec)
+
+	// bits are rounded up to the next byte
+	dec = AudioSpecificConfig{}
+	n, err = dec.UnmarshalPrefix([]byte{0x2b, 0x92, 0x08, 0x00, 0xcc})
+	require.NoError(t, err)
+	require.Equal(t, 4, n)
+
+	_, err = dec.UnmarshalPrefix([]byte{0x12})
+	require.EqualError(t, err, "not enough bits")
+}
+
 func TestAudioSpecificConfigMarshal(t *testing.T) {
 	for _, ca := range audioSpecificConfigCases {
 		t.Run(ca.name, func(t *testing.T) {
@@ -132,6 +283,52 @@ func TestAudioSpecificConfigMarshal(t *testing.T) {
 	}
 }
 
+func TestAudioSpecificConfigMarshalNormalizeSampleRates(t *testing.T) {
+	enc, err := AudioSpecificConfig{
+		Type:               ObjectTypeAACLC,
+		SampleRate:         48000,
+		ExplicitSampleRate: true,
+		ChannelCount:       2,
+	}.MarshalWithOptions(AudioSpecificConfigMarshalOptions{
+		NormalizeSampleRates: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []byte{17, 144}, enc)
+
+	// sample rates without an index are always encoded explicitly
+	enc, err = AudioSpecificConfig{
+		Type:         ObjectTypeAACLC,
+		SampleRate:   53000,
+		ChannelCount: 2,
+	}.MarshalWithOptions(AudioSpecificConfigMarshalOptions{
+		NormalizeSampleRates: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x17, 0x80, 0x67, 0x84, 0x10}, enc)
+}
+
+func TestAudioSpecificConfigClone(t *testing.T) {
+	for _, ca := range audioSpecificConfigCases {
+		t.Run(ca.name, func(t *testing.T) {
+			var orig AudioSpecificConfig
+			err := orig.Unmarshal(ca.enc)
+			require.NoError(t, err)
+
+			c := orig.Clone()
+			require.Equal(t, orig, c)
+
+			for i := range c.ExtensionData {
+				c.ExtensionData[i]++
+			}
+			if c.ProgramConfigElement != nil {
+				c.ProgramConfigElement.FrontElements[0].TagSelect++
+				c.ProgramConfigElement.LFEElements[0]++
+			}
+			require.Equal(t, ca.dec, orig)
+		})
+	}
+}
+
 func TestAudioSpecificConfigMarshalErrors(t *testing.T) {
 	_, err := AudioSpecificConfig{
 		Type:         ObjectTypeAACLC,
@@ -139,6 +336,314 @@ func TestAudioSpecificConfigMarshalErrors(t *testing.T) {
 		ChannelCount: 0,
 	}.Marshal()
 	require.Error(t, err)
+
+	_, err = AudioSpecificConfig{
+		Type:         ObjectTypeAACLC,
+		SampleRate:   44100,
+		ChannelCount: 2,
+		ProgramConfigElement: &ProgramConfigElement{
+			FrontElements: []ProgramConfigElementChannel{{IsCPE: false}},
+		},
+	}.Marshal()
+	require.EqualError(t, err, "channel count (2) doesn't match program config element (1)")
+
+	_, err = AudioSpecificConfig{
+		Type:         ObjectTypeAACLC,
+		SampleRate:   0,
+		ChannelCount: 2,
+	}.Marshal()
+	require.EqualError(t, err, "invalid sample rate (0)")
+
+	_, err = AudioSpecificConfig{
+		Type:         ObjectTypeAACLC,
+		SampleRate:   0x1000000,
+		ChannelCount: 2,
+	}.Marshal()
+	require.EqualError(t, err, "invalid sample rate (16777216)")
+
+	_, err = AudioSpecificConfig{
+		Type:                ObjectTypeAACLC,
+		SampleRate:          24000,
+		ChannelCount:        2,
+		ExtensionType:       ObjectTypeSBR,
+		ExtensionSampleRate: -1,
+	}.Marshal()
+	require.EqualError(t, err, "invalid extension sample rate (-1)")
+}
+
+func TestAudioSpecificConfigMarshalToSmallBuffer(t *testing.T) {
+	c := AudioSpecificConfig{
+		Type:         ObjectTypeAACLC,
+		SampleRate:   44100,
+		ChannelCount: 2,
+	}
+
+	buf := make([]byte, 1)
+	pos := 0
+	err := c.MarshalTo(buf, &pos)
+	require.EqualError(t, err, "not enough bits")
+}
+
+func TestAudioSpecificConfigMarshalTo(t *testing.T) {
+	c := AudioSpecificConfig{
+		Type:         ObjectTypeAACLC,
+		SampleRate:   44100,
+		ChannelCount: 2,
+	}
+	require.Equal(t, 16, c.MarshalSizeBits())
+
+	buf := []byte{0xaa, 0x00, 0x00, 0xbb}
+	pos := 8
+	err := c.MarshalTo(buf, &pos)
+	require.NoError(t, err)
+	require.Equal(t, 24, pos)
+	require.Equal(t, []byte{0xaa, 0x12, 0x10, 0xbb}, buf)
+
+	var dec AudioSpecificConfig
+	pos = 8
+	err = dec.UnmarshalFromPos(buf, &pos)
+	require.NoError(t, err)
+	require.Equal(t, 24, pos)
+	require.Equal(t, c, dec)
+}
+
+func TestAudioSpecificConfigSamplesPerAccessUnit(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		conf AudioSpecificConfig
+		n    int
+	}{
+		{
+			"aac-lc",
+			AudioSpecificConfig{
+				Type:         ObjectTypeAACLC,
+				SampleRate:   44100,
+				ChannelCount: 2,
+			},
+			1024,
+		},
+		{
+			"aac-lc 960",
+			AudioSpecificConfig{
+				Type:            ObjectTypeAACLC,
+				SampleRate:      48000,
+				ChannelCount:    2,
+				FrameLengthFlag: true,
+			},
+			960,
+		},
+		{
+			"sbr",
+			AudioSpecificConfig{
+				Type:                ObjectTypeAACLC,
+				SampleRate:          24000,
+				ChannelCount:        2,
+				ExtensionType:       ObjectTypeSBR,
+				ExtensionSampleRate: 48000,
+			},
+			2048,
+		},
+		{
+			"ps 960",
+			AudioSpecificConfig{
+				Type:                ObjectTypeAACLC,
+				SampleRate:          24000,
+				ChannelCount:        1,
+				ExtensionType:       ObjectTypePS,
+				ExtensionSampleRate: 48000,
+				FrameLengthFlag:     true,
+			},
+			1920,
+		},
+		{
+			"downsampled sbr",
+			AudioSpecificConfig{
+				Type:                ObjectTypeAACLC,
+				SampleRate:          48000,
+				ChannelCount:        2,
+				ExtensionType:       ObjectTypeSBR,
+				ExtensionSampleRate: 48000,
+			},
+			1024,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			require.Equal(t, ca.n, ca.conf.SamplesPerAccessUnit())
+		})
+	}
+}
+
+func TestAudioSpecificConfigAccessUnitDuration(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		conf AudioSpecificConfig
+		d    time.Duration
+	}{
+		{
+			"aac-lc",
+			AudioSpecificConfig{
+				Type:         ObjectTypeAACLC,
+				SampleRate:   48000,
+				ChannelCount: 2,
+			},
+			21333333 * time.Nanosecond,
+		},
+		{
+			"aac-lc 960",
+			AudioSpecificConfig{
+				Type:            ObjectTypeAACLC,
+				SampleRate:      48000,
+				ChannelCount:    2,
+				FrameLengthFlag: true,
+			},
+			20 * time.Millisecond,
+		},
+		{
+			"sbr 960",
+			AudioSpecificConfig{
+				Type:                ObjectTypeAACLC,
+				SampleRate:          24000,
+				ChannelCount:        2,
+				ExtensionType:       ObjectTypeSBR,
+				ExtensionSampleRate: 48000,
+				FrameLengthFlag:     true,
+			},
+			40 * time.Millisecond,
+		},
+		{
+			"missing sample rate",
+			AudioSpecificConfig{
+				Type:         ObjectTypeAACLC,
+				ChannelCount: 2,
+			},
+			0,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			require.Equal(t, ca.d, ca.conf.AccessUnitDuration())
+		})
+	}
+}
+
+func TestAudioSpecificConfigPrimingSamples(t *testing.T) {
+	require.Equal(t, 2048, AudioSpecificConfig{
+		Type:         ObjectTypeAACLC,
+		SampleRate:   44100,
+		ChannelCount: 2,
+	}.PrimingSamples())
+
+	require.Equal(t, 4096, AudioSpecificConfig{
+		Type:                ObjectTypeAACLC,
+		SampleRate:          24000,
+		ChannelCount:        2,
+		ExtensionType:       ObjectTypeSBR,
+		ExtensionSampleRate: 48000,
+	}.PrimingSamples())
+
+	require.Equal(t, 1920, AudioSpecificConfig{
+		Type:            ObjectTypeAACLC,
+		SampleRate:      48000,
+		ChannelCount:    2,
+		FrameLengthFlag: true,
+	}.PrimingSamples())
+}
+
+func TestAudioSpecificConfigCodecString(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		conf AudioSpecificConfig
+		cs   string
+	}{
+		{
+			"aac-lc",
+			AudioSpecificConfig{
+				Type:         ObjectTypeAACLC,
+				SampleRate:   44100,
+				ChannelCount: 2,
+			},
+			"mp4a.40.2",
+		},
+		{
+			"aac-main",
+			AudioSpecificConfig{
+				Type:         ObjectTypeAACMain,
+				SampleRate:   44100,
+				ChannelCount: 2,
+			},
+			"mp4a.40.1",
+		},
+		{
+			"he-aac hierarchical",
+			AudioSpecificConfig{
+				Type:                ObjectTypeAACLC,
+				SampleRate:          24000,
+				ChannelCount:        2,
+				ExtensionType:       ObjectTypeSBR,
+				ExtensionSampleRate: 48000,
+			},
+			"mp4a.40.5",
+		},
+		{
+			"he-aac backward compatible",
+			AudioSpecificConfig{
+				Type:                        ObjectTypeAACLC,
+				SampleRate:                  24000,
+				ChannelCount:                2,
+				ExtensionType:               ObjectTypeSBR,
+				ExtensionSampleRate:         48000,
+				BackwardCompatibleSignaling: true,
+			},
+			"mp4a.40.5",
+		},
+		{
+			"he-aac v2",
+			AudioSpecificConfig{
+				Type:                ObjectTypeAACLC,
+				SampleRate:          24000,
+				ChannelCount:        1,
+				ExtensionType:       ObjectTypePS,
+				ExtensionSampleRate: 48000,
+			},
+			"mp4a.40.29",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			require.Equal(t, ca.cs, ca.conf.CodecString())
+		})
+	}
+}
+
+func TestAudioSpecificConfigEqual(t *testing.T) {
+	for _, ca := range audioSpecificConfigCases {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec AudioSpecificConfig
+			err := dec.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.True(t, dec.Equal(ca.dec))
+		})
+	}
+
+	// same config with different SBR signaling methods
+	var conf1 AudioSpecificConfig
+	err := conf1.Unmarshal([]byte{0x2b, 0x92, 0x08, 0x00})
+	require.NoError(t, err)
+
+	var conf2 AudioSpecificConfig
+	err = conf2.Unmarshal([]byte{0x13, 0x90, 0x56, 0xe5, 0xa0})
+	require.NoError(t, err)
+	require.NotEqual(t, conf1, conf2)
+	require.True(t, conf1.Equal(conf2))
+
+	conf2.ExtensionSampleRate = 48000
+	require.False(t, conf1.Equal(conf2))
+
+	conf2 = conf1
+	conf2.ChannelCount = 1
+	require.False(t, conf1.Equal(conf2))
+
+	conf2 = conf1
+	conf2.ExtensionData = []byte{0x00}
+	require.True(t, conf1.Equal(conf2))
 }
 
 func FuzzAudioSpecificConfigUnmarshal(f *testing.F) {
@@ -152,5 +657,11 @@ func FuzzAudioSpecificConfigUnmarshal(f *testing.F) {
 		if err == nil {
 			conf.Marshal() //nolint:errcheck
 		}
+
+		conf = AudioSpecificConfig{}
+		err = conf.UnmarshalWithOptions(b, AudioSpecificConfigUnmarshalOptions{Lenient: true})
+		if err == nil {
+			conf.Marshal() //nolint:errcheck
+		}
 	})
 }
@@ -0,0 +1,193 @@
+package mpeg4audio
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// descriptor tags.
+// Specification: ISO 14496-1, 7.2.2.1
+const (
+	esDescrTag            = 0x03
+	decoderConfigDescrTag = 0x04
+	decSpecificInfoTag    = 0x05
+)
+
+// object type indications of audio streams that carry an AudioSpecificConfig.
+// Specification: ISO 14496-1, 7.2.6.6.2
+const (
+	objectTypeIndicationAudioISO14496part3     = 0x40
+	objectTypeIndicationAudioISO13818part7Main = 0x66
+	objectTypeIndicationAudioISO13818part7LC   = 0x67
+	objectTypeIndicationAudioISO13818part7SSR  = 0x68
+)
+
+// Specification: ISO 14496-1, Table 6
+const (
+	streamTypeAudioStream = 0x05
+)
+
+// readDescriptor reads a descriptor and returns its tag, its content and its total size.
+// Specification: ISO 14496-1, 8.3.3
+func readDescriptor(buf []byte) (uint8, []byte, int, error) {
+	if len(buf) < 2 {
+		return 0, nil, 0, fmt.Errorf("not enough bytes")
+	}
+
+	tag := buf[0]
+	size := 0
+	n := 1
+
+	for i := 0; ; i++ {
+		if i == 4 {
+			return 0, nil, 0, fmt.Errorf("invalid descriptor size")
+		}
+
+		if n >= len(buf) {
+			return 0, nil, 0, fmt.Errorf("not enough bytes")
+		}
+
+		b := buf[n]
+		n++
+		size = size<<7 | int(b&0x7F)
+
+		if (b & 0x80) == 0 {
+			break
+		}
+	}
+
+	if (len(buf) - n) < size {
+		return 0, nil, 0, fmt.Errorf("not enough bytes")
+	}
+
+	return tag, buf[n : n+size], n + size, nil
+}
+
+// findDescriptor returns the content of the first descriptor with the given tag.
+func findDescriptor(buf []byte, tag uint8) ([]byte, error) {
+	for len(buf) != 0 {
+		curTag, content, n, err := readDescriptor(buf)
+		if err != nil {
+			return nil, err
+		}
+
+		if curTag == tag {
+			return content, nil
+		}
+
+		buf = buf[n:]
+	}
+
+	return nil, fmt.Errorf("descriptor with tag %d not found", tag)
+}
+
+// ESDS is the payload of an esds box that carries a MPEG-4 Audio stream.
+// It contains an ES_Descriptor, that contains a DecoderConfigDescriptor,
+// that contains the AudioSpecificConfig.
+// Specification: ISO 14496-14, 5.6 and ISO 14496-1, 7.2.6.5
+type ESDS struct {
+	ESID         uint16
+	BufferSizeDB uint32
+	MaxBitrate   uint32
+	AvgBitrate   uint32
+	Config       AudioSpecificConfig
+}
+
+// Unmarshal decodes an ESDS, starting from the version and flags fields.
+func (e *ESDS) Unmarshal(buf []byte) error {
+	// version and flags
+	if len(buf) < 4 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	if buf[0] != 0 {
+		return fmt.Errorf("unsupported esds version (%d)", buf[0])
+	}
+
+	tag, es, _, err := readDescriptor(buf[4:])
+	if err != nil {
+		return err
+	}
+
+	if tag != esDescrTag {
+		return fmt.Errorf("ES_Descriptor not found")
+	}
+
+	// ES_ID and flags
+	if len(es) < 3 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	e.ESID = binary.BigEndian.Uint16(es)
+	flags := es[2]
+	pos := 3
+
+	// streamDependenceFlag
+	if (flags & 0x80) != 0 {
+		pos += 2
+	}
+
+	// URL_Flag
+	if (flags & 0x40) != 0 {
+		if len(es) <= pos {
+			return fmt.Errorf("not enough bytes")
+		}
+		pos += 1 + int(es[pos])
+	}
+
+	// OCRstreamFlag
+	if (flags & 0x20) != 0 {
+		pos += 2
+	}
+
+	if len(es) < pos {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	dcd, err := findDescriptor(es[pos:], decoderConfigDescrTag)
+	if err != nil {
+		return err
+	}
+
+	// objectTypeIndication, streamType, bufferSizeDB, maxBitrate, avgBitrate
+	if len(dcd) < 13 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	switch oti := dcd[0]; oti {
+	case objectTypeIndicationAudioISO14496part3,
+		objectTypeIndicationAudioISO13818part7Main,
+		objectTypeIndicationAudioISO13818part7LC,
+		objectTypeIndicationAudioISO13818part7SSR:
+
+	default:
+		return fmt.Errorf("unsupported object type indication (0x%x)", oti)
+	}
+
+	if streamType := dcd[1] >> 2; streamType != streamTypeAudioStream {
+		return fmt.Errorf("unsupported stream type (0x%x)", streamType)
+	}
+
+	e.BufferSizeDB = uint32(dcd[2])<<16 | uint32(dcd[3])<<8 | uint32(dcd[4])
+	e.MaxBitrate = binary.BigEndian.Uint32(dcd[5:])
+	e.AvgBitrate = binary.BigEndian.Uint32(dcd[9:])
+
+	dsi, err := findDescriptor(dcd[13:], decSpecificInfoTag)
+	if err != nil {
+		return err
+	}
+
+	return e.Config.Unmarshal(dsi)
+}
+
+// UnmarshalESDS decodes the AudioSpecificConfig contained in the payload of an esds box,
+// starting from the version and flags fields.
+func UnmarshalESDS(buf []byte) (*AudioSpecificConfig, error) {
+	var e ESDS
+	err := e.Unmarshal(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &e.Config, nil
+}
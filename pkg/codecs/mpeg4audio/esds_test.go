@@ -0,0 +1,142 @@
+package mpeg4audio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesESDS = []struct {
+	name string
+	enc  []byte
+	dec  ESDS
+}{
+	{
+		"aac-lc",
+		[]byte{
+			0x00, 0x00, 0x00, 0x00, 0x03, 0x19, 0x00, 0x01,
+			0x00, 0x04, 0x11, 0x40, 0x15, 0x00, 0x00, 0x00,
+			0x00, 0x01, 0xf4, 0x00, 0x00, 0x01, 0xf4, 0x00,
+			0x05, 0x02, 0x12, 0x10, 0x06, 0x01, 0x02,
+		},
+		ESDS{
+			ESID:       1,
+			MaxBitrate: 128000,
+			AvgBitrate: 128000,
+			Config: AudioSpecificConfig{
+				Type:         ObjectTypeAACLC,
+				SampleRate:   44100,
+				ChannelCount: 2,
+			},
+		},
+	},
+	{
+		"padded sizes and optional fields",
+		[]byte{
+			0x00, 0x00, 0x00, 0x00, 0x03, 0x80, 0x80, 0x80,
+			0x28, 0x00, 0x02, 0xe0, 0x00, 0x01, 0x01, 0x61,
+			0x00, 0x03, 0x06, 0x80, 0x80, 0x80, 0x01, 0x02,
+			0x04, 0x80, 0x80, 0x80, 0x14, 0x67, 0x15, 0x00,
+			0x00, 0x00, 0x00, 0x01, 0xf4, 0x00, 0x00, 0x01,
+			0xf4, 0x00, 0x05, 0x80, 0x80, 0x80, 0x02, 0x11,
+			0x90,
+		},
+		ESDS{
+			ESID:       2,
+			MaxBitrate: 128000,
+			AvgBitrate: 128000,
+			Config: AudioSpecificConfig{
+				Type:         ObjectTypeAACLC,
+				SampleRate:   48000,
+				ChannelCount: 2,
+			},
+		},
+	},
+}
+
+func TestESDSUnmarshal(t *testing.T) {
+	for _, ca := range casesESDS {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec ESDS
+			err := dec.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestUnmarshalESDS(t *testing.T) {
+	for _, ca := range casesESDS {
+		t.Run(ca.name, func(t *testing.T) {
+			conf, err := UnmarshalESDS(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec.Config, *conf)
+		})
+	}
+}
+
+func TestUnmarshalESDSErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		enc  []byte
+		err  string
+	}{
+		{
+			"empty",
+			[]byte{},
+			"not enough bytes",
+		},
+		{
+			"missing ES_Descriptor",
+			[]byte{0x00, 0x00, 0x00, 0x00, 0x04, 0x00},
+			"ES_Descriptor not found",
+		},
+		{
+			"invalid size",
+			[]byte{0x00, 0x00, 0x00, 0x00, 0x03, 0x80, 0x80, 0x80, 0x80, 0x01},
+			"invalid descriptor size",
+		},
+		{
+			"video",
+			[]byte{
+				0x00, 0x00, 0x00, 0x00, 0x03, 0x12, 0x00, 0x01,
+				0x00, 0x04, 0x0d, 0x20, 0x11, 0x00, 0x00, 0x00,
+				0x00, 0x01, 0xf4, 0x00, 0x00, 0x01, 0xf4, 0x00,
+			},
+			"unsupported object type indication (0x20)",
+		},
+		{
+			"video stream type",
+			[]byte{
+				0x00, 0x00, 0x00, 0x00, 0x03, 0x12, 0x00, 0x01,
+				0x00, 0x04, 0x0d, 0x40, 0x11, 0x00, 0x00, 0x00,
+				0x00, 0x01, 0xf4, 0x00, 0x00, 0x01, 0xf4, 0x00,
+			},
+			"unsupported stream type (0x4)",
+		},
+		{
+			"missing DecoderSpecificInfo",
+			[]byte{
+				0x00, 0x00, 0x00, 0x00, 0x03, 0x12, 0x00, 0x01,
+				0x00, 0x04, 0x0d, 0x40, 0x15, 0x00, 0x00, 0x00,
+				0x00, 0x01, 0xf4, 0x00, 0x00, 0x01, 0xf4, 0x00,
+			},
+			"descriptor with tag 5 not found",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			_, err := UnmarshalESDS(ca.enc)
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func FuzzUnmarshalESDS(f *testing.F) {
+	for _, ca := range casesESDS {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		UnmarshalESDS(b) //nolint:errcheck
+	})
+}
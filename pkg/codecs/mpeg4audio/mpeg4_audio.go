@@ -5,6 +5,8 @@ const (
 	// MaxAccessUnitSize is the maximum size of an access unit.
 	MaxAccessUnitSize = 5 * 1024
 
-	// SamplesPerAccessUnit is the number of samples contained inside an access unit.
+	// SamplesPerAccessUnit is the number of samples contained inside an access unit,
+	// when frameLengthFlag is false. Use AudioSpecificConfig.SamplesPerAccessUnit()
+	// to take into account the 960-sample frame length and SBR / PS extensions.
 	SamplesPerAccessUnit = 1024
 )
@@ -1,12 +1,100 @@
 package mpeg4audio
 
+import (
+	"fmt"
+)
+
 // ObjectType is a MPEG-4 Audio object type.
 // Specification: ISO 14496-3, Table 1.17
 type ObjectType int
 
 // supported types.
 const (
-	ObjectTypeAACLC ObjectType = 2
-	ObjectTypeSBR   ObjectType = 5
-	ObjectTypePS    ObjectType = 29
+	ObjectTypeAACMain  ObjectType = 1
+	ObjectTypeAACLC    ObjectType = 2
+	ObjectTypeAACSSR   ObjectType = 3
+	ObjectTypeAACLTP   ObjectType = 4
+	ObjectTypeSBR      ObjectType = 5
+	ObjectTypeERAACLC  ObjectType = 17
+	ObjectTypeERAACLTP ObjectType = 19
+	ObjectTypePS       ObjectType = 29
 )
+
+var objectTypeNames = map[ObjectType]string{
+	ObjectTypeAACMain:  "AAC-Main",
+	ObjectTypeAACLC:    "AAC-LC",
+	ObjectTypeAACSSR:   "AAC-SSR",
+	ObjectTypeAACLTP:   "AAC-LTP",
+	ObjectTypeSBR:      "SBR",
+	6:                  "AAC-Scalable",
+	7:                  "TwinVQ",
+	8:                  "CELP",
+	9:                  "HVXC",
+	12:                 "TTSI",
+	13:                 "Main-Synthesis",
+	14:                 "Wavetable-Synthesis",
+	15:                 "General-MIDI",
+	16:                 "Algorithmic-Synthesis",
+	ObjectTypeERAACLC:  "ER-AAC-LC",
+	ObjectTypeERAACLTP: "ER-AAC-LTP",
+	20:                 "ER-AAC-Scalable",
+	21:                 "ER-TwinVQ",
+	22:                 "ER-BSAC",
+	23:                 "ER-AAC-LD",
+	24:                 "ER-CELP",
+	25:                 "ER-HVXC",
+	26:                 "ER-HILN",
+	27:                 "ER-Parametric",
+	28:                 "SSC",
+	ObjectTypePS:       "PS",
+	30:                 "MPEG-Surround",
+	32:                 "Layer-1",
+	33:                 "Layer-2",
+	34:                 "Layer-3",
+	35:                 "DST",
+	36:                 "ALS",
+	37:                 "SLS",
+	38:                 "SLS-Non-Core",
+	39:                 "ER-AAC-ELD",
+	40:                 "SMR-Simple",
+	41:                 "SMR-Main",
+	42:                 "USAC",
+	43:                 "SAOC",
+	44:                 "LD-MPEG-Surround",
+	45:                 "SAOC-DE",
+}
+
+// String implements fmt.Stringer.
+func (t ObjectType) String() string {
+	if n, ok := objectTypeNames[t]; ok {
+		return n
+	}
+	return fmt.Sprintf("unknown(%d)", int(t))
+}
+
+// isGeneralAudio returns whether the object type is decoded through a GASpecificConfig
+// that this package is able to handle.
+func (t ObjectType) isGeneralAudio() bool {
+	switch t {
+	case ObjectTypeAACMain, ObjectTypeAACLC, ObjectTypeAACSSR, ObjectTypeAACLTP,
+		ObjectTypeERAACLC, ObjectTypeERAACLTP:
+		return true
+	}
+	return false
+}
+
+func (t ObjectType) isErrorResilient() bool {
+	return t == ObjectTypeERAACLC || t == ObjectTypeERAACLTP
+}
+
+// extensionSizeBits returns the size of the GASpecificConfig extension.
+func (t ObjectType) extensionSizeBits() int {
+	if t.isErrorResilient() {
+		// aacSectionDataResilienceFlag, aacScalefactorDataResilienceFlag,
+		// aacSpectralDataResilienceFlag, extensionFlag3
+		return 4
+	}
+
+	// extensionFlag3
+	return 1
+}
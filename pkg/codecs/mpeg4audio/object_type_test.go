@@ -0,0 +1,15 @@
+package mpeg4audio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectTypeString(t *testing.T) {
+	require.Equal(t, "AAC-LC", ObjectTypeAACLC.String())
+	require.Equal(t, "AAC-Main", ObjectTypeAACMain.String())
+	require.Equal(t, "PS", ObjectTypePS.String())
+	require.Equal(t, "USAC", ObjectType(42).String())
+	require.Equal(t, "unknown(31)", ObjectType(31).String())
+}
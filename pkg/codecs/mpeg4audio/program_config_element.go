@@ -0,0 +1,477 @@
+package mpeg4audio
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/pkg/bits"
+)
+
+// ProgramConfigElementChannel is a front, side or back element of a ProgramConfigElement.
+type ProgramConfigElementChannel struct {
+	IsCPE     bool
+	TagSelect uint8
+}
+
+// ProgramConfigElementCC is a coupling channel element of a ProgramConfigElement.
+type ProgramConfigElementCC struct {
+	IsIndSw   bool
+	TagSelect uint8
+}
+
+// ProgramConfigElement is a program_config_element.
+// Specification: ISO 14496-3, Table 4.2
+type ProgramConfigElement struct {
+	ElementInstanceTag     uint8
+	ObjectType             uint8
+	SamplingFrequencyIndex uint8
+
+	FrontElements     []ProgramConfigElementChannel
+	SideElements      []ProgramConfigElementChannel
+	BackElements      []ProgramConfigElementChannel
+	LFEElements       []uint8
+	AssocDataElements []uint8
+	CCElements        []ProgramConfigElementCC
+
+	MonoMixdownPresent         bool
+	MonoMixdownElementNumber   uint8
+	StereoMixdownPresent       bool
+	StereoMixdownElementNumber uint8
+	MatrixMixdownIdxPresent    bool
+	MatrixMixdownIdx           uint8
+	PseudoSurroundEnable       bool
+
+	Comment []byte
+}
+
+func readProgramConfigElementChannels(buf []byte, pos *int, n uint64) ([]ProgramConfigElementChannel, error) {
+	if n == 0 {
+		return nil, nil
+	}
+
+	err := bits.HasSpace(buf, *pos, int(n)*5)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]ProgramConfigElementChannel, n)
+
+	for i := range ret {
+		ret[i].IsCPE = bits.ReadFlagUnsafe(buf, pos)
+		ret[i].TagSelect = uint8(bits.ReadBitsUnsafe(buf, pos, 4))
+	}
+
+	return ret, nil
+}
+
+// unmarshal decodes a ProgramConfigElement.
+// byte alignment is performed relative to alignStart.
+func (e *ProgramConfigElement) unmarshal(buf []byte, pos *int, alignStart int) error {
+	err := bits.HasSpace(buf, *pos, 4+2+4+4+4+4+2+3+4)
+	if err != nil {
+		return err
+	}
+
+	e.ElementInstanceTag = uint8(bits.ReadBitsUnsafe(buf, pos, 4))
+	e.ObjectType = uint8(bits.ReadBitsUnsafe(buf, pos, 2))
+	e.SamplingFrequencyIndex = uint8(bits.ReadBitsUnsafe(buf, pos, 4))
+	numFrontChannelElements := bits.ReadBitsUnsafe(buf, pos, 4)
+	numSideChannelElements := bits.ReadBitsUnsafe(buf, pos, 4)
+	numBackChannelElements := bits.ReadBitsUnsafe(buf, pos, 4)
+	numLFEChannelElements := bits.ReadBitsUnsafe(buf, pos, 2)
+	numAssocDataElements := bits.ReadBitsUnsafe(buf, pos, 3)
+	numValidCCElements := bits.ReadBitsUnsafe(buf, pos, 4)
+
+	e.MonoMixdownPresent, err = bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if e.MonoMixdownPresent {
+		var tmp uint64
+		tmp, err = bits.ReadBits(buf, pos, 4)
+		if err != nil {
+			return err
+		}
+		e.MonoMixdownElementNumber = uint8(tmp)
+	}
+
+	e.StereoMixdownPresent, err = bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if e.StereoMixdownPresent {
+		var tmp uint64
+		tmp, err = bits.ReadBits(buf, pos, 4)
+		if err != nil {
+			return err
+		}
+		e.StereoMixdownElementNumber = uint8(tmp)
+	}
+
+	e.MatrixMixdownIdxPresent, err = bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if e.MatrixMixdownIdxPresent {
+		err = bits.HasSpace(buf, *pos, 3)
+		if err != nil {
+			return err
+		}
+
+		e.MatrixMixdownIdx = uint8(bits.ReadBitsUnsafe(buf, pos, 2))
+		e.PseudoSurroundEnable = bits.ReadFlagUnsafe(buf, pos)
+	}
+
+	e.FrontElements, err = readProgramConfigElementChannels(buf, pos, numFrontChannelElements)
+	if err != nil {
+		return err
+	}
+
+	e.SideElements, err = readProgramConfigElementChannels(buf, pos, numSideChannelElements)
+	if err != nil {
+		return err
+	}
+
+	e.BackElements, err = readProgramConfigElementChannels(buf, pos, numBackChannelElements)
+	if err != nil {
+		return err
+	}
+
+	err = bits.HasSpace(buf, *pos, int(numLFEChannelElements+numAssocDataElements)*4+int(numValidCCElements)*5)
+	if err != nil {
+		return err
+	}
+
+	e.LFEElements = nil
+	for i := uint64(0); i < numLFEChannelElements; i++ {
+		e.LFEElements = append(e.LFEElements, uint8(bits.ReadBitsUnsafe(buf, pos, 4)))
+	}
+
+	e.AssocDataElements = nil
+	for i := uint64(0); i < numAssocDataElements; i++ {
+		e.AssocDataElements = append(e.AssocDataElements, uint8(bits.ReadBitsUnsafe(buf, pos, 4)))
+	}
+
+	e.CCElements = nil
+	for i := uint64(0); i < numValidCCElements; i++ {
+		e.CCElements = append(e.CCElements, ProgramConfigElementCC{
+			IsIndSw:   bits.ReadFlagUnsafe(buf, pos),
+			TagSelect: uint8(bits.ReadBitsUnsafe(buf, pos, 4)),
+		})
+	}
+
+	// byte_alignment()
+	if ((*pos - alignStart) % 8) != 0 {
+		*pos += 8 - ((*pos - alignStart) % 8)
+	}
+
+	commentFieldBytes, err := bits.ReadBits(buf, pos, 8)
+	if err != nil {
+		return err
+	}
+
+	err = bits.HasSpace(buf, *pos, int(commentFieldBytes)*8)
+	if err != nil {
+		return err
+	}
+
+	e.Comment = nil
+	if commentFieldBytes != 0 {
+		e.Comment = make([]byte, commentFieldBytes)
+		for i := range e.Comment {
+			e.Comment[i] = uint8(bits.ReadBitsUnsafe(buf, pos, 8))
+		}
+	}
+
+	return nil
+}
+
+// ChannelCount returns the number of channels described by the element layout.
+func (e ProgramConfigElement) ChannelCount() int {
+	n := len(e.LFEElements)
+
+	for _, els := range [][]ProgramConfigElementChannel{e.FrontElements, e.SideElements, e.BackElements} {
+		for _, el := range els {
+			if el.IsCPE {
+				n += 2
+			} else {
+				n++
+			}
+		}
+	}
+
+	return n
+}
+
+func cloneBytes(s []byte) []byte {
+	if s == nil {
+		return nil
+	}
+	return append(make([]byte, 0, len(s)), s...)
+}
+
+func cloneProgramConfigElementChannels(s []ProgramConfigElementChannel) []ProgramConfigElementChannel {
+	if s == nil {
+		return nil
+	}
+	return append(make([]ProgramConfigElementChannel, 0, len(s)), s...)
+}
+
+// Clone returns a copy of the ProgramConfigElement that doesn't share memory with the original.
+func (e ProgramConfigElement) Clone() ProgramConfigElement {
+	e.FrontElements = cloneProgramConfigElementChannels(e.FrontElements)
+	e.SideElements = cloneProgramConfigElementChannels(e.SideElements)
+	e.BackElements = cloneProgramConfigElementChannels(e.BackElements)
+	e.LFEElements = cloneBytes(e.LFEElements)
+	e.AssocDataElements = cloneBytes(e.AssocDataElements)
+	if e.CCElements != nil {
+		e.CCElements = append(make([]ProgramConfigElementCC, 0, len(e.CCElements)), e.CCElements...)
+	}
+	e.Comment = cloneBytes(e.Comment)
+	return e
+}
+
+// marshalSizeBits returns the size of the element in bits,
+// given the number of bits that separate it from the alignment start.
+func (e ProgramConfigElement) marshalSizeBits(offset int) int {
+	n := 4 + 2 + 4 + 4 + 4 + 4 + 2 + 3 + 4 + 1 + 1 + 1
+
+	if e.MonoMixdownPresent {
+		n += 4
+	}
+
+	if e.StereoMixdownPresent {
+		n += 4
+	}
+
+	if e.MatrixMixdownIdxPresent {
+		n += 3
+	}
+
+	n += (len(e.FrontElements) + len(e.SideElements) + len(e.BackElements)) * 5
+	n += (len(e.LFEElements) + len(e.AssocDataElements)) * 4
+	n += len(e.CCElements) * 5
+
+	if ((offset + n) % 8) != 0 {
+		n += 8 - ((offset + n) % 8)
+	}
+
+	n += 8 + len(e.Comment)*8
+
+	return n
+}
+
+func writeProgramConfigElementChannels(buf []byte, pos *int, els []ProgramConfigElementChannel) error {
+	var err error
+
+	for _, el := range els {
+		if el.IsCPE {
+			err = bits.WriteBits(buf, pos, 1, 1)
+			if err != nil {
+				return err
+			}
+		} else {
+			err = bits.WriteBits(buf, pos, 0, 1)
+			if err != nil {
+				return err
+			}
+		}
+		err = bits.WriteBits(buf, pos, uint64(el.TagSelect), 4)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// marshalTo encodes a ProgramConfigElement.
+// byte alignment is performed relative to alignStart.
+func (e ProgramConfigElement) marshalTo(buf []byte, pos *int, alignStart int) error {
+	if len(e.FrontElements) > 15 || len(e.SideElements) > 15 || len(e.BackElements) > 15 ||
+		len(e.LFEElements) > 3 || len(e.AssocDataElements) > 7 || len(e.CCElements) > 15 {
+		return fmt.Errorf("too many elements in program config element")
+	}
+
+	if len(e.Comment) > 255 {
+		return fmt.Errorf("program config element comment is too long")
+	}
+
+	var err error
+
+	err = bits.WriteBits(buf, pos, uint64(e.ElementInstanceTag), 4)
+	if err != nil {
+		return err
+	}
+
+	err = bits.WriteBits(buf, pos, uint64(e.ObjectType), 2)
+	if err != nil {
+		return err
+	}
+
+	err = bits.WriteBits(buf, pos, uint64(e.SamplingFrequencyIndex), 4)
+	if err != nil {
+		return err
+	}
+
+	err = bits.WriteBits(buf, pos, uint64(len(e.FrontElements)), 4)
+	if err != nil {
+		return err
+	}
+
+	err = bits.WriteBits(buf, pos, uint64(len(e.SideElements)), 4)
+	if err != nil {
+		return err
+	}
+
+	err = bits.WriteBits(buf, pos, uint64(len(e.BackElements)), 4)
+	if err != nil {
+		return err
+	}
+
+	err = bits.WriteBits(buf, pos, uint64(len(e.LFEElements)), 2)
+	if err != nil {
+		return err
+	}
+
+	err = bits.WriteBits(buf, pos, uint64(len(e.AssocDataElements)), 3)
+	if err != nil {
+		return err
+	}
+
+	err = bits.WriteBits(buf, pos, uint64(len(e.CCElements)), 4)
+	if err != nil {
+		return err
+	}
+
+	if e.MonoMixdownPresent {
+		err = bits.WriteBits(buf, pos, 1, 1)
+		if err != nil {
+			return err
+		}
+
+		err = bits.WriteBits(buf, pos, uint64(e.MonoMixdownElementNumber), 4)
+		if err != nil {
+			return err
+		}
+	} else {
+		err = bits.WriteBits(buf, pos, 0, 1)
+		if err != nil {
+			return err
+		}
+	}
+
+	if e.StereoMixdownPresent {
+		err = bits.WriteBits(buf, pos, 1, 1)
+		if err != nil {
+			return err
+		}
+
+		err = bits.WriteBits(buf, pos, uint64(e.StereoMixdownElementNumber), 4)
+		if err != nil {
+			return err
+		}
+	} else {
+		err = bits.WriteBits(buf, pos, 0, 1)
+		if err != nil {
+			return err
+		}
+	}
+
+	if e.MatrixMixdownIdxPresent {
+		err = bits.WriteBits(buf, pos, 1, 1)
+		if err != nil {
+			return err
+		}
+
+		err = bits.WriteBits(buf, pos, uint64(e.MatrixMixdownIdx), 2)
+		if err != nil {
+			return err
+		}
+
+		if e.PseudoSurroundEnable {
+			err = bits.WriteBits(buf, pos, 1, 1)
+			if err != nil {
+				return err
+			}
+		} else {
+			err = bits.WriteBits(buf, pos, 0, 1)
+			if err != nil {
+				return err
+			}
+		}
+	} else {
+		err = bits.WriteBits(buf, pos, 0, 1)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = writeProgramConfigElementChannels(buf, pos, e.FrontElements)
+	if err != nil {
+		return err
+	}
+
+	err = writeProgramConfigElementChannels(buf, pos, e.SideElements)
+	if err != nil {
+		return err
+	}
+
+	err = writeProgramConfigElementChannels(buf, pos, e.BackElements)
+	if err != nil {
+		return err
+	}
+
+	for _, tag := range e.LFEElements {
+		err = bits.WriteBits(buf, pos, uint64(tag), 4)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, tag := range e.AssocDataElements {
+		err = bits.WriteBits(buf, pos, uint64(tag), 4)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, el := range e.CCElements {
+		if el.IsIndSw {
+			err = bits.WriteBits(buf, pos, 1, 1)
+			if err != nil {
+				return err
+			}
+		} else {
+			err = bits.WriteBits(buf, pos, 0, 1)
+			if err != nil {
+				return err
+			}
+		}
+		err = bits.WriteBits(buf, pos, uint64(el.TagSelect), 4)
+		if err != nil {
+			return err
+		}
+	}
+
+	// byte_alignment()
+	if ((*pos - alignStart) % 8) != 0 {
+		*pos += 8 - ((*pos - alignStart) % 8)
+	}
+
+	err = bits.WriteBits(buf, pos, uint64(len(e.Comment)), 8)
+	if err != nil {
+		return err
+	}
+
+	for _, b := range e.Comment {
+		err = bits.WriteBits(buf, pos, uint64(b), 8)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
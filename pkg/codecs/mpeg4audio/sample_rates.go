@@ -31,3 +31,19 @@ var reverseSampleRates = map[int]int{
 	8000:  11,
 	7350:  12,
 }
+
+// SampleRateToIndex returns the sampling frequency index of a sample rate.
+// Specification: ISO 14496-3, Table 1.18
+func SampleRateToIndex(rate int) (int, bool) {
+	index, ok := reverseSampleRates[rate]
+	return index, ok
+}
+
+// IndexToSampleRate returns the sample rate of a sampling frequency index.
+// Specification: ISO 14496-3, Table 1.18
+func IndexToSampleRate(index int) (int, bool) {
+	if index < 0 || index >= len(sampleRates) {
+		return 0, false
+	}
+	return sampleRates[index], true
+}
@@ -0,0 +1,32 @@
+package mpeg4audio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSampleRateToIndex(t *testing.T) {
+	index, ok := SampleRateToIndex(48000)
+	require.True(t, ok)
+	require.Equal(t, 3, index)
+
+	index, ok = SampleRateToIndex(7350)
+	require.True(t, ok)
+	require.Equal(t, 12, index)
+
+	_, ok = SampleRateToIndex(53000)
+	require.False(t, ok)
+}
+
+func TestIndexToSampleRate(t *testing.T) {
+	rate, ok := IndexToSampleRate(4)
+	require.True(t, ok)
+	require.Equal(t, 44100, rate)
+
+	_, ok = IndexToSampleRate(13)
+	require.False(t, ok)
+
+	_, ok = IndexToSampleRate(-1)
+	require.False(t, ok)
+}
@@ -24,6 +24,9 @@ type StreamMuxConfigProgram struct {
 // StreamMuxConfig is a StreamMuxConfig.
 // Specification: ISO 14496-3, Table 1.42
 type StreamMuxConfig struct {
+	AudioMuxVersion    uint
+	TaraBufferFullness uint32 // audioMuxVersion = 1 only
+
 	NumSubFrames     uint
 	Programs         []*StreamMuxConfigProgram
 	OtherDataPresent bool
@@ -32,27 +35,84 @@ type StreamMuxConfig struct {
 	CRCCheckSum      uint8
 }
 
+func latmGetValue(buf []byte, pos *int) (uint32, error) {
+	bytesForValue, err := bits.ReadBits(buf, pos, 2)
+	if err != nil {
+		return 0, err
+	}
+
+	tmp, err := bits.ReadBits(buf, pos, 8*(int(bytesForValue)+1))
+	if err != nil {
+		return 0, err
+	}
+
+	return uint32(tmp), nil
+}
+
+func latmValueMarshalSizeBits(v uint32) int {
+	n := 1
+	for v > 0xFF {
+		v >>= 8
+		n++
+	}
+	return 2 + 8*n
+}
+
+func latmPutValue(buf []byte, pos *int, v uint32) error {
+	n := (latmValueMarshalSizeBits(v) - 2) / 8
+	err := bits.WriteBits(buf, pos, uint64(n-1), 2)
+	if err != nil {
+		return err
+	}
+
+	return bits.WriteBits(buf, pos, uint64(v), 8*n)
+}
+
 // Unmarshal decodes a StreamMuxConfig.
 func (c *StreamMuxConfig) Unmarshal(buf []byte) error {
 	pos := 0
+	return c.unmarshal(buf, &pos)
+}
 
-	err := bits.HasSpace(buf, pos, 12)
+func (c *StreamMuxConfig) unmarshal(buf []byte, pos *int) error {
+	audioMuxVersion, err := bits.ReadFlag(buf, pos)
 	if err != nil {
 		return err
 	}
 
-	audioMuxVersion := bits.ReadFlagUnsafe(buf, &pos)
 	if audioMuxVersion {
-		return fmt.Errorf("audioMuxVersion = 1 is not supported")
+		c.AudioMuxVersion = 1
+
+		var audioMuxVersionA bool
+		audioMuxVersionA, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if audioMuxVersionA {
+			return fmt.Errorf("audioMuxVersionA = 1 is not supported")
+		}
+
+		c.TaraBufferFullness, err = latmGetValue(buf, pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		c.AudioMuxVersion = 0
 	}
 
-	allStreamsSameTimeFraming := bits.ReadFlagUnsafe(buf, &pos)
+	err = bits.HasSpace(buf, *pos, 11)
+	if err != nil {
+		return err
+	}
+
+	allStreamsSameTimeFraming := bits.ReadFlagUnsafe(buf, pos)
 	if !allStreamsSameTimeFraming {
 		return fmt.Errorf("allStreamsSameTimeFraming = 0 is not supported")
 	}
 
-	c.NumSubFrames = uint(bits.ReadBitsUnsafe(buf, &pos, 6))
-	numProgram := uint(bits.ReadBitsUnsafe(buf, &pos, 4))
+	c.NumSubFrames = uint(bits.ReadBitsUnsafe(buf, pos, 6))
+	numProgram := uint(bits.ReadBitsUnsafe(buf, pos, 4))
 
 	c.Programs = make([]*StreamMuxConfigProgram, numProgram+1)
 
@@ -61,7 +121,7 @@ func (c *StreamMuxConfig) Unmarshal(buf []byte) error {
 		c.Programs[prog] = p
 
 		var numLayer uint64
-		numLayer, err = bits.ReadBits(buf, &pos, 3)
+		numLayer, err = bits.ReadBits(buf, pos, 3)
 		if err != nil {
 			return err
 		}
@@ -77,7 +137,7 @@ func (c *StreamMuxConfig) Unmarshal(buf []byte) error {
 			if prog == 0 && lay == 0 {
 				useSameConfig = false
 			} else {
-				useSameConfig, err = bits.ReadFlag(buf, &pos)
+				useSameConfig, err = bits.ReadFlag(buf, pos)
 				if err != nil {
 					return err
 				}
@@ -85,14 +145,42 @@ func (c *StreamMuxConfig) Unmarshal(buf []byte) error {
 
 			if !useSameConfig {
 				l.AudioSpecificConfig = &AudioSpecificConfig{}
-				err = l.AudioSpecificConfig.UnmarshalFromPos(buf, &pos)
-				if err != nil {
-					return err
+
+				if c.AudioMuxVersion == 0 {
+					err = l.AudioSpecificConfig.UnmarshalFromPos(buf, pos)
+					if err != nil {
+						return err
+					}
+				} else {
+					var ascLen uint32
+					ascLen, err = latmGetValue(buf, pos)
+					if err != nil {
+						return err
+					}
+
+					ascStart := *pos
+
+					err = l.AudioSpecificConfig.UnmarshalFromPos(buf, pos)
+					if err != nil {
+						return err
+					}
+
+					used := uint32(*pos - ascStart)
+					if used > ascLen {
+						return fmt.Errorf("invalid ascLen")
+					}
+
+					// fillBits
+					err = bits.HasSpace(buf, *pos, int(ascLen-used))
+					if err != nil {
+						return err
+					}
+					*pos += int(ascLen - used)
 				}
 			}
 
 			var tmp uint64
-			tmp, err = bits.ReadBits(buf, &pos, 3)
+			tmp, err = bits.ReadBits(buf, pos, 3)
 			if err != nil {
 				// support truncated configs
 				l.LatmBufferFullness = 255
@@ -104,28 +192,28 @@ func (c *StreamMuxConfig) Unmarshal(buf []byte) error {
 
 			switch l.FrameLengthType {
 			case 0:
-				tmp, err = bits.ReadBits(buf, &pos, 8)
+				tmp, err = bits.ReadBits(buf, pos, 8)
 				if err != nil {
 					return err
 				}
 				l.LatmBufferFullness = uint(tmp)
 
 			case 1:
-				tmp, err = bits.ReadBits(buf, &pos, 9)
+				tmp, err = bits.ReadBits(buf, pos, 9)
 				if err != nil {
 					return err
 				}
 				l.FrameLength = uint(tmp)
 
 			case 4, 5, 3:
-				tmp, err = bits.ReadBits(buf, &pos, 6)
+				tmp, err = bits.ReadBits(buf, pos, 6)
 				if err != nil {
 					return err
 				}
 				l.CELPframeLengthTableIndex = uint(tmp)
 
 			case 6, 7:
-				l.HVXCframeLengthTableIndex, err = bits.ReadFlag(buf, &pos)
+				l.HVXCframeLengthTableIndex, err = bits.ReadFlag(buf, pos)
 				if err != nil {
 					return err
 				}
@@ -133,37 +221,32 @@ func (c *StreamMuxConfig) Unmarshal(buf []byte) error {
 		}
 	}
 
-	c.OtherDataPresent, err = bits.ReadFlag(buf, &pos)
+	c.OtherDataPresent, err = bits.ReadFlag(buf, pos)
 	if err != nil {
 		return err
 	}
 
 	if c.OtherDataPresent {
-		for {
-			c.OtherDataLenBits *= 256
-
-			err = bits.HasSpace(buf, pos, 9)
+		if c.AudioMuxVersion == 1 {
+			c.OtherDataLenBits, err = latmGetValue(buf, pos)
 			if err != nil {
 				return err
 			}
-
-			otherDataLenEsc := bits.ReadFlagUnsafe(buf, &pos)
-			otherDataLenTmp := uint32(bits.ReadBitsUnsafe(buf, &pos, 8))
-			c.OtherDataLenBits += otherDataLenTmp
-
-			if !otherDataLenEsc {
-				break
+		} else {
+			err = c.unmarshalOtherDataLenBits(buf, pos)
+			if err != nil {
+				return err
 			}
 		}
 	}
 
-	c.CRCCheckPresent, err = bits.ReadFlag(buf, &pos)
+	c.CRCCheckPresent, err = bits.ReadFlag(buf, pos)
 	if err != nil {
 		return err
 	}
 
 	if c.CRCCheckPresent {
-		tmp, err := bits.ReadBits(buf, &pos, 8)
+		tmp, err := bits.ReadBits(buf, pos, 8)
 		if err != nil {
 			return err
 		}
@@ -173,9 +256,36 @@ func (c *StreamMuxConfig) Unmarshal(buf []byte) error {
 	return nil
 }
 
-func (c StreamMuxConfig) marshalSize() int {
+func (c *StreamMuxConfig) unmarshalOtherDataLenBits(buf []byte, pos *int) error {
+	c.OtherDataLenBits = 0
+
+	for {
+		c.OtherDataLenBits *= 256
+
+		err := bits.HasSpace(buf, *pos, 9)
+		if err != nil {
+			return err
+		}
+
+		otherDataLenEsc := bits.ReadFlagUnsafe(buf, pos)
+		otherDataLenTmp := uint32(bits.ReadBitsUnsafe(buf, pos, 8))
+		c.OtherDataLenBits += otherDataLenTmp
+
+		if !otherDataLenEsc {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (c StreamMuxConfig) marshalSizeBits() int {
 	n := 12
 
+	if c.AudioMuxVersion == 1 {
+		n += 1 + latmValueMarshalSizeBits(c.TaraBufferFullness)
+	}
+
 	for prog, p := range c.Programs {
 		n += 3
 
@@ -185,7 +295,12 @@ func (c StreamMuxConfig) marshalSize() int {
 			}
 
 			if l.AudioSpecificConfig != nil {
-				n += l.AudioSpecificConfig.marshalSizeBits()
+				ascLen := l.AudioSpecificConfig.marshalSizeBits(false)
+				n += ascLen
+
+				if c.AudioMuxVersion == 1 {
+					n += latmValueMarshalSizeBits(uint32(ascLen))
+				}
 			}
 
 			n += 3
@@ -209,13 +324,17 @@ func (c StreamMuxConfig) marshalSize() int {
 	n++ // otherDataPresent
 
 	if c.OtherDataPresent {
-		tmp := c.OtherDataLenBits
-		for {
-			tmp /= 256
-			n += 9
+		if c.AudioMuxVersion == 1 {
+			n += latmValueMarshalSizeBits(c.OtherDataLenBits)
+		} else {
+			tmp := c.OtherDataLenBits
+			for {
+				tmp /= 256
+				n += 9
 
-			if tmp == 0 {
-				break
+				if tmp == 0 {
+					break
+				}
 			}
 		}
 	}
@@ -226,6 +345,12 @@ func (c StreamMuxConfig) marshalSize() int {
 		n += 8
 	}
 
+	return n
+}
+
+func (c StreamMuxConfig) marshalSize() int {
+	n := c.marshalSizeBits()
+
 	ret := n / 8
 	if (n % 8) != 0 {
 		ret++
@@ -239,54 +364,150 @@ func (c StreamMuxConfig) Marshal() ([]byte, error) {
 	buf := make([]byte, c.marshalSize())
 	pos := 0
 
-	bits.WriteBits(buf, &pos, 0, 1) // audioMuxVersion
-	bits.WriteBits(buf, &pos, 1, 1) // allStreamsSameTimeFraming
-	bits.WriteBits(buf, &pos, uint64(c.NumSubFrames), 6)
-	bits.WriteBits(buf, &pos, uint64(len(c.Programs)-1), 4)
+	err := c.marshalTo(buf, &pos)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+func (c StreamMuxConfig) marshalTo(buf []byte, pos *int) error {
+	if c.AudioMuxVersion > 1 {
+		return fmt.Errorf("unsupported audioMuxVersion (%d)", c.AudioMuxVersion)
+	}
+
+	var err error
+
+	if c.AudioMuxVersion == 1 {
+		err = bits.WriteBits(buf, pos, 1, 1) // audioMuxVersion
+		if err != nil {
+			return err
+		}
+
+		err = bits.WriteBits(buf, pos, 0, 1) // audioMuxVersionA
+		if err != nil {
+			return err
+		}
+
+		err = latmPutValue(buf, pos, c.TaraBufferFullness)
+		if err != nil {
+			return err
+		}
+	} else {
+		err = bits.WriteBits(buf, pos, 0, 1) // audioMuxVersion
+		if err != nil {
+			return err
+		}
+	}
+
+	err = bits.WriteBits(buf, pos, 1, 1) // allStreamsSameTimeFraming
+	if err != nil {
+		return err
+	}
+
+	err = bits.WriteBits(buf, pos, uint64(c.NumSubFrames), 6)
+	if err != nil {
+		return err
+	}
+
+	err = bits.WriteBits(buf, pos, uint64(len(c.Programs)-1), 4)
+	if err != nil {
+		return err
+	}
 
 	for prog, p := range c.Programs {
-		bits.WriteBits(buf, &pos, uint64(len(p.Layers)-1), 3)
+		err = bits.WriteBits(buf, pos, uint64(len(p.Layers)-1), 3)
+		if err != nil {
+			return err
+		}
 
 		for lay, l := range p.Layers {
 			if prog != 0 || lay != 0 {
 				if l.AudioSpecificConfig != nil {
-					bits.WriteBits(buf, &pos, 0, 1)
+					err = bits.WriteBits(buf, pos, 0, 1)
+					if err != nil {
+						return err
+					}
 				} else {
-					bits.WriteBits(buf, &pos, 1, 1)
+					err = bits.WriteBits(buf, pos, 1, 1)
+					if err != nil {
+						return err
+					}
 				}
 			}
 
 			if l.AudioSpecificConfig != nil {
-				err := l.AudioSpecificConfig.marshalTo(buf, &pos)
+				if c.AudioMuxVersion == 1 {
+					err = latmPutValue(buf, pos, uint32(l.AudioSpecificConfig.marshalSizeBits(false)))
+					if err != nil {
+						return err
+					}
+				}
+
+				err = l.AudioSpecificConfig.marshalTo(buf, pos, false)
 				if err != nil {
-					return nil, err
+					return err
 				}
 			}
 
-			bits.WriteBits(buf, &pos, uint64(l.FrameLengthType), 3)
+			err = bits.WriteBits(buf, pos, uint64(l.FrameLengthType), 3)
+			if err != nil {
+				return err
+			}
 
 			switch l.FrameLengthType {
 			case 0:
-				bits.WriteBits(buf, &pos, uint64(l.LatmBufferFullness), 8)
+				err = bits.WriteBits(buf, pos, uint64(l.LatmBufferFullness), 8)
+				if err != nil {
+					return err
+				}
 
 			case 1:
-				bits.WriteBits(buf, &pos, uint64(l.FrameLength), 9)
+				err = bits.WriteBits(buf, pos, uint64(l.FrameLength), 9)
+				if err != nil {
+					return err
+				}
 
 			case 4, 5, 3:
-				bits.WriteBits(buf, &pos, uint64(l.CELPframeLengthTableIndex), 6)
+				err = bits.WriteBits(buf, pos, uint64(l.CELPframeLengthTableIndex), 6)
+				if err != nil {
+					return err
+				}
 
 			case 6, 7:
 				if l.HVXCframeLengthTableIndex {
-					bits.WriteBits(buf, &pos, 1, 1)
+					err = bits.WriteBits(buf, pos, 1, 1)
+					if err != nil {
+						return err
+					}
 				} else {
-					bits.WriteBits(buf, &pos, 0, 1)
+					err = bits.WriteBits(buf, pos, 0, 1)
+					if err != nil {
+						return err
+					}
 				}
 			}
 		}
 	}
 
-	if c.OtherDataPresent {
-		bits.WriteBits(buf, &pos, 1, 1)
+	switch {
+	case c.OtherDataPresent && c.AudioMuxVersion == 1:
+		err = bits.WriteBits(buf, pos, 1, 1)
+		if err != nil {
+			return err
+		}
+
+		err = latmPutValue(buf, pos, c.OtherDataLenBits)
+		if err != nil {
+			return err
+		}
+
+	case c.OtherDataPresent:
+		err = bits.WriteBits(buf, pos, 1, 1)
+		if err != nil {
+			return err
+		}
 
 		var lenBytes []byte
 		tmp := c.OtherDataLenBits
@@ -303,22 +524,89 @@ func (c StreamMuxConfig) Marshal() ([]byte, error) {
 		}
 
 		for i := len(lenBytes) - 1; i > 0; i-- {
-			bits.WriteBits(buf, &pos, 1, 1)
-			bits.WriteBits(buf, &pos, uint64(lenBytes[i]), 8)
+			err = bits.WriteBits(buf, pos, 1, 1)
+			if err != nil {
+				return err
+			}
+
+			err = bits.WriteBits(buf, pos, uint64(lenBytes[i]), 8)
+			if err != nil {
+				return err
+			}
 		}
 
-		bits.WriteBits(buf, &pos, 0, 1)
-		bits.WriteBits(buf, &pos, uint64(lenBytes[0]), 8)
-	} else {
-		bits.WriteBits(buf, &pos, 0, 1)
+		err = bits.WriteBits(buf, pos, 0, 1)
+		if err != nil {
+			return err
+		}
+
+		err = bits.WriteBits(buf, pos, uint64(lenBytes[0]), 8)
+		if err != nil {
+			return err
+		}
+
+	default:
+		err = bits.WriteBits(buf, pos, 0, 1)
+		if err != nil {
+			return err
+		}
 	}
 
 	if c.CRCCheckPresent {
-		bits.WriteBits(buf, &pos, 1, 1)
-		bits.WriteBits(buf, &pos, uint64(c.CRCCheckSum), 8)
+		err = bits.WriteBits(buf, pos, 1, 1)
+		if err != nil {
+			return err
+		}
+
+		err = bits.WriteBits(buf, pos, uint64(c.CRCCheckSum), 8)
+		if err != nil {
+			return err
+		}
 	} else {
-		bits.WriteBits(buf, &pos, 0, 1)
+		err = bits.WriteBits(buf, pos, 0, 1)
+		if err != nil {
+			return err
+		}
 	}
 
-	return buf, nil
+	return nil
+}
+
+// Clone returns a copy of the StreamMuxConfig that doesn't share memory with the original.
+func (c StreamMuxConfig) Clone() StreamMuxConfig {
+	if c.Programs == nil {
+		return c
+	}
+
+	programs := make([]*StreamMuxConfigProgram, len(c.Programs))
+
+	for i, p := range c.Programs {
+		if p == nil {
+			continue
+		}
+
+		p2 := *p
+
+		if p.Layers != nil {
+			p2.Layers = make([]*StreamMuxConfigLayer, len(p.Layers))
+
+			for j, l := range p.Layers {
+				if l == nil {
+					continue
+				}
+
+				l2 := *l
+				if l.AudioSpecificConfig != nil {
+					asc := l.AudioSpecificConfig.Clone()
+					l2.AudioSpecificConfig = &asc
+				}
+				p2.Layers[j] = &l2
+			}
+		}
+
+		programs[i] = &p2
+	}
+
+	c.Programs = programs
+	return c
 }
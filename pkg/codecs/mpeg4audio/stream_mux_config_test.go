@@ -119,6 +119,24 @@ var streamMuxConfigCases = []struct {
 			CRCCheckSum:      64,
 		},
 	},
+	{
+		"audio mux version 1",
+		[]byte{0x8f, 0xf8, 0x00, 0x01, 0x01, 0x19, 0x01, 0xfe, 0x00},
+		StreamMuxConfig{
+			AudioMuxVersion:    1,
+			TaraBufferFullness: 255,
+			Programs: []*StreamMuxConfigProgram{{
+				Layers: []*StreamMuxConfigLayer{{
+					AudioSpecificConfig: &AudioSpecificConfig{
+						Type:         2,
+						SampleRate:   48000,
+						ChannelCount: 2,
+					},
+					LatmBufferFullness: 255,
+				}},
+			}},
+		},
+	},
 }
 
 func TestStreamMuxConfigUnmarshal(t *testing.T) {
@@ -132,6 +150,29 @@ func TestStreamMuxConfigUnmarshal(t *testing.T) {
 	}
 }
 
+func TestStreamMuxConfigClone(t *testing.T) {
+	for _, ca := range streamMuxConfigCases {
+		t.Run(ca.name, func(t *testing.T) {
+			var orig StreamMuxConfig
+			err := orig.Unmarshal(ca.enc)
+			require.NoError(t, err)
+
+			c := orig.Clone()
+			require.Equal(t, orig, c)
+
+			for _, p := range c.Programs {
+				for _, l := range p.Layers {
+					l.FrameLength++
+					if l.AudioSpecificConfig != nil {
+						l.AudioSpecificConfig.ChannelCount++
+					}
+				}
+			}
+			require.Equal(t, ca.dec, orig)
+		})
+	}
+}
+
 func TestStreamMuxConfigUnmarshalTruncated(t *testing.T) {
 	var dec StreamMuxConfig
 	err := dec.Unmarshal([]byte{0x40, 0x00, 0x23, 0x10})
@@ -0,0 +1,255 @@
+package opus
+
+import (
+	"fmt"
+)
+
+var opusHeadMagic = []byte{'O', 'p', 'u', 's', 'H', 'e', 'a', 'd'}
+
+// DecoderConfiguration is the decoder configuration of an Opus stream.
+// It is carried by the OpusSpecificBox (dOps) in MP4 and by the
+// identification header (OpusHead) in Ogg and Matroska.
+// Specification: Encapsulation of Opus in ISO Base Media File Format, 4.3.2
+// Specification: RFC7845, 5.1
+type DecoderConfiguration struct {
+	ChannelCount         int
+	PreSkip              uint16
+	InputSampleRate      uint32
+	OutputGain           int16
+	ChannelMappingFamily uint8
+
+	// channel mapping table, present when ChannelMappingFamily is not 0.
+	StreamCount    uint8
+	CoupledCount   uint8
+	ChannelMapping []uint8
+}
+
+// stream count, coupled count and channel mapping used by channel mapping family 1,
+// indexed by channel count minus one.
+// Specification: RFC7845, 5.1.1.2
+var family1Mappings = []struct {
+	streamCount    uint8
+	coupledCount   uint8
+	channelMapping []uint8
+}{
+	{1, 0, []uint8{0}},
+	{1, 1, []uint8{0, 1}},
+	{2, 1, []uint8{0, 2, 1}},
+	{2, 2, []uint8{0, 1, 2, 3}},
+	{3, 2, []uint8{0, 4, 1, 2, 3}},
+	{4, 2, []uint8{0, 4, 1, 2, 3, 5}},
+	{4, 3, []uint8{0, 4, 1, 2, 3, 5, 6}},
+	{5, 3, []uint8{0, 6, 1, 2, 3, 4, 5, 7}},
+}
+
+// NewDecoderConfiguration allocates a DecoderConfiguration that describes
+// a stream with the given channel count and pre-skip.
+// Mono and stereo streams use channel mapping family 0,
+// while streams with up to 8 channels use channel mapping family 1,
+// with the Vorbis channel order and the stream layout of the reference encoder.
+func NewDecoderConfiguration(channelCount int, preSkip uint16) (*DecoderConfiguration, error) {
+	if channelCount < 1 || channelCount > len(family1Mappings) {
+		return nil, fmt.Errorf("invalid channel count (%d)", channelCount)
+	}
+
+	c := &DecoderConfiguration{
+		ChannelCount:    channelCount,
+		PreSkip:         preSkip,
+		InputSampleRate: 48000,
+	}
+
+	if channelCount > 2 {
+		m := family1Mappings[channelCount-1]
+		c.ChannelMappingFamily = 1
+		c.StreamCount = m.streamCount
+		c.CoupledCount = m.coupledCount
+		c.ChannelMapping = append([]uint8(nil), m.channelMapping...)
+	}
+
+	return c, nil
+}
+
+func (c DecoderConfiguration) validate() error {
+	if c.ChannelCount < 1 || c.ChannelCount > 255 {
+		return fmt.Errorf("invalid channel count (%d)", c.ChannelCount)
+	}
+
+	switch c.ChannelMappingFamily {
+	case 0:
+		if c.ChannelCount > 2 {
+			return fmt.Errorf("channel mapping family 0 supports up to 2 channels, got %d", c.ChannelCount)
+		}
+		return nil
+
+	case 1:
+		if c.ChannelCount > 8 {
+			return fmt.Errorf("channel mapping family 1 supports up to 8 channels, got %d", c.ChannelCount)
+		}
+	}
+
+	if c.StreamCount == 0 {
+		return fmt.Errorf("invalid stream count (%d)", c.StreamCount)
+	}
+
+	if c.CoupledCount > c.StreamCount {
+		return fmt.Errorf("coupled count (%d) is greater than stream count (%d)", c.CoupledCount, c.StreamCount)
+	}
+
+	decodedCount := int(c.StreamCount) + int(c.CoupledCount)
+	if decodedCount > 255 {
+		return fmt.Errorf("too many decoded channels (%d)", decodedCount)
+	}
+
+	if len(c.ChannelMapping) != c.ChannelCount {
+		return fmt.Errorf("channel mapping size (%d) doesn't match channel count (%d)",
+			len(c.ChannelMapping), c.ChannelCount)
+	}
+
+	for _, m := range c.ChannelMapping {
+		if m != 255 && int(m) >= decodedCount {
+			return fmt.Errorf("invalid channel mapping entry (%d)", m)
+		}
+	}
+
+	return nil
+}
+
+func (c *DecoderConfiguration) unmarshalMappingTable(buf []byte) error {
+	c.StreamCount = 0
+	c.CoupledCount = 0
+	c.ChannelMapping = nil
+
+	if c.ChannelMappingFamily != 0 {
+		if len(buf) < (2 + c.ChannelCount) {
+			return fmt.Errorf("not enough bytes")
+		}
+
+		c.StreamCount = buf[0]
+		c.CoupledCount = buf[1]
+		c.ChannelMapping = buf[2 : 2+c.ChannelCount]
+	}
+
+	return c.validate()
+}
+
+func (c DecoderConfiguration) marshalSize() int {
+	n := 11
+	if c.ChannelMappingFamily != 0 {
+		n += 2 + len(c.ChannelMapping)
+	}
+	return n
+}
+
+// Unmarshal decodes a DecoderConfiguration from an OpusSpecificBox (dOps) payload.
+func (c *DecoderConfiguration) Unmarshal(buf []byte) error {
+	if len(buf) < 11 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	if buf[0] != 0 {
+		return fmt.Errorf("unsupported version (%d)", buf[0])
+	}
+
+	c.ChannelCount = int(buf[1])
+	c.PreSkip = uint16(buf[2])<<8 | uint16(buf[3])
+	c.InputSampleRate = uint32(buf[4])<<24 | uint32(buf[5])<<16 | uint32(buf[6])<<8 | uint32(buf[7])
+	c.OutputGain = int16(uint16(buf[8])<<8 | uint16(buf[9]))
+	c.ChannelMappingFamily = buf[10]
+
+	return c.unmarshalMappingTable(buf[11:])
+}
+
+// Marshal encodes a DecoderConfiguration into an OpusSpecificBox (dOps) payload.
+func (c DecoderConfiguration) Marshal() ([]byte, error) {
+	err := c.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, c.marshalSize())
+
+	buf[1] = uint8(c.ChannelCount)
+	buf[2] = uint8(c.PreSkip >> 8)
+	buf[3] = uint8(c.PreSkip)
+	buf[4] = uint8(c.InputSampleRate >> 24)
+	buf[5] = uint8(c.InputSampleRate >> 16)
+	buf[6] = uint8(c.InputSampleRate >> 8)
+	buf[7] = uint8(c.InputSampleRate)
+	buf[8] = uint8(c.OutputGain >> 8)
+	buf[9] = uint8(c.OutputGain)
+	buf[10] = c.ChannelMappingFamily
+
+	c.marshalMappingTable(buf[11:])
+
+	return buf, nil
+}
+
+func (c DecoderConfiguration) marshalMappingTable(buf []byte) {
+	if c.ChannelMappingFamily != 0 {
+		buf[0] = c.StreamCount
+		buf[1] = c.CoupledCount
+		copy(buf[2:], c.ChannelMapping)
+	}
+}
+
+// UnmarshalOpusHead decodes a DecoderConfiguration from an identification header (OpusHead).
+func (c *DecoderConfiguration) UnmarshalOpusHead(buf []byte) error {
+	if len(buf) < 19 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	for i, b := range opusHeadMagic {
+		if buf[i] != b {
+			return fmt.Errorf("invalid magic signature")
+		}
+	}
+
+	// the upper four bits are the major version, that must be zero.
+	if (buf[8] >> 4) != 0 {
+		return fmt.Errorf("unsupported version (%d)", buf[8])
+	}
+
+	c.ChannelCount = int(buf[9])
+	c.PreSkip = uint16(buf[11])<<8 | uint16(buf[10])
+	c.InputSampleRate = uint32(buf[15])<<24 | uint32(buf[14])<<16 | uint32(buf[13])<<8 | uint32(buf[12])
+	c.OutputGain = int16(uint16(buf[17])<<8 | uint16(buf[16]))
+	c.ChannelMappingFamily = buf[18]
+
+	return c.unmarshalMappingTable(buf[19:])
+}
+
+// MarshalOpusHead encodes a DecoderConfiguration into an identification header (OpusHead).
+// The header is also the CodecPrivate of Opus tracks in Matroska / WebM.
+func (c DecoderConfiguration) MarshalOpusHead() ([]byte, error) {
+	err := c.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 8+c.marshalSize())
+
+	copy(buf, opusHeadMagic)
+	buf[8] = 1
+	buf[9] = uint8(c.ChannelCount)
+	buf[10] = uint8(c.PreSkip)
+	buf[11] = uint8(c.PreSkip >> 8)
+	buf[12] = uint8(c.InputSampleRate)
+	buf[13] = uint8(c.InputSampleRate >> 8)
+	buf[14] = uint8(c.InputSampleRate >> 16)
+	buf[15] = uint8(c.InputSampleRate >> 24)
+	buf[16] = uint8(c.OutputGain)
+	buf[17] = uint8(c.OutputGain >> 8)
+	buf[18] = c.ChannelMappingFamily
+
+	c.marshalMappingTable(buf[19:])
+
+	return buf, nil
+}
+
+// Clone returns a copy of the DecoderConfiguration that doesn't share memory with the original.
+func (c DecoderConfiguration) Clone() DecoderConfiguration {
+	if c.ChannelMapping != nil {
+		c.ChannelMapping = append(make([]uint8, 0, len(c.ChannelMapping)), c.ChannelMapping...)
+	}
+	return c
+}
@@ -0,0 +1,221 @@
+package opus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesDecoderConfiguration = []struct {
+	name        string
+	dec         DecoderConfiguration
+	encDOps     []byte
+	encOpusHead []byte
+}{
+	{
+		"stereo",
+		DecoderConfiguration{
+			ChannelCount:    2,
+			PreSkip:         312,
+			InputSampleRate: 48000,
+		},
+		[]byte{
+			0x00, 0x02, 0x01, 0x38, 0x00, 0x00, 0xbb, 0x80,
+			0x00, 0x00, 0x00,
+		},
+		[]byte{
+			'O', 'p', 'u', 's', 'H', 'e', 'a', 'd',
+			0x01, 0x02, 0x38, 0x01, 0x80, 0xbb, 0x00, 0x00,
+			0x00, 0x00, 0x00,
+		},
+	},
+	{
+		"5.1",
+		DecoderConfiguration{
+			ChannelCount:         6,
+			PreSkip:              312,
+			InputSampleRate:      48000,
+			OutputGain:           -256,
+			ChannelMappingFamily: 1,
+			StreamCount:          4,
+			CoupledCount:         2,
+			ChannelMapping:       []uint8{0, 4, 1, 2, 3, 5},
+		},
+		[]byte{
+			0x00, 0x06, 0x01, 0x38, 0x00, 0x00, 0xbb, 0x80,
+			0xff, 0x00, 0x01, 0x04, 0x02, 0x00, 0x04, 0x01,
+			0x02, 0x03, 0x05,
+		},
+		[]byte{
+			'O', 'p', 'u', 's', 'H', 'e', 'a', 'd',
+			0x01, 0x06, 0x38, 0x01, 0x80, 0xbb, 0x00, 0x00,
+			0x00, 0xff, 0x01, 0x04, 0x02, 0x00, 0x04, 0x01,
+			0x02, 0x03, 0x05,
+		},
+	},
+}
+
+func TestDecoderConfigurationUnmarshal(t *testing.T) {
+	for _, ca := range casesDecoderConfiguration {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec DecoderConfiguration
+			err := dec.Unmarshal(ca.encDOps)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestDecoderConfigurationMarshal(t *testing.T) {
+	for _, ca := range casesDecoderConfiguration {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.encDOps, enc)
+		})
+	}
+}
+
+func TestDecoderConfigurationUnmarshalOpusHead(t *testing.T) {
+	for _, ca := range casesDecoderConfiguration {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec DecoderConfiguration
+			err := dec.UnmarshalOpusHead(ca.encOpusHead)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestDecoderConfigurationMarshalOpusHead(t *testing.T) {
+	for _, ca := range casesDecoderConfiguration {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.MarshalOpusHead()
+			require.NoError(t, err)
+			require.Equal(t, ca.encOpusHead, enc)
+		})
+	}
+}
+
+func TestDecoderConfigurationClone(t *testing.T) {
+	for _, ca := range casesDecoderConfiguration {
+		t.Run(ca.name, func(t *testing.T) {
+			var orig DecoderConfiguration
+			err := orig.Unmarshal(ca.encDOps)
+			require.NoError(t, err)
+
+			c := orig.Clone()
+			require.Equal(t, orig, c)
+
+			for i := range c.ChannelMapping {
+				c.ChannelMapping[i]++
+			}
+			require.Equal(t, ca.dec, orig)
+		})
+	}
+}
+
+func TestNewDecoderConfiguration(t *testing.T) {
+	for _, ca := range casesDecoderConfiguration {
+		t.Run(ca.name, func(t *testing.T) {
+			c, err := NewDecoderConfiguration(ca.dec.ChannelCount, ca.dec.PreSkip)
+			require.NoError(t, err)
+
+			// output gain is not set by NewDecoderConfiguration
+			c.OutputGain = ca.dec.OutputGain
+			require.Equal(t, ca.dec, *c)
+		})
+	}
+
+	for i := 1; i <= 8; i++ {
+		c, err := NewDecoderConfiguration(i, 312)
+		require.NoError(t, err)
+
+		_, err = c.MarshalOpusHead()
+		require.NoError(t, err)
+	}
+
+	_, err := NewDecoderConfiguration(9, 312)
+	require.EqualError(t, err, "invalid channel count (9)")
+}
+
+func TestDecoderConfigurationInvalidMapping(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		dec  DecoderConfiguration
+		err  string
+	}{
+		{
+			"family 0 with too many channels",
+			DecoderConfiguration{
+				ChannelCount: 3,
+			},
+			"channel mapping family 0 supports up to 2 channels, got 3",
+		},
+		{
+			"family 1 with too many channels",
+			DecoderConfiguration{
+				ChannelCount:         9,
+				ChannelMappingFamily: 1,
+			},
+			"channel mapping family 1 supports up to 8 channels, got 9",
+		},
+		{
+			"wrong mapping size",
+			DecoderConfiguration{
+				ChannelCount:         2,
+				ChannelMappingFamily: 1,
+				StreamCount:          1,
+				CoupledCount:         1,
+				ChannelMapping:       []uint8{0},
+			},
+			"channel mapping size (1) doesn't match channel count (2)",
+		},
+		{
+			"invalid mapping entry",
+			DecoderConfiguration{
+				ChannelCount:         2,
+				ChannelMappingFamily: 1,
+				StreamCount:          1,
+				CoupledCount:         0,
+				ChannelMapping:       []uint8{0, 1},
+			},
+			"invalid channel mapping entry (1)",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			_, err := ca.dec.Marshal()
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func FuzzDecoderConfigurationUnmarshal(f *testing.F) {
+	for _, ca := range casesDecoderConfiguration {
+		f.Add(ca.encDOps)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var dec DecoderConfiguration
+		err := dec.Unmarshal(b)
+		if err == nil {
+			_, err = dec.Marshal()
+			require.NoError(t, err)
+		}
+	})
+}
+
+func FuzzDecoderConfigurationUnmarshalOpusHead(f *testing.F) {
+	for _, ca := range casesDecoderConfiguration {
+		f.Add(ca.encOpusHead)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var dec DecoderConfiguration
+		err := dec.UnmarshalOpusHead(b)
+		if err == nil {
+			_, err = dec.MarshalOpusHead()
+			require.NoError(t, err)
+		}
+	})
+}
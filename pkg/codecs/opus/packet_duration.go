@@ -1,9 +1,16 @@
 package opus
 
 import (
+	"fmt"
 	"time"
 )
 
+const (
+	// maximum duration of a packet, in samples at 48kHz.
+	// Specification: RFC6716, 3.2.5
+	maxPacketDuration = 120 * 48
+)
+
 var frameSizes = [32]int{
 	480, 960, 1920, 2880, // Silk NB
 	480, 960, 1920, 2880, // Silk MB
@@ -18,6 +25,8 @@ var frameSizes = [32]int{
 
 // PacketDuration returns the duration of an Opus packet.
 // Specification: RFC6716, 3.1
+//
+// Deprecated: replaced by PacketDuration2.
 func PacketDuration(pkt []byte) time.Duration {
 	if len(pkt) == 0 {
 		return 0
@@ -42,3 +51,52 @@ func PacketDuration(pkt []byte) time.Duration {
 
 	return (time.Duration(frameDuration) * time.Duration(frameCount) * time.Millisecond) / 48
 }
+
+// PacketDuration2 returns the duration of an Opus packet, in samples at 48kHz.
+// Specification: RFC6716, 3.1, 3.2
+func PacketDuration2(pkt []byte) (int, error) {
+	if len(pkt) == 0 {
+		return 0, fmt.Errorf("packet is empty")
+	}
+
+	frameSize := frameSizes[pkt[0]>>3]
+
+	var frameCount int
+
+	switch pkt[0] & 3 {
+	case 0:
+		frameCount = 1
+
+	case 1:
+		// two frames with equal compressed size
+		if ((len(pkt) - 1) % 2) != 0 {
+			return 0, fmt.Errorf("invalid packet size (%d)", len(pkt))
+		}
+		frameCount = 2
+
+	case 2:
+		// two frames with different compressed size
+		if len(pkt) < 2 {
+			return 0, fmt.Errorf("invalid packet size (%d)", len(pkt))
+		}
+		frameCount = 2
+
+	case 3:
+		// an arbitrary number of frames, CBR or VBR
+		if len(pkt) < 2 {
+			return 0, fmt.Errorf("invalid packet size (%d)", len(pkt))
+		}
+
+		frameCount = int(pkt[1] & 0x3F)
+		if frameCount == 0 {
+			return 0, fmt.Errorf("invalid frame count (%d)", frameCount)
+		}
+	}
+
+	duration := frameSize * frameCount
+	if duration > maxPacketDuration {
+		return 0, fmt.Errorf("packet duration (%d) is too big, maximum is %d", duration, maxPacketDuration)
+	}
+
+	return duration, nil
+}
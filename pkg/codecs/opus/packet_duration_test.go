@@ -27,6 +27,92 @@ func TestPacketDuration(t *testing.T) {
 	}
 }
 
+var casesPacketDuration2 = []struct {
+	name     string
+	byts     []byte
+	duration int
+}{
+	{
+		"code 0, silk 20ms",
+		[]byte{0x08},
+		960,
+	},
+	{
+		"code 0, celt 2.5ms",
+		[]byte{0x80},
+		120,
+	},
+	{
+		"code 1, celt 20ms",
+		[]byte{0xf9, 0x01, 0x02},
+		1920,
+	},
+	{
+		"code 2, silk 60ms",
+		[]byte{0x1a, 0x01, 0x02, 0x03},
+		5760,
+	},
+	{
+		"code 3 cbr, celt 10ms",
+		[]byte{0xf3, 0x03, 0x01, 0x02, 0x03},
+		1440,
+	},
+	{
+		"code 3 vbr with padding, celt 20ms",
+		[]byte{0xfb, 0xc6, 0x01, 0x01, 0x02, 0x02, 0x02, 0x02, 0x02, 0x00},
+		5760,
+	},
+}
+
+func TestPacketDuration2(t *testing.T) {
+	for _, ca := range casesPacketDuration2 {
+		t.Run(ca.name, func(t *testing.T) {
+			duration, err := PacketDuration2(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.duration, duration)
+		})
+	}
+}
+
+func TestPacketDuration2Error(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		byts []byte
+		err  string
+	}{
+		{
+			"empty",
+			[]byte{},
+			"packet is empty",
+		},
+		{
+			"code 1 with odd size",
+			[]byte{0xf9, 0x01},
+			"invalid packet size (2)",
+		},
+		{
+			"code 3 without frame count",
+			[]byte{0xfb},
+			"invalid packet size (1)",
+		},
+		{
+			"code 3 with zero frames",
+			[]byte{0xfb, 0x00},
+			"invalid frame count (0)",
+		},
+		{
+			"code 3 longer than 120ms",
+			[]byte{0x1b, 0x03, 0x01, 0x02, 0x03},
+			"packet duration (8640) is too big, maximum is 5760",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			_, err := PacketDuration2(ca.byts)
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
 func FuzzPacketDuration(f *testing.F) {
 	for _, ca := range casesPacketDuration {
 		f.Add(ca.byts)
@@ -36,3 +122,13 @@ func FuzzPacketDuration(f *testing.F) {
 		PacketDuration(b)
 	})
 }
+
+func FuzzPacketDuration2(f *testing.F) {
+	for _, ca := range casesPacketDuration2 {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		PacketDuration2(b) //nolint:errcheck
+	})
+}
@@ -0,0 +1,57 @@
+package vp8
+
+import (
+	"fmt"
+)
+
+// FrameHeader is the uncompressed data chunk of a VP8 frame.
+// Specification: RFC6386, 9.1
+type FrameHeader struct {
+	NonKeyFrame   bool
+	Version       uint8
+	ShowFrame     bool
+	FirstPartSize uint32
+
+	// key frames only
+	Width           uint16
+	HorizontalScale uint8
+	Height          uint16
+	VerticalScale   uint8
+}
+
+// Unmarshal decodes a FrameHeader.
+func (h *FrameHeader) Unmarshal(buf []byte) error {
+	if len(buf) < 3 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	tag := uint32(buf[0]) | uint32(buf[1])<<8 | uint32(buf[2])<<16
+
+	h.NonKeyFrame = (tag & 0x01) != 0
+	h.Version = uint8((tag >> 1) & 0x07)
+	h.ShowFrame = ((tag >> 4) & 0x01) != 0
+	h.FirstPartSize = tag >> 5
+
+	if h.NonKeyFrame {
+		h.Width = 0
+		h.HorizontalScale = 0
+		h.Height = 0
+		h.VerticalScale = 0
+		return nil
+	}
+
+	if len(buf) < 10 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	if buf[3] != 0x9d || buf[4] != 0x01 || buf[5] != 0x2a {
+		return fmt.Errorf("invalid start code")
+	}
+
+	h.Width = (uint16(buf[6]) | uint16(buf[7])<<8) & 0x3FFF
+	h.HorizontalScale = buf[7] >> 6
+	h.Height = (uint16(buf[8]) | uint16(buf[9])<<8) & 0x3FFF
+	h.VerticalScale = buf[9] >> 6
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package vp8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesFrameHeader = []struct {
+	name string
+	byts []byte
+	h    FrameHeader
+}{
+	{
+		"key frame",
+		[]byte{
+			0x50, 0x42, 0x00, 0x9d, 0x01, 0x2a, 0x80, 0x02,
+			0xe0, 0x01,
+		},
+		FrameHeader{
+			ShowFrame:     true,
+			FirstPartSize: 530,
+			Width:         640,
+			Height:        480,
+		},
+	},
+	{
+		"key frame with scaling",
+		[]byte{
+			0x30, 0x01, 0x00, 0x9d, 0x01, 0x2a, 0x00, 0x45,
+			0xd0, 0x82,
+		},
+		FrameHeader{
+			ShowFrame:       true,
+			FirstPartSize:   9,
+			Width:           1280,
+			HorizontalScale: 1,
+			Height:          720,
+			VerticalScale:   2,
+		},
+	},
+	{
+		"inter frame",
+		[]byte{0x31, 0x01, 0x00},
+		FrameHeader{
+			NonKeyFrame:   true,
+			ShowFrame:     true,
+			FirstPartSize: 9,
+		},
+	},
+}
+
+func TestFrameHeaderUnmarshal(t *testing.T) {
+	for _, ca := range casesFrameHeader {
+		t.Run(ca.name, func(t *testing.T) {
+			var h FrameHeader
+			err := h.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.h, h)
+		})
+	}
+}
+
+func TestFrameHeaderUnmarshalInvalidStartCode(t *testing.T) {
+	var h FrameHeader
+	err := h.Unmarshal([]byte{
+		0x50, 0x42, 0x00, 0x9d, 0x01, 0x2b, 0x80, 0x02,
+		0xe0, 0x01,
+	})
+	require.EqualError(t, err, "invalid start code")
+}
+
+func FuzzFrameHeaderUnmarshal(f *testing.F) {
+	for _, ca := range casesFrameHeader {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var h FrameHeader
+		h.Unmarshal(b) //nolint:errcheck
+	})
+}
@@ -0,0 +1,205 @@
+package vp9
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// chroma subsampling values of a CodecConfigurationRecord.
+const (
+	chromaSubsampling420Colocated = 1
+	chromaSubsampling444          = 3
+)
+
+// CodecConfigurationRecord is a VPCodecConfigurationRecord.
+// It is the content of a vpcC box, without the version and flags fields.
+// Specification: https://www.webmproject.org/vp9/mp4/#vp-codec-configuration-box
+type CodecConfigurationRecord struct {
+	Profile                 uint8
+	Level                   uint8
+	BitDepth                uint8
+	ChromaSubsampling       uint8
+	VideoFullRangeFlag      bool
+	ColourPrimaries         uint8
+	TransferCharacteristics uint8
+	MatrixCoefficients      uint8
+	CodecInitializationData []byte
+}
+
+// Unmarshal decodes a CodecConfigurationRecord.
+func (r *CodecConfigurationRecord) Unmarshal(buf []byte) error {
+	if len(buf) < 8 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	r.Profile = buf[0]
+	r.Level = buf[1]
+	r.BitDepth = buf[2] >> 4
+	r.ChromaSubsampling = (buf[2] >> 1) & 0b111
+	r.VideoFullRangeFlag = (buf[2] & 0b1) != 0
+	r.ColourPrimaries = buf[3]
+	r.TransferCharacteristics = buf[4]
+	r.MatrixCoefficients = buf[5]
+
+	codecInitializationDataSize := int(binary.BigEndian.Uint16(buf[6:]))
+	if len(buf[8:]) != codecInitializationDataSize {
+		return fmt.Errorf("invalid codec initialization data size")
+	}
+
+	if codecInitializationDataSize != 0 {
+		r.CodecInitializationData = buf[8:]
+	} else {
+		r.CodecInitializationData = nil
+	}
+
+	return r.validate()
+}
+
+// validate checks that bit depth and chroma subsampling are allowed by the profile.
+// Specification: VP9 Bitstream & Decoding Process Specification, Annex A
+func (r CodecConfigurationRecord) validate() error {
+	if r.Profile > 3 {
+		return fmt.Errorf("invalid profile: %d", r.Profile)
+	}
+
+	if r.BitDepth != 8 && r.BitDepth != 10 && r.BitDepth != 12 {
+		return fmt.Errorf("invalid bit depth: %d", r.BitDepth)
+	}
+
+	if r.ChromaSubsampling > chromaSubsampling444 {
+		return fmt.Errorf("invalid chroma subsampling: %d", r.ChromaSubsampling)
+	}
+
+	if (r.Profile <= 1) != (r.BitDepth == 8) {
+		return fmt.Errorf("bit depth %d is not allowed by profile %d", r.BitDepth, r.Profile)
+	}
+
+	if (r.Profile == 0 || r.Profile == 2) != (r.ChromaSubsampling <= chromaSubsampling420Colocated) {
+		return fmt.Errorf("chroma subsampling %d is not allowed by profile %d", r.ChromaSubsampling, r.Profile)
+	}
+
+	return nil
+}
+
+// Marshal encodes a CodecConfigurationRecord.
+func (r CodecConfigurationRecord) Marshal() ([]byte, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(r.CodecInitializationData) > 0xFFFF {
+		return nil, fmt.Errorf("codec initialization data is too big")
+	}
+
+	buf := make([]byte, 8+len(r.CodecInitializationData))
+
+	buf[0] = r.Profile
+	buf[1] = r.Level
+	buf[2] = r.BitDepth<<4 | r.ChromaSubsampling<<1
+	if r.VideoFullRangeFlag {
+		buf[2] |= 1
+	}
+	buf[3] = r.ColourPrimaries
+	buf[4] = r.TransferCharacteristics
+	buf[5] = r.MatrixCoefficients
+	binary.BigEndian.PutUint16(buf[6:], uint16(len(r.CodecInitializationData)))
+	copy(buf[8:], r.CodecInitializationData)
+
+	return buf, nil
+}
+
+// Clone returns a copy of the CodecConfigurationRecord that doesn't share memory with the original.
+func (r CodecConfigurationRecord) Clone() CodecConfigurationRecord {
+	if r.CodecInitializationData != nil {
+		r.CodecInitializationData = append(make([]byte, 0, len(r.CodecInitializationData)),
+			r.CodecInitializationData...)
+	}
+	return r
+}
+
+// CodecConfigurationRecord returns a CodecConfigurationRecord that describes the stream.
+// It requires a header that contains the color configuration, i.e. the one of a key frame.
+// Since the level can't be derived from the header, it is set to 1 (10),
+// and can be replaced by the caller.
+func (h Header) CodecConfigurationRecord() (*CodecConfigurationRecord, error) {
+	if h.ColorConfig == nil {
+		return nil, fmt.Errorf("header doesn't contain the color configuration")
+	}
+
+	r := &CodecConfigurationRecord{
+		Profile:            h.Profile,
+		Level:              10,
+		BitDepth:           h.ColorConfig.BitDepth,
+		ChromaSubsampling:  h.ChromaSubsampling(),
+		VideoFullRangeFlag: h.ColorConfig.ColorRange,
+	}
+
+	// ISO/IEC 23091-2 code points corresponding to color_space
+	switch h.ColorConfig.ColorSpace {
+	case 1, 3: // CS_BT_601, CS_SMPTE_170
+		r.ColourPrimaries = 6
+		r.TransferCharacteristics = 6
+		r.MatrixCoefficients = 6
+
+	case 2: // CS_BT_709
+		r.ColourPrimaries = 1
+		r.TransferCharacteristics = 1
+		r.MatrixCoefficients = 1
+
+	case 4: // CS_SMPTE_240
+		r.ColourPrimaries = 7
+		r.TransferCharacteristics = 7
+		r.MatrixCoefficients = 7
+
+	case 5: // CS_BT_2020
+		r.ColourPrimaries = 9
+		switch h.ColorConfig.BitDepth {
+		case 10:
+			r.TransferCharacteristics = 14
+		case 12:
+			r.TransferCharacteristics = 15
+		default:
+			r.TransferCharacteristics = 1
+		}
+		r.MatrixCoefficients = 9
+
+	case 7: // CS_RGB
+		r.ColourPrimaries = 2
+		r.TransferCharacteristics = 2
+		r.MatrixCoefficients = 0
+
+	default: // CS_UNKNOWN, CS_RESERVED
+		r.ColourPrimaries = 2
+		r.TransferCharacteristics = 2
+		r.MatrixCoefficients = 2
+	}
+
+	err := r.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// CodecString returns the codec string of the video,
+// that is used in the codecs parameter of MIME types.
+// All optional fields are included.
+// Specification: https://www.webmproject.org/vp9/mp4/#codecs-parameter-string
+func (r CodecConfigurationRecord) CodecString() string {
+	fullRange := 0
+	if r.VideoFullRangeFlag {
+		fullRange = 1
+	}
+
+	return fmt.Sprintf("vp09.%02d.%02d.%02d.%02d.%02d.%02d.%02d.%02d",
+		r.Profile,
+		r.Level,
+		r.BitDepth,
+		r.ChromaSubsampling,
+		r.ColourPrimaries,
+		r.TransferCharacteristics,
+		r.MatrixCoefficients,
+		fullRange)
+}
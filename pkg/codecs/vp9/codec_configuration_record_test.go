@@ -0,0 +1,145 @@
+package vp9
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesCodecConfigurationRecord = []struct {
+	name string
+	byts []byte
+	rec  CodecConfigurationRecord
+}{
+	{
+		"profile 0",
+		[]byte{0x00, 0x0a, 0x82, 0x01, 0x01, 0x01, 0x00, 0x00},
+		CodecConfigurationRecord{
+			Level:                   10,
+			BitDepth:                8,
+			ChromaSubsampling:       1,
+			ColourPrimaries:         1,
+			TransferCharacteristics: 1,
+			MatrixCoefficients:      1,
+		},
+	},
+	{
+		"profile 3, full range, initialization data",
+		[]byte{0x03, 0x1f, 0xc7, 0x09, 0x0f, 0x09, 0x00, 0x02, 0x01, 0x02},
+		CodecConfigurationRecord{
+			Profile:                 3,
+			Level:                   31,
+			BitDepth:                12,
+			ChromaSubsampling:       3,
+			VideoFullRangeFlag:      true,
+			ColourPrimaries:         9,
+			TransferCharacteristics: 15,
+			MatrixCoefficients:      9,
+			CodecInitializationData: []byte{0x01, 0x02},
+		},
+	},
+}
+
+func TestCodecConfigurationRecordUnmarshal(t *testing.T) {
+	for _, ca := range casesCodecConfigurationRecord {
+		t.Run(ca.name, func(t *testing.T) {
+			var rec CodecConfigurationRecord
+			err := rec.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.rec, rec)
+		})
+	}
+}
+
+func TestCodecConfigurationRecordMarshal(t *testing.T) {
+	for _, ca := range casesCodecConfigurationRecord {
+		t.Run(ca.name, func(t *testing.T) {
+			byts, err := ca.rec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.byts, byts)
+		})
+	}
+}
+
+func TestCodecConfigurationRecordClone(t *testing.T) {
+	for _, ca := range casesCodecConfigurationRecord {
+		t.Run(ca.name, func(t *testing.T) {
+			var orig CodecConfigurationRecord
+			err := orig.Unmarshal(ca.byts)
+			require.NoError(t, err)
+
+			c := orig.Clone()
+			require.Equal(t, orig, c)
+
+			for i := range c.CodecInitializationData {
+				c.CodecInitializationData[i]++
+			}
+			require.Equal(t, ca.rec, orig)
+		})
+	}
+}
+
+func TestCodecConfigurationRecordCodecString(t *testing.T) {
+	require.Equal(t, "vp09.00.10.08.01.01.01.01.00", casesCodecConfigurationRecord[0].rec.CodecString())
+	require.Equal(t, "vp09.03.31.12.03.09.15.09.01", casesCodecConfigurationRecord[1].rec.CodecString())
+}
+
+func TestCodecConfigurationRecordValidate(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		rec  CodecConfigurationRecord
+		err  string
+	}{
+		{
+			"invalid profile",
+			CodecConfigurationRecord{Profile: 4, BitDepth: 8},
+			"invalid profile: 4",
+		},
+		{
+			"invalid bit depth",
+			CodecConfigurationRecord{BitDepth: 9},
+			"invalid bit depth: 9",
+		},
+		{
+			"bit depth not allowed",
+			CodecConfigurationRecord{BitDepth: 10, ChromaSubsampling: 1},
+			"bit depth 10 is not allowed by profile 0",
+		},
+		{
+			"chroma subsampling not allowed",
+			CodecConfigurationRecord{Profile: 1, BitDepth: 8, ChromaSubsampling: 1},
+			"chroma subsampling 1 is not allowed by profile 1",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			_, err := ca.rec.Marshal()
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func TestHeaderCodecConfigurationRecord(t *testing.T) {
+	rec, err := casesHeader[1].sh.CodecConfigurationRecord()
+	require.NoError(t, err)
+	require.Equal(t, &casesCodecConfigurationRecord[0].rec, rec)
+
+	_, err = Header{NonKeyFrame: true}.CodecConfigurationRecord()
+	require.EqualError(t, err, "header doesn't contain the color configuration")
+}
+
+func FuzzCodecConfigurationRecordUnmarshal(f *testing.F) {
+	for _, ca := range casesCodecConfigurationRecord {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var rec CodecConfigurationRecord
+		err := rec.Unmarshal(b)
+		if err == nil {
+			var byts []byte
+			byts, err = rec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, b, byts)
+		}
+	})
+}
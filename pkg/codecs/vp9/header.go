@@ -94,6 +94,47 @@ func (s *Header_FrameSize) unmarshal(buf []byte, pos *int) error {
 	return nil
 }
 
+// Header_RenderSize is the render_size member of an header.
+type Header_RenderSize struct { //nolint:revive
+	RenderWidthMinus1  uint16
+	RenderHeightMinus1 uint16
+}
+
+func (s *Header_RenderSize) unmarshal(buf []byte, pos *int) error {
+	err := bits.HasSpace(buf, *pos, 32)
+	if err != nil {
+		return err
+	}
+
+	s.RenderWidthMinus1 = uint16(bits.ReadBitsUnsafe(buf, pos, 16))
+	s.RenderHeightMinus1 = uint16(bits.ReadBitsUnsafe(buf, pos, 16))
+	return nil
+}
+
+func readFrameSyncCode(buf []byte, pos *int) error {
+	err := bits.HasSpace(buf, *pos, 24)
+	if err != nil {
+		return err
+	}
+
+	frameSyncByte0 := uint8(bits.ReadBitsUnsafe(buf, pos, 8))
+	if frameSyncByte0 != 0x49 {
+		return fmt.Errorf("wrong frame_sync_byte_0")
+	}
+
+	frameSyncByte1 := uint8(bits.ReadBitsUnsafe(buf, pos, 8))
+	if frameSyncByte1 != 0x83 {
+		return fmt.Errorf("wrong frame_sync_byte_1")
+	}
+
+	frameSyncByte2 := uint8(bits.ReadBitsUnsafe(buf, pos, 8))
+	if frameSyncByte2 != 0x42 {
+		return fmt.Errorf("wrong frame_sync_byte_2")
+	}
+
+	return nil
+}
+
 // Header is a VP9 Frame header.
 // Specification:
 // https://storage.googleapis.com/downloads.webmproject.org/docs/vp9/vp9-bitstream-specification-v0.6-20160331-draft.pdf
@@ -104,8 +145,14 @@ type Header struct {
 	NonKeyFrame        bool
 	ShowFrame          bool
 	ErrorResilientMode bool
+	IntraOnly          bool
+	ResetFrameContext  uint8
+	RefreshFrameFlags  uint8
 	ColorConfig        *Header_ColorConfig
 	FrameSize          *Header_FrameSize
+
+	// render size, present when it is different from the frame size.
+	RenderSize *Header_RenderSize
 }
 
 // Unmarshal decodes a Header.
@@ -159,34 +206,86 @@ func (h *Header) Unmarshal(buf []byte) error {
 	h.ErrorResilientMode = bits.ReadFlagUnsafe(buf, &pos)
 
 	if !h.NonKeyFrame {
-		err := bits.HasSpace(buf, pos, 24)
+		err = readFrameSyncCode(buf, &pos)
 		if err != nil {
 			return err
 		}
 
-		frameSyncByte0 := uint8(bits.ReadBitsUnsafe(buf, &pos, 8))
-		if frameSyncByte0 != 0x49 {
-			return fmt.Errorf("wrong frame_sync_byte_0")
+		h.ColorConfig = &Header_ColorConfig{}
+		err = h.ColorConfig.unmarshal(h.Profile, buf, &pos)
+		if err != nil {
+			return err
 		}
 
-		frameSyncByte1 := uint8(bits.ReadBitsUnsafe(buf, &pos, 8))
-		if frameSyncByte1 != 0x83 {
-			return fmt.Errorf("wrong frame_sync_byte_1")
+		return h.unmarshalFrameAndRenderSize(buf, &pos)
+	}
+
+	if !h.ShowFrame {
+		h.IntraOnly, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
 		}
+	}
 
-		frameSyncByte2 := uint8(bits.ReadBitsUnsafe(buf, &pos, 8))
-		if frameSyncByte2 != 0x42 {
-			return fmt.Errorf("wrong frame_sync_byte_2")
+	if !h.ErrorResilientMode {
+		var tmp uint64
+		tmp, err = bits.ReadBits(buf, &pos, 2)
+		if err != nil {
+			return err
 		}
+		h.ResetFrameContext = uint8(tmp)
+	}
 
+	// frame sizes of inter frames depend on reference frames
+	// and can't be decoded from the header alone.
+	if !h.IntraOnly {
+		return nil
+	}
+
+	err = readFrameSyncCode(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if h.Profile > 0 {
 		h.ColorConfig = &Header_ColorConfig{}
 		err = h.ColorConfig.unmarshal(h.Profile, buf, &pos)
 		if err != nil {
 			return err
 		}
+	} else {
+		h.ColorConfig = &Header_ColorConfig{
+			BitDepth:     8,
+			ColorSpace:   1, // CS_BT_601
+			SubsamplingX: true,
+			SubsamplingY: true,
+		}
+	}
+
+	tmp, err := bits.ReadBits(buf, &pos, 8)
+	if err != nil {
+		return err
+	}
+	h.RefreshFrameFlags = uint8(tmp)
 
-		h.FrameSize = &Header_FrameSize{}
-		err = h.FrameSize.unmarshal(buf, &pos)
+	return h.unmarshalFrameAndRenderSize(buf, &pos)
+}
+
+func (h *Header) unmarshalFrameAndRenderSize(buf []byte, pos *int) error {
+	h.FrameSize = &Header_FrameSize{}
+	err := h.FrameSize.unmarshal(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	renderAndFrameSizeDifferent, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if renderAndFrameSizeDifferent {
+		h.RenderSize = &Header_RenderSize{}
+		err = h.RenderSize.unmarshal(buf, pos)
 		if err != nil {
 			return err
 		}
@@ -211,6 +310,22 @@ func (h Header) Height() int {
 	return int(h.FrameSize.FrameHeightMinus1) + 1
 }
 
+// RenderWidth returns the width at which the frame is meant to be displayed.
+func (h Header) RenderWidth() int {
+	if h.RenderSize == nil {
+		return h.Width()
+	}
+	return int(h.RenderSize.RenderWidthMinus1) + 1
+}
+
+// RenderHeight returns the height at which the frame is meant to be displayed.
+func (h Header) RenderHeight() int {
+	if h.RenderSize == nil {
+		return h.Height()
+	}
+	return int(h.RenderSize.RenderHeightMinus1) + 1
+}
+
 // ChromaSubsampling returns the chroma subsampling format, in ISO-BMFF/vpcC format.
 func (h Header) ChromaSubsampling() uint8 {
 	if h.ColorConfig == nil {
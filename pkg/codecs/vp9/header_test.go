@@ -58,6 +58,45 @@ var casesHeader = []struct {
 		3840,
 		2160,
 	},
+	{
+		"intra only with render size",
+		[]byte{
+			0x84, 0x89, 0x30, 0x68, 0x5f, 0xe0, 0x4f, 0xe0,
+			0x2c, 0xf0, 0x4f, 0xf0, 0x2c, 0xf0,
+		},
+		Header{
+			NonKeyFrame:       true,
+			IntraOnly:         true,
+			RefreshFrameFlags: 0xff,
+			ColorConfig: &Header_ColorConfig{
+				BitDepth:     8,
+				ColorSpace:   1,
+				SubsamplingX: true,
+				SubsamplingY: true,
+			},
+			FrameSize: &Header_FrameSize{
+				FrameWidthMinus1:  639,
+				FrameHeightMinus1: 359,
+			},
+			RenderSize: &Header_RenderSize{
+				RenderWidthMinus1:  1279,
+				RenderHeightMinus1: 719,
+			},
+		},
+		640,
+		360,
+	},
+	{
+		"inter",
+		[]byte{0x86, 0x40},
+		Header{
+			NonKeyFrame:       true,
+			ShowFrame:         true,
+			ResetFrameContext: 1,
+		},
+		0,
+		0,
+	},
 }
 
 func TestHeaderUnmarshal(t *testing.T) {
@@ -73,6 +112,19 @@ func TestHeaderUnmarshal(t *testing.T) {
 	}
 }
 
+func TestHeaderRenderSize(t *testing.T) {
+	var sh Header
+	err := sh.Unmarshal(casesHeader[0].byts)
+	require.NoError(t, err)
+	require.Equal(t, 1920, sh.RenderWidth())
+	require.Equal(t, 804, sh.RenderHeight())
+
+	err = sh.Unmarshal(casesHeader[2].byts)
+	require.NoError(t, err)
+	require.Equal(t, 1280, sh.RenderWidth())
+	require.Equal(t, 720, sh.RenderHeight())
+}
+
 func FuzzHeaderUnmarshal(f *testing.F) {
 	for _, ca := range casesHeader {
 		f.Add(ca.byts)
@@ -84,6 +136,8 @@ func FuzzHeaderUnmarshal(f *testing.F) {
 		if err == nil {
 			sh.Width()
 			sh.Height()
+			sh.RenderWidth()
+			sh.RenderHeight()
 			sh.ChromaSubsampling()
 		}
 	})
@@ -0,0 +1,15 @@
+package fmp4
+
+// CodecEAC3 is the E-AC-3 codec.
+type CodecEAC3 struct {
+	SampleRate   int
+	ChannelCount int
+	Config       DEC3
+}
+
+// IsVideo implements Codec.
+func (CodecEAC3) IsVideo() bool {
+	return false
+}
+
+func (*CodecEAC3) isCodec() {}
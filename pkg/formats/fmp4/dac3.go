@@ -0,0 +1,71 @@
+package fmp4
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/pkg/bits"
+	"github.com/bluenviron/mediacommon/pkg/codecs/ac3"
+)
+
+// DAC3 is the content of a dac3 box, that carries the configuration of an AC-3 stream.
+// Specification: ETSI TS 102 366, F.4
+type DAC3 struct {
+	Fscod       uint8
+	Bsid        uint8
+	Bsmod       uint8
+	Acmod       uint8
+	LfeOn       bool
+	BitRateCode uint8
+}
+
+// Fill fills a DAC3 with the parameters of an AC-3 frame.
+func (d *DAC3) Fill(syncInfo ac3.SyncInfo, bsi ac3.BSI) {
+	d.Fscod = syncInfo.Fscod
+	d.Bsid = bsi.Bsid
+	d.Bsmod = bsi.Bsmod
+	d.Acmod = bsi.Acmod
+	d.LfeOn = bsi.LfeOn
+	d.BitRateCode = syncInfo.Frmsizecod >> 1
+}
+
+// Unmarshal decodes a DAC3.
+func (d *DAC3) Unmarshal(buf []byte) error {
+	if len(buf) != 3 {
+		return fmt.Errorf("invalid size")
+	}
+
+	pos := 0
+	d.Fscod = uint8(bits.ReadBitsUnsafe(buf, &pos, 2))
+	d.Bsid = uint8(bits.ReadBitsUnsafe(buf, &pos, 5))
+	d.Bsmod = uint8(bits.ReadBitsUnsafe(buf, &pos, 3))
+	d.Acmod = uint8(bits.ReadBitsUnsafe(buf, &pos, 3))
+	d.LfeOn = bits.ReadFlagUnsafe(buf, &pos)
+	d.BitRateCode = uint8(bits.ReadBitsUnsafe(buf, &pos, 5))
+
+	return nil
+}
+
+// Marshal encodes a DAC3.
+func (d DAC3) Marshal() ([]byte, error) {
+	if d.Fscod > 0b11 || d.Bsid > 0b11111 || d.Bsmod > 0b111 || d.Acmod > 0b111 || d.BitRateCode > 0b11111 {
+		return nil, fmt.Errorf("invalid parameters")
+	}
+
+	buf := make([]byte, 3)
+	pos := 0
+
+	bits.WriteBitsUnsafe(buf, &pos, uint64(d.Fscod), 2)
+	bits.WriteBitsUnsafe(buf, &pos, uint64(d.Bsid), 5)
+	bits.WriteBitsUnsafe(buf, &pos, uint64(d.Bsmod), 3)
+	bits.WriteBitsUnsafe(buf, &pos, uint64(d.Acmod), 3)
+
+	if d.LfeOn {
+		bits.WriteBitsUnsafe(buf, &pos, 1, 1)
+	} else {
+		pos++
+	}
+
+	bits.WriteBitsUnsafe(buf, &pos, uint64(d.BitRateCode), 5)
+
+	return buf, nil
+}
@@ -0,0 +1,89 @@
+package fmp4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bluenviron/mediacommon/pkg/codecs/ac3"
+)
+
+var casesDAC3 = []struct {
+	name string
+	enc  []byte
+	dec  DAC3
+}{
+	{
+		"5.1",
+		[]byte{0x10, 0x3d, 0xc0},
+		DAC3{
+			Bsid:        8,
+			Acmod:       7,
+			LfeOn:       true,
+			BitRateCode: 14,
+		},
+	},
+	{
+		"mono",
+		[]byte{0x50, 0x0a, 0x80},
+		DAC3{
+			Fscod:       1,
+			Bsid:        8,
+			Acmod:       1,
+			BitRateCode: 20,
+		},
+	},
+}
+
+func TestDAC3Unmarshal(t *testing.T) {
+	for _, ca := range casesDAC3 {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec DAC3
+			err := dec.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestDAC3Marshal(t *testing.T) {
+	for _, ca := range casesDAC3 {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func TestDAC3Fill(t *testing.T) {
+	var dac3 DAC3
+	dac3.Fill(
+		ac3.SyncInfo{Fscod: 0, Frmsizecod: 29},
+		ac3.BSI{Bsid: 8, Bsmod: 0, Acmod: 7, LfeOn: true},
+	)
+	require.Equal(t, casesDAC3[0].dec, dac3)
+}
+
+func TestDAC3Errors(t *testing.T) {
+	var dec DAC3
+	err := dec.Unmarshal([]byte{0x10, 0x3d})
+	require.EqualError(t, err, "invalid size")
+
+	_, err = DAC3{Bsid: 32}.Marshal()
+	require.EqualError(t, err, "invalid parameters")
+}
+
+func FuzzDAC3Unmarshal(f *testing.F) {
+	for _, ca := range casesDAC3 {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var dec DAC3
+		err := dec.Unmarshal(b)
+		if err == nil {
+			dec.Marshal() //nolint:errcheck
+		}
+	})
+}
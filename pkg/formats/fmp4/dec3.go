@@ -0,0 +1,195 @@
+package fmp4
+
+import (
+	"fmt"
+
+	"github.com/abema/go-mp4"
+
+	"github.com/bluenviron/mediacommon/pkg/bits"
+	"github.com/bluenviron/mediacommon/pkg/codecs/ac3"
+)
+
+// ec-3 and dec3 are not defined by go-mp4.
+// ec-3 is registered as an audio sample entry, in order to walk its children.
+// dec3 is read and written as raw data.
+func boxTypeEC3() mp4.BoxType  { return mp4.StrToBoxType("ec-3") }
+func boxTypeDEC3() mp4.BoxType { return mp4.StrToBoxType("dec3") }
+
+func init() {
+	mp4.AddAnyTypeBoxDef(&mp4.AudioSampleEntry{}, boxTypeEC3())
+}
+
+// DEC3_IndSub is an independent substream of a DEC3.
+type DEC3_IndSub struct { //nolint:revive
+	Fscod     uint8
+	Bsid      uint8
+	Asvc      bool
+	Bsmod     uint8
+	Acmod     uint8
+	LfeOn     bool
+	NumDepSub uint8
+	ChanLoc   uint16
+}
+
+// DEC3 is the content of a dec3 box, that carries the configuration of an E-AC-3 stream.
+// Specification: ETSI TS 102 366, F.6
+type DEC3 struct {
+	// in kbit/s
+	DataRate uint16
+
+	IndSubs []DEC3_IndSub
+}
+
+// chan_loc is made of the chanmap locations that cannot be described by
+// acmod and lfeon, that are bits 5 to 12 (from Lc/Rc to Cvh) and bit 14 (LFE2),
+// where bit 0 is the most significant one.
+// Specification: ETSI TS 102 366, Table E.1.4 and Table F.6.1
+func chanLocFromChanmap(chanmap uint16) uint16 {
+	return ((chanmap >> 2) & 0b111111110) | ((chanmap >> 1) & 0b1)
+}
+
+// Fill fills a DEC3 with the parameters of an E-AC-3 frame.
+// Dependent substreams are assigned to the preceding independent substream.
+func (d *DEC3) Fill(frame ac3.EAC3Frame) error {
+	if len(frame.BSIs) == 0 || !frame.BSIs[0].Strmtyp.IsIndependent() {
+		return fmt.Errorf("first substream is not independent")
+	}
+
+	bitrate := 0
+	d.IndSubs = nil
+
+	for _, bsi := range frame.BSIs {
+		bitrate += bsi.Bitrate()
+
+		if bsi.Strmtyp.IsIndependent() {
+			d.IndSubs = append(d.IndSubs, DEC3_IndSub{
+				Fscod: bsi.Fscod,
+				Bsid:  bsi.Bsid,
+				Acmod: bsi.Acmod,
+				LfeOn: bsi.LfeOn,
+			})
+			continue
+		}
+
+		ind := &d.IndSubs[len(d.IndSubs)-1]
+		ind.NumDepSub++
+
+		if bsi.Chanmape {
+			ind.ChanLoc |= chanLocFromChanmap(bsi.Chanmap)
+		}
+	}
+
+	d.DataRate = uint16(bitrate / 1000)
+
+	return nil
+}
+
+// Unmarshal decodes a DEC3.
+func (d *DEC3) Unmarshal(buf []byte) error {
+	if len(buf) < 2 {
+		return fmt.Errorf("not enough bits")
+	}
+
+	pos := 0
+	d.DataRate = uint16(bits.ReadBitsUnsafe(buf, &pos, 13))
+	numIndSub := int(bits.ReadBitsUnsafe(buf, &pos, 3)) + 1
+	d.IndSubs = make([]DEC3_IndSub, numIndSub)
+
+	for i := range d.IndSubs {
+		err := bits.HasSpace(buf, pos, 24)
+		if err != nil {
+			return err
+		}
+
+		ind := &d.IndSubs[i]
+		ind.Fscod = uint8(bits.ReadBitsUnsafe(buf, &pos, 2))
+		ind.Bsid = uint8(bits.ReadBitsUnsafe(buf, &pos, 5))
+		pos++ // reserved
+		ind.Asvc = bits.ReadFlagUnsafe(buf, &pos)
+		ind.Bsmod = uint8(bits.ReadBitsUnsafe(buf, &pos, 3))
+		ind.Acmod = uint8(bits.ReadBitsUnsafe(buf, &pos, 3))
+		ind.LfeOn = bits.ReadFlagUnsafe(buf, &pos)
+		pos += 3 // reserved
+		ind.NumDepSub = uint8(bits.ReadBitsUnsafe(buf, &pos, 4))
+
+		if ind.NumDepSub > 0 {
+			var tmp uint64
+			tmp, err = bits.ReadBits(buf, &pos, 9)
+			if err != nil {
+				return err
+			}
+			ind.ChanLoc = uint16(tmp)
+		} else {
+			ind.ChanLoc = 0
+			pos++ // reserved
+		}
+	}
+
+	return nil
+}
+
+func (d DEC3) marshalSize() int {
+	n := 2
+	for _, ind := range d.IndSubs {
+		if ind.NumDepSub > 0 {
+			n += 4
+		} else {
+			n += 3
+		}
+	}
+	return n
+}
+
+// Marshal encodes a DEC3.
+func (d DEC3) Marshal() ([]byte, error) {
+	if d.DataRate > 0x1FFF {
+		return nil, fmt.Errorf("invalid data rate: %d", d.DataRate)
+	}
+
+	if len(d.IndSubs) == 0 || len(d.IndSubs) > 8 {
+		return nil, fmt.Errorf("invalid independent substream count: %d", len(d.IndSubs))
+	}
+
+	buf := make([]byte, d.marshalSize())
+	pos := 0
+
+	bits.WriteBitsUnsafe(buf, &pos, uint64(d.DataRate), 13)
+	bits.WriteBitsUnsafe(buf, &pos, uint64(len(d.IndSubs)-1), 3)
+
+	for _, ind := range d.IndSubs {
+		if ind.Fscod > 0b11 || ind.Bsid > 0b11111 || ind.Bsmod > 0b111 || ind.Acmod > 0b111 ||
+			ind.NumDepSub > 0b1111 || ind.ChanLoc > 0x1FF {
+			return nil, fmt.Errorf("invalid independent substream parameters")
+		}
+
+		bits.WriteBitsUnsafe(buf, &pos, uint64(ind.Fscod), 2)
+		bits.WriteBitsUnsafe(buf, &pos, uint64(ind.Bsid), 5)
+		pos++ // reserved
+
+		if ind.Asvc {
+			bits.WriteBitsUnsafe(buf, &pos, 1, 1)
+		} else {
+			pos++
+		}
+
+		bits.WriteBitsUnsafe(buf, &pos, uint64(ind.Bsmod), 3)
+		bits.WriteBitsUnsafe(buf, &pos, uint64(ind.Acmod), 3)
+
+		if ind.LfeOn {
+			bits.WriteBitsUnsafe(buf, &pos, 1, 1)
+		} else {
+			pos++
+		}
+
+		pos += 3 // reserved
+		bits.WriteBitsUnsafe(buf, &pos, uint64(ind.NumDepSub), 4)
+
+		if ind.NumDepSub > 0 {
+			bits.WriteBitsUnsafe(buf, &pos, uint64(ind.ChanLoc), 9)
+		} else {
+			pos++ // reserved
+		}
+	}
+
+	return buf, nil
+}
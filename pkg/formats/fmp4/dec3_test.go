@@ -0,0 +1,194 @@
+package fmp4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bluenviron/mediacommon/pkg/codecs/ac3"
+)
+
+var casesDEC3 = []struct {
+	name string
+	enc  []byte
+	dec  DEC3
+}{
+	{
+		"5.1",
+		[]byte{0x00, 0x30, 0x20, 0x0f, 0x00},
+		DEC3{
+			DataRate: 6,
+			IndSubs: []DEC3_IndSub{{
+				Bsid:  16,
+				Acmod: 7,
+				LfeOn: true,
+			}},
+		},
+	},
+	{
+		"7.1 with dependent substream",
+		[]byte{0x03, 0x00, 0x20, 0x0f, 0x02, 0x80},
+		DEC3{
+			DataRate: 96,
+			IndSubs: []DEC3_IndSub{{
+				Bsid:      16,
+				Acmod:     7,
+				LfeOn:     true,
+				NumDepSub: 1,
+				ChanLoc:   0x80,
+			}},
+		},
+	},
+	{
+		"two independent substreams",
+		[]byte{
+			0x00, 0x11, 0x20, 0x0f, 0x00, 0x60, 0x04, 0x00,
+		},
+		DEC3{
+			DataRate: 2,
+			IndSubs: []DEC3_IndSub{
+				{
+					Bsid:  16,
+					Acmod: 7,
+					LfeOn: true,
+				},
+				{
+					Fscod: 1,
+					Bsid:  16,
+					Acmod: 2,
+				},
+			},
+		},
+	},
+}
+
+func TestDEC3Unmarshal(t *testing.T) {
+	for _, ca := range casesDEC3 {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec DEC3
+			err := dec.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestDEC3Marshal(t *testing.T) {
+	for _, ca := range casesDEC3 {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func TestDEC3Fill(t *testing.T) {
+	ind := ac3.EAC3BSI{
+		Strmtyp:    ac3.EAC3StreamTypeIndependent,
+		Frmsiz:     0x17f,
+		Numblkscod: 3,
+		Acmod:      7,
+		LfeOn:      true,
+		Bsid:       16,
+	}
+	dep := ac3.EAC3BSI{
+		Strmtyp:    ac3.EAC3StreamTypeDependent,
+		Frmsiz:     0x17f,
+		Numblkscod: 3,
+		Acmod:      2,
+		Bsid:       16,
+		Chanmape:   true,
+		Chanmap:    0x0200,
+	}
+
+	var dec3 DEC3
+	err := dec3.Fill(ac3.EAC3Frame{
+		BSIs:       []ac3.EAC3BSI{ind, dep},
+		Substreams: [][]byte{{}, {}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, DEC3{
+		DataRate: 384,
+		IndSubs: []DEC3_IndSub{{
+			Bsid:      16,
+			Acmod:     7,
+			LfeOn:     true,
+			NumDepSub: 1,
+			ChanLoc:   0x80,
+		}},
+	}, dec3)
+
+	dep2 := dep
+	dep2.Acmod = 1
+	dep2.Chanmap = 0x0502
+
+	err = dec3.Fill(ac3.EAC3Frame{
+		BSIs:       []ac3.EAC3BSI{ind, dep, dep2},
+		Substreams: [][]byte{{}, {}, {}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, DEC3{
+		DataRate: 576,
+		IndSubs: []DEC3_IndSub{{
+			Bsid:      16,
+			Acmod:     7,
+			LfeOn:     true,
+			NumDepSub: 2,
+			ChanLoc:   0x1c1,
+		}},
+	}, dec3)
+
+	err = dec3.Fill(ac3.EAC3Frame{
+		BSIs:       []ac3.EAC3BSI{dep},
+		Substreams: [][]byte{{}},
+	})
+	require.EqualError(t, err, "first substream is not independent")
+}
+
+func TestDEC3ChanLocFromChanmap(t *testing.T) {
+	for _, ca := range []struct {
+		name    string
+		chanmap uint16
+		chanLoc uint16
+	}{
+		{"Lrs/Rrs", 0x0200, 0x080},
+		{"LFE2", 0x0002, 0x001},
+		{"LFE", 0x0001, 0x000},
+		{"Lc/Rc, Cs and LFE2", 0x0502, 0x141},
+		{"L, C, R, Ls, Rs", 0xf800, 0x000},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			require.Equal(t, ca.chanLoc, chanLocFromChanmap(ca.chanmap))
+		})
+	}
+}
+
+func TestDEC3Errors(t *testing.T) {
+	var dec DEC3
+	err := dec.Unmarshal([]byte{0x03, 0x00, 0x20, 0x0f, 0x02})
+	require.EqualError(t, err, "not enough bits")
+
+	_, err = DEC3{}.Marshal()
+	require.EqualError(t, err, "invalid independent substream count: 0")
+
+	_, err = DEC3{DataRate: 0x2000, IndSubs: []DEC3_IndSub{{}}}.Marshal()
+	require.EqualError(t, err, "invalid data rate: 8192")
+
+	_, err = DEC3{IndSubs: []DEC3_IndSub{{NumDepSub: 1, ChanLoc: 0x200}}}.Marshal()
+	require.EqualError(t, err, "invalid independent substream parameters")
+}
+
+func FuzzDEC3Unmarshal(f *testing.F) {
+	for _, ca := range casesDEC3 {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var dec DEC3
+		err := dec.Unmarshal(b)
+		if err == nil {
+			dec.Marshal() //nolint:errcheck
+		}
+	})
+}
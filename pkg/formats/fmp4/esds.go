@@ -0,0 +1,93 @@
+package fmp4
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/pkg/codecs/mpeg4audio"
+)
+
+// Specification: ISO 14496-1, Table 1
+const (
+	descriptorTagES                  = 0x03
+	descriptorTagDecoderConfig       = 0x04
+	descriptorTagDecoderSpecificInfo = 0x05
+	descriptorTagSLConfig            = 0x06
+)
+
+// descriptors are written with a 4-byte size, like most muxers do.
+func writeDescriptorHeader(buf []byte, tag uint8, size int) int {
+	buf[0] = tag
+	buf[1] = 0x80 | uint8(size>>21)
+	buf[2] = 0x80 | uint8(size>>14)
+	buf[3] = 0x80 | uint8(size>>7)
+	buf[4] = uint8(size & 0x7F)
+	return 5
+}
+
+// ESDS is the payload of an esds box that carries a MPEG-4 Audio stream.
+// It contains an ES_Descriptor, that contains a DecoderConfigDescriptor,
+// that contains the AudioSpecificConfig.
+// Specification: ISO 14496-1, 7.2.6.5
+// Specification: ISO 14496-14, 5.6
+type ESDS struct {
+	ESID         uint16
+	BufferSizeDB uint32
+	MaxBitrate   uint32
+	AvgBitrate   uint32
+	Config       mpeg4audio.AudioSpecificConfig
+}
+
+// Unmarshal decodes an ESDS, starting from the version and flags fields.
+func (e *ESDS) Unmarshal(buf []byte) error {
+	var dec mpeg4audio.ESDS
+	err := dec.Unmarshal(buf)
+	if err != nil {
+		return err
+	}
+
+	*e = ESDS(dec)
+	return nil
+}
+
+// Marshal encodes an ESDS.
+func (e ESDS) Marshal() ([]byte, error) {
+	if e.BufferSizeDB > 0xFFFFFF {
+		return nil, fmt.Errorf("invalid buffer size: %d", e.BufferSizeDB)
+	}
+
+	enc, err := e.Config.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	decSpecificInfoSize := len(enc)
+	decConfigSize := 13 + 5 + decSpecificInfoSize
+	slConfigSize := 1
+	esSize := 3 + 5 + decConfigSize + 5 + slConfigSize
+
+	buf := make([]byte, 4+5+esSize)
+
+	pos := 4 // version and flags
+	pos += writeDescriptorHeader(buf[pos:], descriptorTagES, esSize)
+	binary.BigEndian.PutUint16(buf[pos:], e.ESID)
+	pos += 3 // ES_ID, flags and streamPriority
+
+	pos += writeDescriptorHeader(buf[pos:], descriptorTagDecoderConfig, decConfigSize)
+	buf[pos] = objectTypeIndicationAudioISO14496part3
+	buf[pos+1] = streamTypeAudioStream<<2 | 0x01 // upStream = 0, reserved = 1
+	buf[pos+2] = uint8(e.BufferSizeDB >> 16)
+	buf[pos+3] = uint8(e.BufferSizeDB >> 8)
+	buf[pos+4] = uint8(e.BufferSizeDB)
+	binary.BigEndian.PutUint32(buf[pos+5:], e.MaxBitrate)
+	binary.BigEndian.PutUint32(buf[pos+9:], e.AvgBitrate)
+	pos += 13
+
+	pos += writeDescriptorHeader(buf[pos:], descriptorTagDecoderSpecificInfo, decSpecificInfoSize)
+	pos += copy(buf[pos:], enc)
+
+	pos += writeDescriptorHeader(buf[pos:], descriptorTagSLConfig, slConfigSize)
+	buf[pos] = 0x02 // predefined = MP4 file
+
+	return buf, nil
+}
@@ -0,0 +1,108 @@
+package fmp4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bluenviron/mediacommon/pkg/codecs/mpeg4audio"
+)
+
+var casesESDS = []struct {
+	name string
+	enc  []byte
+	dec  ESDS
+}{
+	{
+		"standard",
+		[]byte{
+			0x00, 0x00, 0x00, 0x00, 0x03, 0x80, 0x80, 0x80,
+			0x22, 0x00, 0x01, 0x00, 0x04, 0x80, 0x80, 0x80,
+			0x14, 0x40, 0x15, 0x00, 0x30, 0x00, 0x00, 0x01,
+			0xf7, 0x39, 0x00, 0x01, 0xf7, 0x39, 0x05, 0x80,
+			0x80, 0x80, 0x02, 0x11, 0x90, 0x06, 0x80, 0x80,
+			0x80, 0x01, 0x02,
+		},
+		ESDS{
+			ESID:         1,
+			BufferSizeDB: 12288,
+			MaxBitrate:   128825,
+			AvgBitrate:   128825,
+			Config: mpeg4audio.AudioSpecificConfig{
+				Type:         mpeg4audio.ObjectTypeAACLC,
+				SampleRate:   48000,
+				ChannelCount: 2,
+			},
+		},
+	},
+}
+
+func TestESDSUnmarshal(t *testing.T) {
+	for _, ca := range casesESDS {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec ESDS
+			err := dec.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestESDSUnmarshalShortSizes(t *testing.T) {
+	var dec ESDS
+	err := dec.Unmarshal([]byte{
+		0x00, 0x00, 0x00, 0x00, 0x03, 0x19, 0x00, 0x00,
+		0x00, 0x04, 0x11, 0x40, 0x15, 0x00, 0x30, 0x00,
+		0x00, 0x11, 0x94, 0x00, 0x00, 0x11, 0x94, 0x00,
+		0x05, 0x02, 0x11, 0x90, 0x06, 0x01, 0x02,
+	})
+	require.NoError(t, err)
+	require.Equal(t, ESDS{
+		BufferSizeDB: 12288,
+		MaxBitrate:   1152000,
+		AvgBitrate:   1152000,
+		Config: mpeg4audio.AudioSpecificConfig{
+			Type:         mpeg4audio.ObjectTypeAACLC,
+			SampleRate:   48000,
+			ChannelCount: 2,
+		},
+	}, dec)
+}
+
+func TestESDSUnmarshalErrors(t *testing.T) {
+	var dec ESDS
+	err := dec.Unmarshal([]byte{
+		0x00, 0x00, 0x00, 0x00, 0x03, 0x19, 0x00, 0x00,
+		0x00, 0x04, 0x11, 0x6b, 0x15, 0x00, 0x30, 0x00,
+		0x00, 0x11, 0x94, 0x00, 0x00, 0x11, 0x94, 0x00,
+		0x05, 0x02, 0x11, 0x90, 0x06, 0x01, 0x02,
+	})
+	require.EqualError(t, err, "unsupported object type indication (0x6b)")
+
+	err = dec.Unmarshal([]byte{0x00, 0x00, 0x00, 0x00, 0x03, 0x05, 0x00, 0x00, 0x00, 0x06, 0x00})
+	require.EqualError(t, err, "descriptor with tag 4 not found")
+}
+
+func TestESDSMarshal(t *testing.T) {
+	for _, ca := range casesESDS {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func FuzzESDSUnmarshal(f *testing.F) {
+	for _, ca := range casesESDS {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var dec ESDS
+		err := dec.Unmarshal(b)
+		if err == nil {
+			dec.Marshal() //nolint:errcheck
+		}
+	})
+}
@@ -1,6 +1,51 @@
 // Package fmp4 contains a fragmented-MP4 reader and writer.
 package fmp4
 
+import (
+	"errors"
+	"fmt"
+
+	"github.com/abema/go-mp4"
+)
+
 const (
 	maxSamplesPerTrun = 120 * 160 // 120fps * 60 seconds
+
+	defaultMaxBoxDepth = 16
 )
+
+var (
+	// ErrBoxTooBig is returned when the size of a box exceeds the maximum allowed size.
+	ErrBoxTooBig = errors.New("box is too big")
+
+	// ErrBoxTooDeep is returned when the nesting depth of a box exceeds the maximum allowed depth.
+	ErrBoxTooDeep = errors.New("box is nested too deeply")
+
+	// ErrBoxTruncated is returned when the declared size of a box exceeds the available data.
+	ErrBoxTruncated = errors.New("box is truncated")
+)
+
+// checkBox checks a box against limits.
+// end is the position where the data ends.
+func checkBox(h *mp4.ReadHandle, end uint64, maxSize uint64, maxDepth int) error {
+	if h.BoxInfo.Size > (end - h.BoxInfo.Offset) {
+		return fmt.Errorf("%w: box '%v' has size %d, but at most %d bytes are available",
+			ErrBoxTruncated, h.BoxInfo.Type, h.BoxInfo.Size, end-h.BoxInfo.Offset)
+	}
+
+	if maxSize != 0 && h.BoxInfo.Size > maxSize {
+		return fmt.Errorf("%w: box '%v' has size %d, maximum is %d",
+			ErrBoxTooBig, h.BoxInfo.Type, h.BoxInfo.Size, maxSize)
+	}
+
+	if maxDepth == 0 {
+		maxDepth = defaultMaxBoxDepth
+	}
+
+	if len(h.Path) > maxDepth {
+		return fmt.Errorf("%w: box '%v' has depth %d, maximum is %d",
+			ErrBoxTooDeep, h.BoxInfo.Type, len(h.Path), maxDepth)
+	}
+
+	return nil
+}
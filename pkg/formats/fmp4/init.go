@@ -1,14 +1,16 @@
 package fmp4
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 
 	"github.com/abema/go-mp4"
 
 	"github.com/bluenviron/mediacommon/pkg/codecs/av1"
+	"github.com/bluenviron/mediacommon/pkg/codecs/h264"
 	"github.com/bluenviron/mediacommon/pkg/codecs/h265"
-	"github.com/bluenviron/mediacommon/pkg/codecs/mpeg4audio"
+	"github.com/bluenviron/mediacommon/pkg/codecs/opus"
 )
 
 // Specification: ISO 14496-1, Table 5
@@ -16,6 +18,9 @@ const (
 	objectTypeIndicationVisualISO14496part2    = 0x20
 	objectTypeIndicationAudioISO14496part3     = 0x40
 	objectTypeIndicationVisualISO1318part2Main = 0x61
+	objectTypeIndicationAudioISO13818part7Main = 0x66
+	objectTypeIndicationAudioISO13818part7LC   = 0x67
+	objectTypeIndicationAudioISO13818part7SSR  = 0x68
 	objectTypeIndicationAudioISO11172part3     = 0x6B
 	objectTypeIndicationVisualISO10918part1    = 0x6C
 )
@@ -47,28 +52,28 @@ func av1FindSequenceHeader(bs []byte) ([]byte, error) {
 	return nil, fmt.Errorf("sequence header not found")
 }
 
-func h265FindParams(params []mp4.HEVCNaluArray) ([]byte, []byte, []byte, error) {
+func h265FindParams(params []h265.DecoderConfigurationRecord_NALUArray) ([]byte, []byte, []byte, error) {
 	var vps []byte
 	var sps []byte
 	var pps []byte
 
 	for _, arr := range params {
-		switch h265.NALUType(arr.NaluType) {
+		switch arr.NALUType {
 		case h265.NALUType_VPS_NUT, h265.NALUType_SPS_NUT, h265.NALUType_PPS_NUT:
-			if arr.NumNalus != 1 {
+			if len(arr.NALUs) != 1 {
 				return nil, nil, nil, fmt.Errorf("multiple VPS/SPS/PPS are not supported")
 			}
 		}
 
-		switch h265.NALUType(arr.NaluType) {
+		switch arr.NALUType {
 		case h265.NALUType_VPS_NUT:
-			vps = arr.Nalus[0].NALUnit
+			vps = arr.NALUs[0]
 
 		case h265.NALUType_SPS_NUT:
-			sps = arr.Nalus[0].NALUnit
+			sps = arr.NALUs[0]
 
 		case h265.NALUType_PPS_NUT:
-			pps = arr.Nalus[0].NALUnit
+			pps = arr.NALUs[0]
 		}
 	}
 
@@ -87,28 +92,37 @@ func h265FindParams(params []mp4.HEVCNaluArray) ([]byte, []byte, []byte, error)
 	return vps, sps, pps, nil
 }
 
-func h264FindParams(avcc *mp4.AVCDecoderConfiguration) ([]byte, []byte, error) {
-	if len(avcc.SequenceParameterSets) > 1 {
+func h264FindParams(avcc *h264.DecoderConfigurationRecord) ([]byte, []byte, error) {
+	if len(avcc.SPS) > 1 {
 		return nil, nil, fmt.Errorf("multiple SPS are not supported")
 	}
 
 	var sps []byte
-	if len(avcc.SequenceParameterSets) == 1 {
-		sps = avcc.SequenceParameterSets[0].NALUnit
+	if len(avcc.SPS) == 1 {
+		sps = avcc.SPS[0]
 	}
 
-	if len(avcc.PictureParameterSets) > 1 {
+	if len(avcc.PPS) > 1 {
 		return nil, nil, fmt.Errorf("multiple PPS are not supported")
 	}
 
 	var pps []byte
-	if len(avcc.PictureParameterSets) == 1 {
-		pps = avcc.PictureParameterSets[0].NALUnit
+	if len(avcc.PPS) == 1 {
+		pps = avcc.PPS[0]
 	}
 
 	return sps, pps, nil
 }
 
+func readBoxData(h *mp4.ReadHandle) ([]byte, error) {
+	var buf bytes.Buffer
+	_, err := h.ReadData(&buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 func esdsFindDecoderConf(descriptors []mp4.Descriptor) *mp4.DecoderConfigDescriptor {
 	for _, desc := range descriptors {
 		if desc.Tag == mp4.DecoderConfigDescrTag {
@@ -130,10 +144,35 @@ func esdsFindDecoderSpecificInfo(descriptors []mp4.Descriptor) []byte {
 // Init is a fMP4 initialization block.
 type Init struct {
 	Tracks []*InitTrack
+
+	// major brand of the ftyp box. It is used by Marshal only.
+	// It defaults to mp42.
+	MajorBrand [4]byte
+
+	// compatible brands of the ftyp box. They are used by Marshal only.
+	// They default to mp41, mp42, isom, hlsf.
+	CompatibleBrands [][4]byte
+}
+
+// InitUnmarshalOptions contains options of Init.UnmarshalWithOptions.
+type InitUnmarshalOptions struct {
+	// maximum size of a box, including the header.
+	// When it is zero, the size is only limited by the available data.
+	MaxBoxSize uint64
+
+	// maximum nesting depth of a box.
+	// When it is zero, it defaults to 16.
+	MaxBoxDepth int
 }
 
 // Unmarshal decodes a fMP4 initialization block.
 func (i *Init) Unmarshal(r io.ReadSeeker) error {
+	return i.UnmarshalWithOptions(r, InitUnmarshalOptions{})
+}
+
+// UnmarshalWithOptions decodes a fMP4 initialization block.
+// Boxes whose declared size exceeds the available data are always rejected.
+func (i *Init) UnmarshalWithOptions(r io.ReadSeeker, opts InitUnmarshalOptions) error {
 	type readState int
 
 	const (
@@ -149,6 +188,7 @@ func (i *Init) Unmarshal(r io.ReadSeeker) error {
 		waitingAudioEsds
 		waitingDOps
 		waitingDac3
+		waitingDec3
 		waitingPcmC
 	)
 
@@ -159,8 +199,29 @@ func (i *Init) Unmarshal(r io.ReadSeeker) error {
 	var sampleRate int
 	var channelCount int
 
-	_, err := mp4.ReadBoxStructure(r, func(h *mp4.ReadHandle) (interface{}, error) {
-		if !h.BoxInfo.IsSupportedType() {
+	start, err := r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+
+	end, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.Seek(start, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	_, err = mp4.ReadBoxStructure(r, func(h *mp4.ReadHandle) (interface{}, error) {
+		err := checkBox(h, uint64(end), opts.MaxBoxSize, opts.MaxBoxDepth)
+		if err != nil {
+			return nil, err
+		}
+
+		// dec3 is not supported by go-mp4 and is decoded manually
+		if !h.BoxInfo.IsSupportedType() && h.BoxInfo.Type != boxTypeDEC3() {
 			if state != waitingTrak {
 				i.Tracks = i.Tracks[:len(i.Tracks)-1]
 				state = waitingTrak
@@ -194,6 +255,32 @@ func (i *Init) Unmarshal(r io.ReadSeeker) error {
 				curTrack.ID = int(tkhd.TrackID)
 				state = waitingMdhd
 
+			case "edts":
+				return h.Expand()
+
+			case "elst":
+				if state != waitingMdhd {
+					return nil, fmt.Errorf("unexpected box '%v'", h.BoxInfo.Type)
+				}
+
+				box, _, err := h.ReadPayload()
+				if err != nil {
+					return nil, err
+				}
+				elst := box.(*mp4.Elst)
+
+				// skip empty edits
+				for j := range elst.Entries {
+					mediaTime := elst.GetMediaTime(j)
+					if mediaTime >= 0 {
+						if mediaTime > 0x7FFFFFFF {
+							return nil, fmt.Errorf("invalid media time (%d)", mediaTime)
+						}
+						curTrack.EncoderDelay = uint32(mediaTime)
+						break
+					}
+				}
+
 			case "mdia":
 				return h.Expand()
 
@@ -226,13 +313,18 @@ func (i *Init) Unmarshal(r io.ReadSeeker) error {
 					return nil, fmt.Errorf("unexpected box '%v'", h.BoxInfo.Type)
 				}
 
-				box, _, err := h.ReadPayload()
+				buf, err := readBoxData(h)
 				if err != nil {
 					return nil, err
 				}
-				avcc := box.(*mp4.AVCDecoderConfiguration)
 
-				sps, pps, err := h264FindParams(avcc)
+				var avcc h264.DecoderConfigurationRecord
+				err = avcc.Unmarshal(buf)
+				if err != nil {
+					return nil, fmt.Errorf("invalid avcC: %w", err)
+				}
+
+				sps, pps, err := h264FindParams(&avcc)
 				if err != nil {
 					return nil, err
 				}
@@ -295,13 +387,18 @@ func (i *Init) Unmarshal(r io.ReadSeeker) error {
 					return nil, fmt.Errorf("unexpected box '%v'", h.BoxInfo.Type)
 				}
 
-				box, _, err := h.ReadPayload()
+				buf, err := readBoxData(h)
 				if err != nil {
 					return nil, err
 				}
-				hvcc := box.(*mp4.HvcC)
 
-				vps, sps, pps, err := h265FindParams(hvcc.NaluArrays)
+				var hvcc h265.DecoderConfigurationRecord
+				err = hvcc.Unmarshal(buf)
+				if err != nil {
+					return nil, fmt.Errorf("invalid hvcC: %w", err)
+				}
+
+				vps, sps, pps, err := h265FindParams(hvcc.NALUArrays)
 				if err != nil {
 					return nil, err
 				}
@@ -325,11 +422,16 @@ func (i *Init) Unmarshal(r io.ReadSeeker) error {
 					return nil, fmt.Errorf("unexpected box '%v'", h.BoxInfo.Type)
 				}
 
-				box, _, err := h.ReadPayload()
+				buf, err := readBoxData(h)
 				if err != nil {
 					return nil, err
 				}
-				av1c := box.(*mp4.Av1C)
+
+				var av1c av1.CodecConfigurationRecord
+				err = av1c.Unmarshal(buf)
+				if err != nil {
+					return nil, fmt.Errorf("invalid av1C: %w", err)
+				}
 
 				sequenceHeader, err := av1FindSequenceHeader(av1c.ConfigOBUs)
 				if err != nil {
@@ -353,14 +455,19 @@ func (i *Init) Unmarshal(r io.ReadSeeker) error {
 					return nil, fmt.Errorf("unexpected box '%v'", h.BoxInfo.Type)
 				}
 
-				box, _, err := h.ReadPayload()
+				buf, err := readBoxData(h)
 				if err != nil {
 					return nil, err
 				}
-				dops := box.(*mp4.DOps)
+
+				var dops opus.DecoderConfiguration
+				err = dops.Unmarshal(buf)
+				if err != nil {
+					return nil, fmt.Errorf("invalid dOps: %w", err)
+				}
 
 				curTrack.Codec = &CodecOpus{
-					ChannelCount: int(dops.OutputChannelCount),
+					ChannelCount: dops.ChannelCount,
 				}
 				state = waitingTrak
 
@@ -445,20 +552,23 @@ func (i *Init) Unmarshal(r io.ReadSeeker) error {
 
 				case waitingAudioEsds:
 					switch conf.ObjectTypeIndication {
-					case objectTypeIndicationAudioISO14496part3:
-						spec := esdsFindDecoderSpecificInfo(esds.Descriptors)
-						if spec == nil {
-							return nil, fmt.Errorf("unable to find decoder specific info")
+					case objectTypeIndicationAudioISO14496part3,
+						objectTypeIndicationAudioISO13818part7Main,
+						objectTypeIndicationAudioISO13818part7LC,
+						objectTypeIndicationAudioISO13818part7SSR:
+						buf, err := readBoxData(h)
+						if err != nil {
+							return nil, err
 						}
 
-						var c mpeg4audio.Config
-						err := c.Unmarshal(spec)
+						var dec ESDS
+						err = dec.Unmarshal(buf)
 						if err != nil {
-							return nil, fmt.Errorf("invalid MPEG-4 Audio configuration: %w", err)
+							return nil, fmt.Errorf("invalid esds: %w", err)
 						}
 
 						curTrack.Codec = &CodecMPEG4Audio{
-							Config: c,
+							Config: dec.Config,
 						}
 
 					case objectTypeIndicationAudioISO11172part3:
@@ -498,11 +608,16 @@ func (i *Init) Unmarshal(r io.ReadSeeker) error {
 					return nil, fmt.Errorf("unexpected box '%v'", h.BoxInfo.Type)
 				}
 
-				box, _, err := h.ReadPayload()
+				buf, err := readBoxData(h)
 				if err != nil {
 					return nil, err
 				}
-				dac3 := box.(*mp4.Dac3)
+
+				var dac3 DAC3
+				err = dac3.Unmarshal(buf)
+				if err != nil {
+					return nil, fmt.Errorf("invalid dac3: %w", err)
+				}
 
 				curTrack.Codec = &CodecAC3{
 					SampleRate:   sampleRate,
@@ -511,11 +626,50 @@ func (i *Init) Unmarshal(r io.ReadSeeker) error {
 					Bsid:         dac3.Bsid,
 					Bsmod:        dac3.Bsmod,
 					Acmod:        dac3.Acmod,
-					LfeOn:        dac3.LfeOn != 0,
+					LfeOn:        dac3.LfeOn,
 					BitRateCode:  dac3.BitRateCode,
 				}
 				state = waitingTrak
 
+			case "ec-3":
+				if state != waitingCodec {
+					return nil, fmt.Errorf("unexpected box '%v'", h.BoxInfo.Type)
+				}
+
+				box, _, err := h.ReadPayload()
+				if err != nil {
+					return nil, err
+				}
+				eac3 := box.(*mp4.AudioSampleEntry)
+
+				sampleRate = int(eac3.SampleRate / 65536)
+				channelCount = int(eac3.ChannelCount)
+				state = waitingDec3
+				return h.Expand()
+
+			case "dec3":
+				if state != waitingDec3 {
+					return nil, fmt.Errorf("unexpected box '%v'", h.BoxInfo.Type)
+				}
+
+				buf, err := readBoxData(h)
+				if err != nil {
+					return nil, err
+				}
+
+				var dec3 DEC3
+				err = dec3.Unmarshal(buf)
+				if err != nil {
+					return nil, fmt.Errorf("invalid dec3: %w", err)
+				}
+
+				curTrack.Codec = &CodecEAC3{
+					SampleRate:   sampleRate,
+					ChannelCount: channelCount,
+					Config:       dec3,
+				}
+				state = waitingTrak
+
 			case "ipcm":
 				if state != waitingCodec {
 					return nil, fmt.Errorf("unexpected box '%v'", h.BoxInfo.Type)
@@ -587,16 +741,32 @@ func (i *Init) Marshal(w io.WriteSeeker) error {
 
 	mw := newMP4Writer(w)
 
-	_, err := mw.writeBox(&mp4.Ftyp{ // <ftyp/>
-		MajorBrand:   [4]byte{'m', 'p', '4', '2'},
-		MinorVersion: 1,
-		CompatibleBrands: []mp4.CompatibleBrandElem{
-			{CompatibleBrand: [4]byte{'m', 'p', '4', '1'}},
-			{CompatibleBrand: [4]byte{'m', 'p', '4', '2'}},
-			{CompatibleBrand: [4]byte{'i', 's', 'o', 'm'}},
-			{CompatibleBrand: [4]byte{'h', 'l', 's', 'f'}},
-		},
-	})
+	majorBrand := i.MajorBrand
+	if majorBrand == [4]byte{} {
+		majorBrand = [4]byte{'m', 'p', '4', '2'}
+	}
+
+	compatibleBrands := i.CompatibleBrands
+	if compatibleBrands == nil {
+		compatibleBrands = [][4]byte{
+			{'m', 'p', '4', '1'},
+			{'m', 'p', '4', '2'},
+			{'i', 's', 'o', 'm'},
+			{'h', 'l', 's', 'f'},
+		}
+	}
+
+	ftyp := &mp4.Ftyp{ // <ftyp/>
+		MajorBrand:       majorBrand,
+		MinorVersion:     1,
+		CompatibleBrands: make([]mp4.CompatibleBrandElem, len(compatibleBrands)),
+	}
+
+	for j, brand := range compatibleBrands {
+		ftyp.CompatibleBrands[j] = mp4.CompatibleBrandElem{CompatibleBrand: brand}
+	}
+
+	_, err := mw.writeBox(ftyp)
 	if err != nil {
 		return err
 	}
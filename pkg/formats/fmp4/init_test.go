@@ -306,7 +306,7 @@ var casesInit = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x18, 0xff, 0xff, 0x00, 0x00, 0x00, 0x5d, 0x68,
 			0x76, 0x63, 0x43, 0x01, 0x01, 0x60, 0x00, 0x00,
-			0x00, 0x03, 0x00, 0x90, 0x00, 0x00, 0x03, 0x78,
+			0x00, 0x90, 0x00, 0x00, 0x00, 0x00, 0x00, 0x78,
 			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
 			0x13, 0x03, 0x20, 0x00, 0x01, 0x00, 0x04, 0x01,
 			0x02, 0x03, 0x04, 0x21, 0x00, 0x01, 0x00, 0x2a,
@@ -1191,6 +1191,108 @@ var casesInit = []struct {
 			},
 		},
 	},
+	{
+		"ec-3",
+		[]byte{
+			0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
+			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
+			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
+			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
+			0x00, 0x00, 0x02, 0x33, 0x6d, 0x6f, 0x6f, 0x76,
+			0x00, 0x00, 0x00, 0x6c, 0x6d, 0x76, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0x97,
+			0x74, 0x72, 0x61, 0x6b, 0x00, 0x00, 0x00, 0x5c,
+			0x74, 0x6b, 0x68, 0x64, 0x00, 0x00, 0x00, 0x03,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x33, 0x6d, 0x64, 0x69, 0x61,
+			0x00, 0x00, 0x00, 0x20, 0x6d, 0x64, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x5f, 0x90,
+			0x00, 0x00, 0x00, 0x00, 0x55, 0xc4, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x2d, 0x68, 0x64, 0x6c, 0x72,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x73, 0x6f, 0x75, 0x6e, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x53, 0x6f, 0x75, 0x6e, 0x64, 0x48, 0x61, 0x6e,
+			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x00,
+			0xde, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x10, 0x73, 0x6d, 0x68, 0x64, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x24, 0x64, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x1c, 0x64, 0x72, 0x65, 0x66, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x0c, 0x75, 0x72, 0x6c, 0x20, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0xa2, 0x73, 0x74, 0x62,
+			0x6c, 0x00, 0x00, 0x00, 0x56, 0x73, 0x74, 0x73,
+			0x64, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x46, 0x65, 0x63, 0x2d,
+			0x33, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x08, 0x00, 0x10, 0x00, 0x00, 0x00,
+			0x00, 0xbb, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x0e, 0x64, 0x65, 0x63, 0x33, 0x03, 0x00, 0x20,
+			0x0f, 0x02, 0x80, 0x00, 0x00, 0x00, 0x14, 0x62,
+			0x74, 0x72, 0x74, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0xf7, 0x39, 0x00, 0x01, 0xf7, 0x39, 0x00,
+			0x00, 0x00, 0x10, 0x73, 0x74, 0x74, 0x73, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x10, 0x73, 0x74, 0x73, 0x63, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x14, 0x73, 0x74, 0x73, 0x7a, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x73,
+			0x74, 0x63, 0x6f, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x28, 0x6d,
+			0x76, 0x65, 0x78, 0x00, 0x00, 0x00, 0x20, 0x74,
+			0x72, 0x65, 0x78, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00,
+		},
+		Init{
+			Tracks: []*InitTrack{
+				{
+					ID:        1,
+					TimeScale: 90000,
+					Codec: &CodecEAC3{
+						SampleRate:   48000,
+						ChannelCount: 8,
+						Config: DEC3{
+							DataRate: 96,
+							IndSubs: []DEC3_IndSub{{
+								Bsid:      16,
+								Acmod:     7,
+								LfeOn:     true,
+								NumDepSub: 1,
+								ChanLoc:   0x80,
+							}},
+						},
+					},
+				},
+			},
+		},
+	},
 	{
 		"lpcm",
 		[]byte{
@@ -1478,6 +1580,24 @@ func TestInitUnmarshal(t *testing.T) {
 	}
 }
 
+func TestInitUnmarshalLimits(t *testing.T) {
+	enc := casesInit[0].enc
+
+	var init Init
+	err := init.Unmarshal(bytes.NewReader(enc[:len(enc)-1]))
+	require.ErrorIs(t, err, ErrBoxTruncated)
+
+	err = init.UnmarshalWithOptions(bytes.NewReader(enc), InitUnmarshalOptions{
+		MaxBoxSize: 128,
+	})
+	require.ErrorIs(t, err, ErrBoxTooBig)
+
+	err = init.UnmarshalWithOptions(bytes.NewReader(enc), InitUnmarshalOptions{
+		MaxBoxDepth: 2,
+	})
+	require.ErrorIs(t, err, ErrBoxTooDeep)
+}
+
 func TestInitUnmarshalExternal(t *testing.T) {
 	for _, ca := range []struct {
 		name string
@@ -2066,6 +2186,64 @@ func TestInitUnmarshalExternal(t *testing.T) {
 	}
 }
 
+func TestInitUnmarshalMismatchedAvcC(t *testing.T) {
+	in := Init{
+		Tracks: []*InitTrack{{
+			ID:        1,
+			TimeScale: 90000,
+			Codec:     testVideoTrack,
+		}},
+	}
+
+	var buf seekablebuffer.Buffer
+	err := in.Marshal(&buf)
+	require.NoError(t, err)
+
+	// write a AVCLevelIndication that differs from the SPS, like some muxers do
+	byts := buf.Bytes()
+	i := bytes.Index(byts, []byte{'a', 'v', 'c', 'C'})
+	require.NotEqual(t, -1, i)
+	byts[i+4+3] = 0x1f
+
+	var dec Init
+	err = dec.Unmarshal(bytes.NewReader(byts))
+	require.NoError(t, err)
+	require.Equal(t, in, dec)
+}
+
+func TestInitMarshalH265ConstraintFlags(t *testing.T) {
+	in := Init{
+		Tracks: []*InitTrack{{
+			ID:        1,
+			TimeScale: 90000,
+			Codec: &CodecH265{
+				VPS: []byte{0x01, 0x02, 0x03, 0x04},
+				// general_constraint_indicator_flags contain emulation prevention bytes
+				SPS: []byte{
+					0x42, 0x01, 0x01, 0x01, 0x60, 0x00, 0x00, 0x03,
+					0x00, 0x90, 0x00, 0x00, 0x03, 0x00, 0x00, 0x03,
+					0x00, 0x78, 0xa0, 0x03, 0xc0, 0x80, 0x10, 0xe5,
+					0x96, 0x66, 0x69, 0x24, 0xca, 0xe0, 0x10, 0x00,
+					0x00, 0x03, 0x00, 0x10, 0x00, 0x00, 0x03, 0x01,
+					0xe0, 0x80,
+				},
+				PPS: []byte{0x08},
+			},
+		}},
+	}
+
+	var buf seekablebuffer.Buffer
+	err := in.Marshal(&buf)
+	require.NoError(t, err)
+
+	byts := buf.Bytes()
+	i := bytes.Index(byts, []byte{'h', 'v', 'c', 'C'})
+	require.NotEqual(t, -1, i)
+
+	// progressive_source_flag and frame_only_constraint_flag
+	require.Equal(t, []byte{0x90, 0x00, 0x00, 0x00, 0x00, 0x00}, byts[i+4+6:i+4+12])
+}
+
 func TestInitMarshal(t *testing.T) {
 	for _, ca := range casesInit {
 		t.Run(ca.name, func(t *testing.T) {
@@ -2077,6 +2255,60 @@ func TestInitMarshal(t *testing.T) {
 	}
 }
 
+func TestInitMarshalBrands(t *testing.T) {
+	in := Init{
+		Tracks: []*InitTrack{{
+			ID:        1,
+			TimeScale: 90000,
+			Codec:     testVideoTrack,
+		}},
+		MajorBrand: [4]byte{'i', 's', 'o', '6'},
+		CompatibleBrands: [][4]byte{
+			{'i', 's', 'o', '6'},
+			{'c', 'm', 'f', 'c'},
+		},
+	}
+
+	var buf seekablebuffer.Buffer
+	err := in.Marshal(&buf)
+	require.NoError(t, err)
+	require.Equal(t, []byte{
+		0x00, 0x00, 0x00, 0x18,
+		'f', 't', 'y', 'p',
+		'i', 's', 'o', '6', 0x00, 0x00, 0x00, 0x01,
+		'i', 's', 'o', '6', 'c', 'm', 'f', 'c',
+	}, buf.Bytes()[:24])
+}
+
+func TestInitEncoderDelay(t *testing.T) {
+	in := Init{
+		Tracks: []*InitTrack{{
+			ID:           1,
+			TimeScale:    uint32(testAudioTrack.SampleRate),
+			Codec:        testAudioTrack,
+			EncoderDelay: uint32(testAudioTrack.Config.PrimingSamples()),
+		}},
+	}
+
+	var buf seekablebuffer.Buffer
+	err := in.Marshal(&buf)
+	require.NoError(t, err)
+	require.True(t, bytes.Contains(buf.Bytes(), []byte{
+		0x00, 0x00, 0x00, 0x24,
+		'e', 'd', 't', 's',
+		0x00, 0x00, 0x00, 0x1c,
+		'e', 'l', 's', 't',
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x08, 0x00,
+		0x00, 0x01, 0x00, 0x00,
+	}))
+
+	var dec Init
+	err = dec.Unmarshal(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, in, dec)
+}
+
 func TestInitMarshalEmptyParameters(t *testing.T) {
 	for _, ca := range []struct {
 		name  string
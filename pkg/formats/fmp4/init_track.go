@@ -35,12 +35,20 @@ type InitTrack struct {
 
 	// codec.
 	Codec Codec
+
+	// encoder delay, i.e. duration of the samples at the beginning of the track
+	// that must not be presented, in TimeScale units.
+	// When it is not zero, an edit list is written.
+	// With MPEG-4 audio, it is typically filled with mpeg4audio.AudioSpecificConfig.PrimingSamples().
+	EncoderDelay uint32
 }
 
 func (it *InitTrack) marshal(w *mp4Writer) error {
 	/*
 		|trak|
 		|    |tkhd|
+		|    |edts| (when EncoderDelay is not zero)
+		|    |    |elst|
 		|    |mdia|
 		|    |    |mdhd|
 		|    |    |hdlr|
@@ -76,6 +84,9 @@ func (it *InitTrack) marshal(w *mp4Writer) error {
 		|    |    |    |    |    |ac-3| (AC-3)
 		|    |    |    |    |    |    |dac3|
 		|    |    |    |    |    |    |btrt|
+		|    |    |    |    |    |ec-3| (E-AC-3)
+		|    |    |    |    |    |    |dec3|
+		|    |    |    |    |    |    |btrt|
 		|    |    |    |    |    |ipcm| (LPCM)
 		|    |    |    |    |    |    |pcmC|
 		|    |    |    |    |    |    |btrt|
@@ -199,6 +210,36 @@ func (it *InitTrack) marshal(w *mp4Writer) error {
 		}
 	}
 
+	if it.EncoderDelay != 0 {
+		if it.EncoderDelay > 0x7FFFFFFF {
+			return fmt.Errorf("encoder delay is too big")
+		}
+
+		_, err = w.writeBoxStart(&mp4.Edts{}) // <edts>
+		if err != nil {
+			return err
+		}
+
+		// segment_duration is zero since the duration of fragmented tracks is unknown.
+		_, err = w.writeBox(&mp4.Elst{ // <elst/>
+			EntryCount: 1,
+			Entries: []mp4.ElstEntry{{
+				SegmentDurationV0: 0,
+				MediaTimeV0:       int32(it.EncoderDelay),
+				MediaRateInteger:  1,
+				MediaRateFraction: 0,
+			}},
+		})
+		if err != nil {
+			return err
+		}
+
+		err = w.writeBoxEnd() // </edts>
+		if err != nil {
+			return err
+		}
+	}
+
 	_, err = w.writeBoxStart(&mp4.Mdia{}) // <mdia>
 	if err != nil {
 		return err
@@ -415,11 +456,8 @@ func (it *InitTrack) marshal(w *mp4Writer) error {
 			ConfigurationVersion:        1,
 			GeneralProfileIdc:           h265SPS.ProfileTierLevel.GeneralProfileIdc,
 			GeneralProfileCompatibility: h265SPS.ProfileTierLevel.GeneralProfileCompatibilityFlag,
-			GeneralConstraintIndicator: [6]uint8{
-				codec.SPS[7], codec.SPS[8], codec.SPS[9],
-				codec.SPS[10], codec.SPS[11], codec.SPS[12],
-			},
-			GeneralLevelIdc: h265SPS.ProfileTierLevel.GeneralLevelIdc,
+			GeneralConstraintIndicator:  h265SPS.ProfileTierLevel.GeneralConstraintIndicatorFlags(),
+			GeneralLevelIdc:             h265SPS.ProfileTierLevel.GeneralLevelIdc,
 			// MinSpatialSegmentationIdc
 			// ParallelismType
 			ChromaFormatIdc:      uint8(h265SPS.ChromaFormatIdc),
@@ -714,40 +752,18 @@ func (it *InitTrack) marshal(w *mp4Writer) error {
 			return err
 		}
 
-		enc, _ := codec.Config.Marshal()
+		var enc []byte
+		enc, err = ESDS{
+			ESID:       uint16(it.ID),
+			MaxBitrate: maxBitrate,
+			AvgBitrate: avgBitrate,
+			Config:     codec.Config,
+		}.Marshal()
+		if err != nil {
+			return err
+		}
 
-		_, err = w.writeBox(&mp4.Esds{ // <esds/>
-			Descriptors: []mp4.Descriptor{
-				{
-					Tag:  mp4.ESDescrTag,
-					Size: 32 + uint32(len(enc)),
-					ESDescriptor: &mp4.ESDescriptor{
-						ESID: uint16(it.ID),
-					},
-				},
-				{
-					Tag:  mp4.DecoderConfigDescrTag,
-					Size: 18 + uint32(len(enc)),
-					DecoderConfigDescriptor: &mp4.DecoderConfigDescriptor{
-						ObjectTypeIndication: objectTypeIndicationAudioISO14496part3,
-						StreamType:           streamTypeAudioStream,
-						Reserved:             true,
-						MaxBitrate:           maxBitrate,
-						AvgBitrate:           avgBitrate,
-					},
-				},
-				{
-					Tag:  mp4.DecSpecificInfoTag,
-					Size: uint32(len(enc)),
-					Data: enc,
-				},
-				{
-					Tag:  mp4.SLConfigDescrTag,
-					Size: 1,
-					Data: []byte{0x02},
-				},
-			},
-		})
+		_, err = w.writeRawBox(mp4.BoxTypeEsds(), enc) // <esds/>
 		if err != nil {
 			return err
 		}
@@ -815,23 +831,51 @@ func (it *InitTrack) marshal(w *mp4Writer) error {
 			return err
 		}
 
-		_, err = w.writeBox(&mp4.Dac3{ // <dac3/>
-			Fscod: codec.Fscod,
-			Bsid:  codec.Bsid,
-			Bsmod: codec.Bsmod,
-			Acmod: codec.Acmod,
-			LfeOn: func() uint8 {
-				if codec.LfeOn {
-					return 1
-				}
-				return 0
-			}(),
+		var enc []byte
+		enc, err = DAC3{
+			Fscod:       codec.Fscod,
+			Bsid:        codec.Bsid,
+			Bsmod:       codec.Bsmod,
+			Acmod:       codec.Acmod,
+			LfeOn:       codec.LfeOn,
 			BitRateCode: codec.BitRateCode,
+		}.Marshal()
+		if err != nil {
+			return err
+		}
+
+		_, err = w.writeRawBox(mp4.BoxTypeDAC3(), enc) // <dac3/>
+		if err != nil {
+			return err
+		}
+
+	case *CodecEAC3:
+		_, err = w.writeBoxStart(&mp4.AudioSampleEntry{ // <ec-3>
+			SampleEntry: mp4.SampleEntry{
+				AnyTypeBox: mp4.AnyTypeBox{
+					Type: boxTypeEC3(),
+				},
+				DataReferenceIndex: 1,
+			},
+			ChannelCount: uint16(codec.ChannelCount),
+			SampleSize:   16,
+			SampleRate:   uint32(codec.SampleRate * 65536),
 		})
 		if err != nil {
 			return err
 		}
 
+		var enc []byte
+		enc, err = codec.Config.Marshal()
+		if err != nil {
+			return err
+		}
+
+		_, err = w.writeRawBox(boxTypeDEC3(), enc) // <dec3/>
+		if err != nil {
+			return err
+		}
+
 	case *CodecLPCM:
 		_, err = w.writeBoxStart(&mp4.AudioSampleEntry{ // <ipcm>
 			SampleEntry: mp4.SampleEntry{
@@ -17,9 +17,22 @@ func newMP4Writer(w io.WriteSeeker) *mp4Writer {
 }
 
 func (w *mp4Writer) writeBoxStart(box mp4.IImmutableBox) (int, error) {
+	return w.writeBoxStartInner(box, false)
+}
+
+// writeLargeBoxStart writes the beginning of a box whose size is stored
+// into the 64-bit largesize field, that is needed when the size exceeds 2^32-1.
+func (w *mp4Writer) writeLargeBoxStart(box mp4.IImmutableBox) (int, error) {
+	return w.writeBoxStartInner(box, true)
+}
+
+func (w *mp4Writer) writeBoxStartInner(box mp4.IImmutableBox, large bool) (int, error) {
 	bi := &mp4.BoxInfo{
 		Type: box.GetType(),
 	}
+	if large {
+		bi.HeaderSize = mp4.LargeHeaderSize
+	}
 	var err error
 	bi, err = w.w.StartBox(bi)
 	if err != nil {
@@ -53,6 +66,20 @@ func (w *mp4Writer) writeBox(box mp4.IImmutableBox) (int, error) {
 	return off, nil
 }
 
+func (w *mp4Writer) writeLargeBox(box mp4.IImmutableBox) (int, error) {
+	off, err := w.writeLargeBoxStart(box)
+	if err != nil {
+		return 0, err
+	}
+
+	err = w.writeBoxEnd()
+	if err != nil {
+		return 0, err
+	}
+
+	return off, nil
+}
+
 func (w *mp4Writer) rewriteBox(off int, box mp4.IImmutableBox) error {
 	prevOff, err := w.w.Seek(0, io.SeekCurrent)
 	if err != nil {
@@ -81,3 +108,24 @@ func (w *mp4Writer) rewriteBox(off int, box mp4.IImmutableBox) error {
 
 	return nil
 }
+
+// writeRawBox writes a box whose content is already encoded,
+// that is needed for boxes that are not supported by go-mp4.
+func (w *mp4Writer) writeRawBox(typ mp4.BoxType, payload []byte) (int, error) {
+	bi, err := w.w.StartBox(&mp4.BoxInfo{Type: typ})
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = w.w.Write(payload)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = w.w.EndBox()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(bi.Offset), nil
+}
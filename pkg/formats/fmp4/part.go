@@ -1,13 +1,22 @@
 package fmp4
 
 import (
+	"fmt"
 	"io"
+	"math"
 
 	"github.com/abema/go-mp4"
 )
 
 const (
+	tfhdFlagBaseDataOffsetPresent        = 0x01
+	tfhdFlagDefaultSampleDurationPresent = 0x08
+	tfhdFlagDefaultSampleSizePresent     = 0x10
+	tfhdFlagDefaultSampleFlagsPresent    = 0x20
+	tfhdFlagDefaultBaseIsMoof            = 0x20000
+
 	trunFlagDataOffsetPreset                       = 0x01
+	trunFlagFirstSampleFlagsPresent                = 0x04
 	trunFlagSampleDurationPresent                  = 0x100
 	trunFlagSampleSizePresent                      = 0x200
 	trunFlagSampleFlagsPresent                     = 0x400
@@ -16,6 +25,11 @@ const (
 	sampleFlagIsNonSyncSample = 1 << 16
 )
 
+// maximum size of a box whose size can be stored into the 32-bit size field.
+// Bigger boxes use the 64-bit largesize field.
+// It is a variable in order to allow tests to lower it.
+var maxSmallBoxSize uint64 = math.MaxUint32
+
 // Part is a fMP4 part.
 type Part struct {
 	SequenceNumber uint32
@@ -86,13 +100,27 @@ func (p *Part) Marshal(w io.WriteSeeker) error {
 		}
 	}
 
-	mdatOffset, err := mw.writeBox(mdat)
+	var mdatOffset int
+	var mdatHeaderSize int
+
+	if (uint64(dataSize) + mp4.SmallHeaderSize) > maxSmallBoxSize {
+		mdatOffset, err = mw.writeLargeBox(mdat)
+		mdatHeaderSize = mp4.LargeHeaderSize
+	} else {
+		mdatOffset, err = mw.writeBox(mdat)
+		mdatHeaderSize = mp4.SmallHeaderSize
+	}
 	if err != nil {
 		return err
 	}
 
 	for i := range p.Tracks {
-		truns[i].DataOffset = int32(dataOffsets[i] + mdatOffset - moofOffset + 8)
+		dataOffset := dataOffsets[i] + mdatOffset - moofOffset + mdatHeaderSize
+		if dataOffset > math.MaxInt32 {
+			return fmt.Errorf("data offset of track %d (%d) exceeds maximum", p.Tracks[i].ID, dataOffset)
+		}
+
+		truns[i].DataOffset = int32(dataOffset)
 		err = mw.rewriteBox(trunOffsets[i], truns[i])
 		if err != nil {
 			return err
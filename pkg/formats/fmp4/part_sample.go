@@ -13,6 +13,13 @@ type PartSample struct {
 	Payload         []byte
 }
 
+func (ps PartSample) flags() uint32 {
+	if ps.IsNonSyncSample {
+		return sampleFlagIsNonSyncSample
+	}
+	return 0
+}
+
 // NewPartSampleAV1 creates a sample with AV1 data.
 func NewPartSampleAV1(sequenceHeaderPresent bool, tu [][]byte) (*PartSample, error) {
 	bs, err := av1.BitstreamMarshal(tu)
@@ -1,9 +1,22 @@
 package fmp4
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/abema/go-mp4"
 )
 
+func durationMp4ToGo(v int64, timeScale uint32) time.Duration {
+	timeScale64 := int64(timeScale)
+	secs := v / timeScale64
+	dec := v % timeScale64
+	return time.Duration(secs)*time.Second + time.Duration(dec)*time.Second/time.Duration(timeScale64)
+}
+
+// PartTrackSampleFunc is the prototype of the callback passed to PartTrack.ForEachSample.
+type PartTrackSampleFunc func(pts time.Duration, dts time.Duration, duration time.Duration, sample *PartSample) error
+
 // PartTrack is a track of Part.
 type PartTrack struct {
 	ID       int
@@ -11,6 +24,65 @@ type PartTrack struct {
 	Samples  []*PartSample
 }
 
+// ForEachSample calls cb for each sample, in decoding order, together with its timestamps.
+// The DTS of the first sample is BaseTime, and the DTS of the following samples
+// is increased by the duration of the previous ones.
+// The PTS is the DTS plus PTSOffset, that can be negative (trun version 1),
+// therefore the PTS can be lower than the DTS and timestamps are signed.
+// Timestamps are converted from the time scale of the track.
+func (pt PartTrack) ForEachSample(timeScale uint32, cb PartTrackSampleFunc) error {
+	if timeScale == 0 {
+		return fmt.Errorf("invalid time scale")
+	}
+
+	dts := int64(pt.BaseTime)
+
+	for _, sample := range pt.Samples {
+		pts := dts + int64(sample.PTSOffset)
+
+		err := cb(
+			durationMp4ToGo(pts, timeScale),
+			durationMp4ToGo(dts, timeScale),
+			durationMp4ToGo(int64(sample.Duration), timeScale),
+			sample)
+		if err != nil {
+			return err
+		}
+
+		dts += int64(sample.Duration)
+	}
+
+	return nil
+}
+
+func (pt *PartTrack) sameDurations() bool {
+	for _, sample := range pt.Samples[1:] {
+		if sample.Duration != pt.Samples[0].Duration {
+			return false
+		}
+	}
+	return true
+}
+
+func (pt *PartTrack) sameSizes() bool {
+	for _, sample := range pt.Samples[1:] {
+		if len(sample.Payload) != len(pt.Samples[0].Payload) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameFlags checks whether samples starting from the given one have the same flags.
+func (pt *PartTrack) sameFlags(start int) bool {
+	for _, sample := range pt.Samples[start+1:] {
+		if sample.flags() != pt.Samples[start].flags() {
+			return false
+		}
+	}
+	return true
+}
+
 func (pt *PartTrack) marshal(w *mp4Writer) (*mp4.Trun, int, error) {
 	/*
 		|traf|
@@ -24,14 +96,64 @@ func (pt *PartTrack) marshal(w *mp4Writer) (*mp4.Trun, int, error) {
 		return nil, 0, err
 	}
 
-	flags := 0
-
-	_, err = w.writeBox(&mp4.Tfhd{ // <tfhd/>
-		FullBox: mp4.FullBox{
-			Flags: [3]byte{2, byte(flags >> 8), byte(flags)},
-		},
+	tfhd := &mp4.Tfhd{ // <tfhd/>
 		TrackID: uint32(pt.ID),
-	})
+	}
+	tfhdFlags := tfhdFlagDefaultBaseIsMoof
+
+	trunFlags := trunFlagDataOffsetPreset
+
+	// fields that are shared by all samples are moved into tfhd.
+	if len(pt.Samples) != 0 {
+		if pt.sameDurations() {
+			tfhdFlags |= tfhdFlagDefaultSampleDurationPresent
+			tfhd.DefaultSampleDuration = pt.Samples[0].Duration
+		} else {
+			trunFlags |= trunFlagSampleDurationPresent
+		}
+
+		if pt.sameSizes() {
+			tfhdFlags |= tfhdFlagDefaultSampleSizePresent
+			tfhd.DefaultSampleSize = uint32(len(pt.Samples[0].Payload))
+		} else {
+			trunFlags |= trunFlagSampleSizePresent
+		}
+	}
+
+	var firstSampleFlags uint32
+
+	switch {
+	case len(pt.Samples) == 0:
+
+	case pt.sameFlags(0):
+		if pt.Samples[0].flags() != 0 {
+			tfhdFlags |= tfhdFlagDefaultSampleFlagsPresent
+			tfhd.DefaultSampleFlags = pt.Samples[0].flags()
+		}
+
+	// typical of video, where only the first sample is a sync sample.
+	case pt.sameFlags(1):
+		trunFlags |= trunFlagFirstSampleFlagsPresent
+		firstSampleFlags = pt.Samples[0].flags()
+
+		if pt.Samples[1].flags() != 0 {
+			tfhdFlags |= tfhdFlagDefaultSampleFlagsPresent
+			tfhd.DefaultSampleFlags = pt.Samples[1].flags()
+		}
+
+	default:
+		trunFlags |= trunFlagSampleFlagsPresent
+	}
+
+	for _, sample := range pt.Samples {
+		if sample.PTSOffset != 0 {
+			trunFlags |= trunFlagSampleCompositionTimeOffsetPresentOrV1
+		}
+	}
+
+	tfhd.Flags = [3]byte{byte(tfhdFlags >> 16), byte(tfhdFlags >> 8), byte(tfhdFlags)}
+
+	_, err = w.writeBox(tfhd)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -47,37 +169,20 @@ func (pt *PartTrack) marshal(w *mp4Writer) (*mp4.Trun, int, error) {
 		return nil, 0, err
 	}
 
-	flags = trunFlagDataOffsetPreset |
-		trunFlagSampleDurationPresent |
-		trunFlagSampleSizePresent
-
-	for _, sample := range pt.Samples {
-		if sample.IsNonSyncSample {
-			flags |= trunFlagSampleFlagsPresent
-		}
-		if sample.PTSOffset != 0 {
-			flags |= trunFlagSampleCompositionTimeOffsetPresentOrV1
-		}
-	}
-
 	trun := &mp4.Trun{ // <trun/>
 		FullBox: mp4.FullBox{
 			Version: 1,
-			Flags:   [3]byte{0, byte(flags >> 8), byte(flags)},
+			Flags:   [3]byte{0, byte(trunFlags >> 8), byte(trunFlags)},
 		},
-		SampleCount: uint32(len(pt.Samples)),
+		SampleCount:      uint32(len(pt.Samples)),
+		FirstSampleFlags: firstSampleFlags,
 	}
 
 	for _, sample := range pt.Samples {
-		var flags uint32
-		if sample.IsNonSyncSample {
-			flags |= sampleFlagIsNonSyncSample
-		}
-
 		trun.Entries = append(trun.Entries, mp4.TrunEntry{
 			SampleDuration:                sample.Duration,
 			SampleSize:                    uint32(len(sample.Payload)),
-			SampleFlags:                   flags,
+			SampleFlags:                   sample.flags(),
 			SampleCompositionTimeOffsetV1: sample.PTSOffset,
 		})
 	}
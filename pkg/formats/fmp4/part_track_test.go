@@ -0,0 +1,117 @@
+package fmp4
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bluenviron/mediacommon/pkg/formats/fmp4/seekablebuffer"
+	"github.com/stretchr/testify/require"
+)
+
+type partTrackTestSample struct {
+	pts      time.Duration
+	dts      time.Duration
+	duration time.Duration
+	payload  []byte
+}
+
+func TestPartTrackForEachSample(t *testing.T) {
+	// I, P, B, B in decoding order: B-frames are presented before the P-frame,
+	// therefore their PTS offset is negative.
+	in := Parts{{
+		Tracks: []*PartTrack{{
+			ID:       1,
+			BaseTime: 90000,
+			Samples: []*PartSample{
+				{
+					Duration: 3000,
+					Payload:  []byte{1},
+				},
+				{
+					Duration:        3000,
+					PTSOffset:       6000,
+					IsNonSyncSample: true,
+					Payload:         []byte{2},
+				},
+				{
+					Duration:        3000,
+					PTSOffset:       -3000,
+					IsNonSyncSample: true,
+					Payload:         []byte{3},
+				},
+				{
+					Duration:        3000,
+					PTSOffset:       -3000,
+					IsNonSyncSample: true,
+					Payload:         []byte{4},
+				},
+			},
+		}},
+	}}
+
+	var buf seekablebuffer.Buffer
+	err := in.Marshal(&buf)
+	require.NoError(t, err)
+
+	var parts Parts
+	err = parts.Unmarshal(buf.Bytes())
+	require.NoError(t, err)
+
+	var samples []partTrackTestSample
+
+	err = parts[0].Tracks[0].ForEachSample(90000,
+		func(pts time.Duration, dts time.Duration, duration time.Duration, sample *PartSample) error {
+			samples = append(samples, partTrackTestSample{
+				pts:      pts,
+				dts:      dts,
+				duration: duration,
+				payload:  sample.Payload,
+			})
+			return nil
+		})
+	require.NoError(t, err)
+
+	require.Equal(t, []partTrackTestSample{
+		{
+			pts:      1 * time.Second,
+			dts:      1 * time.Second,
+			duration: 33333333,
+			payload:  []byte{1},
+		},
+		{
+			pts:      1100 * time.Millisecond,
+			dts:      1033333333,
+			duration: 33333333,
+			payload:  []byte{2},
+		},
+		{
+			pts:      1033333333,
+			dts:      1066666666,
+			duration: 33333333,
+			payload:  []byte{3},
+		},
+		{
+			pts:      1066666666,
+			dts:      1100 * time.Millisecond,
+			duration: 33333333,
+			payload:  []byte{4},
+		},
+	}, samples)
+}
+
+func TestPartTrackForEachSampleErrors(t *testing.T) {
+	pt := PartTrack{
+		Samples: []*PartSample{{Duration: 10}, {Duration: 10}},
+	}
+
+	err := pt.ForEachSample(0, func(_, _, _ time.Duration, _ *PartSample) error {
+		return nil
+	})
+	require.EqualError(t, err, "invalid time scale")
+
+	err = pt.ForEachSample(1000, func(_, _, _ time.Duration, _ *PartSample) error {
+		return fmt.Errorf("stop")
+	})
+	require.EqualError(t, err, "stop")
+}
@@ -11,8 +11,25 @@ import (
 // Parts is a sequence of fMP4 parts.
 type Parts []*Part
 
+// PartsUnmarshalOptions contains options of Parts.UnmarshalWithOptions.
+type PartsUnmarshalOptions struct {
+	// maximum size of a box, including the header.
+	// When it is zero, the size is only limited by the available data.
+	MaxBoxSize uint64
+
+	// maximum nesting depth of a box.
+	// When it is zero, it defaults to 16.
+	MaxBoxDepth int
+}
+
 // Unmarshal decodes one or more fMP4 parts.
 func (ps *Parts) Unmarshal(byts []byte) error {
+	return ps.UnmarshalWithOptions(byts, PartsUnmarshalOptions{})
+}
+
+// UnmarshalWithOptions decodes one or more fMP4 parts.
+// Boxes whose declared size exceeds the available data are always rejected.
+func (ps *Parts) UnmarshalWithOptions(byts []byte, opts PartsUnmarshalOptions) error {
 	type readState int
 
 	const (
@@ -28,8 +45,15 @@ func (ps *Parts) Unmarshal(byts []byte) error {
 	var curTrack *PartTrack
 	var tfdt *mp4.Tfdt
 	var tfhd *mp4.Tfhd
+	var dataEnd uint64
+	var trunPresent bool
 
 	_, err := mp4.ReadBoxStructure(bytes.NewReader(byts), func(h *mp4.ReadHandle) (interface{}, error) {
+		err := checkBox(h, uint64(len(byts)), opts.MaxBoxSize, opts.MaxBoxDepth)
+		if err != nil {
+			return nil, err
+		}
+
 		if h.BoxInfo.IsSupportedType() {
 			switch h.BoxInfo.Type.String() {
 			case "moof":
@@ -40,6 +64,8 @@ func (ps *Parts) Unmarshal(byts []byte) error {
 				curPart = &Part{}
 				*ps = append(*ps, curPart)
 				moofOffset = h.BoxInfo.Offset
+				dataEnd = moofOffset
+				curTrack = nil
 				state = waitingMfhd
 				return h.Expand()
 
@@ -72,6 +98,7 @@ func (ps *Parts) Unmarshal(byts []byte) error {
 				curPart.Tracks = append(curPart.Tracks, curTrack)
 				tfdt = nil
 				tfhd = nil
+				trunPresent = false
 				state = waitingTfdtTfhdTrun
 				return h.Expand()
 
@@ -99,12 +126,17 @@ func (ps *Parts) Unmarshal(byts []byte) error {
 				}
 				tfdt = box.(*mp4.Tfdt)
 
-				if tfdt.FullBox.Version != 1 {
+				switch tfdt.FullBox.Version {
+				case 0:
+					curTrack.BaseTime = uint64(tfdt.BaseMediaDecodeTimeV0)
+
+				case 1:
+					curTrack.BaseTime = tfdt.BaseMediaDecodeTimeV1
+
+				default:
 					return nil, fmt.Errorf("unsupported tfdt version")
 				}
 
-				curTrack.BaseTime = tfdt.BaseMediaDecodeTimeV1
-
 			case "trun":
 				if state != waitingTfdtTfhdTrun || tfhd == nil {
 					return nil, fmt.Errorf("unexpected trun")
@@ -125,17 +157,37 @@ func (ps *Parts) Unmarshal(byts []byte) error {
 				}
 				trun := box.(*mp4.Trun)
 
-				trunFlags := uint16(trun.Flags[1])<<8 | uint16(trun.Flags[2])
-				if (trunFlags & trunFlagDataOffsetPreset) == 0 {
-					return nil, fmt.Errorf("unsupported flags")
-				}
+				trunFlags := uint32(trun.Flags[0])<<16 | uint32(trun.Flags[1])<<8 | uint32(trun.Flags[2])
+				tfhdFlags := uint32(tfhd.Flags[0])<<16 | uint32(tfhd.Flags[1])<<8 | uint32(tfhd.Flags[2])
 
 				existing := len(curTrack.Samples)
 				tmp := make([]*PartSample, existing+len(trun.Entries))
 				copy(tmp, curTrack.Samples)
 				curTrack.Samples = tmp
 
-				pos := uint64(trun.DataOffset) + moofOffset
+				// the first trun of a track fragment is relative to the base data offset,
+				// while the following ones continue from where the previous one ended.
+				var pos uint64
+				if !trunPresent {
+					switch {
+					case (tfhdFlags & tfhdFlagBaseDataOffsetPresent) != 0:
+						pos = tfhd.BaseDataOffset
+
+					case (tfhdFlags & tfhdFlagDefaultBaseIsMoof) != 0:
+						pos = moofOffset
+
+					default:
+						pos = dataEnd
+					}
+				} else {
+					pos = dataEnd
+				}
+				trunPresent = true
+
+				if (trunFlags & trunFlagDataOffsetPreset) != 0 {
+					pos = uint64(int64(pos) + int64(trun.DataOffset))
+				}
+
 				if uint64(len(byts)) < pos {
 					return nil, fmt.Errorf("invalid data_offset / moof_offset")
 				}
@@ -151,12 +203,21 @@ func (ps *Parts) Unmarshal(byts []byte) error {
 						s.Duration = tfhd.DefaultSampleDuration
 					}
 
-					s.PTSOffset = e.SampleCompositionTimeOffsetV1
+					if trun.FullBox.Version == 0 {
+						s.PTSOffset = int32(e.SampleCompositionTimeOffsetV0)
+					} else {
+						s.PTSOffset = e.SampleCompositionTimeOffsetV1
+					}
 
 					var sampleFlags uint32
-					if (trunFlags & trunFlagSampleFlagsPresent) != 0 {
+					switch {
+					case (trunFlags & trunFlagSampleFlagsPresent) != 0:
 						sampleFlags = e.SampleFlags
-					} else {
+
+					case i == 0 && (trunFlags&trunFlagFirstSampleFlagsPresent) != 0:
+						sampleFlags = trun.FirstSampleFlags
+
+					default:
 						sampleFlags = tfhd.DefaultSampleFlags
 					}
 					s.IsNonSyncSample = ((sampleFlags & sampleFlagIsNonSyncSample) != 0)
@@ -174,10 +235,13 @@ func (ps *Parts) Unmarshal(byts []byte) error {
 
 					s.Payload = ptr[:size]
 					ptr = ptr[size:]
+					pos += uint64(size)
 
 					curTrack.Samples[existing+i] = s
 				}
 
+				dataEnd = pos
+
 			case "mdat":
 				if state != waitingTraf && state != waitingTfdtTfhdTrun {
 					return nil, fmt.Errorf("unexpected mdat")
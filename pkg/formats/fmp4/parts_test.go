@@ -53,33 +53,32 @@ var casesParts = []struct {
 			},
 		}},
 		[]byte{
-			0x00, 0x00, 0x00, 0xc8, 0x6d, 0x6f, 0x6f, 0x66,
+			0x00, 0x00, 0x00, 0xbc, 0x6d, 0x6f, 0x6f, 0x66,
 			0x00, 0x00, 0x00, 0x10, 0x6d, 0x66, 0x68, 0x64,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x04,
-			0x00, 0x00, 0x00, 0x60, 0x74, 0x72, 0x61, 0x66,
-			0x00, 0x00, 0x00, 0x10, 0x74, 0x66, 0x68, 0x64,
-			0x00, 0x02, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00,
-			0x00, 0x00, 0x00, 0x14, 0x74, 0x66, 0x64, 0x74,
-			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x01, 0x5f, 0x90, 0x00, 0x00, 0x00, 0x34,
-			0x74, 0x72, 0x75, 0x6e, 0x01, 0x00, 0x0f, 0x01,
-			0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00, 0xd0,
-			0x00, 0x00, 0x00, 0x1e, 0x00, 0x00, 0x00, 0x02,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x3c, 0x00, 0x00, 0x00, 0x02,
-			0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x0f,
-			0x00, 0x00, 0x00, 0x50, 0x74, 0x72, 0x61, 0x66,
-			0x00, 0x00, 0x00, 0x10, 0x74, 0x66, 0x68, 0x64,
-			0x00, 0x02, 0x00, 0x00, 0x00, 0x00, 0x01, 0x01,
+			0x00, 0x00, 0x00, 0x5c, 0x74, 0x72, 0x61, 0x66,
+			0x00, 0x00, 0x00, 0x18, 0x74, 0x66, 0x68, 0x64,
+			0x00, 0x02, 0x00, 0x30, 0x00, 0x00, 0x01, 0x00,
+			0x00, 0x00, 0x00, 0x02, 0x00, 0x01, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x14, 0x74, 0x66, 0x64, 0x74,
 			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0xac, 0x44, 0x00, 0x00, 0x00, 0x24,
-			0x74, 0x72, 0x75, 0x6e, 0x01, 0x00, 0x03, 0x01,
-			0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00, 0xd4,
-			0x00, 0x00, 0x00, 0x1e, 0x00, 0x00, 0x00, 0x02,
-			0x00, 0x00, 0x00, 0x1e, 0x00, 0x00, 0x00, 0x02,
-			0x00, 0x00, 0x00, 0x10, 0x6d, 0x64, 0x61, 0x74,
-			0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08,
+			0x00, 0x01, 0x5f, 0x90, 0x00, 0x00, 0x00, 0x28,
+			0x74, 0x72, 0x75, 0x6e, 0x01, 0x00, 0x09, 0x05,
+			0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00, 0xc4,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x1e,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x3c,
+			0x00, 0x00, 0x00, 0x0f, 0x00, 0x00, 0x00, 0x48,
+			0x74, 0x72, 0x61, 0x66, 0x00, 0x00, 0x00, 0x18,
+			0x74, 0x66, 0x68, 0x64, 0x00, 0x02, 0x00, 0x18,
+			0x00, 0x00, 0x01, 0x01, 0x00, 0x00, 0x00, 0x1e,
+			0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00, 0x14,
+			0x74, 0x66, 0x64, 0x74, 0x01, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xac, 0x44,
+			0x00, 0x00, 0x00, 0x14, 0x74, 0x72, 0x75, 0x6e,
+			0x01, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x02,
+			0x00, 0x00, 0x00, 0xc8, 0x00, 0x00, 0x00, 0x10,
+			0x6d, 0x64, 0x61, 0x74, 0x01, 0x02, 0x03, 0x04,
+			0x05, 0x06, 0x07, 0x08,
 		},
 	},
 	{
@@ -125,31 +124,80 @@ var casesParts = []struct {
 			0x00, 0x00, 0x00, 0x10, 0x6d, 0x66, 0x68, 0x64,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x04,
 			0x00, 0x00, 0x00, 0x48, 0x74, 0x72, 0x61, 0x66,
-			0x00, 0x00, 0x00, 0x10, 0x74, 0x66, 0x68, 0x64,
-			0x00, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x64,
+			0x00, 0x00, 0x00, 0x18, 0x74, 0x66, 0x68, 0x64,
+			0x00, 0x02, 0x00, 0x18, 0x00, 0x00, 0x00, 0x64,
+			0x00, 0x00, 0x00, 0x1e, 0x00, 0x00, 0x00, 0x02,
 			0x00, 0x00, 0x00, 0x14, 0x74, 0x66, 0x64, 0x74,
 			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x01, 0x5f, 0x90, 0x00, 0x00, 0x00, 0x1c,
-			0x74, 0x72, 0x75, 0x6e, 0x01, 0x00, 0x03, 0x01,
+			0x00, 0x01, 0x5f, 0x90, 0x00, 0x00, 0x00, 0x14,
+			0x74, 0x72, 0x75, 0x6e, 0x01, 0x00, 0x00, 0x01,
 			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x68,
-			0x00, 0x00, 0x00, 0x1e, 0x00, 0x00, 0x00, 0x02,
 			0x00, 0x00, 0x00, 0x0a, 0x6d, 0x64, 0x61, 0x74,
 			0x01, 0x02, 0x00, 0x00, 0x00, 0x60, 0x6d, 0x6f,
 			0x6f, 0x66, 0x00, 0x00, 0x00, 0x10, 0x6d, 0x66,
 			0x68, 0x64, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x04, 0x00, 0x00, 0x00, 0x48, 0x74, 0x72,
-			0x61, 0x66, 0x00, 0x00, 0x00, 0x10, 0x74, 0x66,
-			0x68, 0x64, 0x00, 0x02, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x64, 0x00, 0x00, 0x00, 0x14, 0x74, 0x66,
+			0x61, 0x66, 0x00, 0x00, 0x00, 0x18, 0x74, 0x66,
+			0x68, 0x64, 0x00, 0x02, 0x00, 0x18, 0x00, 0x00,
+			0x00, 0x64, 0x00, 0x00, 0x00, 0x1e, 0x00, 0x00,
+			0x00, 0x02, 0x00, 0x00, 0x00, 0x14, 0x74, 0x66,
 			0x64, 0x74, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x02, 0xbf, 0x20, 0x00, 0x00,
-			0x00, 0x1c, 0x74, 0x72, 0x75, 0x6e, 0x01, 0x00,
-			0x03, 0x01, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
-			0x00, 0x68, 0x00, 0x00, 0x00, 0x1e, 0x00, 0x00,
-			0x00, 0x02, 0x00, 0x00, 0x00, 0x0a, 0x6d, 0x64,
+			0x00, 0x14, 0x74, 0x72, 0x75, 0x6e, 0x01, 0x00,
+			0x00, 0x01, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x68, 0x00, 0x00, 0x00, 0x0a, 0x6d, 0x64,
 			0x61, 0x74, 0x03, 0x04,
 		},
 	},
+	{
+		"per-sample fields",
+		Parts{{
+			SequenceNumber: 2,
+			Tracks: []*PartTrack{
+				{
+					ID:       1,
+					BaseTime: 3000,
+					Samples: []*PartSample{
+						{
+							Duration: 10,
+							Payload:  []byte{1},
+						},
+						{
+							Duration:        20,
+							PTSOffset:       5,
+							IsNonSyncSample: true,
+							Payload:         []byte{2, 3},
+						},
+						{
+							Duration: 10,
+							Payload:  []byte{4},
+						},
+					},
+				},
+			},
+		}},
+		[]byte{
+			0x00, 0x00, 0x00, 0x88, 0x6d, 0x6f, 0x6f, 0x66,
+			0x00, 0x00, 0x00, 0x10, 0x6d, 0x66, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02,
+			0x00, 0x00, 0x00, 0x70, 0x74, 0x72, 0x61, 0x66,
+			0x00, 0x00, 0x00, 0x10, 0x74, 0x66, 0x68, 0x64,
+			0x00, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			0x00, 0x00, 0x00, 0x14, 0x74, 0x66, 0x64, 0x74,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x0b, 0xb8, 0x00, 0x00, 0x00, 0x44,
+			0x74, 0x72, 0x75, 0x6e, 0x01, 0x00, 0x0f, 0x01,
+			0x00, 0x00, 0x00, 0x03, 0x00, 0x00, 0x00, 0x90,
+			0x00, 0x00, 0x00, 0x0a, 0x00, 0x00, 0x00, 0x01,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x14, 0x00, 0x00, 0x00, 0x02,
+			0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x05,
+			0x00, 0x00, 0x00, 0x0a, 0x00, 0x00, 0x00, 0x01,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x0c, 0x6d, 0x64, 0x61, 0x74,
+			0x01, 0x02, 0x03, 0x04,
+		},
+	},
 }
 
 func TestPartsMarshal(t *testing.T) {
@@ -174,6 +222,97 @@ func TestPartsUnmarshal(t *testing.T) {
 	}
 }
 
+func TestPartsMarshalLargeSize(t *testing.T) {
+	prev := maxSmallBoxSize
+	maxSmallBoxSize = 8
+	defer func() { maxSmallBoxSize = prev }()
+
+	var buf seekablebuffer.Buffer
+	err := casesParts[0].parts.Marshal(&buf)
+	require.NoError(t, err)
+
+	enc := buf.Bytes()
+	mdatStart := len(enc) - 16 - 8
+	require.Equal(t, []byte{
+		0x00, 0x00, 0x00, 0x01, 'm', 'd', 'a', 't',
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x18,
+	}, enc[mdatStart:mdatStart+16])
+
+	var parts Parts
+	err = parts.Unmarshal(enc)
+	require.NoError(t, err)
+	require.Equal(t, casesParts[0].parts, parts)
+}
+
+func TestPartsUnmarshalLimits(t *testing.T) {
+	enc := casesParts[0].enc
+
+	var parts Parts
+	err := parts.Unmarshal(append(append([]byte(nil), enc...), 0x00, 0x00, 0x01, 0x00, 'f', 'r', 'e', 'e'))
+	require.ErrorIs(t, err, ErrBoxTruncated)
+
+	parts = nil
+	err = parts.UnmarshalWithOptions(enc, PartsUnmarshalOptions{
+		MaxBoxSize: 64,
+	})
+	require.ErrorIs(t, err, ErrBoxTooBig)
+
+	parts = nil
+	err = parts.UnmarshalWithOptions(enc, PartsUnmarshalOptions{
+		MaxBoxDepth: 1,
+	})
+	require.ErrorIs(t, err, ErrBoxTooDeep)
+}
+
+func TestPartsUnmarshalDefaults(t *testing.T) {
+	var parts Parts
+	err := parts.Unmarshal([]byte{
+		0x00, 0x00, 0x00, 0x7c, 0x6d, 0x6f, 0x6f, 0x66,
+		0x00, 0x00, 0x00, 0x10, 0x6d, 0x66, 0x68, 0x64,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+		0x00, 0x00, 0x00, 0x64, 0x74, 0x72, 0x61, 0x66,
+		0x00, 0x00, 0x00, 0x1c, 0x74, 0x66, 0x68, 0x64,
+		0x00, 0x00, 0x00, 0x38, 0x00, 0x00, 0x00, 0x01,
+		0x00, 0x00, 0x04, 0x00, 0x00, 0x00, 0x00, 0x02,
+		0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10,
+		0x74, 0x66, 0x64, 0x74, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x03, 0xe8, 0x00, 0x00, 0x00, 0x20,
+		0x74, 0x72, 0x75, 0x6e, 0x00, 0x00, 0x08, 0x05,
+		0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00, 0x84,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x02, 0x00, 0x00, 0x00, 0x00, 0x10,
+		0x74, 0x72, 0x75, 0x6e, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x0e,
+		0x6d, 0x64, 0x61, 0x74, 0x01, 0x02, 0x03, 0x04,
+		0x05, 0x06,
+	})
+	require.NoError(t, err)
+	require.Equal(t, Parts{{
+		SequenceNumber: 1,
+		Tracks: []*PartTrack{{
+			ID:       1,
+			BaseTime: 1000,
+			Samples: []*PartSample{
+				{
+					Duration: 1024,
+					Payload:  []byte{1, 2},
+				},
+				{
+					Duration:        1024,
+					PTSOffset:       512,
+					IsNonSyncSample: true,
+					Payload:         []byte{3, 4},
+				},
+				{
+					Duration:        1024,
+					IsNonSyncSample: true,
+					Payload:         []byte{5, 6},
+				},
+			},
+		}},
+	}}, parts)
+}
+
 func FuzzPartsUnmarshal(f *testing.F) {
 	for _, ca := range casesParts {
 		f.Add(ca.enc)
@@ -0,0 +1,202 @@
+package mpegts
+
+import (
+	"fmt"
+)
+
+// maximum value of adaptation_field_length, i.e. the size of a packet
+// without the 4-byte header and the length byte.
+const maxAdaptationFieldLength = 188 - 4 - 1
+
+func unmarshalPCR(buf []byte) int64 {
+	base := int64(buf[0])<<25 |
+		int64(buf[1])<<17 |
+		int64(buf[2])<<9 |
+		int64(buf[3])<<1 |
+		int64(buf[4])>>7
+	ext := int64(buf[4]&0x01)<<8 | int64(buf[5])
+	return base*300 + ext
+}
+
+func marshalPCR(buf []byte, pcr int64) {
+	base := pcr / 300
+	ext := pcr % 300
+	buf[0] = uint8(base >> 25)
+	buf[1] = uint8(base >> 17)
+	buf[2] = uint8(base >> 9)
+	buf[3] = uint8(base >> 1)
+	buf[4] = uint8(base<<7) | 0x7E | uint8(ext>>8)&0x01
+	buf[5] = uint8(ext)
+}
+
+// AdaptationField is the adaptation field of a MPEG-TS packet.
+// Specification: ISO 13818-1, 2.4.3.4
+type AdaptationField struct {
+	DiscontinuityIndicator            bool
+	RandomAccessIndicator             bool
+	ElementaryStreamPriorityIndicator bool
+
+	// program clock reference, in 27 MHz units (program_clock_reference_base * 300 + extension).
+	PCRPresent bool
+	PCR        int64
+
+	// original program clock reference, in 27 MHz units.
+	OPCRPresent bool
+	OPCR        int64
+
+	// number of packets that precede the splicing point, present when SplicingPointFlag is true.
+	SplicingPointFlag bool
+	SpliceCountdown   int8
+
+	// present when transport_private_data_flag is set.
+	TransportPrivateData []byte
+}
+
+// Unmarshal decodes an AdaptationField, starting from the adaptation_field_length byte.
+// It returns the size of the adaptation field, including the length byte and stuffing bytes.
+// The adaptation field extension is skipped.
+func (f *AdaptationField) Unmarshal(buf []byte) (int, error) {
+	if len(buf) < 1 {
+		return 0, fmt.Errorf("buffer is too short")
+	}
+
+	length := int(buf[0])
+
+	if length > maxAdaptationFieldLength {
+		return 0, fmt.Errorf("invalid adaptation_field_length (%d)", length)
+	}
+
+	if len(buf) < (1 + length) {
+		return 0, fmt.Errorf("buffer is too short")
+	}
+
+	*f = AdaptationField{}
+
+	// a single stuffing byte
+	if length == 0 {
+		return 1, nil
+	}
+
+	flags := buf[1]
+	f.DiscontinuityIndicator = (flags & 0x80) != 0
+	f.RandomAccessIndicator = (flags & 0x40) != 0
+	f.ElementaryStreamPriorityIndicator = (flags & 0x20) != 0
+	f.PCRPresent = (flags & 0x10) != 0
+	f.OPCRPresent = (flags & 0x08) != 0
+	f.SplicingPointFlag = (flags & 0x04) != 0
+	transportPrivateDataFlag := (flags & 0x02) != 0
+
+	data := buf[2 : 1+length]
+
+	if f.PCRPresent {
+		if len(data) < 6 {
+			return 0, fmt.Errorf("invalid adaptation_field_length (%d)", length)
+		}
+		f.PCR = unmarshalPCR(data)
+		data = data[6:]
+	}
+
+	if f.OPCRPresent {
+		if len(data) < 6 {
+			return 0, fmt.Errorf("invalid adaptation_field_length (%d)", length)
+		}
+		f.OPCR = unmarshalPCR(data)
+		data = data[6:]
+	}
+
+	if f.SplicingPointFlag {
+		if len(data) < 1 {
+			return 0, fmt.Errorf("invalid adaptation_field_length (%d)", length)
+		}
+		f.SpliceCountdown = int8(data[0])
+		data = data[1:]
+	}
+
+	if transportPrivateDataFlag {
+		if len(data) < 1 {
+			return 0, fmt.Errorf("invalid adaptation_field_length (%d)", length)
+		}
+
+		privateDataLength := int(data[0])
+		if len(data) < (1 + privateDataLength) {
+			return 0, fmt.Errorf("invalid transport_private_data_length (%d)", privateDataLength)
+		}
+
+		f.TransportPrivateData = data[1 : 1+privateDataLength]
+	}
+
+	// the adaptation field extension and stuffing bytes are skipped
+	return 1 + length, nil
+}
+
+func (f AdaptationField) marshalSize() int {
+	n := 2
+	if f.PCRPresent {
+		n += 6
+	}
+	if f.OPCRPresent {
+		n += 6
+	}
+	if f.SplicingPointFlag {
+		n++
+	}
+	if f.TransportPrivateData != nil {
+		n += 1 + len(f.TransportPrivateData)
+	}
+	return n
+}
+
+// Marshal encodes an AdaptationField, including the adaptation_field_length byte.
+// Stuffing bytes are not added.
+func (f AdaptationField) Marshal() ([]byte, error) {
+	if len(f.TransportPrivateData) > 0xFF {
+		return nil, fmt.Errorf("transport private data is too big")
+	}
+
+	size := f.marshalSize()
+	if (size - 1) > maxAdaptationFieldLength {
+		return nil, fmt.Errorf("adaptation field is too big")
+	}
+
+	buf := make([]byte, size)
+	buf[0] = uint8(size - 1)
+
+	if f.DiscontinuityIndicator {
+		buf[1] |= 0x80
+	}
+	if f.RandomAccessIndicator {
+		buf[1] |= 0x40
+	}
+	if f.ElementaryStreamPriorityIndicator {
+		buf[1] |= 0x20
+	}
+
+	pos := 2
+
+	if f.PCRPresent {
+		buf[1] |= 0x10
+		marshalPCR(buf[pos:], f.PCR)
+		pos += 6
+	}
+
+	if f.OPCRPresent {
+		buf[1] |= 0x08
+		marshalPCR(buf[pos:], f.OPCR)
+		pos += 6
+	}
+
+	if f.SplicingPointFlag {
+		buf[1] |= 0x04
+		buf[pos] = uint8(f.SpliceCountdown)
+		pos++
+	}
+
+	if f.TransportPrivateData != nil {
+		buf[1] |= 0x02
+		buf[pos] = uint8(len(f.TransportPrivateData))
+		pos++
+		copy(buf[pos:], f.TransportPrivateData)
+	}
+
+	return buf, nil
+}
@@ -0,0 +1,142 @@
+package mpegts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesAdaptationField = []struct {
+	name string
+	dec  AdaptationField
+	enc  []byte
+}{
+	{
+		"random access with pcr",
+		AdaptationField{
+			RandomAccessIndicator: true,
+			PCRPresent:            true,
+			PCR:                   27000000,
+		},
+		[]byte{0x07, 0x50, 0x00, 0x00, 0xaf, 0xc8, 0x7e, 0x00},
+	},
+	{
+		"discontinuity, splice countdown and private data",
+		AdaptationField{
+			DiscontinuityIndicator: true,
+			PCRPresent:             true,
+			PCR:                    599,
+			SplicingPointFlag:      true,
+			SpliceCountdown:        -2,
+			TransportPrivateData:   []byte{0x01, 0x02},
+		},
+		[]byte{
+			0x0b, 0x96, 0x00, 0x00, 0x00, 0x00, 0xff, 0x2b,
+			0xfe, 0x02, 0x01, 0x02,
+		},
+	},
+	{
+		"opcr",
+		AdaptationField{
+			ElementaryStreamPriorityIndicator: true,
+			OPCRPresent:                       true,
+			OPCR:                              27000000,
+		},
+		[]byte{0x07, 0x28, 0x00, 0x00, 0xaf, 0xc8, 0x7e, 0x00},
+	},
+	{
+		"flags only",
+		AdaptationField{},
+		[]byte{0x01, 0x00},
+	},
+}
+
+func TestAdaptationFieldUnmarshal(t *testing.T) {
+	for _, ca := range casesAdaptationField {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec AdaptationField
+			n, err := dec.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, len(ca.enc), n)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestAdaptationFieldUnmarshalStuffing(t *testing.T) {
+	var dec AdaptationField
+	n, err := dec.Unmarshal([]byte{0x03, 0x40, 0xff, 0xff, 0x47})
+	require.NoError(t, err)
+	require.Equal(t, 4, n)
+	require.Equal(t, AdaptationField{RandomAccessIndicator: true}, dec)
+
+	// a single stuffing byte
+	n, err = dec.Unmarshal([]byte{0x00, 0x47})
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.Equal(t, AdaptationField{}, dec)
+}
+
+func TestAdaptationFieldUnmarshalErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		enc  []byte
+		err  string
+	}{
+		{
+			"empty",
+			[]byte{},
+			"buffer is too short",
+		},
+		{
+			"truncated",
+			[]byte{0x07, 0x50, 0x00},
+			"buffer is too short",
+		},
+		{
+			"invalid length",
+			[]byte{0xb8},
+			"invalid adaptation_field_length (184)",
+		},
+		{
+			"missing pcr",
+			[]byte{0x02, 0x10, 0x00},
+			"invalid adaptation_field_length (2)",
+		},
+		{
+			"invalid private data length",
+			[]byte{0x03, 0x02, 0x05, 0x00},
+			"invalid transport_private_data_length (5)",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec AdaptationField
+			_, err := dec.Unmarshal(ca.enc)
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func TestAdaptationFieldMarshal(t *testing.T) {
+	for _, ca := range casesAdaptationField {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func FuzzAdaptationFieldUnmarshal(f *testing.F) {
+	for _, ca := range casesAdaptationField {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var dec AdaptationField
+		_, err := dec.Unmarshal(b)
+		if err == nil {
+			dec.Marshal() //nolint:errcheck
+		}
+	})
+}
@@ -18,6 +18,15 @@ func (CodecMPEG4Audio) IsVideo() bool {
 
 func (*CodecMPEG4Audio) isCodec() {}
 
+// checkADTS checks that the configuration can be described by ADTS.
+// Access units are wrapped into ADTS packets, that can't describe all configurations.
+// In particular, ADTS doesn't support the 960-sample frame length,
+// therefore such streams would be decoded with wrong timestamps.
+func (c CodecMPEG4Audio) checkADTS() error {
+	_, err := c.Config.ToADTS()
+	return err
+}
+
 func (c CodecMPEG4Audio) marshal(pid uint16) (*astits.PMTElementaryStream, error) {
 	return &astits.PMTElementaryStream{
 		ElementaryPID: pid,
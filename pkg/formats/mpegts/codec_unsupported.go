@@ -1,6 +1,8 @@
 package mpegts
 
 import (
+	"fmt"
+
 	"github.com/asticode/go-astits"
 )
 
@@ -15,5 +17,5 @@ func (CodecUnsupported) IsVideo() bool {
 func (*CodecUnsupported) isCodec() {}
 
 func (c CodecUnsupported) marshal(uint16) (*astits.PMTElementaryStream, error) {
-	panic("this should not happen")
+	return nil, fmt.Errorf("unsupported codec")
 }
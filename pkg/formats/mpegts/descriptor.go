@@ -0,0 +1,146 @@
+package mpegts
+
+import (
+	"fmt"
+)
+
+const (
+	descriptorTagRegistration = 0x05
+	descriptorTagTeletext     = 0x56
+	descriptorTagSubtitling   = 0x59
+)
+
+const (
+	cueiIdentifier = 'C'<<24 | 'U'<<16 | 'E'<<8 | 'I'
+)
+
+// Descriptor is a MPEG-TS descriptor.
+// Specification: ISO 13818-1, 2.6
+type Descriptor struct {
+	Tag uint8
+
+	// payload of descriptors that are not decoded.
+	Data []byte
+
+	// decoded payload of known descriptors.
+	// When set, it is used in place of Data.
+	Subtitling *DescriptorSubtitling
+	Teletext   *DescriptorTeletext
+}
+
+func (d *Descriptor) unmarshalPayload(buf []byte) {
+	switch d.Tag {
+	case descriptorTagSubtitling:
+		var sub DescriptorSubtitling
+		err := sub.unmarshal(buf)
+		if err == nil {
+			d.Subtitling = &sub
+			return
+		}
+
+	case descriptorTagTeletext:
+		var ttx DescriptorTeletext
+		err := ttx.unmarshal(buf)
+		if err == nil {
+			d.Teletext = &ttx
+			return
+		}
+	}
+
+	// keep descriptors that are unknown or can't be decoded as they are.
+	d.Data = buf
+}
+
+func (d Descriptor) payloadSize() int {
+	switch {
+	case d.Subtitling != nil:
+		return d.Subtitling.marshalSize()
+
+	case d.Teletext != nil:
+		return d.Teletext.marshalSize()
+
+	default:
+		return len(d.Data)
+	}
+}
+
+func (d Descriptor) marshalPayload(buf []byte) error {
+	switch {
+	case d.Subtitling != nil:
+		return d.Subtitling.marshalTo(buf)
+
+	case d.Teletext != nil:
+		return d.Teletext.marshalTo(buf)
+
+	default:
+		copy(buf, d.Data)
+		return nil
+	}
+}
+
+func unmarshalDescriptors(buf []byte) ([]Descriptor, error) {
+	var descs []Descriptor
+
+	for len(buf) != 0 {
+		if len(buf) < 2 {
+			return nil, fmt.Errorf("invalid descriptor")
+		}
+
+		le := int(buf[1])
+		if len(buf) < (2 + le) {
+			return nil, fmt.Errorf("invalid descriptor length (%d)", le)
+		}
+
+		d := Descriptor{
+			Tag: buf[0],
+		}
+		d.unmarshalPayload(buf[2 : 2+le])
+
+		descs = append(descs, d)
+		buf = buf[2+le:]
+	}
+
+	return descs, nil
+}
+
+func descriptorsMarshalSize(descs []Descriptor) int {
+	n := 0
+	for _, d := range descs {
+		n += 2 + d.payloadSize()
+	}
+	return n
+}
+
+func marshalDescriptors(buf []byte, descs []Descriptor) (int, error) {
+	pos := 0
+
+	for _, d := range descs {
+		size := d.payloadSize()
+		if size > 255 {
+			return 0, fmt.Errorf("descriptor size (%d) is too big, maximum is 255", size)
+		}
+
+		buf[pos] = d.Tag
+		buf[pos+1] = uint8(size)
+		pos += 2
+
+		err := d.marshalPayload(buf[pos : pos+size])
+		if err != nil {
+			return 0, err
+		}
+		pos += size
+	}
+
+	return pos, nil
+}
+
+// findFormatIdentifier returns the format_identifier of the first registration descriptor.
+// Specification: ISO 13818-1, 2.6.8
+func findFormatIdentifier(descs []Descriptor) (uint32, bool) {
+	for _, d := range descs {
+		if d.Tag == descriptorTagRegistration && len(d.Data) >= 4 {
+			return uint32(d.Data[0])<<24 | uint32(d.Data[1])<<16 | uint32(d.Data[2])<<8 | uint32(d.Data[3]), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,62 @@
+package mpegts
+
+import (
+	"fmt"
+)
+
+// DescriptorSubtitling_Subtitle is a subtitle of a DescriptorSubtitling.
+type DescriptorSubtitling_Subtitle struct { //nolint:revive
+	// ISO 639-2 language code.
+	Language          string
+	SubtitlingType    uint8
+	CompositionPageID uint16
+	AncillaryPageID   uint16
+}
+
+// DescriptorSubtitling is a DVB subtitling descriptor.
+// Specification: ETSI EN 300 468, 6.2.41
+type DescriptorSubtitling struct {
+	Subtitles []DescriptorSubtitling_Subtitle
+}
+
+func (d *DescriptorSubtitling) unmarshal(buf []byte) error {
+	if (len(buf) % 8) != 0 {
+		return fmt.Errorf("invalid subtitling descriptor length (%d)", len(buf))
+	}
+
+	d.Subtitles = make([]DescriptorSubtitling_Subtitle, len(buf)/8)
+
+	for i := range d.Subtitles {
+		b := buf[i*8:]
+		d.Subtitles[i] = DescriptorSubtitling_Subtitle{
+			Language:          string(b[:3]),
+			SubtitlingType:    b[3],
+			CompositionPageID: uint16(b[4])<<8 | uint16(b[5]),
+			AncillaryPageID:   uint16(b[6])<<8 | uint16(b[7]),
+		}
+	}
+
+	return nil
+}
+
+func (d DescriptorSubtitling) marshalSize() int {
+	return len(d.Subtitles) * 8
+}
+
+func (d DescriptorSubtitling) marshalTo(buf []byte) error {
+	for i, s := range d.Subtitles {
+		if len(s.Language) != 3 {
+			return fmt.Errorf("invalid language code '%s'", s.Language)
+		}
+
+		b := buf[i*8:]
+		copy(b, s.Language)
+		b[3] = s.SubtitlingType
+		b[4] = uint8(s.CompositionPageID >> 8)
+		b[5] = uint8(s.CompositionPageID)
+		b[6] = uint8(s.AncillaryPageID >> 8)
+		b[7] = uint8(s.AncillaryPageID)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package mpegts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesDescriptorSubtitling = []struct {
+	name string
+	dec  DescriptorSubtitling
+	enc  []byte
+}{
+	{
+		"two languages",
+		DescriptorSubtitling{
+			Subtitles: []DescriptorSubtitling_Subtitle{
+				{
+					Language:          "eng",
+					SubtitlingType:    0x10,
+					CompositionPageID: 1,
+					AncillaryPageID:   1,
+				},
+				{
+					Language:          "ita",
+					SubtitlingType:    0x20,
+					CompositionPageID: 2,
+					AncillaryPageID:   0x0102,
+				},
+			},
+		},
+		[]byte{
+			0x65, 0x6e, 0x67, 0x10, 0x00, 0x01, 0x00, 0x01,
+			0x69, 0x74, 0x61, 0x20, 0x00, 0x02, 0x01, 0x02,
+		},
+	},
+}
+
+func TestDescriptorSubtitlingUnmarshal(t *testing.T) {
+	for _, ca := range casesDescriptorSubtitling {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec DescriptorSubtitling
+			err := dec.unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestDescriptorSubtitlingMarshal(t *testing.T) {
+	for _, ca := range casesDescriptorSubtitling {
+		t.Run(ca.name, func(t *testing.T) {
+			buf := make([]byte, ca.dec.marshalSize())
+			err := ca.dec.marshalTo(buf)
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, buf)
+		})
+	}
+}
+
+func TestDescriptorSubtitlingInvalid(t *testing.T) {
+	// descriptors that can't be decoded are kept as they are
+	descs, err := unmarshalDescriptors([]byte{0x59, 0x03, 0x65, 0x6e, 0x67})
+	require.NoError(t, err)
+	require.Equal(t, []Descriptor{{
+		Tag:  0x59,
+		Data: []byte{0x65, 0x6e, 0x67},
+	}}, descs)
+}
+
+func FuzzDescriptorSubtitlingUnmarshal(f *testing.F) {
+	for _, ca := range casesDescriptorSubtitling {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var dec DescriptorSubtitling
+		err := dec.unmarshal(b)
+		if err == nil {
+			buf := make([]byte, dec.marshalSize())
+			dec.marshalTo(buf) //nolint:errcheck
+		}
+	})
+}
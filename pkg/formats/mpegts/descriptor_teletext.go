@@ -0,0 +1,67 @@
+package mpegts
+
+import (
+	"fmt"
+)
+
+// DescriptorTeletext_Page is a page of a DescriptorTeletext.
+type DescriptorTeletext_Page struct { //nolint:revive
+	// ISO 639-2 language code.
+	Language       string
+	Type           uint8
+	MagazineNumber uint8
+	PageNumber     uint8
+}
+
+// DescriptorTeletext is a DVB teletext descriptor.
+// Specification: ETSI EN 300 468, 6.2.43
+type DescriptorTeletext struct {
+	Pages []DescriptorTeletext_Page
+}
+
+func (d *DescriptorTeletext) unmarshal(buf []byte) error {
+	if (len(buf) % 5) != 0 {
+		return fmt.Errorf("invalid teletext descriptor length (%d)", len(buf))
+	}
+
+	d.Pages = make([]DescriptorTeletext_Page, len(buf)/5)
+
+	for i := range d.Pages {
+		b := buf[i*5:]
+		d.Pages[i] = DescriptorTeletext_Page{
+			Language:       string(b[:3]),
+			Type:           b[3] >> 3,
+			MagazineNumber: b[3] & 0x07,
+			PageNumber:     b[4],
+		}
+	}
+
+	return nil
+}
+
+func (d DescriptorTeletext) marshalSize() int {
+	return len(d.Pages) * 5
+}
+
+func (d DescriptorTeletext) marshalTo(buf []byte) error {
+	for i, p := range d.Pages {
+		if len(p.Language) != 3 {
+			return fmt.Errorf("invalid language code '%s'", p.Language)
+		}
+
+		if p.Type > 0x1F {
+			return fmt.Errorf("invalid teletext type (%d)", p.Type)
+		}
+
+		if p.MagazineNumber > 0x07 {
+			return fmt.Errorf("invalid magazine number (%d)", p.MagazineNumber)
+		}
+
+		b := buf[i*5:]
+		copy(b, p.Language)
+		b[3] = p.Type<<3 | p.MagazineNumber
+		b[4] = p.PageNumber
+	}
+
+	return nil
+}
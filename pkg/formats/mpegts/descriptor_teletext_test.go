@@ -0,0 +1,72 @@
+package mpegts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesDescriptorTeletext = []struct {
+	name string
+	dec  DescriptorTeletext
+	enc  []byte
+}{
+	{
+		"initial page and subtitles",
+		DescriptorTeletext{
+			Pages: []DescriptorTeletext_Page{
+				{
+					Language:       "deu",
+					Type:           1,
+					MagazineNumber: 1,
+				},
+				{
+					Language:   "fra",
+					Type:       2,
+					PageNumber: 0x88,
+				},
+			},
+		},
+		[]byte{
+			0x64, 0x65, 0x75, 0x09, 0x00, 0x66, 0x72, 0x61,
+			0x10, 0x88,
+		},
+	},
+}
+
+func TestDescriptorTeletextUnmarshal(t *testing.T) {
+	for _, ca := range casesDescriptorTeletext {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec DescriptorTeletext
+			err := dec.unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestDescriptorTeletextMarshal(t *testing.T) {
+	for _, ca := range casesDescriptorTeletext {
+		t.Run(ca.name, func(t *testing.T) {
+			buf := make([]byte, ca.dec.marshalSize())
+			err := ca.dec.marshalTo(buf)
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, buf)
+		})
+	}
+}
+
+func FuzzDescriptorTeletextUnmarshal(f *testing.F) {
+	for _, ca := range casesDescriptorTeletext {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var dec DescriptorTeletext
+		err := dec.unmarshal(b)
+		if err == nil {
+			buf := make([]byte, dec.marshalSize())
+			dec.marshalTo(buf) //nolint:errcheck
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package mpegts
+
+import (
+	"fmt"
+)
+
+// PAT_Program is a program of a PAT.
+type PAT_Program struct { //nolint:revive
+	// program_number. When zero, PID is the network PID.
+	ProgramNumber uint16
+
+	// PID of the PMT of the program.
+	PID uint16
+}
+
+// PAT is a program association table.
+// Specification: ISO 13818-1, 2.4.4.3
+type PAT struct {
+	TransportStreamID uint16
+	Programs          []PAT_Program
+}
+
+// Unmarshal decodes a PAT.
+// The buffer must start with table_id, without the pointer_field.
+func (p *PAT) Unmarshal(buf []byte) error {
+	var data []byte
+	var err error
+	p.TransportStreamID, data, err = unmarshalSection(buf, tableIDPAT)
+	if err != nil {
+		return err
+	}
+
+	if (len(data) % 4) != 0 {
+		return fmt.Errorf("invalid program list length (%d)", len(data))
+	}
+
+	p.Programs = make([]PAT_Program, len(data)/4)
+
+	for i := range p.Programs {
+		p.Programs[i] = PAT_Program{
+			ProgramNumber: uint16(data[i*4])<<8 | uint16(data[i*4+1]),
+			PID:           uint16(data[i*4+2]&0x1F)<<8 | uint16(data[i*4+3]),
+		}
+	}
+
+	return nil
+}
+
+func (p PAT) marshalSize() int {
+	return sectionMarshalSize(len(p.Programs) * 4)
+}
+
+// Marshal encodes a PAT.
+// The buffer starts with table_id, without the pointer_field.
+func (p PAT) Marshal() ([]byte, error) {
+	buf := make([]byte, p.marshalSize())
+
+	err := marshalSectionHeader(buf, tableIDPAT, p.TransportStreamID)
+	if err != nil {
+		return nil, err
+	}
+
+	pos := 8
+
+	for _, prog := range p.Programs {
+		if prog.PID > 0x1FFF {
+			return nil, fmt.Errorf("invalid PID (%d)", prog.PID)
+		}
+
+		buf[pos] = uint8(prog.ProgramNumber >> 8)
+		buf[pos+1] = uint8(prog.ProgramNumber)
+		buf[pos+2] = 0b11100000 | uint8(prog.PID>>8)
+		buf[pos+3] = uint8(prog.PID)
+		pos += 4
+	}
+
+	marshalSectionCRC(buf)
+
+	return buf, nil
+}
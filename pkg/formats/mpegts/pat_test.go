@@ -0,0 +1,103 @@
+package mpegts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesPAT = []struct {
+	name string
+	dec  PAT
+	enc  []byte
+}{
+	{
+		"single program",
+		PAT{
+			Programs: []PAT_Program{{
+				ProgramNumber: 1,
+				PID:           4096,
+			}},
+		},
+		[]byte{
+			0x00, 0xb0, 0x0d, 0x00, 0x00, 0xc1, 0x00, 0x00,
+			0x00, 0x01, 0xf0, 0x00, 0x71, 0x10, 0xd8, 0x78,
+		},
+	},
+	{
+		"network pid and multiple programs",
+		PAT{
+			TransportStreamID: 1,
+			Programs: []PAT_Program{
+				{
+					ProgramNumber: 0,
+					PID:           16,
+				},
+				{
+					ProgramNumber: 1,
+					PID:           4096,
+				},
+				{
+					ProgramNumber: 2,
+					PID:           4097,
+				},
+			},
+		},
+		[]byte{
+			0x00, 0xb0, 0x15, 0x00, 0x01, 0xc1, 0x00, 0x00,
+			0x00, 0x00, 0xe0, 0x10, 0x00, 0x01, 0xf0, 0x00,
+			0x00, 0x02, 0xf0, 0x01, 0xf5, 0x01, 0x21, 0x58,
+		},
+	},
+}
+
+func TestPATUnmarshal(t *testing.T) {
+	for _, ca := range casesPAT {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec PAT
+			err := dec.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestPATUnmarshalStuffing(t *testing.T) {
+	var dec PAT
+	err := dec.Unmarshal(append(append([]byte(nil), casesPAT[0].enc...), 0xff, 0xff, 0xff))
+	require.NoError(t, err)
+	require.Equal(t, casesPAT[0].dec, dec)
+}
+
+func TestPATUnmarshalCRCMismatch(t *testing.T) {
+	enc := append([]byte(nil), casesPAT[0].enc...)
+	enc[len(enc)-1] ^= 0xff
+
+	var dec PAT
+	err := dec.Unmarshal(enc)
+	require.EqualError(t, err, "CRC mismatch: expected 7110d878, got 7110d887")
+}
+
+func TestPATMarshal(t *testing.T) {
+	for _, ca := range casesPAT {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func FuzzPATUnmarshal(f *testing.F) {
+	for _, ca := range casesPAT {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var dec PAT
+		err := dec.Unmarshal(b)
+		if err == nil {
+			dec.Marshal() //nolint:errcheck
+		}
+	})
+}
@@ -0,0 +1,191 @@
+package mpegts
+
+import (
+	"fmt"
+)
+
+const (
+	streamIDProgramStreamMap = 0xBC
+	streamIDPaddingStream    = 0xBE
+	streamIDPrivateStream2   = 0xBF
+	streamIDECM              = 0xF0
+	streamIDEMM              = 0xF1
+	streamIDDSMCC            = 0xF2
+	streamIDH2221TypeE       = 0xF8
+	streamIDDirectory        = 0xFF
+)
+
+func pesHasOptionalHeader(streamID uint8) bool {
+	switch streamID {
+	case streamIDProgramStreamMap, streamIDPaddingStream, streamIDPrivateStream2,
+		streamIDECM, streamIDEMM, streamIDDSMCC, streamIDH2221TypeE, streamIDDirectory:
+		return false
+	}
+	return true
+}
+
+func unmarshalPESTimestamp(buf []byte) int64 {
+	return int64(buf[0]&0x0E)<<29 |
+		int64(buf[1])<<22 |
+		int64(buf[2]&0xFE)<<14 |
+		int64(buf[3])<<7 |
+		int64(buf[4])>>1
+}
+
+func marshalPESTimestamp(buf []byte, prefix uint8, ts int64) {
+	buf[0] = prefix<<4 | uint8(ts>>29)&0x0E | 0x01
+	buf[1] = uint8(ts >> 22)
+	buf[2] = uint8(ts>>14)&0xFE | 0x01
+	buf[3] = uint8(ts >> 7)
+	buf[4] = uint8(ts<<1) | 0x01
+}
+
+// PESHeader is the header of a PES packet.
+// Specification: ISO 13818-1, 2.4.3.6
+type PESHeader struct {
+	StreamID uint8
+
+	// PES_packet_length.
+	// It is zero when the packet is unbounded, and that is allowed for video streams only.
+	PacketLength uint16
+
+	DataAlignmentIndicator bool
+
+	PTSPresent bool
+	PTS        int64
+
+	DTSPresent bool
+	DTS        int64
+}
+
+// Unmarshal decodes a PESHeader.
+// It returns the offset of the payload, that is the size of the header including stuffing bytes.
+func (h *PESHeader) Unmarshal(buf []byte) (int, error) {
+	if len(buf) < 6 {
+		return 0, fmt.Errorf("buffer is too short")
+	}
+
+	if buf[0] != 0 || buf[1] != 0 || buf[2] != 1 {
+		return 0, fmt.Errorf("invalid packet_start_code_prefix")
+	}
+
+	h.StreamID = buf[3]
+	h.PacketLength = uint16(buf[4])<<8 | uint16(buf[5])
+	h.DataAlignmentIndicator = false
+	h.PTSPresent = false
+	h.PTS = 0
+	h.DTSPresent = false
+	h.DTS = 0
+
+	if !pesHasOptionalHeader(h.StreamID) {
+		return 6, nil
+	}
+
+	if len(buf) < 9 {
+		return 0, fmt.Errorf("buffer is too short")
+	}
+
+	if (buf[6] >> 6) != 0b10 {
+		return 0, fmt.Errorf("invalid optional header marker")
+	}
+
+	h.DataAlignmentIndicator = ((buf[6] >> 2) & 0x01) != 0
+
+	ptsDTSFlags := buf[7] >> 6
+	headerDataLength := int(buf[8])
+
+	if len(buf) < (9 + headerDataLength) {
+		return 0, fmt.Errorf("buffer is too short")
+	}
+
+	if h.PacketLength != 0 && int(h.PacketLength) < (3+headerDataLength) {
+		return 0, fmt.Errorf("invalid PES_packet_length (%d)", h.PacketLength)
+	}
+
+	data := buf[9 : 9+headerDataLength]
+
+	switch ptsDTSFlags {
+	case 0b00:
+
+	case 0b10:
+		if len(data) < 5 {
+			return 0, fmt.Errorf("invalid PES_header_data_length (%d)", headerDataLength)
+		}
+
+		h.PTSPresent = true
+		h.PTS = unmarshalPESTimestamp(data)
+
+	case 0b11:
+		if len(data) < 10 {
+			return 0, fmt.Errorf("invalid PES_header_data_length (%d)", headerDataLength)
+		}
+
+		h.PTSPresent = true
+		h.PTS = unmarshalPESTimestamp(data)
+		h.DTSPresent = true
+		h.DTS = unmarshalPESTimestamp(data[5:])
+
+	default:
+		return 0, fmt.Errorf("invalid PTS_DTS_flags (%d)", ptsDTSFlags)
+	}
+
+	// remaining optional fields and stuffing bytes are skipped
+	return 9 + headerDataLength, nil
+}
+
+func (h PESHeader) marshalSize() int {
+	if !pesHasOptionalHeader(h.StreamID) {
+		return 6
+	}
+
+	n := 9
+	if h.PTSPresent {
+		n += 5
+	}
+	if h.DTSPresent {
+		n += 5
+	}
+	return n
+}
+
+// Marshal encodes a PESHeader.
+func (h PESHeader) Marshal() ([]byte, error) {
+	if h.DTSPresent && !h.PTSPresent {
+		return nil, fmt.Errorf("DTS can't be present without PTS")
+	}
+
+	buf := make([]byte, h.marshalSize())
+
+	buf[2] = 1
+	buf[3] = h.StreamID
+	buf[4] = uint8(h.PacketLength >> 8)
+	buf[5] = uint8(h.PacketLength)
+
+	if !pesHasOptionalHeader(h.StreamID) {
+		return buf, nil
+	}
+
+	if h.PacketLength != 0 && int(h.PacketLength) < (len(buf)-6) {
+		return nil, fmt.Errorf("invalid PES_packet_length (%d)", h.PacketLength)
+	}
+
+	buf[6] = 0b10000000
+	if h.DataAlignmentIndicator {
+		buf[6] |= 1 << 2
+	}
+
+	buf[8] = uint8(len(buf) - 9)
+
+	switch {
+	case h.DTSPresent:
+		buf[7] = 0b11 << 6
+		marshalPESTimestamp(buf[9:], 0b0011, h.PTS)
+		marshalPESTimestamp(buf[14:], 0b0001, h.DTS)
+
+	case h.PTSPresent:
+		buf[7] = 0b10 << 6
+		marshalPESTimestamp(buf[9:], 0b0010, h.PTS)
+	}
+
+	return buf, nil
+}
@@ -0,0 +1,128 @@
+package mpegts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesPESHeader = []struct {
+	name string
+	dec  PESHeader
+	enc  []byte
+}{
+	{
+		"video pts, unbounded",
+		PESHeader{
+			StreamID:   0xe0,
+			PTSPresent: true,
+			PTS:        2700000,
+		},
+		[]byte{
+			0x00, 0x00, 0x01, 0xe0, 0x00, 0x00, 0x80, 0x80,
+			0x05, 0x21, 0x00, 0xa5, 0x65, 0xc1,
+		},
+	},
+	{
+		"video pts and dts, unbounded",
+		PESHeader{
+			StreamID:   0xe0,
+			PTSPresent: true,
+			PTS:        2880000,
+			DTSPresent: true,
+			DTS:        2790000,
+		},
+		[]byte{
+			0x00, 0x00, 0x01, 0xe0, 0x00, 0x00, 0x80, 0xc0,
+			0x0a, 0x31, 0x00, 0xaf, 0xe4, 0x01, 0x11, 0x00,
+			0xab, 0x24, 0xe1,
+		},
+	},
+	{
+		"audio pts",
+		PESHeader{
+			StreamID:     0xc0,
+			PacketLength: 16,
+			PTSPresent:   true,
+			PTS:          2700000,
+		},
+		[]byte{
+			0x00, 0x00, 0x01, 0xc0, 0x00, 0x10, 0x80, 0x80,
+			0x05, 0x21, 0x00, 0xa5, 0x65, 0xc1,
+		},
+	},
+	{
+		"no timestamps",
+		PESHeader{
+			StreamID:               0xbd,
+			PacketLength:           3,
+			DataAlignmentIndicator: true,
+		},
+		[]byte{
+			0x00, 0x00, 0x01, 0xbd, 0x00, 0x03, 0x84, 0x00,
+			0x00,
+		},
+	},
+	{
+		"padding stream",
+		PESHeader{
+			StreamID:     0xbe,
+			PacketLength: 10,
+		},
+		[]byte{
+			0x00, 0x00, 0x01, 0xbe, 0x00, 0x0a,
+		},
+	},
+}
+
+func TestPESHeaderUnmarshal(t *testing.T) {
+	for _, ca := range casesPESHeader {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec PESHeader
+			n, err := dec.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, len(ca.enc), n)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestPESHeaderUnmarshalStuffing(t *testing.T) {
+	var dec PESHeader
+	n, err := dec.Unmarshal([]byte{
+		0x00, 0x00, 0x01, 0xe0, 0x00, 0x00, 0x80, 0x80,
+		0x08, 0x21, 0x00, 0xa5, 0x65, 0xc1, 0xff, 0xff,
+		0xff, 0x01, 0x02,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 17, n)
+	require.Equal(t, PESHeader{
+		StreamID:   0xe0,
+		PTSPresent: true,
+		PTS:        2700000,
+	}, dec)
+}
+
+func TestPESHeaderMarshal(t *testing.T) {
+	for _, ca := range casesPESHeader {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func FuzzPESHeaderUnmarshal(f *testing.F) {
+	for _, ca := range casesPESHeader {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var dec PESHeader
+		_, err := dec.Unmarshal(b)
+		if err == nil {
+			dec.Marshal() //nolint:errcheck
+		}
+	})
+}
@@ -0,0 +1,181 @@
+package mpegts
+
+import (
+	"fmt"
+)
+
+const (
+	streamTypeSCTE35 = 0x86
+)
+
+// PMT_ElementaryStream is an elementary stream of a PMT.
+type PMT_ElementaryStream struct { //nolint:revive
+	StreamType    uint8
+	ElementaryPID uint16
+	Descriptors   []Descriptor
+}
+
+// FormatIdentifier returns the format_identifier of the registration descriptor
+// of the elementary stream, if present.
+// It allows to detect codecs that are carried with stream_type 0x06,
+// like Opus ("Opus") and AV1 ("AV01").
+func (es PMT_ElementaryStream) FormatIdentifier() (uint32, bool) {
+	return findFormatIdentifier(es.Descriptors)
+}
+
+// PMT is a program map table.
+// Specification: ISO 13818-1, 2.4.4.8
+type PMT struct {
+	ProgramNumber      uint16
+	PCRPID             uint16
+	ProgramDescriptors []Descriptor
+	ElementaryStreams  []PMT_ElementaryStream
+}
+
+// Unmarshal decodes a PMT.
+// The buffer must start with table_id, without the pointer_field.
+func (p *PMT) Unmarshal(buf []byte) error {
+	var data []byte
+	var err error
+	p.ProgramNumber, data, err = unmarshalSection(buf, tableIDPMT)
+	if err != nil {
+		return err
+	}
+
+	if len(data) < 4 {
+		return fmt.Errorf("buffer is too short")
+	}
+
+	p.PCRPID = uint16(data[0]&0x1F)<<8 | uint16(data[1])
+
+	programInfoLength := int(data[2]&0x0F)<<8 | int(data[3])
+	data = data[4:]
+
+	if len(data) < programInfoLength {
+		return fmt.Errorf("invalid program_info_length (%d)", programInfoLength)
+	}
+
+	p.ProgramDescriptors, err = unmarshalDescriptors(data[:programInfoLength])
+	if err != nil {
+		return err
+	}
+	data = data[programInfoLength:]
+
+	p.ElementaryStreams = nil
+
+	for len(data) != 0 {
+		if len(data) < 5 {
+			return fmt.Errorf("buffer is too short")
+		}
+
+		es := PMT_ElementaryStream{
+			StreamType:    data[0],
+			ElementaryPID: uint16(data[1]&0x1F)<<8 | uint16(data[2]),
+		}
+
+		esInfoLength := int(data[3]&0x0F)<<8 | int(data[4])
+		data = data[5:]
+
+		if len(data) < esInfoLength {
+			return fmt.Errorf("invalid ES_info_length (%d)", esInfoLength)
+		}
+
+		es.Descriptors, err = unmarshalDescriptors(data[:esInfoLength])
+		if err != nil {
+			return err
+		}
+		data = data[esInfoLength:]
+
+		p.ElementaryStreams = append(p.ElementaryStreams, es)
+	}
+
+	return nil
+}
+
+// FormatIdentifier returns the format_identifier of the registration descriptor
+// of the program, if present.
+func (p PMT) FormatIdentifier() (uint32, bool) {
+	return findFormatIdentifier(p.ProgramDescriptors)
+}
+
+// SCTE35PIDs returns the PIDs of elementary streams that carry SCTE-35 splice information.
+// Specification: ANSI/SCTE 35, 8.1
+func (p PMT) SCTE35PIDs() []uint16 {
+	programIdentifier, programOK := p.FormatIdentifier()
+	var ret []uint16
+
+	for _, es := range p.ElementaryStreams {
+		if es.StreamType != streamTypeSCTE35 {
+			continue
+		}
+
+		identifier, ok := es.FormatIdentifier()
+		if !ok {
+			identifier, ok = programIdentifier, programOK
+		}
+
+		if ok && identifier == cueiIdentifier {
+			ret = append(ret, es.ElementaryPID)
+		}
+	}
+
+	return ret
+}
+
+func (p PMT) marshalSize() int {
+	n := 4 + descriptorsMarshalSize(p.ProgramDescriptors)
+	for _, es := range p.ElementaryStreams {
+		n += 5 + descriptorsMarshalSize(es.Descriptors)
+	}
+	return sectionMarshalSize(n)
+}
+
+// Marshal encodes a PMT.
+// The buffer starts with table_id, without the pointer_field.
+func (p PMT) Marshal() ([]byte, error) {
+	buf := make([]byte, p.marshalSize())
+
+	err := marshalSectionHeader(buf, tableIDPMT, p.ProgramNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.PCRPID > 0x1FFF {
+		return nil, fmt.Errorf("invalid PCR PID (%d)", p.PCRPID)
+	}
+
+	buf[8] = 0b11100000 | uint8(p.PCRPID>>8)
+	buf[9] = uint8(p.PCRPID)
+
+	n, err := marshalDescriptors(buf[12:], p.ProgramDescriptors)
+	if err != nil {
+		return nil, err
+	}
+
+	buf[10] = 0b11110000 | uint8(n>>8)
+	buf[11] = uint8(n)
+	pos := 12 + n
+
+	for _, es := range p.ElementaryStreams {
+		if es.ElementaryPID > 0x1FFF {
+			return nil, fmt.Errorf("invalid PID (%d)", es.ElementaryPID)
+		}
+
+		buf[pos] = es.StreamType
+		buf[pos+1] = 0b11100000 | uint8(es.ElementaryPID>>8)
+		buf[pos+2] = uint8(es.ElementaryPID)
+
+		n, err = marshalDescriptors(buf[pos+5:], es.Descriptors)
+		if err != nil {
+			return nil, err
+		}
+
+		buf[pos+3] = 0b11110000 | uint8(n>>8)
+		buf[pos+4] = uint8(n)
+		pos += 5 + n
+	}
+
+	marshalSectionCRC(buf)
+
+	return buf, nil
+}
@@ -0,0 +1,199 @@
+package mpegts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesPMT = []struct {
+	name string
+	dec  PMT
+	enc  []byte
+}{
+	{
+		"opus",
+		PMT{
+			ProgramNumber: 1,
+			PCRPID:        257,
+			ElementaryStreams: []PMT_ElementaryStream{{
+				StreamType:    0x06,
+				ElementaryPID: 257,
+				Descriptors: []Descriptor{
+					{
+						Tag:  0x05,
+						Data: []byte{'O', 'p', 'u', 's'},
+					},
+					{
+						Tag:  0x7f,
+						Data: []byte{0x80, 0x02},
+					},
+				},
+			}},
+		},
+		[]byte{
+			0x02, 0xb0, 0x1c, 0x00, 0x01, 0xc1, 0x00, 0x00,
+			0xe1, 0x01, 0xf0, 0x00, 0x06, 0xe1, 0x01, 0xf0,
+			0x0a, 0x05, 0x04, 0x4f, 0x70, 0x75, 0x73, 0x7f,
+			0x02, 0x80, 0x02, 0xcc, 0x21, 0x3d, 0x58,
+		},
+	},
+	{
+		"av1 and scte-35",
+		PMT{
+			ProgramNumber: 1,
+			PCRPID:        256,
+			ProgramDescriptors: []Descriptor{{
+				Tag:  0x05,
+				Data: []byte{'C', 'U', 'E', 'I'},
+			}},
+			ElementaryStreams: []PMT_ElementaryStream{
+				{
+					StreamType:    0x1b,
+					ElementaryPID: 256,
+				},
+				{
+					StreamType:    0x06,
+					ElementaryPID: 257,
+					Descriptors: []Descriptor{{
+						Tag:  0x05,
+						Data: []byte{'A', 'V', '0', '1'},
+					}},
+				},
+				{
+					StreamType:    0x86,
+					ElementaryPID: 258,
+					Descriptors: []Descriptor{{
+						Tag:  0x8a,
+						Data: []byte{0x00},
+					}},
+				},
+			},
+		},
+		[]byte{
+			0x02, 0xb0, 0x2b, 0x00, 0x01, 0xc1, 0x00, 0x00,
+			0xe1, 0x00, 0xf0, 0x06, 0x05, 0x04, 0x43, 0x55,
+			0x45, 0x49, 0x1b, 0xe1, 0x00, 0xf0, 0x00, 0x06,
+			0xe1, 0x01, 0xf0, 0x06, 0x05, 0x04, 0x41, 0x56,
+			0x30, 0x31, 0x86, 0xe1, 0x02, 0xf0, 0x03, 0x8a,
+			0x01, 0x00, 0x0d, 0x6b, 0x03, 0xe4,
+		},
+	},
+	{
+		"dvb subtitles and teletext",
+		PMT{
+			ProgramNumber: 1,
+			PCRPID:        256,
+			ElementaryStreams: []PMT_ElementaryStream{
+				{
+					StreamType:    0x06,
+					ElementaryPID: 259,
+					Descriptors: []Descriptor{{
+						Tag: 0x59,
+						Subtitling: &DescriptorSubtitling{
+							Subtitles: []DescriptorSubtitling_Subtitle{{
+								Language:          "eng",
+								SubtitlingType:    0x10,
+								CompositionPageID: 1,
+								AncillaryPageID:   1,
+							}},
+						},
+					}},
+				},
+				{
+					StreamType:    0x06,
+					ElementaryPID: 260,
+					Descriptors: []Descriptor{{
+						Tag: 0x56,
+						Teletext: &DescriptorTeletext{
+							Pages: []DescriptorTeletext_Page{
+								{
+									Language:       "deu",
+									Type:           1,
+									MagazineNumber: 1,
+								},
+								{
+									Language:   "fra",
+									Type:       2,
+									PageNumber: 0x88,
+								},
+							},
+						},
+					}},
+				},
+			},
+		},
+		[]byte{
+			0x02, 0xb0, 0x2d, 0x00, 0x01, 0xc1, 0x00, 0x00,
+			0xe1, 0x00, 0xf0, 0x00, 0x06, 0xe1, 0x03, 0xf0,
+			0x0a, 0x59, 0x08, 0x65, 0x6e, 0x67, 0x10, 0x00,
+			0x01, 0x00, 0x01, 0x06, 0xe1, 0x04, 0xf0, 0x0c,
+			0x56, 0x0a, 0x64, 0x65, 0x75, 0x09, 0x00, 0x66,
+			0x72, 0x61, 0x10, 0x88, 0xf6, 0x1b, 0xea, 0xf7,
+		},
+	},
+}
+
+func TestPMTUnmarshal(t *testing.T) {
+	for _, ca := range casesPMT {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec PMT
+			err := dec.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestPMTMarshal(t *testing.T) {
+	for _, ca := range casesPMT {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func TestPMTFormatIdentifier(t *testing.T) {
+	var dec PMT
+	err := dec.Unmarshal(casesPMT[0].enc)
+	require.NoError(t, err)
+
+	identifier, ok := dec.ElementaryStreams[0].FormatIdentifier()
+	require.Equal(t, true, ok)
+	require.Equal(t, uint32(opusIdentifier), identifier)
+
+	_, ok = dec.FormatIdentifier()
+	require.Equal(t, false, ok)
+
+	err = dec.Unmarshal(casesPMT[1].enc)
+	require.NoError(t, err)
+
+	identifier, ok = dec.ElementaryStreams[1].FormatIdentifier()
+	require.Equal(t, true, ok)
+	require.Equal(t, uint32('A'<<24|'V'<<16|'0'<<8|'1'), identifier)
+
+	identifier, ok = dec.FormatIdentifier()
+	require.Equal(t, true, ok)
+	require.Equal(t, uint32(cueiIdentifier), identifier)
+}
+
+func TestPMTSCTE35PIDs(t *testing.T) {
+	require.Equal(t, []uint16(nil), casesPMT[0].dec.SCTE35PIDs())
+	require.Equal(t, []uint16{258}, casesPMT[1].dec.SCTE35PIDs())
+}
+
+func FuzzPMTUnmarshal(f *testing.F) {
+	for _, ca := range casesPMT {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var dec PMT
+		err := dec.Unmarshal(b)
+		if err == nil {
+			dec.Marshal() //nolint:errcheck
+		}
+	})
+}
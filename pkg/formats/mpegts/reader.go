@@ -84,18 +84,37 @@ func NewReader(br io.Reader) (*Reader, error) {
 		tracks = append(tracks, &track)
 	}
 
+	r := &Reader{
+		tracks:        tracks,
+		onDecodeError: func(error) {},
+		onData:        make(map[uint16]func(int64, int64, []byte) error),
+	}
+
 	// rewind demuxer
-	dem = astits.NewDemuxer(
+	r.dem = astits.NewDemuxer(
 		context.Background(),
 		&playbackReader{r: br, buf: rr.buf},
-		astits.DemuxerOptPacketSize(188))
+		astits.DemuxerOptPacketSize(188),
+		astits.DemuxerOptPacketsParser(r.parsePackets))
 
-	return &Reader{
-		tracks:        tracks,
-		dem:           dem,
-		onDecodeError: func(error) {},
-		onData:        make(map[uint16]func(int64, int64, []byte) error),
-	}, nil
+	return r, nil
+}
+
+// parsePackets is called by the demuxer before decoding a group of packets.
+func (r *Reader) parsePackets(ps []*astits.Packet) ([]*astits.DemuxerData, bool, error) {
+	// when a continuity counter discontinuity is detected, the demuxer discards
+	// packets accumulated until then, and following packets form a group that
+	// doesn't start with a payload unit start indicator.
+	// This group is the remainder of an incomplete unit and must be discarded too.
+	if len(ps) != 0 && !ps[0].Header.PayloadUnitStartIndicator {
+		if _, ok := r.onData[ps[0].Header.PID]; ok {
+			r.onDecodeError(fmt.Errorf("discarding incomplete PES packet of PID %d after a discontinuity",
+				ps[0].Header.PID))
+		}
+		return nil, true, nil
+	}
+
+	return nil, false, nil
 }
 
 // Tracks returns detected tracks.
@@ -206,12 +225,7 @@ func (r *Reader) OnDataMPEG4Audio(track *Track, cb ReaderOnDataMPEG4AudioFunc) {
 			return nil
 		}
 
-		aus := make([][]byte, len(pkts))
-		for i, pkt := range pkts {
-			aus[i] = pkt.AU
-		}
-
-		return cb(pts, aus)
+		return cb(pts, pkts.AccessUnits())
 	}
 }
 
@@ -223,26 +237,10 @@ func (r *Reader) OnDataMPEG1Audio(track *Track, cb ReaderOnDataMPEG1AudioFunc) {
 			return nil
 		}
 
-		var frames [][]byte
-
-		for len(data) > 0 {
-			var h mpeg1audio.FrameHeader
-			err := h.Unmarshal(data)
-			if err != nil {
-				r.onDecodeError(err)
-				return nil
-			}
-
-			fl := h.FrameLen()
-			if len(data) < fl {
-				r.onDecodeError(fmt.Errorf("buffer is too short"))
-				return nil
-			}
-
-			var frame []byte
-			frame, data = data[:fl], data[fl:]
-
-			frames = append(frames, frame)
+		frames, err := mpeg1audio.SplitFrames(data)
+		if err != nil {
+			r.onDecodeError(err)
+			return nil
 		}
 
 		return cb(pts, frames)
@@ -10,6 +10,7 @@ import (
 	"github.com/asticode/go-astits"
 	"github.com/stretchr/testify/require"
 
+	"github.com/bluenviron/mediacommon/pkg/codecs/h264"
 	"github.com/bluenviron/mediacommon/pkg/codecs/h265"
 	"github.com/bluenviron/mediacommon/pkg/codecs/mpeg4audio"
 )
@@ -1326,6 +1327,74 @@ func TestReaderDecodeErrors(t *testing.T) {
 	}
 }
 
+func TestReaderDiscontinuity(t *testing.T) {
+	track := &Track{
+		PID:   257,
+		Codec: &CodecH264{},
+	}
+
+	var buf bytes.Buffer
+	w := &Writer{W: &buf, Tracks: []*Track{track}}
+	err := w.Initialize()
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		err := w.WriteH26x(track, int64(i)*90000, int64(i)*90000, true, [][]byte{
+			testH264SPS,
+			append([]byte{byte(h264.NALUTypeIDR)}, bytes.Repeat([]byte{byte(i + 1)}, 500)...),
+		})
+		require.NoError(t, err)
+	}
+
+	// remove the second packet of the second PES packet
+	var pkts [][]byte
+	starts := 0
+	for enc := buf.Bytes(); len(enc) != 0; enc = enc[188:] {
+		pkt := enc[:188]
+		pid := uint16(pkt[1]&0x1f)<<8 | uint16(pkt[2])
+
+		if pid == track.PID {
+			if (pkt[1] & 0x40) != 0 {
+				starts++
+			} else if starts == 2 {
+				starts++
+				continue
+			}
+		}
+
+		pkts = append(pkts, pkt)
+	}
+
+	r, err := NewReader(bytes.NewReader(bytes.Join(pkts, nil)))
+	require.NoError(t, err)
+
+	var recv []int64
+
+	r.OnDataH264(r.Tracks()[0], func(pts int64, _ int64, au [][]byte) error {
+		require.Equal(t, 500, len(au[1])-1)
+		recv = append(recv, pts)
+		return nil
+	})
+
+	decodeErrRecv := false
+
+	r.OnDecodeError(func(err error) {
+		require.EqualError(t, err, "discarding incomplete PES packet of PID 257 after a discontinuity")
+		decodeErrRecv = true
+	})
+
+	for {
+		err := r.Read()
+		if err != nil {
+			require.Equal(t, astits.ErrNoMorePackets, err)
+			break
+		}
+	}
+
+	require.Equal(t, true, decodeErrRecv)
+	require.Equal(t, []int64{0, 2 * 90000}, recv)
+}
+
 func FuzzReader(f *testing.F) {
 	for _, ca := range casesReadWriter {
 		var buf bytes.Buffer
@@ -0,0 +1,98 @@
+package mpegts
+
+import (
+	"fmt"
+)
+
+const (
+	tableIDPAT = 0x00
+	tableIDPMT = 0x02
+)
+
+// Specification: ISO 13818-1, Annex A
+func sectionCRC32(buf []byte) uint32 {
+	crc := uint32(0xFFFFFFFF)
+	for _, b := range buf {
+		crc ^= uint32(b) << 24
+		for i := 0; i < 8; i++ {
+			if (crc & 0x80000000) != 0 {
+				crc = (crc << 1) ^ 0x04C11DB7
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// unmarshalSection decodes the header and the CRC of a section with the long syntax.
+// It returns the table_id_extension and the section data.
+// Specification: ISO 13818-1, 2.4.4
+func unmarshalSection(buf []byte, tableID uint8) (uint16, []byte, error) {
+	if len(buf) < 3 {
+		return 0, nil, fmt.Errorf("buffer is too short")
+	}
+
+	if buf[0] != tableID {
+		return 0, nil, fmt.Errorf("invalid table_id (%d)", buf[0])
+	}
+
+	if (buf[1] & 0x80) == 0 {
+		return 0, nil, fmt.Errorf("section_syntax_indicator is not set")
+	}
+
+	sectionLength := int(buf[1]&0x0F)<<8 | int(buf[2])
+	if sectionLength < 9 {
+		return 0, nil, fmt.Errorf("invalid section_length (%d)", sectionLength)
+	}
+
+	if len(buf) < (3 + sectionLength) {
+		return 0, nil, fmt.Errorf("buffer is too short")
+	}
+
+	buf = buf[:3+sectionLength]
+
+	crc := uint32(buf[len(buf)-4])<<24 | uint32(buf[len(buf)-3])<<16 |
+		uint32(buf[len(buf)-2])<<8 | uint32(buf[len(buf)-1])
+	computed := sectionCRC32(buf[:len(buf)-4])
+
+	if crc != computed {
+		return 0, nil, fmt.Errorf("CRC mismatch: expected %.8x, got %.8x", computed, crc)
+	}
+
+	tableIDExtension := uint16(buf[3])<<8 | uint16(buf[4])
+
+	return tableIDExtension, buf[8 : len(buf)-4], nil
+}
+
+func sectionMarshalSize(dataLen int) int {
+	return 8 + dataLen + 4
+}
+
+// marshalSectionHeader encodes the header of a section with the long syntax.
+// The section data must be written after the header, then marshalSectionCRC must be called.
+func marshalSectionHeader(buf []byte, tableID uint8, tableIDExtension uint16) error {
+	sectionLength := len(buf) - 3
+	if sectionLength > 1021 {
+		return fmt.Errorf("section_length (%d) is too big, maximum is 1021", sectionLength)
+	}
+
+	buf[0] = tableID
+	buf[1] = 0b10110000 | uint8(sectionLength>>8)
+	buf[2] = uint8(sectionLength)
+	buf[3] = uint8(tableIDExtension >> 8)
+	buf[4] = uint8(tableIDExtension)
+	buf[5] = 0b11000001 // version_number = 0, current_next_indicator = 1
+	buf[6] = 0          // section_number
+	buf[7] = 0          // last_section_number
+
+	return nil
+}
+
+func marshalSectionCRC(buf []byte) {
+	crc := sectionCRC32(buf[:len(buf)-4])
+	buf[len(buf)-4] = uint8(crc >> 24)
+	buf[len(buf)-3] = uint8(crc >> 16)
+	buf[len(buf)-2] = uint8(crc >> 8)
+	buf[len(buf)-1] = uint8(crc)
+}
@@ -0,0 +1,599 @@
+package mpegts
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/pkg/bits"
+)
+
+const (
+	tableIDSpliceInfo = 0xFC
+
+	// legacy value of splice_command_length, used when the length is not known.
+	spliceCommandLengthUnknown = 0xFFF
+)
+
+// SpliceCommandType is the type of a splice command.
+// Specification: ANSI/SCTE 35, Table 7
+type SpliceCommandType uint8
+
+// splice command types.
+const (
+	SpliceCommandTypeSpliceNull           SpliceCommandType = 0x00
+	SpliceCommandTypeSpliceSchedule       SpliceCommandType = 0x04
+	SpliceCommandTypeSpliceInsert         SpliceCommandType = 0x05
+	SpliceCommandTypeTimeSignal           SpliceCommandType = 0x06
+	SpliceCommandTypeBandwidthReservation SpliceCommandType = 0x07
+	SpliceCommandTypePrivateCommand       SpliceCommandType = 0xFF
+)
+
+// SpliceInfoSection_SpliceTime is a splice_time().
+// Specification: ANSI/SCTE 35, 9.7.1
+type SpliceInfoSection_SpliceTime struct { //nolint:revive
+	TimeSpecifiedFlag bool
+
+	// TimeSpecifiedFlag == true
+	// 90kHz time, before the application of pts_adjustment.
+	PTSTime uint64
+}
+
+func (t *SpliceInfoSection_SpliceTime) unmarshal(buf []byte, pos *int) error {
+	var err error
+	t.TimeSpecifiedFlag, err = bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if t.TimeSpecifiedFlag {
+		err = bits.HasSpace(buf, *pos, 39)
+		if err != nil {
+			return err
+		}
+
+		*pos += 6 // reserved
+		t.PTSTime = bits.ReadBitsUnsafe(buf, pos, 33)
+	} else {
+		err = bits.HasSpace(buf, *pos, 7)
+		if err != nil {
+			return err
+		}
+
+		*pos += 7 // reserved
+		t.PTSTime = 0
+	}
+
+	return nil
+}
+
+func (t SpliceInfoSection_SpliceTime) marshalSizeBits() int {
+	if t.TimeSpecifiedFlag {
+		return 40
+	}
+	return 8
+}
+
+func (t SpliceInfoSection_SpliceTime) marshalTo(buf []byte, pos *int) error {
+	if t.TimeSpecifiedFlag {
+		if t.PTSTime > 0x1FFFFFFFF {
+			return fmt.Errorf("invalid pts_time (%d)", t.PTSTime)
+		}
+
+		bits.WriteBitsUnsafe(buf, pos, 0b1111111, 7)
+		bits.WriteBitsUnsafe(buf, pos, t.PTSTime, 33)
+	} else {
+		bits.WriteBitsUnsafe(buf, pos, 0b01111111, 8)
+	}
+
+	return nil
+}
+
+// SpliceInfoSection_BreakDuration is a break_duration().
+// Specification: ANSI/SCTE 35, 9.7.2
+type SpliceInfoSection_BreakDuration struct { //nolint:revive
+	AutoReturn bool
+
+	// 90kHz duration.
+	Duration uint64
+}
+
+func (d *SpliceInfoSection_BreakDuration) unmarshal(buf []byte, pos *int) error {
+	err := bits.HasSpace(buf, *pos, 40)
+	if err != nil {
+		return err
+	}
+
+	d.AutoReturn = bits.ReadFlagUnsafe(buf, pos)
+	*pos += 6 // reserved
+	d.Duration = bits.ReadBitsUnsafe(buf, pos, 33)
+
+	return nil
+}
+
+func (d SpliceInfoSection_BreakDuration) marshalTo(buf []byte, pos *int) error {
+	if d.Duration > 0x1FFFFFFFF {
+		return fmt.Errorf("invalid duration (%d)", d.Duration)
+	}
+
+	if d.AutoReturn {
+		bits.WriteBitsUnsafe(buf, pos, 1, 1)
+	} else {
+		bits.WriteBitsUnsafe(buf, pos, 0, 1)
+	}
+	bits.WriteBitsUnsafe(buf, pos, 0b111111, 6)
+	bits.WriteBitsUnsafe(buf, pos, d.Duration, 33)
+
+	return nil
+}
+
+// SpliceInfoSection_SpliceInsertComponent is a component of a splice_insert().
+type SpliceInfoSection_SpliceInsertComponent struct { //nolint:revive
+	ComponentTag uint8
+
+	// SpliceImmediateFlag == false
+	SpliceTime SpliceInfoSection_SpliceTime
+}
+
+// SpliceInfoSection_SpliceInsert is a splice_insert().
+// Specification: ANSI/SCTE 35, 9.7.3
+type SpliceInfoSection_SpliceInsert struct { //nolint:revive
+	SpliceEventID              uint32
+	SpliceEventCancelIndicator bool
+
+	// SpliceEventCancelIndicator == false
+	OutOfNetworkIndicator bool
+	ProgramSpliceFlag     bool
+	DurationFlag          bool
+	SpliceImmediateFlag   bool
+	EventIDComplianceFlag bool
+
+	// ProgramSpliceFlag == true && SpliceImmediateFlag == false
+	SpliceTime SpliceInfoSection_SpliceTime
+
+	// ProgramSpliceFlag == false
+	Components []SpliceInfoSection_SpliceInsertComponent
+
+	// DurationFlag == true
+	BreakDuration SpliceInfoSection_BreakDuration
+
+	// SpliceEventCancelIndicator == false
+	UniqueProgramID uint16
+	AvailNum        uint8
+	AvailsExpected  uint8
+}
+
+func (c *SpliceInfoSection_SpliceInsert) unmarshal(buf []byte, pos *int) error {
+	err := bits.HasSpace(buf, *pos, 40)
+	if err != nil {
+		return err
+	}
+
+	c.SpliceEventID = uint32(bits.ReadBitsUnsafe(buf, pos, 32))
+	c.SpliceEventCancelIndicator = bits.ReadFlagUnsafe(buf, pos)
+	*pos += 7 // reserved
+
+	c.OutOfNetworkIndicator = false
+	c.ProgramSpliceFlag = false
+	c.DurationFlag = false
+	c.SpliceImmediateFlag = false
+	c.EventIDComplianceFlag = false
+	c.SpliceTime = SpliceInfoSection_SpliceTime{}
+	c.Components = nil
+	c.BreakDuration = SpliceInfoSection_BreakDuration{}
+	c.UniqueProgramID = 0
+	c.AvailNum = 0
+	c.AvailsExpected = 0
+
+	if c.SpliceEventCancelIndicator {
+		return nil
+	}
+
+	err = bits.HasSpace(buf, *pos, 8)
+	if err != nil {
+		return err
+	}
+
+	c.OutOfNetworkIndicator = bits.ReadFlagUnsafe(buf, pos)
+	c.ProgramSpliceFlag = bits.ReadFlagUnsafe(buf, pos)
+	c.DurationFlag = bits.ReadFlagUnsafe(buf, pos)
+	c.SpliceImmediateFlag = bits.ReadFlagUnsafe(buf, pos)
+	c.EventIDComplianceFlag = bits.ReadFlagUnsafe(buf, pos)
+	*pos += 3 // reserved
+
+	if c.ProgramSpliceFlag {
+		if !c.SpliceImmediateFlag {
+			err = c.SpliceTime.unmarshal(buf, pos)
+			if err != nil {
+				return err
+			}
+		}
+	} else {
+		var componentCount uint64
+		componentCount, err = bits.ReadBits(buf, pos, 8)
+		if err != nil {
+			return err
+		}
+
+		c.Components = make([]SpliceInfoSection_SpliceInsertComponent, componentCount)
+
+		for i := range c.Components {
+			var tmp uint64
+			tmp, err = bits.ReadBits(buf, pos, 8)
+			if err != nil {
+				return err
+			}
+			c.Components[i].ComponentTag = uint8(tmp)
+
+			if !c.SpliceImmediateFlag {
+				err = c.Components[i].SpliceTime.unmarshal(buf, pos)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if c.DurationFlag {
+		err = c.BreakDuration.unmarshal(buf, pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = bits.HasSpace(buf, *pos, 32)
+	if err != nil {
+		return err
+	}
+
+	c.UniqueProgramID = uint16(bits.ReadBitsUnsafe(buf, pos, 16))
+	c.AvailNum = uint8(bits.ReadBitsUnsafe(buf, pos, 8))
+	c.AvailsExpected = uint8(bits.ReadBitsUnsafe(buf, pos, 8))
+
+	return nil
+}
+
+func (c SpliceInfoSection_SpliceInsert) marshalSizeBits() int {
+	n := 40
+
+	if c.SpliceEventCancelIndicator {
+		return n
+	}
+
+	n += 8
+
+	if c.ProgramSpliceFlag {
+		if !c.SpliceImmediateFlag {
+			n += c.SpliceTime.marshalSizeBits()
+		}
+	} else {
+		n += 8
+		for _, comp := range c.Components {
+			n += 8
+			if !c.SpliceImmediateFlag {
+				n += comp.SpliceTime.marshalSizeBits()
+			}
+		}
+	}
+
+	if c.DurationFlag {
+		n += 40
+	}
+
+	return n + 32
+}
+
+func (c SpliceInfoSection_SpliceInsert) marshalTo(buf []byte, pos *int) error {
+	bits.WriteBitsUnsafe(buf, pos, uint64(c.SpliceEventID), 32)
+	if c.SpliceEventCancelIndicator {
+		bits.WriteBitsUnsafe(buf, pos, 0b11111111, 8)
+		return nil
+	}
+	bits.WriteBitsUnsafe(buf, pos, 0b01111111, 8)
+
+	var flags uint64
+	if c.OutOfNetworkIndicator {
+		flags |= 1 << 7
+	}
+	if c.ProgramSpliceFlag {
+		flags |= 1 << 6
+	}
+	if c.DurationFlag {
+		flags |= 1 << 5
+	}
+	if c.SpliceImmediateFlag {
+		flags |= 1 << 4
+	}
+	if c.EventIDComplianceFlag {
+		flags |= 1 << 3
+	}
+	bits.WriteBitsUnsafe(buf, pos, flags|0b111, 8)
+
+	if c.ProgramSpliceFlag {
+		if !c.SpliceImmediateFlag {
+			err := c.SpliceTime.marshalTo(buf, pos)
+			if err != nil {
+				return err
+			}
+		}
+	} else {
+		if len(c.Components) > 0xFF {
+			return fmt.Errorf("too many components (%d)", len(c.Components))
+		}
+
+		bits.WriteBitsUnsafe(buf, pos, uint64(len(c.Components)), 8)
+
+		for _, comp := range c.Components {
+			bits.WriteBitsUnsafe(buf, pos, uint64(comp.ComponentTag), 8)
+
+			if !c.SpliceImmediateFlag {
+				err := comp.SpliceTime.marshalTo(buf, pos)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if c.DurationFlag {
+		err := c.BreakDuration.marshalTo(buf, pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	bits.WriteBitsUnsafe(buf, pos, uint64(c.UniqueProgramID), 16)
+	bits.WriteBitsUnsafe(buf, pos, uint64(c.AvailNum), 8)
+	bits.WriteBitsUnsafe(buf, pos, uint64(c.AvailsExpected), 8)
+
+	return nil
+}
+
+// SpliceInfoSection is a SCTE-35 splice information section.
+// It is carried by elementary streams that can be found with PMT.SCTE35PIDs.
+// Encrypted sections are not supported.
+// Specification: ANSI/SCTE 35, 9.6
+type SpliceInfoSection struct {
+	SAPType           uint8
+	ProtocolVersion   uint8
+	PTSAdjustment     uint64
+	CWIndex           uint8
+	Tier              uint16
+	SpliceCommandType SpliceCommandType
+
+	// decoded command.
+	// SpliceCommandType == SpliceCommandTypeSpliceInsert
+	SpliceInsert *SpliceInfoSection_SpliceInsert
+	// SpliceCommandType == SpliceCommandTypeTimeSignal
+	TimeSignal *SpliceInfoSection_SpliceTime
+
+	// payload of commands that are not decoded.
+	SpliceCommand []byte
+
+	// splice descriptors, not decoded.
+	Descriptors []byte
+}
+
+// Unmarshal decodes a SpliceInfoSection.
+// The buffer must start with table_id, without the pointer_field.
+func (s *SpliceInfoSection) Unmarshal(buf []byte) error {
+	if len(buf) < 3 {
+		return fmt.Errorf("buffer is too short")
+	}
+
+	if buf[0] != tableIDSpliceInfo {
+		return fmt.Errorf("invalid table_id (%d)", buf[0])
+	}
+
+	if (buf[1] & 0x80) != 0 {
+		return fmt.Errorf("section_syntax_indicator is set")
+	}
+
+	s.SAPType = (buf[1] >> 4) & 0b11
+
+	sectionLength := int(buf[1]&0x0F)<<8 | int(buf[2])
+	if sectionLength < 17 {
+		return fmt.Errorf("invalid section_length (%d)", sectionLength)
+	}
+
+	if len(buf) < (3 + sectionLength) {
+		return fmt.Errorf("buffer is too short")
+	}
+
+	buf = buf[:3+sectionLength]
+
+	crc := uint32(buf[len(buf)-4])<<24 | uint32(buf[len(buf)-3])<<16 |
+		uint32(buf[len(buf)-2])<<8 | uint32(buf[len(buf)-1])
+	computed := sectionCRC32(buf[:len(buf)-4])
+
+	if crc != computed {
+		return fmt.Errorf("CRC mismatch: expected %.8x, got %.8x", computed, crc)
+	}
+
+	s.ProtocolVersion = buf[3]
+
+	if (buf[4] & 0x80) != 0 {
+		return fmt.Errorf("encrypted sections are not supported")
+	}
+
+	s.PTSAdjustment = uint64(buf[4]&0x01)<<32 | uint64(buf[5])<<24 | uint64(buf[6])<<16 |
+		uint64(buf[7])<<8 | uint64(buf[8])
+	s.CWIndex = buf[9]
+	s.Tier = uint16(buf[10])<<4 | uint16(buf[11]>>4)
+	spliceCommandLength := int(buf[11]&0x0F)<<8 | int(buf[12])
+	s.SpliceCommandType = SpliceCommandType(buf[13])
+
+	data := buf[14 : len(buf)-4]
+
+	if spliceCommandLength != spliceCommandLengthUnknown && len(data) < spliceCommandLength {
+		return fmt.Errorf("invalid splice_command_length (%d)", spliceCommandLength)
+	}
+
+	s.SpliceInsert = nil
+	s.TimeSignal = nil
+	s.SpliceCommand = nil
+	pos := 0
+
+	switch s.SpliceCommandType {
+	case SpliceCommandTypeSpliceInsert:
+		s.SpliceInsert = &SpliceInfoSection_SpliceInsert{}
+		err := s.SpliceInsert.unmarshal(data, &pos)
+		if err != nil {
+			return err
+		}
+
+	case SpliceCommandTypeTimeSignal:
+		s.TimeSignal = &SpliceInfoSection_SpliceTime{}
+		err := s.TimeSignal.unmarshal(data, &pos)
+		if err != nil {
+			return err
+		}
+
+	case SpliceCommandTypeSpliceNull:
+
+	default:
+		if spliceCommandLength == spliceCommandLengthUnknown {
+			return fmt.Errorf("splice_command_length of command type %d is unknown", s.SpliceCommandType)
+		}
+
+		s.SpliceCommand = data[:spliceCommandLength]
+		pos = spliceCommandLength * 8
+	}
+
+	if spliceCommandLength != spliceCommandLengthUnknown {
+		if pos > (spliceCommandLength * 8) {
+			return fmt.Errorf("invalid splice_command_length (%d)", spliceCommandLength)
+		}
+		pos = spliceCommandLength * 8
+	} else {
+		pos = (pos + 7) / 8 * 8
+	}
+
+	data = data[pos/8:]
+
+	if len(data) < 2 {
+		return fmt.Errorf("buffer is too short")
+	}
+
+	descriptorLoopLength := int(data[0])<<8 | int(data[1])
+	data = data[2:]
+
+	if len(data) < descriptorLoopLength {
+		return fmt.Errorf("invalid descriptor_loop_length (%d)", descriptorLoopLength)
+	}
+
+	if descriptorLoopLength != 0 {
+		s.Descriptors = data[:descriptorLoopLength]
+	} else {
+		s.Descriptors = nil
+	}
+
+	return nil
+}
+
+func (s SpliceInfoSection) spliceCommandSize() int {
+	switch {
+	case s.SpliceInsert != nil:
+		return (s.SpliceInsert.marshalSizeBits() + 7) / 8
+
+	case s.TimeSignal != nil:
+		return (s.TimeSignal.marshalSizeBits() + 7) / 8
+
+	default:
+		return len(s.SpliceCommand)
+	}
+}
+
+func (s SpliceInfoSection) marshalSize() int {
+	return 14 + s.spliceCommandSize() + 2 + len(s.Descriptors) + 4
+}
+
+// Marshal encodes a SpliceInfoSection.
+// The buffer starts with table_id, without the pointer_field.
+func (s SpliceInfoSection) Marshal() ([]byte, error) {
+	switch s.SpliceCommandType {
+	case SpliceCommandTypeSpliceInsert:
+		if s.SpliceInsert == nil {
+			return nil, fmt.Errorf("splice insert command is missing")
+		}
+
+	case SpliceCommandTypeTimeSignal:
+		if s.TimeSignal == nil {
+			return nil, fmt.Errorf("time signal command is missing")
+		}
+
+	default:
+		if s.SpliceInsert != nil || s.TimeSignal != nil {
+			return nil, fmt.Errorf("command doesn't match splice_command_type")
+		}
+	}
+
+	if s.SAPType > 0b11 {
+		return nil, fmt.Errorf("invalid sap_type (%d)", s.SAPType)
+	}
+
+	if s.PTSAdjustment > 0x1FFFFFFFF {
+		return nil, fmt.Errorf("invalid pts_adjustment (%d)", s.PTSAdjustment)
+	}
+
+	if s.Tier > 0xFFF {
+		return nil, fmt.Errorf("invalid tier (%d)", s.Tier)
+	}
+
+	spliceCommandLength := s.spliceCommandSize()
+	if spliceCommandLength >= spliceCommandLengthUnknown {
+		return nil, fmt.Errorf("splice command is too big")
+	}
+
+	if len(s.Descriptors) > 0xFFFF {
+		return nil, fmt.Errorf("descriptors are too big")
+	}
+
+	buf := make([]byte, s.marshalSize())
+
+	sectionLength := len(buf) - 3
+	if sectionLength > 4093 {
+		return nil, fmt.Errorf("section_length (%d) is too big, maximum is 4093", sectionLength)
+	}
+
+	buf[0] = tableIDSpliceInfo
+	buf[1] = 0b00000000 | s.SAPType<<4 | uint8(sectionLength>>8)
+	buf[2] = uint8(sectionLength)
+	buf[3] = s.ProtocolVersion
+	buf[4] = 0b00000000 | uint8(s.PTSAdjustment>>32) // encrypted_packet = 0, encryption_algorithm = 0
+	buf[5] = uint8(s.PTSAdjustment >> 24)
+	buf[6] = uint8(s.PTSAdjustment >> 16)
+	buf[7] = uint8(s.PTSAdjustment >> 8)
+	buf[8] = uint8(s.PTSAdjustment)
+	buf[9] = s.CWIndex
+	buf[10] = uint8(s.Tier >> 4)
+	buf[11] = uint8(s.Tier<<4) | uint8(spliceCommandLength>>8)
+	buf[12] = uint8(spliceCommandLength)
+	buf[13] = uint8(s.SpliceCommandType)
+
+	pos := 14 * 8
+
+	switch {
+	case s.SpliceInsert != nil:
+		err := s.SpliceInsert.marshalTo(buf, &pos)
+		if err != nil {
+			return nil, err
+		}
+
+	case s.TimeSignal != nil:
+		err := s.TimeSignal.marshalTo(buf, &pos)
+		if err != nil {
+			return nil, err
+		}
+
+	default:
+		copy(buf[14:], s.SpliceCommand)
+	}
+
+	n := 14 + spliceCommandLength
+	buf[n] = uint8(len(s.Descriptors) >> 8)
+	buf[n+1] = uint8(len(s.Descriptors))
+	copy(buf[n+2:], s.Descriptors)
+
+	marshalSectionCRC(buf)
+
+	return buf, nil
+}
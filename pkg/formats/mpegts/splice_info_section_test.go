@@ -0,0 +1,171 @@
+package mpegts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesSpliceInfoSection = []struct {
+	name string
+	dec  SpliceInfoSection
+	enc  []byte
+}{
+	{
+		"splice insert",
+		SpliceInfoSection{
+			SAPType:           3,
+			CWIndex:           0xff,
+			Tier:              0xfff,
+			SpliceCommandType: SpliceCommandTypeSpliceInsert,
+			SpliceInsert: &SpliceInfoSection_SpliceInsert{
+				SpliceEventID:         0x4800008f,
+				OutOfNetworkIndicator: true,
+				ProgramSpliceFlag:     true,
+				DurationFlag:          true,
+				EventIDComplianceFlag: true,
+				SpliceTime: SpliceInfoSection_SpliceTime{
+					TimeSpecifiedFlag: true,
+					PTSTime:           1936310318,
+				},
+				BreakDuration: SpliceInfoSection_BreakDuration{
+					AutoReturn: true,
+					Duration:   5426421,
+				},
+			},
+			Descriptors: []byte{
+				0x00, 0x08, 0x43, 0x55, 0x45, 0x49, 0x00, 0x00,
+				0x01, 0x35,
+			},
+		},
+		[]byte{
+			0xfc, 0x30, 0x2f, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0xff, 0xff, 0xf0, 0x14, 0x05, 0x48, 0x00,
+			0x00, 0x8f, 0x7f, 0xef, 0xfe, 0x73, 0x69, 0xc0,
+			0x2e, 0xfe, 0x00, 0x52, 0xcc, 0xf5, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x0a, 0x00, 0x08, 0x43, 0x55,
+			0x45, 0x49, 0x00, 0x00, 0x01, 0x35, 0x62, 0xdb,
+			0xa3, 0x0a,
+		},
+	},
+	{
+		"time signal",
+		SpliceInfoSection{
+			SAPType:           3,
+			CWIndex:           0xff,
+			Tier:              0xfff,
+			SpliceCommandType: SpliceCommandTypeTimeSignal,
+			TimeSignal: &SpliceInfoSection_SpliceTime{
+				TimeSpecifiedFlag: true,
+				PTSTime:           1924989008,
+			},
+			Descriptors: []byte{
+				0x02, 0x1c, 0x43, 0x55, 0x45, 0x49, 0x48, 0x00,
+				0x00, 0x8e, 0x7f, 0xcf, 0x00, 0x01, 0xa5, 0x99,
+				0xb0, 0x08, 0x08, 0x00, 0x00, 0x00, 0x00, 0x2c,
+				0xa0, 0xa1, 0x8a, 0x34, 0x02, 0x00,
+			},
+		},
+		[]byte{
+			0xfc, 0x30, 0x34, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0xff, 0xff, 0xf0, 0x05, 0x06, 0xfe, 0x72,
+			0xbd, 0x00, 0x50, 0x00, 0x1e, 0x02, 0x1c, 0x43,
+			0x55, 0x45, 0x49, 0x48, 0x00, 0x00, 0x8e, 0x7f,
+			0xcf, 0x00, 0x01, 0xa5, 0x99, 0xb0, 0x08, 0x08,
+			0x00, 0x00, 0x00, 0x00, 0x2c, 0xa0, 0xa1, 0x8a,
+			0x34, 0x02, 0x00, 0x9a, 0xc9, 0xd1, 0x7e,
+		},
+	},
+	{
+		"splice insert with components",
+		SpliceInfoSection{
+			PTSAdjustment:     0x100000000,
+			SpliceCommandType: SpliceCommandTypeSpliceInsert,
+			SpliceInsert: &SpliceInfoSection_SpliceInsert{
+				SpliceEventID:         12,
+				OutOfNetworkIndicator: false,
+				Components: []SpliceInfoSection_SpliceInsertComponent{
+					{
+						ComponentTag: 1,
+						SpliceTime: SpliceInfoSection_SpliceTime{
+							TimeSpecifiedFlag: true,
+							PTSTime:           90000,
+						},
+					},
+					{
+						ComponentTag: 2,
+					},
+				},
+				UniqueProgramID: 34,
+				AvailNum:        1,
+				AvailsExpected:  2,
+			},
+		},
+		[]byte{
+			0xfc, 0x00, 0x24, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x13, 0x05, 0x00, 0x00,
+			0x00, 0x0c, 0x7f, 0x07, 0x02, 0x01, 0xfe, 0x00,
+			0x01, 0x5f, 0x90, 0x02, 0x7f, 0x00, 0x22, 0x01,
+			0x02, 0x00, 0x00, 0xd2, 0xbd, 0x21, 0xe4,
+		},
+	},
+	{
+		"splice null",
+		SpliceInfoSection{
+			SpliceCommandType: SpliceCommandTypeSpliceNull,
+		},
+		[]byte{
+			0xfc, 0x00, 0x11, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0xe5, 0xe6, 0x39, 0x3b,
+		},
+	},
+	{
+		"private command",
+		SpliceInfoSection{
+			SpliceCommandType: SpliceCommandTypePrivateCommand,
+			SpliceCommand:     []byte{0x43, 0x55, 0x45, 0x49, 0x01, 0x02},
+		},
+		[]byte{
+			0xfc, 0x00, 0x17, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x06, 0xff, 0x43, 0x55,
+			0x45, 0x49, 0x01, 0x02, 0x00, 0x00, 0x86, 0xcb,
+			0xd4, 0x4a,
+		},
+	},
+}
+
+func TestSpliceInfoSectionUnmarshal(t *testing.T) {
+	for _, ca := range casesSpliceInfoSection {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec SpliceInfoSection
+			err := dec.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestSpliceInfoSectionMarshal(t *testing.T) {
+	for _, ca := range casesSpliceInfoSection {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func FuzzSpliceInfoSectionUnmarshal(f *testing.F) {
+	for _, ca := range casesSpliceInfoSection {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var dec SpliceInfoSection
+		err := dec.Unmarshal(b)
+		if err == nil {
+			dec.Marshal() //nolint:errcheck
+		}
+	})
+}
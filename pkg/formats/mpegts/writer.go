@@ -48,6 +48,9 @@ func mpeg1AudioMarshalSize(frames [][]byte) int {
 
 // Writer is a MPEG-TS writer.
 type Writer struct {
+	W      io.Writer
+	Tracks []*Track
+
 	nextPID            uint16
 	mux                *astits.Muxer
 	pcrCounter         int
@@ -55,28 +58,60 @@ type Writer struct {
 }
 
 // NewWriter allocates a Writer.
+//
+// Deprecated: replaced by Writer.Initialize.
 func NewWriter(
 	bw io.Writer,
 	tracks []*Track,
 ) *Writer {
 	w := &Writer{
-		nextPID: 256,
+		W:      bw,
+		Tracks: tracks,
 	}
 
+	err := w.initialize()
+	if err != nil {
+		panic(err)
+	}
+
+	return w
+}
+
+// Initialize initializes a Writer.
+func (w *Writer) Initialize() error {
+	for _, track := range w.Tracks {
+		if codec, ok := track.Codec.(*CodecMPEG4Audio); ok {
+			err := codec.checkADTS()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return w.initialize()
+}
+
+func (w *Writer) initialize() error {
+	w.nextPID = 256
+
 	w.mux = astits.NewMuxer(
 		context.Background(),
-		bw)
+		w.W)
 
-	for _, track := range tracks {
+	for _, track := range w.Tracks {
 		if track.PID == 0 {
 			track.PID = w.nextPID
 			w.nextPID++
 		}
-		es, _ := track.marshal()
 
-		err := w.mux.AddElementaryStream(*es)
+		es, err := track.marshal()
 		if err != nil {
-			panic(err) // TODO: return error instead of panicking
+			return err
+		}
+
+		err = w.mux.AddElementaryStream(*es)
+		if err != nil {
+			return err
 		}
 	}
 
@@ -86,7 +121,7 @@ func NewWriter(
 	// * AdaptationField != nil
 	// * RandomAccessIndicator = true
 
-	return w
+	return nil
 }
 
 // WriteH26x writes a H26x access unit.
@@ -205,6 +240,13 @@ func (w *Writer) WriteMPEG4Audio(
 	aus [][]byte,
 ) error {
 	aacCodec := track.Codec.(*CodecMPEG4Audio)
+
+	// writers allocated with NewWriter skip the check performed by Initialize
+	err := aacCodec.checkADTS()
+	if err != nil {
+		return err
+	}
+
 	pkts := make(mpeg4audio.ADTSPackets, len(aus))
 
 	for i, au := range aus {
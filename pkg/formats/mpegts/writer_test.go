@@ -12,6 +12,7 @@ import (
 
 	"github.com/bluenviron/mediacommon/pkg/codecs/h264"
 	"github.com/bluenviron/mediacommon/pkg/codecs/h265"
+	"github.com/bluenviron/mediacommon/pkg/codecs/mpeg4audio"
 )
 
 func h265RandomAccessPresent(au [][]byte) bool {
@@ -29,7 +30,9 @@ func TestWriter(t *testing.T) {
 	for _, ca := range casesReadWriter {
 		t.Run(ca.name, func(t *testing.T) {
 			var buf bytes.Buffer
-			w := NewWriter(&buf, []*Track{ca.track})
+			w := &Writer{W: &buf, Tracks: []*Track{ca.track}}
+			err := w.Initialize()
+			require.NoError(t, err)
 
 			for _, sample := range ca.samples {
 				switch ca.track.Codec.(type) {
@@ -104,6 +107,46 @@ func TestWriterAutomaticPID(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	NewWriter(&buf, []*Track{track})
+	w := &Writer{W: &buf, Tracks: []*Track{track}}
+	err := w.Initialize()
+	require.NoError(t, err)
 	require.NotEqual(t, 0, track.PID)
 }
+
+func TestWriterUnsupportedCodec(t *testing.T) {
+	var buf bytes.Buffer
+	w := &Writer{W: &buf, Tracks: []*Track{{Codec: &CodecUnsupported{}}}}
+	err := w.Initialize()
+	require.EqualError(t, err, "unsupported codec")
+}
+
+func TestWriterMPEG4AudioUnsupportedConfig(t *testing.T) {
+	var buf bytes.Buffer
+	w := &Writer{W: &buf, Tracks: []*Track{{Codec: &CodecMPEG4Audio{
+		Config: mpeg4audio.Config{
+			Type:            mpeg4audio.ObjectTypeAACLC,
+			SampleRate:      48000,
+			ChannelCount:    2,
+			FrameLengthFlag: true,
+		},
+	}}}}
+	err := w.Initialize()
+	require.EqualError(t, err, "frame length of 960 samples can't be described by ADTS")
+}
+
+func TestNewWriterMPEG4AudioUnsupportedConfig(t *testing.T) {
+	track := &Track{Codec: &CodecMPEG4Audio{
+		Config: mpeg4audio.Config{
+			Type:            mpeg4audio.ObjectTypeAACLC,
+			SampleRate:      48000,
+			ChannelCount:    2,
+			FrameLengthFlag: true,
+		},
+	}}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf, []*Track{track}) //nolint:staticcheck
+
+	err := w.WriteMPEG4Audio(track, 0, [][]byte{{1, 2}})
+	require.EqualError(t, err, "frame length of 960 samples can't be described by ADTS")
+}
@@ -0,0 +1,25 @@
+package ogg
+
+var crcTable = func() [256]uint32 {
+	var t [256]uint32
+	for i := range t {
+		crc := uint32(i) << 24
+		for j := 0; j < 8; j++ {
+			if (crc & 0x80000000) != 0 {
+				crc = (crc << 1) ^ 0x04c11db7
+			} else {
+				crc <<= 1
+			}
+		}
+		t[i] = crc
+	}
+	return t
+}()
+
+// Specification: RFC 3533, 6
+func crcUpdate(crc uint32, buf []byte) uint32 {
+	for _, b := range buf {
+		crc = (crc << 8) ^ crcTable[byte(crc>>24)^b]
+	}
+	return crc
+}
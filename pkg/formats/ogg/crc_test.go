@@ -0,0 +1,11 @@
+package ogg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCRC(t *testing.T) {
+	require.Equal(t, uint32(0x89a1897f), crcUpdate(0, []byte("123456789")))
+}
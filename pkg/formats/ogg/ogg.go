@@ -0,0 +1,8 @@
+// Package ogg contains Ogg utilities.
+package ogg
+
+const (
+	// MaxPacketSize is the maximum size of a packet.
+	// It is high enough to contain comment headers with embedded pictures.
+	MaxPacketSize = 8 * 1024 * 1024
+)
@@ -0,0 +1,170 @@
+package ogg
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/bluenviron/mediacommon/pkg/codecs/opus"
+)
+
+var opusTagsMagic = []byte{'O', 'p', 'u', 's', 'T', 'a', 'g', 's'}
+
+// OpusPacket is an Opus packet read by OpusReader.
+type OpusPacket struct {
+	Data []byte
+
+	// position of the first sample of the packet, in samples at 48kHz.
+	// It includes the pre-skip.
+	PTS int64
+
+	// duration of the packet, in samples at 48kHz.
+	// On the last page, it is shortened by end trimming.
+	Duration int
+}
+
+// OpusReader is a reader of Opus streams in Ogg.
+// It decodes headers and computes timestamps and durations of packets from granule positions.
+// The number of samples of the stream, that is needed to write edit lists,
+// is GranulePosition() minus Config.PreSkip.
+// Specification: RFC7845
+type OpusReader struct {
+	// source of data.
+	R io.Reader
+
+	// decoder configuration, filled by Initialize.
+	Config opus.DecoderConfiguration
+
+	r                      Reader
+	granulePosition        int64
+	granulePositionPresent bool
+}
+
+// Initialize initializes an OpusReader.
+// It reads the identification header and the comment header.
+func (r *OpusReader) Initialize() error {
+	r.r = Reader{R: r.R}
+	err := r.r.Initialize()
+	if err != nil {
+		return err
+	}
+
+	packets, _, err := r.r.ReadPackets()
+	if err != nil {
+		return err
+	}
+
+	if len(packets) != 1 {
+		return fmt.Errorf("identification header must be alone in its page")
+	}
+
+	err = r.Config.UnmarshalOpusHead(packets[0])
+	if err != nil {
+		return err
+	}
+
+	packets, _, err = r.r.ReadPackets()
+	if err != nil {
+		return err
+	}
+
+	if len(packets) != 1 || !bytes.HasPrefix(packets[0], opusTagsMagic) {
+		return fmt.Errorf("comment header not found")
+	}
+
+	return nil
+}
+
+// GranulePosition returns the granule position of the last page that has been read,
+// that is the number of samples at 48kHz decoded so far, including the pre-skip.
+func (r *OpusReader) GranulePosition() int64 {
+	return r.granulePosition
+}
+
+// ReadPackets reads pages until at least one packet is complete, and returns complete packets.
+func (r *OpusReader) ReadPackets() ([]OpusPacket, error) {
+	for {
+		p, err := r.r.ReadPage()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.BeginningOfStream {
+			return nil, fmt.Errorf("chained streams are not supported")
+		}
+
+		packets, err := r.r.processPage(p)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(packets) != 0 {
+			return r.processPackets(packets, p.GranulePosition, p.EndOfStream)
+		}
+	}
+}
+
+func (r *OpusReader) processPackets(packets [][]byte, granulePosition int64, endOfStream bool) ([]OpusPacket, error) {
+	if granulePosition < 0 {
+		return nil, fmt.Errorf("invalid granule position (%d)", granulePosition)
+	}
+
+	durations := make([]int, len(packets))
+	total := int64(0)
+
+	for i, pkt := range packets {
+		var err error
+		durations[i], err = opus.PacketDuration2(pkt)
+		if err != nil {
+			return nil, err
+		}
+		total += int64(durations[i])
+	}
+
+	// the granule position is the position of the last sample of the page
+	start := granulePosition - total
+
+	if endOfStream {
+		// the granule position of the last page can be less than the
+		// position of the last sample, in order to trim the end of the stream.
+		// When the previous page is not available, the stream starts from zero.
+		base := r.granulePosition
+		if !r.granulePositionPresent {
+			base = 0
+		}
+
+		if start < base {
+			start = base
+			trim := base + total - granulePosition
+
+			for i := len(durations) - 1; i >= 0 && trim > 0; i-- {
+				t := int64(durations[i])
+				if t > trim {
+					t = trim
+				}
+				durations[i] -= int(t)
+				trim -= t
+			}
+		}
+	} else if start < 0 {
+		return nil, fmt.Errorf("granule position (%d) is less than the duration of packets (%d)",
+			granulePosition, total)
+	}
+
+	r.granulePosition = granulePosition
+	r.granulePositionPresent = true
+
+	ret := make([]OpusPacket, len(packets))
+	pts := start
+
+	for i, pkt := range packets {
+		ret[i] = OpusPacket{
+			Data:     pkt,
+			PTS:      pts,
+			Duration: durations[i],
+		}
+		pts += int64(durations[i])
+	}
+
+	return ret, nil
+}
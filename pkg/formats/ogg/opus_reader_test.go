@@ -0,0 +1,160 @@
+package ogg
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bluenviron/mediacommon/pkg/codecs/opus"
+)
+
+func opusHeaderPages(t *testing.T) []Page {
+	conf, err := opus.NewDecoderConfiguration(2, 312)
+	require.NoError(t, err)
+
+	head, err := conf.MarshalOpusHead()
+	require.NoError(t, err)
+
+	return []Page{
+		{
+			BeginningOfStream: true,
+			SerialNumber:      10,
+			Packets:           [][]byte{head},
+		},
+		{
+			SerialNumber:   10,
+			SequenceNumber: 1,
+			Packets:        [][]byte{append([]byte("OpusTags"), 0, 0, 0, 0, 0, 0, 0, 0)},
+		},
+	}
+}
+
+func TestOpusReader(t *testing.T) {
+	// 0xf8: CELT FB 20ms, one frame
+	buf := marshalPages(t, append(opusHeaderPages(t),
+		Page{
+			SerialNumber:    10,
+			SequenceNumber:  2,
+			GranulePosition: 1920,
+			Packets:         [][]byte{{0xf8, 1}, {0xf8, 2}},
+		},
+		Page{
+			EndOfStream:     true,
+			SerialNumber:    10,
+			SequenceNumber:  3,
+			GranulePosition: 1920 + 960 + 100,
+			Packets:         [][]byte{{0xf8, 3}, {0xf8, 4}},
+		},
+	))
+
+	r := &OpusReader{R: bytes.NewReader(buf)}
+	err := r.Initialize()
+	require.NoError(t, err)
+	require.Equal(t, 2, r.Config.ChannelCount)
+	require.Equal(t, uint16(312), r.Config.PreSkip)
+
+	packets, err := r.ReadPackets()
+	require.NoError(t, err)
+	require.Equal(t, []OpusPacket{
+		{Data: []byte{0xf8, 1}, PTS: 0, Duration: 960},
+		{Data: []byte{0xf8, 2}, PTS: 960, Duration: 960},
+	}, packets)
+	require.Equal(t, int64(1920), r.GranulePosition())
+
+	packets, err = r.ReadPackets()
+	require.NoError(t, err)
+	require.Equal(t, []OpusPacket{
+		{Data: []byte{0xf8, 3}, PTS: 1920, Duration: 960},
+		{Data: []byte{0xf8, 4}, PTS: 2880, Duration: 100},
+	}, packets)
+	require.Equal(t, int64(2980), r.GranulePosition())
+	require.Equal(t, int64(2980-312), r.GranulePosition()-int64(r.Config.PreSkip))
+
+	_, err = r.ReadPackets()
+	require.Equal(t, io.EOF, err)
+}
+
+func TestOpusReaderShortSinglePage(t *testing.T) {
+	buf := marshalPages(t, append(opusHeaderPages(t),
+		Page{
+			EndOfStream:     true,
+			SerialNumber:    10,
+			SequenceNumber:  2,
+			GranulePosition: 1000,
+			Packets:         [][]byte{{0xf8, 1}, {0xf8, 2}},
+		},
+	))
+
+	r := &OpusReader{R: bytes.NewReader(buf)}
+	err := r.Initialize()
+	require.NoError(t, err)
+
+	packets, err := r.ReadPackets()
+	require.NoError(t, err)
+	require.Equal(t, []OpusPacket{
+		{Data: []byte{0xf8, 1}, PTS: 0, Duration: 960},
+		{Data: []byte{0xf8, 2}, PTS: 960, Duration: 40},
+	}, packets)
+}
+
+func TestOpusReaderErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name  string
+		pages []Page
+		err   string
+	}{
+		{
+			"granule position less than duration",
+			[]Page{{
+				SerialNumber:    10,
+				SequenceNumber:  2,
+				GranulePosition: 500,
+				Packets:         [][]byte{{0xf8, 1}},
+			}},
+			"granule position (500) is less than the duration of packets (960)",
+		},
+		{
+			"chained stream",
+			[]Page{{
+				BeginningOfStream: true,
+				SerialNumber:      11,
+				Packets:           [][]byte{{0xf8, 1}},
+			}},
+			"chained streams are not supported",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			buf := marshalPages(t, append(opusHeaderPages(t), ca.pages...))
+
+			r := &OpusReader{R: bytes.NewReader(buf)}
+			err := r.Initialize()
+			require.NoError(t, err)
+
+			_, err = r.ReadPackets()
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func FuzzOpusReader(f *testing.F) {
+	for _, ca := range casesPage {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		r := &OpusReader{R: bytes.NewReader(b)}
+		err := r.Initialize()
+		if err != nil {
+			return
+		}
+
+		for {
+			_, err = r.ReadPackets()
+			if err != nil {
+				break
+			}
+		}
+	})
+}
@@ -0,0 +1,208 @@
+package ogg
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+const (
+	pageHeaderSize  = 27
+	maxPageSegments = 255
+)
+
+const (
+	headerTypeContinued         = 0x01
+	headerTypeBeginningOfStream = 0x02
+	headerTypeEndOfStream       = 0x04
+)
+
+// Page is a Ogg page.
+// Specification: RFC 3533, 6
+type Page struct {
+	// whether the first packet is the continuation of a packet of the previous page.
+	Continued bool
+
+	BeginningOfStream bool
+	EndOfStream       bool
+
+	// codec-dependent position, or -1 when no packet finishes on the page.
+	GranulePosition int64
+
+	SerialNumber   uint32
+	SequenceNumber uint32
+
+	// packets, or parts of packets, contained in the page.
+	Packets [][]byte
+
+	// whether the last packet continues in the next page.
+	Incomplete bool
+}
+
+// pageSize returns the size of the page that begins the buffer,
+// or zero if the buffer doesn't contain the whole header.
+func pageSize(buf []byte) (int, error) {
+	if len(buf) < pageHeaderSize {
+		return 0, nil
+	}
+
+	if string(buf[:4]) != "OggS" {
+		return 0, fmt.Errorf("capture pattern not found")
+	}
+
+	segmentCount := int(buf[26])
+	if len(buf) < (pageHeaderSize + segmentCount) {
+		return 0, nil
+	}
+
+	n := pageHeaderSize + segmentCount
+	for _, v := range buf[pageHeaderSize : pageHeaderSize+segmentCount] {
+		n += int(v)
+	}
+
+	return n, nil
+}
+
+// Unmarshal decodes a Page.
+// It returns the number of bytes read.
+func (p *Page) Unmarshal(buf []byte) (int, error) {
+	if len(buf) < pageHeaderSize {
+		return 0, fmt.Errorf("not enough bytes")
+	}
+
+	if string(buf[:4]) != "OggS" {
+		return 0, fmt.Errorf("capture pattern not found")
+	}
+
+	if buf[4] != 0 {
+		return 0, fmt.Errorf("unsupported version (%d)", buf[4])
+	}
+
+	headerType := buf[5]
+	p.Continued = (headerType & headerTypeContinued) != 0
+	p.BeginningOfStream = (headerType & headerTypeBeginningOfStream) != 0
+	p.EndOfStream = (headerType & headerTypeEndOfStream) != 0
+
+	p.GranulePosition = int64(binary.LittleEndian.Uint64(buf[6:]))
+	p.SerialNumber = binary.LittleEndian.Uint32(buf[14:])
+	p.SequenceNumber = binary.LittleEndian.Uint32(buf[18:])
+	crc := binary.LittleEndian.Uint32(buf[22:])
+
+	size, err := pageSize(buf)
+	if err != nil {
+		return 0, err
+	}
+
+	if size == 0 || len(buf) < size {
+		return 0, fmt.Errorf("not enough bytes")
+	}
+
+	computed := crcUpdate(0, buf[:22])
+	computed = crcUpdate(computed, []byte{0, 0, 0, 0})
+	computed = crcUpdate(computed, buf[26:size])
+
+	if crc != computed {
+		return 0, fmt.Errorf("CRC mismatch: expected %.8x, got %.8x", computed, crc)
+	}
+
+	segmentCount := int(buf[26])
+	segmentTable := buf[pageHeaderSize : pageHeaderSize+segmentCount]
+	pos := pageHeaderSize + segmentCount
+
+	p.Packets = nil
+	p.Incomplete = false
+	packetStart := pos
+
+	for i, v := range segmentTable {
+		pos += int(v)
+
+		if v < 255 {
+			p.Packets = append(p.Packets, buf[packetStart:pos])
+			packetStart = pos
+		} else if i == (segmentCount - 1) {
+			p.Packets = append(p.Packets, buf[packetStart:pos])
+			p.Incomplete = true
+		}
+	}
+
+	return size, nil
+}
+
+func (p Page) segmentCount() int {
+	n := 0
+	for i, pkt := range p.Packets {
+		n += len(pkt) / 255
+		if i != (len(p.Packets)-1) || !p.Incomplete {
+			n++
+		}
+	}
+	return n
+}
+
+func (p Page) marshalSize() int {
+	n := pageHeaderSize + p.segmentCount()
+	for _, pkt := range p.Packets {
+		n += len(pkt)
+	}
+	return n
+}
+
+// Marshal encodes a Page.
+func (p Page) Marshal() ([]byte, error) {
+	segmentCount := p.segmentCount()
+	if segmentCount > maxPageSegments {
+		return nil, fmt.Errorf("segment count (%d) exceeds maximum allowed (%d)", segmentCount, maxPageSegments)
+	}
+
+	if p.Incomplete {
+		if len(p.Packets) == 0 {
+			return nil, fmt.Errorf("incomplete page without packets")
+		}
+
+		if (len(p.Packets[len(p.Packets)-1]) % 255) != 0 {
+			return nil, fmt.Errorf("size of the incomplete packet must be a multiple of 255")
+		}
+	}
+
+	buf := make([]byte, p.marshalSize())
+
+	copy(buf, "OggS")
+
+	var headerType uint8
+	if p.Continued {
+		headerType |= headerTypeContinued
+	}
+	if p.BeginningOfStream {
+		headerType |= headerTypeBeginningOfStream
+	}
+	if p.EndOfStream {
+		headerType |= headerTypeEndOfStream
+	}
+	buf[5] = headerType
+
+	binary.LittleEndian.PutUint64(buf[6:], uint64(p.GranulePosition))
+	binary.LittleEndian.PutUint32(buf[14:], p.SerialNumber)
+	binary.LittleEndian.PutUint32(buf[18:], p.SequenceNumber)
+	buf[26] = uint8(segmentCount)
+
+	pos := pageHeaderSize
+
+	for i, pkt := range p.Packets {
+		for j := 0; j < len(pkt)/255; j++ {
+			buf[pos] = 255
+			pos++
+		}
+
+		if i != (len(p.Packets)-1) || !p.Incomplete {
+			buf[pos] = uint8(len(pkt) % 255)
+			pos++
+		}
+	}
+
+	for _, pkt := range p.Packets {
+		pos += copy(buf[pos:], pkt)
+	}
+
+	binary.LittleEndian.PutUint32(buf[22:], crcUpdate(0, buf))
+
+	return buf, nil
+}
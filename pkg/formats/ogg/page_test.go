@@ -0,0 +1,156 @@
+package ogg
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesPage = []struct {
+	name string
+	byts []byte
+	page Page
+}{
+	{
+		"beginning of stream",
+		[]byte{
+			0x4f, 0x67, 0x67, 0x53, 0x00, 0x02, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x34, 0x12,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x8e, 0x48,
+			0xf7, 0xf4, 0x01, 0x13, 0x4f, 0x70, 0x75, 0x73,
+			0x48, 0x65, 0x61, 0x64, 0x01, 0x02, 0x38, 0x01,
+			0x80, 0xbb, 0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		Page{
+			BeginningOfStream: true,
+			SerialNumber:      0x1234,
+			Packets: [][]byte{{
+				0x4f, 0x70, 0x75, 0x73, 0x48, 0x65, 0x61, 0x64,
+				0x01, 0x02, 0x38, 0x01, 0x80, 0xbb, 0x00, 0x00,
+				0x00, 0x00, 0x00,
+			}},
+		},
+	},
+	{
+		"multiple packets",
+		[]byte{
+			0x4f, 0x67, 0x67, 0x53, 0x00, 0x00, 0x80, 0x07,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x34, 0x12,
+			0x00, 0x00, 0x02, 0x00, 0x00, 0x00, 0x46, 0xd2,
+			0x1d, 0xab, 0x02, 0x02, 0x02, 0xfc, 0x01, 0xfc,
+			0x02,
+		},
+		Page{
+			GranulePosition: 1920,
+			SerialNumber:    0x1234,
+			SequenceNumber:  2,
+			Packets:         [][]byte{{0xfc, 0x01}, {0xfc, 0x02}},
+		},
+	},
+	{
+		"continued and end of stream",
+		[]byte{
+			0x4f, 0x67, 0x67, 0x53, 0x00, 0x05, 0xff, 0xff,
+			0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x34, 0x12,
+			0x00, 0x00, 0x03, 0x00, 0x00, 0x00, 0x40, 0xcd,
+			0x91, 0x4e, 0x01, 0x03, 0x01, 0x02, 0x03,
+		},
+		Page{
+			Continued:       true,
+			EndOfStream:     true,
+			GranulePosition: -1,
+			SerialNumber:    0x1234,
+			SequenceNumber:  3,
+			Packets:         [][]byte{{0x01, 0x02, 0x03}},
+		},
+	},
+}
+
+func TestPageUnmarshal(t *testing.T) {
+	for _, ca := range casesPage {
+		t.Run(ca.name, func(t *testing.T) {
+			var p Page
+			n, err := p.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, len(ca.byts), n)
+			require.Equal(t, ca.page, p)
+		})
+	}
+}
+
+func TestPageMarshal(t *testing.T) {
+	for _, ca := range casesPage {
+		t.Run(ca.name, func(t *testing.T) {
+			byts, err := ca.page.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.byts, byts)
+		})
+	}
+}
+
+func TestPageIncomplete(t *testing.T) {
+	p := Page{
+		SerialNumber: 1,
+		Packets: [][]byte{
+			bytes.Repeat([]byte{1}, 300),
+			bytes.Repeat([]byte{2}, 510),
+		},
+		Incomplete: true,
+	}
+
+	byts, err := p.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, []byte{255, 45, 255, 255}, byts[27:31])
+
+	var dec Page
+	_, err = dec.Unmarshal(byts)
+	require.NoError(t, err)
+	require.Equal(t, p, dec)
+}
+
+func TestPageUnmarshalErrors(t *testing.T) {
+	var p Page
+	_, err := p.Unmarshal([]byte{0x4f, 0x67, 0x67})
+	require.EqualError(t, err, "not enough bytes")
+
+	byts := append([]byte(nil), casesPage[0].byts...)
+	byts[0] = 0x00
+	_, err = p.Unmarshal(byts)
+	require.EqualError(t, err, "capture pattern not found")
+
+	byts = append([]byte(nil), casesPage[0].byts...)
+	byts[len(byts)-1] = 0x01
+	_, err = p.Unmarshal(byts)
+	require.EqualError(t, err, "CRC mismatch: expected f0365539, got f4f7488e")
+
+	_, err = p.Unmarshal(casesPage[0].byts[:40])
+	require.EqualError(t, err, "not enough bytes")
+}
+
+func TestPageMarshalErrors(t *testing.T) {
+	_, err := Page{
+		Packets:    [][]byte{{1, 2}},
+		Incomplete: true,
+	}.Marshal()
+	require.EqualError(t, err, "size of the incomplete packet must be a multiple of 255")
+
+	_, err = Page{
+		Packets: [][]byte{bytes.Repeat([]byte{1}, 255*255)},
+	}.Marshal()
+	require.EqualError(t, err, "segment count (256) exceeds maximum allowed (255)")
+}
+
+func FuzzPageUnmarshal(f *testing.F) {
+	for _, ca := range casesPage {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var p Page
+		_, err := p.Unmarshal(b)
+		if err == nil {
+			p.Marshal() //nolint:errcheck
+		}
+	})
+}
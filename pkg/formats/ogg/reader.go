@@ -0,0 +1,138 @@
+package ogg
+
+import (
+	"fmt"
+	"io"
+)
+
+// Reader is a Ogg reader.
+// It reads pages and reassembles packets that span multiple pages.
+// Streams that are multiplexed together are not supported,
+// while streams that are chained one after the other are.
+type Reader struct {
+	// source of data.
+	R io.Reader
+
+	buf                []byte
+	serialNumber       uint32
+	nextSequenceNumber uint32
+	started            bool
+	pending            []byte
+	pendingPresent     bool
+}
+
+// Initialize initializes a Reader.
+func (r *Reader) Initialize() error {
+	r.buf = make([]byte, pageHeaderSize+maxPageSegments+maxPageSegments*255)
+	return nil
+}
+
+// ReadPage reads a page.
+func (r *Reader) ReadPage() (*Page, error) {
+	_, err := io.ReadFull(r.R, r.buf[:pageHeaderSize])
+	if err != nil {
+		return nil, err
+	}
+
+	segmentCount := int(r.buf[26])
+
+	_, err = io.ReadFull(r.R, r.buf[pageHeaderSize:pageHeaderSize+segmentCount])
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := pageSize(r.buf)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = io.ReadFull(r.R, r.buf[pageHeaderSize+segmentCount:size])
+	if err != nil {
+		return nil, err
+	}
+
+	// copy the page in order to allow the caller to keep its packets
+	pageBuf := make([]byte, size)
+	copy(pageBuf, r.buf[:size])
+
+	var p Page
+	_, err = p.Unmarshal(pageBuf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// ReadPackets reads pages until at least one packet is complete.
+// It returns complete packets and the granule position of the page in which the last packet ends.
+// Parts of packets that can't be reassembled, due to missing pages, are discarded.
+func (r *Reader) ReadPackets() ([][]byte, int64, error) {
+	for {
+		p, err := r.ReadPage()
+		if err != nil {
+			return nil, 0, err
+		}
+
+		packets, err := r.processPage(p)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if len(packets) != 0 {
+			return packets, p.GranulePosition, nil
+		}
+	}
+}
+
+func (r *Reader) processPage(p *Page) ([][]byte, error) {
+	switch {
+	case !r.started || p.BeginningOfStream:
+		r.started = true
+		r.serialNumber = p.SerialNumber
+		r.pending = nil
+		r.pendingPresent = false
+
+	case p.SerialNumber != r.serialNumber:
+		return nil, fmt.Errorf("multiplexed streams are not supported")
+
+	case p.SequenceNumber != r.nextSequenceNumber:
+		// a page is missing
+		r.pending = nil
+		r.pendingPresent = false
+	}
+
+	r.nextSequenceNumber = p.SequenceNumber + 1
+
+	packets := p.Packets
+
+	if p.Continued && len(packets) != 0 {
+		if r.pendingPresent {
+			if (len(r.pending) + len(packets[0])) > MaxPacketSize {
+				return nil, fmt.Errorf("packet size (%d) is too big, maximum is %d",
+					len(r.pending)+len(packets[0]), MaxPacketSize)
+			}
+
+			packets[0] = append(r.pending, packets[0]...)
+		} else {
+			// the beginning of the packet is missing
+			packets = packets[1:]
+			if len(packets) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	// pending data has been either used or discarded
+	r.pending = nil
+	r.pendingPresent = false
+
+	if p.Incomplete {
+		last := packets[len(packets)-1]
+		r.pending = append([]byte(nil), last...)
+		r.pendingPresent = true
+		packets = packets[:len(packets)-1]
+	}
+
+	return packets, nil
+}
@@ -0,0 +1,155 @@
+package ogg
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func marshalPages(t *testing.T, pages []Page) []byte {
+	var buf []byte
+	for _, p := range pages {
+		byts, err := p.Marshal()
+		require.NoError(t, err)
+		buf = append(buf, byts...)
+	}
+	return buf
+}
+
+func TestReader(t *testing.T) {
+	big := bytes.Repeat([]byte{1, 2, 3}, 200)
+
+	buf := marshalPages(t, []Page{
+		{
+			BeginningOfStream: true,
+			SerialNumber:      10,
+			Packets:           [][]byte{{5, 6}},
+		},
+		{
+			SerialNumber:    10,
+			SequenceNumber:  1,
+			GranulePosition: -1,
+			Packets:         [][]byte{{7}, big[:255]},
+			Incomplete:      true,
+		},
+		{
+			Continued:       true,
+			SerialNumber:    10,
+			SequenceNumber:  2,
+			GranulePosition: -1,
+			Packets:         [][]byte{big[255:510]},
+			Incomplete:      true,
+		},
+		{
+			Continued:       true,
+			SerialNumber:    10,
+			SequenceNumber:  3,
+			GranulePosition: 960,
+			Packets:         [][]byte{big[510:], {8, 9}},
+		},
+	})
+
+	r := &Reader{R: bytes.NewReader(buf)}
+	err := r.Initialize()
+	require.NoError(t, err)
+
+	packets, granulePos, err := r.ReadPackets()
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{5, 6}}, packets)
+	require.Equal(t, int64(0), granulePos)
+
+	packets, granulePos, err = r.ReadPackets()
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{7}}, packets)
+	require.Equal(t, int64(-1), granulePos)
+
+	packets, granulePos, err = r.ReadPackets()
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{big, {8, 9}}, packets)
+	require.Equal(t, int64(960), granulePos)
+
+	_, _, err = r.ReadPackets()
+	require.Equal(t, io.EOF, err)
+}
+
+func TestReaderMissingPage(t *testing.T) {
+	buf := marshalPages(t, []Page{
+		{
+			BeginningOfStream: true,
+			SerialNumber:      10,
+			Packets:           [][]byte{bytes.Repeat([]byte{1}, 255)},
+			Incomplete:        true,
+		},
+		{
+			Continued:      true,
+			SerialNumber:   10,
+			SequenceNumber: 2,
+			Packets:        [][]byte{{2}, {3}},
+		},
+	})
+
+	r := &Reader{R: bytes.NewReader(buf)}
+	err := r.Initialize()
+	require.NoError(t, err)
+
+	packets, _, err := r.ReadPackets()
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{3}}, packets)
+}
+
+func TestReaderChainedStreams(t *testing.T) {
+	buf := marshalPages(t, []Page{
+		{
+			BeginningOfStream: true,
+			EndOfStream:       true,
+			SerialNumber:      10,
+			Packets:           [][]byte{{1}},
+		},
+		{
+			BeginningOfStream: true,
+			SerialNumber:      11,
+			Packets:           [][]byte{{2}},
+		},
+		{
+			SerialNumber:   12,
+			SequenceNumber: 1,
+			Packets:        [][]byte{{3}},
+		},
+	})
+
+	r := &Reader{R: bytes.NewReader(buf)}
+	err := r.Initialize()
+	require.NoError(t, err)
+
+	packets, _, err := r.ReadPackets()
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{1}}, packets)
+
+	packets, _, err = r.ReadPackets()
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{2}}, packets)
+
+	_, _, err = r.ReadPackets()
+	require.EqualError(t, err, "multiplexed streams are not supported")
+}
+
+func FuzzReader(f *testing.F) {
+	for _, ca := range casesPage {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		r := &Reader{R: bytes.NewReader(b)}
+		err := r.Initialize()
+		require.NoError(t, err)
+
+		for {
+			_, _, err = r.ReadPackets()
+			if err != nil {
+				break
+			}
+		}
+	})
+}
@@ -81,3 +81,24 @@ func (w *mp4Writer) rewriteBox(off int, box mp4.IImmutableBox) error {
 
 	return nil
 }
+
+// writeRawBox writes a box whose content is already encoded,
+// that is needed for boxes that are not supported by go-mp4.
+func (w *mp4Writer) writeRawBox(typ mp4.BoxType, payload []byte) (int, error) {
+	bi, err := w.w.StartBox(&mp4.BoxInfo{Type: typ})
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = w.w.Write(payload)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = w.w.EndBox()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(bi.Offset), nil
+}
@@ -13,7 +13,6 @@ import (
 // Specification: ISO 14496-1, Table 5
 const (
 	objectTypeIndicationVisualISO14496part2    = 0x20
-	objectTypeIndicationAudioISO14496part3     = 0x40
 	objectTypeIndicationVisualISO1318part2Main = 0x61
 	objectTypeIndicationAudioISO11172part3     = 0x6B
 	objectTypeIndicationVisualISO10918part1    = 0x6C
@@ -89,6 +88,8 @@ func (t *Track) marshal(w *mp4Writer) (*headerTrackMarshalResult, error) {
 		|    |    |    |    |    |    |esds|
 		|    |    |    |    |    |ac-3| (AC-3)
 		|    |    |    |    |    |    |dac3|
+		|    |    |    |    |    |ec-3| (E-AC-3)
+		|    |    |    |    |    |    |dec3|
 		|    |    |    |    |    |ipcm| (LPCM)
 		|    |    |    |    |    |    |pcmC|
 		|    |    |    |    |stts|
@@ -735,40 +736,18 @@ func (t *Track) marshal(w *mp4Writer) (*headerTrackMarshalResult, error) {
 			return nil, err
 		}
 
-		enc, _ := codec.Config.Marshal()
+		var enc []byte
+		enc, err = fmp4.ESDS{
+			ESID:       uint16(t.ID),
+			MaxBitrate: 128825,
+			AvgBitrate: 128825,
+			Config:     codec.Config,
+		}.Marshal()
+		if err != nil {
+			return nil, err
+		}
 
-		_, err = w.writeBox(&mp4.Esds{ // <esds/>
-			Descriptors: []mp4.Descriptor{
-				{
-					Tag:  mp4.ESDescrTag,
-					Size: 32 + uint32(len(enc)),
-					ESDescriptor: &mp4.ESDescriptor{
-						ESID: uint16(t.ID),
-					},
-				},
-				{
-					Tag:  mp4.DecoderConfigDescrTag,
-					Size: 18 + uint32(len(enc)),
-					DecoderConfigDescriptor: &mp4.DecoderConfigDescriptor{
-						ObjectTypeIndication: objectTypeIndicationAudioISO14496part3,
-						StreamType:           streamTypeAudioStream,
-						Reserved:             true,
-						MaxBitrate:           128825,
-						AvgBitrate:           128825,
-					},
-				},
-				{
-					Tag:  mp4.DecSpecificInfoTag,
-					Size: uint32(len(enc)),
-					Data: enc,
-				},
-				{
-					Tag:  mp4.SLConfigDescrTag,
-					Size: 1,
-					Data: []byte{0x02},
-				},
-			},
-		})
+		_, err = w.writeRawBox(mp4.BoxTypeEsds(), enc) // <esds/>
 		if err != nil {
 			return nil, err
 		}
@@ -853,6 +832,33 @@ func (t *Track) marshal(w *mp4Writer) (*headerTrackMarshalResult, error) {
 			return nil, err
 		}
 
+	case *fmp4.CodecEAC3:
+		_, err = w.writeBoxStart(&mp4.AudioSampleEntry{ // <ec-3>
+			SampleEntry: mp4.SampleEntry{
+				AnyTypeBox: mp4.AnyTypeBox{
+					Type: mp4.StrToBoxType("ec-3"),
+				},
+				DataReferenceIndex: 1,
+			},
+			ChannelCount: uint16(codec.ChannelCount),
+			SampleSize:   16,
+			SampleRate:   uint32(codec.SampleRate * 65536),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var enc []byte
+		enc, err = codec.Config.Marshal()
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = w.writeRawBox(mp4.StrToBoxType("dec3"), enc) // <dec3/>
+		if err != nil {
+			return nil, err
+		}
+
 	case *fmp4.CodecLPCM:
 		_, err = w.writeBoxStart(&mp4.AudioSampleEntry{ // <ipcm>
 			SampleEntry: mp4.SampleEntry{